@@ -877,6 +877,11 @@ func restore_v1alpha2_VirtualMachineReadinessProbeSpec(
 			dst.Spec.ReadinessProbe = &v1alpha2.VirtualMachineReadinessProbeSpec{}
 		}
 		dst.Spec.ReadinessProbe.GuestInfo = src.Spec.ReadinessProbe.GuestInfo
+		dst.Spec.ReadinessProbe.HTTPGet = src.Spec.ReadinessProbe.HTTPGet
+		dst.Spec.ReadinessProbe.Exec = src.Spec.ReadinessProbe.Exec
+		dst.Spec.ReadinessProbe.InitialDelaySeconds = src.Spec.ReadinessProbe.InitialDelaySeconds
+		dst.Spec.ReadinessProbe.SuccessThreshold = src.Spec.ReadinessProbe.SuccessThreshold
+		dst.Spec.ReadinessProbe.FailureThreshold = src.Spec.ReadinessProbe.FailureThreshold
 	}
 }
 
@@ -896,6 +901,13 @@ func (src *VirtualMachine) ConvertTo(dstRaw conversion.Hub) error {
 	restore_v1alpha2_VirtualMachineBootstrapSpec(dst, restored)
 	restore_v1alpha2_VirtualMachineNetworkSpec(dst, restored)
 	restore_v1alpha2_VirtualMachineReadinessProbeSpec(dst, restored)
+	dst.Spec.CurrentSnapshot = restored.Spec.CurrentSnapshot
+	dst.Spec.Clone = restored.Spec.Clone
+	dst.Spec.Crypto = restored.Spec.Crypto
+	dst.Spec.BootOptions = restored.Spec.BootOptions
+	dst.Spec.Affinity = restored.Spec.Affinity
+	dst.Spec.LivenessProbe = restored.Spec.LivenessProbe
+	dst.Spec.StartupProbe = restored.Spec.StartupProbe
 
 	dst.Status = restored.Status
 
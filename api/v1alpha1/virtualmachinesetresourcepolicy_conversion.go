@@ -7,6 +7,7 @@ import (
 	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 )
 
@@ -32,16 +33,49 @@ func Convert_v1alpha2_VirtualMachineSetResourcePolicySpec_To_v1alpha1_VirtualMac
 	return autoConvert_v1alpha2_VirtualMachineSetResourcePolicySpec_To_v1alpha1_VirtualMachineSetResourcePolicySpec(in, out, s)
 }
 
+func Convert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(
+	in *v1alpha2.ResourcePoolSpec, out *ResourcePoolSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(in, out, s)
+}
+
+func Convert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(
+	in *v1alpha2.VirtualMachineSetResourcePolicyStatus, out *VirtualMachineSetResourcePolicyStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(in, out, s)
+}
+
 // ConvertTo converts this VirtualMachineSetResourcePolicy to the Hub version.
 func (src *VirtualMachineSetResourcePolicy) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1alpha2.VirtualMachineSetResourcePolicy)
-	return Convert_v1alpha1_VirtualMachineSetResourcePolicy_To_v1alpha2_VirtualMachineSetResourcePolicy(src, dst, nil)
+	if err := Convert_v1alpha1_VirtualMachineSetResourcePolicy_To_v1alpha2_VirtualMachineSetResourcePolicy(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1alpha2.VirtualMachineSetResourcePolicy{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.HostAffinity = restored.Spec.HostAffinity
+	dst.Spec.TopologySpreadConstraint = restored.Spec.TopologySpreadConstraint
+	dst.Spec.ResourcePool.Shares = restored.Spec.ResourcePool.Shares
+	dst.Spec.ResourcePool.ExpandableReservation = restored.Spec.ResourcePool.ExpandableReservation
+	dst.Status.ResourcePools = restored.Status.ResourcePools
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachineSetResourcePolicy.
 func (dst *VirtualMachineSetResourcePolicy) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1alpha2.VirtualMachineSetResourcePolicy)
-	return Convert_v1alpha2_VirtualMachineSetResourcePolicy_To_v1alpha1_VirtualMachineSetResourcePolicy(src, dst, nil)
+	if err := Convert_v1alpha2_VirtualMachineSetResourcePolicy_To_v1alpha1_VirtualMachineSetResourcePolicy(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachineSetResourcePolicyList to the Hub version.
@@ -152,11 +152,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.ResourcePoolSpec)(nil), (*ResourcePoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(a.(*v1alpha2.ResourcePoolSpec), b.(*ResourcePoolSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*TCPSocketAction)(nil), (*v1alpha2.TCPSocketAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_TCPSocketAction_To_v1alpha2_TCPSocketAction(a.(*TCPSocketAction), b.(*v1alpha2.TCPSocketAction), scope)
 	}); err != nil {
@@ -477,11 +472,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha2.VirtualMachineSetResourcePolicyStatus)(nil), (*VirtualMachineSetResourcePolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(a.(*v1alpha2.VirtualMachineSetResourcePolicyStatus), b.(*VirtualMachineSetResourcePolicyStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineTemplate)(nil), (*v1alpha2.VirtualMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineTemplate_To_v1alpha2_VirtualMachineTemplate(a.(*VirtualMachineTemplate), b.(*v1alpha2.VirtualMachineTemplate), scope)
 	}); err != nil {
@@ -552,6 +542,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.ResourcePoolSpec)(nil), (*ResourcePoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(a.(*v1alpha2.ResourcePoolSpec), b.(*ResourcePoolSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha2.VirtualMachineClassStatus)(nil), (*VirtualMachineClassStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineClassStatus_To_v1alpha1_VirtualMachineClassStatus(a.(*v1alpha2.VirtualMachineClassStatus), b.(*VirtualMachineClassStatus), scope)
 	}); err != nil {
@@ -577,6 +572,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha2.VirtualMachineSetResourcePolicyStatus)(nil), (*VirtualMachineSetResourcePolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(a.(*v1alpha2.VirtualMachineSetResourcePolicyStatus), b.(*VirtualMachineSetResourcePolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha2.VirtualMachineSpec)(nil), (*VirtualMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(a.(*v1alpha2.VirtualMachineSpec), b.(*VirtualMachineSpec), scope)
 	}); err != nil {
@@ -936,14 +936,11 @@ func autoConvert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(in *v1al
 	if err := Convert_v1alpha2_VirtualMachineResourceSpec_To_v1alpha1_VirtualMachineResourceSpec(&in.Limits, &out.Limits, s); err != nil {
 		return err
 	}
+	// WARNING: in.Shares requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExpandableReservation requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec is an autogenerated conversion function.
-func Convert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(in *v1alpha2.ResourcePoolSpec, out *ResourcePoolSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha2_ResourcePoolSpec_To_v1alpha1_ResourcePoolSpec(in, out, s)
-}
-
 func autoConvert_v1alpha1_TCPSocketAction_To_v1alpha2_TCPSocketAction(in *TCPSocketAction, out *v1alpha2.TCPSocketAction, s conversion.Scope) error {
 	out.Port = in.Port
 	out.Host = in.Host
@@ -1268,6 +1265,8 @@ func Convert_v1alpha1_VirtualMachineClassStatus_To_v1alpha2_VirtualMachineClassS
 }
 
 func autoConvert_v1alpha2_VirtualMachineClassStatus_To_v1alpha1_VirtualMachineClassStatus(in *v1alpha2.VirtualMachineClassStatus, out *VirtualMachineClassStatus, s conversion.Scope) error {
+	// WARNING: in.Zones requires manual conversion: does not exist in peer-type
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2014,6 +2013,8 @@ func autoConvert_v1alpha2_VirtualMachineSetResourcePolicySpec_To_v1alpha1_Virtua
 	}
 	// WARNING: in.Folder requires manual conversion: inconvertible types (string vs github.com/vmware-tanzu/vm-operator/api/v1alpha1.FolderSpec)
 	// WARNING: in.ClusterModuleGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostAffinity requires manual conversion: does not exist in peer-type
+	// WARNING: in.TopologySpreadConstraint requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2029,14 +2030,10 @@ func Convert_v1alpha1_VirtualMachineSetResourcePolicyStatus_To_v1alpha2_VirtualM
 
 func autoConvert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(in *v1alpha2.VirtualMachineSetResourcePolicyStatus, out *VirtualMachineSetResourcePolicyStatus, s conversion.Scope) error {
 	out.ClusterModules = *(*[]ClusterModuleStatus)(unsafe.Pointer(&in.ClusterModules))
+	// WARNING: in.ResourcePools requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus is an autogenerated conversion function.
-func Convert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(in *v1alpha2.VirtualMachineSetResourcePolicyStatus, out *VirtualMachineSetResourcePolicyStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha2_VirtualMachineSetResourcePolicyStatus_To_v1alpha1_VirtualMachineSetResourcePolicyStatus(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(in *VirtualMachineSpec, out *v1alpha2.VirtualMachineSpec, s conversion.Scope) error {
 	out.ImageName = in.ImageName
 	out.ClassName = in.ClassName
@@ -2106,9 +2103,16 @@ func autoConvert_v1alpha2_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	} else {
 		out.ReadinessProbe = nil
 	}
+	// WARNING: in.LivenessProbe requires manual conversion: does not exist in peer-type
+	// WARNING: in.StartupProbe requires manual conversion: does not exist in peer-type
 	// WARNING: in.Advanced requires manual conversion: does not exist in peer-type
 	// WARNING: in.Reserved requires manual conversion: does not exist in peer-type
 	out.MinHardwareVersion = in.MinHardwareVersion
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.Clone requires manual conversion: does not exist in peer-type
+	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootOptions requires manual conversion: does not exist in peer-type
+	// WARNING: in.Affinity requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2184,7 +2188,15 @@ func autoConvert_v1alpha2_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.RestartCount requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
+	// WARNING: in.Snapshots requires manual conversion: does not exist in peer-type
+	// WARNING: in.CurrentSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.LastRevertedSnapshot requires manual conversion: does not exist in peer-type
+	// WARNING: in.Crypto requires manual conversion: does not exist in peer-type
+	// WARNING: in.GuestDisks requires manual conversion: does not exist in peer-type
+	// WARNING: in.GuestDisksSampleTime requires manual conversion: does not exist in peer-type
+	// WARNING: in.QuickStats requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2256,5 +2268,7 @@ func autoConvert_v1alpha2_VirtualMachineVolumeStatus_To_v1alpha1_VirtualMachineV
 	out.Attached = in.Attached
 	// WARNING: in.DiskUUID requires manual conversion: does not exist in peer-type
 	out.Error = in.Error
+	// WARNING: in.Capacity requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResizeState requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineAffinityTopologyKeyZone is the topology key of a VM
+	// affinity term whose topology domain is the zone.
+	VirtualMachineAffinityTopologyKeyZone = "topology.kubernetes.io/zone"
+
+	// VirtualMachineAffinityTopologyKeyHost is the topology key of a VM
+	// affinity term whose topology domain is the ESXi host.
+	VirtualMachineAffinityTopologyKeyHost = "kubernetes.io/hostname"
+)
+
+// VirtualMachineAffinitySpec describes the affinity and anti-affinity of a VM
+// with the other VMs in the same Namespace.
+type VirtualMachineAffinitySpec struct {
+	// VMAffinity describes the terms that place the VM in the same topology
+	// domain as the VMs selected by the terms.
+	//
+	// +optional
+	VMAffinity *VirtualMachineAffinityVMAffinitySpec `json:"vmAffinity,omitempty"`
+
+	// VMAntiAffinity describes the terms that place the VM in a different
+	// topology domain than the VMs selected by the terms.
+	//
+	// +optional
+	VMAntiAffinity *VirtualMachineAffinityVMAffinitySpec `json:"vmAntiAffinity,omitempty"`
+}
+
+// VirtualMachineAffinityVMAffinitySpec describes the required and preferred
+// terms of a VM affinity or anti-affinity.
+type VirtualMachineAffinityVMAffinitySpec struct {
+	// Required describes the terms that must be satisfied. A VM is not placed
+	// if none of the candidate zones and clusters satisfy these terms, and
+	// the DRS rules created for the host topology terms are mandatory.
+	//
+	// +optional
+	Required []VMAffinityTerm `json:"required,omitempty"`
+
+	// Preferred describes the terms that should be satisfied. A VM is placed
+	// in a zone or cluster that satisfies these terms when one is
+	// recommended by DRS, and the DRS rules created for the host topology
+	// terms are not mandatory.
+	//
+	// +optional
+	Preferred []VMAffinityTerm `json:"preferred,omitempty"`
+}
+
+// VMAffinityTerm describes the VMs with which a VM is, or is not, in the same
+// topology domain.
+type VMAffinityTerm struct {
+	// LabelSelector selects the VMs, in the same Namespace as the VM, to
+	// which the term applies.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// TopologyKey describes the topology domain of the term.
+	//
+	// When the topology key is "topology.kubernetes.io/zone", the term is
+	// honored when the VM's zone is selected.
+	//
+	// When the topology key is "kubernetes.io/hostname", the term is honored
+	// by a DRS VM-VM rule on the VM's cluster that keeps the VM on the same
+	// host as, or a different host than, the selected VMs in that cluster.
+	// Since the rule only applies within a cluster, the VM is also placed
+	// in a cluster with the selected VMs for an affinity term. The rule is
+	// deleted when the VM is deleted.
+	//
+	// +kubebuilder:validation:Enum=topology.kubernetes.io/zone;kubernetes.io/hostname
+	TopologyKey string `json:"topologyKey"`
+}
@@ -17,6 +17,15 @@ type VirtualMachineReadinessProbeSpec struct {
 	// +optional
 	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty"`
 
+	// HTTPGet specifies an action involving an HTTP GET request to the VM.
+	// +optional
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+
+	// Exec specifies an action involving a command that is run in the guest
+	// through VM Tools.
+	// +optional
+	Exec *GuestExecAction `json:"exec,omitempty"`
+
 	// GuestHeartbeat specifies an action involving the guest heartbeat status.
 	// +optional
 	GuestHeartbeat *GuestHeartbeatAction `json:"guestHeartbeat,omitempty"`
@@ -61,6 +70,39 @@ type VirtualMachineReadinessProbeSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum:=1
 	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// InitialDelaySeconds specifies the number of seconds after the VM is
+	// powered on, or restarted, before the probe is performed.
+	// Defaults to 0 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// SuccessThreshold specifies the number of consecutive times the probe
+	// must succeed before it is considered successful after having failed.
+	// Defaults to 1. Must be 1 for liveness and startup probes.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// FailureThreshold specifies the number of consecutive times the probe
+	// must fail before it is considered failed after having succeeded.
+	// Defaults to 3. Minimum value is 1.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum:=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// VirtualMachineLivenessProbeSpec describes a probe used to determine if a VM
+// is alive. It supports the same actions as a readiness probe, and all probe
+// actions are mutually exclusive.
+//
+// When the probe fails FailureThreshold consecutive times, the VM is restarted
+// in accordance with spec.restartMode. The probe is not run while the VM is not
+// powered on.
+type VirtualMachineLivenessProbeSpec struct {
+	VirtualMachineReadinessProbeSpec `json:",inline"`
 }
 
 // TCPSocketAction describes an action based on opening a socket.
@@ -75,6 +117,98 @@ type TCPSocketAction struct {
 	Host string `json:"host,omitempty"`
 }
 
+// URIScheme identifies the scheme used for connection to a host for HTTPGet
+// actions.
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://.
+	URISchemeHTTP URIScheme = "HTTP"
+	// URISchemeHTTPS means that the scheme used will be https://.
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
+// HTTPHeader describes a custom header to be used in HTTP probes.
+type HTTPHeader struct {
+	// Name is the header field name.
+	Name string `json:"name"`
+
+	// Value is the header field value.
+	Value string `json:"value"`
+}
+
+// HTTPStatusCodeRange describes an inclusive range of HTTP status codes.
+type HTTPStatusCodeRange struct {
+	// Min is the lowest status code in the range.
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+	Min int32 `json:"min"`
+
+	// Max is the highest status code in the range.
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+	Max int32 `json:"max"`
+}
+
+// HTTPGetAction describes an action based on HTTP GET requests.
+type HTTPGetAction struct {
+	// Path specifies the path to access on the HTTP server.
+	// Defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Port specifies a number or name of the port to access on the VM.
+	// If the format of port is a number, it must be in the range 1 to 65535.
+	// If the format of name is a string, it must be an IANA_SVC_NAME.
+	Port intstr.IntOrString `json:"port"`
+
+	// Host is an optional host name to connect to. Host defaults to the VM IP.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Scheme specifies the scheme to use for connecting to the host.
+	// Defaults to HTTP.
+	// +optional
+	// +kubebuilder:default=HTTP
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Scheme URIScheme `json:"scheme,omitempty"`
+
+	// HTTPHeaders specifies custom headers to set in the request.
+	// +optional
+	HTTPHeaders []HTTPHeader `json:"httpHeaders,omitempty"`
+
+	// StatusCodes specifies the range of response status codes for which the
+	// probe succeeds. Defaults to 200 through 399.
+	// +optional
+	StatusCodes *HTTPStatusCodeRange `json:"statusCodes,omitempty"`
+
+	// InsecureSkipTLSVerify specifies whether the server's certificate is
+	// verified when the scheme is HTTPS. This is useful for VMs with
+	// self-signed certificates.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+}
+
+// GuestExecAction describes an action based on running a command in the guest
+// through VM Tools guest operations. The probe succeeds when the command exits
+// with the exit code 0, and fails when the command exits with any other exit
+// code or does not exit before the probe's timeout.
+type GuestExecAction struct {
+	// Command is the command that is run. If the command is an absolute path
+	// on a Linux guest, the program is run directly. Otherwise, the command
+	// is run by the guest's shell, ex. "/bin/bash" or "cmd.exe".
+	Command string `json:"command"`
+
+	// Args describes the arguments passed to the command.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// CredentialsSecretName is the name of the Secret, in the same Namespace
+	// as the VM, that contains the credentials of the guest user that runs
+	// the command. The Secret must have the "username" and "password" keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
 // GuestHeartbeatStatus is the guest heartbeat status.
 type GuestHeartbeatStatus string
 
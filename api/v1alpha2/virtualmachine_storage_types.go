@@ -81,8 +81,36 @@ type VirtualMachineVolumeStatus struct {
 	// +optional
 	DiskUUID string `json:"diskUUID,omitempty"`
 
-	// Error represents the last error seen when attaching or detaching a
-	// volume.  Error will be empty if attachment succeeds.
+	// Error represents the last error seen when attaching, detaching, or
+	// resizing a volume.  Error will be empty if attachment succeeds.
 	// +optional
 	Error string `json:"error,omitempty"`
+
+	// Capacity is the size of the volume's disk as attached to the
+	// VirtualMachine.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// ResizeState describes the progress of expanding the volume's disk after
+	// the size requested by the volume's PersistentVolumeClaim was increased.
+	// ResizeState is empty when the volume is not being resized.
+	// +optional
+	ResizeState VirtualMachineVolumeResizeState `json:"resizeState,omitempty"`
 }
+
+// VirtualMachineVolumeResizeState is the type used to express the progress
+// of expanding a volume's disk.
+//
+// +kubebuilder:validation:Enum=InProgress;Failed
+type VirtualMachineVolumeResizeState string
+
+const (
+	// VirtualMachineVolumeResizeStateInProgress indicates the volume is
+	// waiting on the expansion of its backing storage before its disk may be
+	// expanded.
+	VirtualMachineVolumeResizeStateInProgress VirtualMachineVolumeResizeState = "InProgress"
+
+	// VirtualMachineVolumeResizeStateFailed indicates the last attempt to
+	// expand the volume's disk failed. The reason is available in Error.
+	VirtualMachineVolumeResizeStateFailed VirtualMachineVolumeResizeState = "Failed"
+)
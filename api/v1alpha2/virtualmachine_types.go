@@ -83,6 +83,50 @@ const (
 	VirtualMachineToolsRunningReason = "VirtualMachineToolsRunning"
 )
 
+const (
+	// VirtualMachineClassConfigurationSyncedCondition exposes whether the
+	// CPU and memory of the VirtualMachineClass referenced by spec.className
+	// have been applied to the VM after the VM's class was changed.
+	VirtualMachineClassConfigurationSyncedCondition = "VirtualMachineClassConfigurationSynced"
+
+	// VirtualMachineClassConfigurationPowerCyclePendingReason documents that
+	// the VM's new class could not be applied while the VM is powered on,
+	// for example because CPU or memory hot-add is not enabled for the VM or
+	// is not supported by its guest OS.
+	// The new class is applied the next time the VM is powered off.
+	VirtualMachineClassConfigurationPowerCyclePendingReason = "PowerCyclePending"
+)
+
+const (
+	// VirtualMachineZoneRelocatedCondition exposes whether the VM has been
+	// relocated to the availability zone specified by its zone label after
+	// the label was changed.
+	VirtualMachineZoneRelocatedCondition = "VirtualMachineZoneRelocated"
+
+	// VirtualMachineZoneRelocationInProgressReason documents that the VM is
+	// being relocated to its new availability zone.
+	VirtualMachineZoneRelocationInProgressReason = "RelocationInProgress"
+
+	// VirtualMachineZoneRelocationPlacementFailedReason documents that no
+	// placement for the VM could be found in its new availability zone.
+	VirtualMachineZoneRelocationPlacementFailedReason = "PlacementFailed"
+
+	// VirtualMachineZoneRelocationFailedReason documents that the relocation
+	// of the VM to its new availability zone failed.
+	VirtualMachineZoneRelocationFailedReason = "RelocationFailed"
+)
+
+const (
+	// VirtualMachineHostAffinityCompliantCondition exposes whether the VM runs
+	// on one of the ESXi hosts selected by the host affinity of the VM's
+	// VirtualMachineSetResourcePolicy.
+	VirtualMachineHostAffinityCompliantCondition = "VirtualMachineHostAffinityCompliant"
+
+	// VirtualMachineHostAffinityNotCompliantReason documents that the VM does
+	// not run on one of the ESXi hosts selected by its host affinity.
+	VirtualMachineHostAffinityNotCompliantReason = "NotCompliant"
+)
+
 const (
 	// PauseAnnotation is an annotation that prevents a VM from being
 	// reconciled.
@@ -107,6 +151,13 @@ const (
 	// booted at least once. This annotation cannot be set by users and will not
 	// be removed once set until the VM is deleted.
 	FirstBootDoneAnnotation = "virtualmachine." + GroupName + "/first-boot-done"
+
+	// VCenterNameAnnotation is an annotation that records the name of the
+	// vCenter the VM was created in, which is empty for the default vCenter.
+	// The VM is always managed in this vCenter, even if the VM's zone is
+	// changed or the VM's namespace is assigned to another vCenter. This
+	// annotation cannot be set by users.
+	VCenterNameAnnotation = GroupName + "/vcenter-name"
 )
 
 // VirtualMachine backup/restore related constants.
@@ -177,6 +228,141 @@ const (
 	VirtualMachinePowerOpModeTrySoft VirtualMachinePowerOpMode = "TrySoft"
 )
 
+// VirtualMachineCloneMode describes how the disks of the source VM are copied
+// when cloning a VM.
+// +kubebuilder:validation:Enum=Full;Linked
+type VirtualMachineCloneMode string
+
+const (
+	// VirtualMachineCloneModeFull indicates to make a full copy of the source
+	// VM's disks.
+	VirtualMachineCloneModeFull VirtualMachineCloneMode = "Full"
+
+	// VirtualMachineCloneModeLinked indicates to create the clone's disks as
+	// delta disks that are backed by the disks of the source VM's current
+	// snapshot.
+	//
+	// Linked clones are created much faster and use less storage than full
+	// clones, but the source VM must have a snapshot and the snapshot may not
+	// be deleted while the clone exists.
+	VirtualMachineCloneModeLinked VirtualMachineCloneMode = "Linked"
+)
+
+// VirtualMachineCloneSpec describes the VM from which a VM is cloned.
+type VirtualMachineCloneSpec struct {
+	// VMName is the name of the VirtualMachine resource, in the same
+	// Namespace as this VM, that is cloned.
+	//
+	// The source VM must have been created on the underlying infrastructure
+	// before the clone can be created.
+	VMName string `json:"vmName"`
+
+	// Mode describes how the source VM's disks are copied. Defaults to Full.
+	//
+	// +optional
+	// +kubebuilder:default=Full
+	Mode VirtualMachineCloneMode `json:"mode,omitempty"`
+}
+
+// VirtualMachineCryptoSpec describes the desired encryption and virtual
+// Trusted Platform Module (vTPM) configuration of a VM.
+type VirtualMachineCryptoSpec struct {
+	// Encrypted describes whether the VM's home directory and the VM's disks
+	// are encrypted. PVCs attached to the VM are not encrypted by this field.
+	//
+	// +optional
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// KeyProviderID describes the ID of the key provider used to encrypt the
+	// VM. When omitted, the default key provider is used. When changed, the
+	// VM is rekeyed with a key from the new key provider.
+	//
+	// +optional
+	KeyProviderID string `json:"keyProviderID,omitempty"`
+
+	// VTPM describes whether the VM has a virtual Trusted Platform Module.
+	// A vTPM requires the VM's firmware to be EFI, and the VM's home
+	// directory is encrypted with the key provider when the vTPM is added.
+	//
+	// +optional
+	VTPM bool `json:"vTPM,omitempty"`
+}
+
+// VirtualMachineFirmwareType describes the firmware used to boot a VM.
+// +kubebuilder:validation:Enum=bios;efi
+type VirtualMachineFirmwareType string
+
+const (
+	// VirtualMachineFirmwareTypeBIOS indicates the VM boots with BIOS
+	// firmware.
+	VirtualMachineFirmwareTypeBIOS VirtualMachineFirmwareType = "bios"
+
+	// VirtualMachineFirmwareTypeEFI indicates the VM boots with EFI firmware.
+	VirtualMachineFirmwareTypeEFI VirtualMachineFirmwareType = "efi"
+)
+
+// VirtualMachineBootableDevice describes a type of device from which a VM may
+// be booted.
+// +kubebuilder:validation:Enum=Disk;Network;CDRom
+type VirtualMachineBootableDevice string
+
+const (
+	// VirtualMachineBootableDeviceDisk indicates the VM boots from its boot
+	// disk.
+	VirtualMachineBootableDeviceDisk VirtualMachineBootableDevice = "Disk"
+
+	// VirtualMachineBootableDeviceNetwork indicates the VM boots from its
+	// first network interface, ex. via PXE.
+	VirtualMachineBootableDeviceNetwork VirtualMachineBootableDevice = "Network"
+
+	// VirtualMachineBootableDeviceCDRom indicates the VM boots from its
+	// CD-ROM device.
+	VirtualMachineBootableDeviceCDRom VirtualMachineBootableDevice = "CDRom"
+)
+
+// VirtualMachineBootOptions describes the settings that control how a VM is
+// booted.
+type VirtualMachineBootOptions struct {
+	// Firmware describes the firmware used to boot the VM. When omitted, the
+	// firmware is that of the VM's image.
+	//
+	// +optional
+	Firmware VirtualMachineFirmwareType `json:"firmware,omitempty"`
+
+	// EFISecureBoot describes whether the VM's firmware verifies the digital
+	// signatures of the software the VM boots. Secure boot requires the VM's
+	// firmware to be EFI.
+	//
+	// +optional
+	EFISecureBoot bool `json:"efiSecureBoot,omitempty"`
+
+	// BootOrder describes the order of the types of devices from which the VM
+	// attempts to boot. When omitted, the firmware's default boot order is
+	// used.
+	//
+	// +optional
+	// +listType=set
+	BootOrder []VirtualMachineBootableDevice `json:"bootOrder,omitempty"`
+
+	// BootDelay describes the delay before the VM begins to boot after it is
+	// powered on.
+	//
+	// +optional
+	BootDelay *metav1.Duration `json:"bootDelay,omitempty"`
+
+	// BootRetry describes whether the VM retries to boot after the delay in
+	// BootRetryDelay when no bootable device is found.
+	//
+	// +optional
+	BootRetry bool `json:"bootRetry,omitempty"`
+
+	// BootRetryDelay describes the delay before the VM retries to boot when
+	// BootRetry is true. Defaults to ten seconds.
+	//
+	// +optional
+	BootRetryDelay *metav1.Duration `json:"bootRetryDelay,omitempty"`
+}
+
 // VirtualMachineSpec defines the desired state of a VirtualMachine.
 type VirtualMachineSpec struct {
 	// ImageName describes the name of the image resource used to deploy this
@@ -333,6 +519,24 @@ type VirtualMachineSpec struct {
 	// +optional
 	ReadinessProbe *VirtualMachineReadinessProbeSpec `json:"readinessProbe,omitempty"`
 
+	// LivenessProbe describes a probe used to determine if the VM is alive.
+	// The VM is restarted, in accordance with RestartMode, when the probe
+	// fails.
+	//
+	// +optional
+	LivenessProbe *VirtualMachineLivenessProbeSpec `json:"livenessProbe,omitempty"`
+
+	// StartupProbe describes a probe used to determine if the VM's guest has
+	// started. It supports the same actions as a readiness probe.
+	//
+	// While the probe has not succeeded, the VM is not ready and the readiness
+	// and liveness probes are not run. The probe is run again when the VM is
+	// powered on or restarted. The VM is restarted, in accordance with
+	// RestartMode, when the probe fails FailureThreshold consecutive times.
+	//
+	// +optional
+	StartupProbe *VirtualMachineReadinessProbeSpec `json:"startupProbe,omitempty"`
+
 	// Advanced describes a set of optional, advanced VM configuration options.
 	// +optional
 	Advanced *VirtualMachineAdvancedSpec `json:"advanced,omitempty"`
@@ -383,6 +587,64 @@ type VirtualMachineSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=13
 	MinHardwareVersion int32 `json:"minHardwareVersion,omitempty"`
+
+	// CurrentSnapshot may be used to revert the VM to one of its snapshots by
+	// setting the value of this field to the name of a
+	// VirtualMachineSnapshot resource that refers to this VM.
+	//
+	// The VM is reverted when the value of this field differs from
+	// status.lastRevertedSnapshot. Snapshots taken after a revert therefore
+	// do not cause the VM to be reverted again. To revert to the same
+	// snapshot more than once, clear this field and then set it again.
+	//
+	// Reverting a VM preserves its identity, the PVCs attached to it, and its
+	// Cloud-Init instance ID, so the guest is not customized again. After the
+	// revert the VM is brought back to the power state described by
+	// spec.powerState.
+	//
+	// +optional
+	CurrentSnapshot string `json:"currentSnapshot,omitempty"`
+
+	// Clone describes the VirtualMachine, in the same Namespace as this VM,
+	// from which this VM is cloned when it is created instead of being
+	// deployed from spec.imageName.
+	//
+	// The fields spec.imageName and spec.storageClass default to the values
+	// of the source VM. Specifying a different spec.storageClass places the
+	// clone on storage other than the source VM's storage.
+	//
+	// Only the source VM's boot disks are cloned, PVCs attached to the source
+	// VM are not. The clone is bootstrapped with the bootstrap data from
+	// spec.bootstrap and is given a new instance ID and new MAC addresses, so
+	// it does not reuse the identity of the source VM.
+	//
+	// This field may not be changed once the VM has been created.
+	//
+	// +optional
+	Clone *VirtualMachineCloneSpec `json:"clone,omitempty"`
+
+	// Crypto describes the desired encryption and vTPM configuration of the
+	// VM. The VM's firmware must be EFI when this field is set. When this
+	// field is not set, the VM's encryption and vTPM are left unchanged.
+	//
+	// Please note this field may only be changed when the VM is powered off.
+	//
+	// +optional
+	Crypto *VirtualMachineCryptoSpec `json:"crypto,omitempty"`
+
+	// BootOptions describes the settings that control how the VM is booted.
+	//
+	// Please note this field may only be changed when the VM is powered off.
+	//
+	// +optional
+	BootOptions *VirtualMachineBootOptions `json:"bootOptions,omitempty"`
+
+	// Affinity describes the affinity and anti-affinity of the VM with the
+	// other VMs in the same Namespace. The affinity is honored when the VM is
+	// placed, and by DRS VM-VM rules on the VM's cluster.
+	//
+	// +optional
+	Affinity *VirtualMachineAffinitySpec `json:"affinity,omitempty"`
 }
 
 // VirtualMachineReservedSpec describes a set of VM configuration options
@@ -504,6 +766,12 @@ type VirtualMachineStatus struct {
 	// +optional
 	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
 
+	// RestartCount describes the number of times the VM has been restarted
+	// because its liveness probe failed.
+	//
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+
 	// HardwareVersion describes the VirtualMachine resource's observed
 	// hardware version.
 	//
@@ -512,6 +780,154 @@ type VirtualMachineStatus struct {
 	//
 	// +optional
 	HardwareVersion int32 `json:"hardwareVersion,omitempty"`
+
+	// Snapshots describes the observed snapshot tree of the VM, flattened
+	// into a list in which each snapshot refers to its parent.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+	Snapshots []VirtualMachineSnapshotTreeStatus `json:"snapshots,omitempty"`
+
+	// CurrentSnapshot describes the name of the snapshot on which the VM's
+	// current state is based.
+	//
+	// +optional
+	CurrentSnapshot string `json:"currentSnapshot,omitempty"`
+
+	// LastRevertedSnapshot describes the name of the snapshot to which the VM
+	// was last reverted.
+	//
+	// Please refer to VirtualMachineSpec.CurrentSnapshot for more information
+	// on reverting a VM to a snapshot.
+	//
+	// +optional
+	LastRevertedSnapshot string `json:"lastRevertedSnapshot,omitempty"`
+
+	// Crypto describes the observed encryption and vTPM configuration of the
+	// VM.
+	//
+	// +optional
+	Crypto *VirtualMachineCryptoStatus `json:"crypto,omitempty"`
+
+	// GuestDisks describes the observed capacity and free space of the
+	// guest's filesystems. The free space is refreshed at most once every
+	// five minutes.
+	//
+	// Please note this information is only available if the guest has VM
+	// Tools installed.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=diskPath
+	GuestDisks []VirtualMachineGuestDiskStatus `json:"guestDisks,omitempty"`
+
+	// GuestDisksSampleTime describes when GuestDisks was sampled.
+	//
+	// +optional
+	GuestDisksSampleTime *metav1.Time `json:"guestDisksSampleTime,omitempty"`
+
+	// QuickStats describes a summary of the observed resource usage of the
+	// VM. The summary is only available while the VM is powered on, and is
+	// refreshed at most once every five minutes.
+	//
+	// +optional
+	QuickStats *VirtualMachineQuickStatsStatus `json:"quickStats,omitempty"`
+}
+
+// VirtualMachineCryptoStatus describes the observed encryption and vTPM
+// configuration of a VM.
+type VirtualMachineCryptoStatus struct {
+	// Encrypted describes whether the VM is encrypted.
+	//
+	// +optional
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// KeyProviderID describes the ID of the key provider of the key used to
+	// encrypt the VM.
+	//
+	// +optional
+	KeyProviderID string `json:"keyProviderID,omitempty"`
+
+	// VTPM describes whether the VM has a virtual Trusted Platform Module.
+	//
+	// +optional
+	VTPM bool `json:"vTPM,omitempty"`
+}
+
+// VirtualMachineGuestDiskStatus describes the observed capacity and free space
+// of one of the guest's filesystems.
+type VirtualMachineGuestDiskStatus struct {
+	// DiskPath describes the path at which the filesystem is mounted in the
+	// guest, ex. "/" or "C:\".
+	DiskPath string `json:"diskPath"`
+
+	// FilesystemType describes the type of the filesystem, ex. "ext4" or
+	// "NTFS".
+	//
+	// +optional
+	FilesystemType string `json:"filesystemType,omitempty"`
+
+	// Capacity describes the total capacity of the filesystem.
+	//
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// FreeSpace describes the free space on the filesystem.
+	//
+	// +optional
+	FreeSpace *resource.Quantity `json:"freeSpace,omitempty"`
+}
+
+// VirtualMachineQuickStatsStatus describes a summary of the observed resource
+// usage of a VM.
+type VirtualMachineQuickStatsStatus struct {
+	// SampleTime describes when the quick stats were sampled.
+	//
+	// +optional
+	SampleTime *metav1.Time `json:"sampleTime,omitempty"`
+
+	// CPUUsageMHz describes the VM's CPU usage in MHz.
+	//
+	// +optional
+	CPUUsageMHz int32 `json:"cpuUsageMHz,omitempty"`
+
+	// CPUDemandMHz describes the VM's CPU demand in MHz. A demand that is
+	// higher than the usage indicates the VM is not getting all of the CPU it
+	// would use if it were not contended.
+	//
+	// +optional
+	CPUDemandMHz int32 `json:"cpuDemandMHz,omitempty"`
+
+	// GuestMemoryUsage describes the amount of the guest's memory that is
+	// actively used.
+	//
+	// +optional
+	GuestMemoryUsage *resource.Quantity `json:"guestMemoryUsage,omitempty"`
+
+	// HostMemoryUsage describes the amount of the host's memory that is
+	// consumed by the VM.
+	//
+	// +optional
+	HostMemoryUsage *resource.Quantity `json:"hostMemoryUsage,omitempty"`
+
+	// BalloonedMemory describes the amount of the guest's memory that has
+	// been reclaimed by the balloon driver.
+	//
+	// +optional
+	BalloonedMemory *resource.Quantity `json:"balloonedMemory,omitempty"`
+
+	// SwappedMemory describes the amount of the guest's memory that has been
+	// swapped out by the host.
+	//
+	// +optional
+	SwappedMemory *resource.Quantity `json:"swappedMemory,omitempty"`
+
+	// UptimeSeconds describes the number of seconds the VM has been powered
+	// on.
+	//
+	// +optional
+	UptimeSeconds int32 `json:"uptimeSeconds,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -10,6 +10,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// VirtualMachineClassConditionReady is the Type for a VirtualMachineClass
+	// resource's status condition.
+	//
+	// The condition's status is set to true only when at least one zone has a
+	// cluster that can run VMs of the VirtualMachineClass.
+	VirtualMachineClassConditionReady = "VirtualMachineClassReady"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineClass.
+const (
+	// VirtualMachineClassNoCompatibleZoneReason documents that no zone has a
+	// cluster that can run VMs of the VirtualMachineClass.
+	VirtualMachineClassNoCompatibleZoneReason = "NoCompatibleZone"
+
+	// VirtualMachineClassCompatibilityFailedReason documents that the
+	// compatibility of the VirtualMachineClass could not be determined.
+	VirtualMachineClassCompatibilityFailedReason = "CompatibilityFailed"
+)
+
 // VirtualMachineConfigSpec contains additional virtual machine
 // configuration settings including hardware specifications for the
 // VirtualMachine.
@@ -166,8 +186,53 @@ type VirtualMachineClassSpec struct {
 	ConfigSpec json.RawMessage `json:"configSpec,omitempty"`
 }
 
+// VirtualMachineClassClusterStatus describes whether a cluster in a zone can
+// run VMs of a VirtualMachineClass.
+type VirtualMachineClassClusterStatus struct {
+	// MoID is the managed object ID of the cluster.
+	MoID string `json:"moID"`
+
+	// Compatible is true when the cluster has a host with enough CPU and
+	// memory, and the vGPU profiles, DirectPath I/O devices and instance
+	// storage required by the VirtualMachineClass.
+	Compatible bool `json:"compatible"`
+
+	// Reasons describes why the cluster cannot run VMs of the
+	// VirtualMachineClass.
+	//
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// VirtualMachineClassZoneStatus describes whether a zone can run VMs of a
+// VirtualMachineClass.
+type VirtualMachineClassZoneStatus struct {
+	// Name is the name of the zone.
+	Name string `json:"name"`
+
+	// Compatible is true when at least one cluster in the zone can run VMs of
+	// the VirtualMachineClass.
+	Compatible bool `json:"compatible"`
+
+	// Clusters describes the compatibility of each cluster in the zone.
+	//
+	// +optional
+	Clusters []VirtualMachineClassClusterStatus `json:"clusters,omitempty"`
+}
+
 // VirtualMachineClassStatus defines the observed state of VirtualMachineClass.
 type VirtualMachineClassStatus struct {
+	// Zones describes which zones, and clusters in them, can run VMs of the
+	// VirtualMachineClass.
+	//
+	// +optional
+	Zones []VirtualMachineClassZoneStatus `json:"zones,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineClass.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -177,6 +242,7 @@ type VirtualMachineClassStatus struct {
 // +kubebuilder:printcolumn:name="CPU",type="string",JSONPath=".spec.hardware.cpus"
 // +kubebuilder:printcolumn:name="Memory",type="string",JSONPath=".spec.hardware.memory"
 // +kubebuilder:printcolumn:name="Capabilities",type="string",priority=1,JSONPath=".status.capabilities"
+// +kubebuilder:printcolumn:name="Ready",type="string",priority=1,JSONPath=".status.conditions[?(@.type=='VirtualMachineClassReady')].status"
 
 // VirtualMachineClass is the schema for the virtualmachineclasses API and
 // represents the desired state and observed status of a virtualmachineclasses
@@ -189,6 +255,14 @@ type VirtualMachineClass struct {
 	Status VirtualMachineClassStatus `json:"status,omitempty"`
 }
 
+func (vmClass *VirtualMachineClass) GetConditions() []metav1.Condition {
+	return vmClass.Status.Conditions
+}
+
+func (vmClass *VirtualMachineClass) SetConditions(conditions []metav1.Condition) {
+	vmClass.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // VirtualMachineClassList contains a list of VirtualMachineClass.
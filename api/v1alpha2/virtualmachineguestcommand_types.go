@@ -0,0 +1,202 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
+)
+
+const (
+	// VirtualMachineGuestCommandConditionCompleted is the Type for a
+	// VirtualMachineGuestCommand resource's status condition.
+	//
+	// The condition's status is set to true only when the command has exited
+	// in the guest, regardless of the command's exit code.
+	VirtualMachineGuestCommandConditionCompleted = "GuestCommandCompleted"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineGuestCommand.
+const (
+	// VirtualMachineGuestCommandVMNotFoundReason documents that the VM
+	// referenced by the VirtualMachineGuestCommand does not exist.
+	VirtualMachineGuestCommandVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineGuestCommandVMNotCreatedReason documents that the VM
+	// referenced by the VirtualMachineGuestCommand has not been created on
+	// the underlying infrastructure yet.
+	VirtualMachineGuestCommandVMNotCreatedReason = "VirtualMachineNotCreated"
+
+	// VirtualMachineGuestCommandRunningReason documents that the command has
+	// been started in the guest and has not exited yet.
+	VirtualMachineGuestCommandRunningReason = "Running"
+
+	// VirtualMachineGuestCommandFailedReason documents that the command could
+	// not be run in the guest, ex. because VM Tools is not running or the
+	// credentials are invalid.
+	VirtualMachineGuestCommandFailedReason = "Failed"
+
+	// VirtualMachineGuestCommandTimedOutReason documents that the command did
+	// not exit before its timeout and was terminated.
+	VirtualMachineGuestCommandTimedOutReason = "TimedOut"
+)
+
+const (
+	// VirtualMachineGuestCommandCredentialsUsernameKey is the key in the
+	// credentials Secret for the name of the guest user that runs the
+	// command.
+	VirtualMachineGuestCommandCredentialsUsernameKey = "username"
+
+	// VirtualMachineGuestCommandCredentialsPasswordKey is the key in the
+	// credentials Secret for the password of the guest user that runs the
+	// command.
+	VirtualMachineGuestCommandCredentialsPasswordKey = "password"
+)
+
+// VirtualMachineGuestCommandSpec defines the desired state of a
+// VirtualMachineGuestCommand.
+type VirtualMachineGuestCommandSpec struct {
+	// VMName is the name of the VirtualMachine resource, in the same
+	// Namespace as this resource, in whose guest the command is run.
+	VMName string `json:"vmName"`
+
+	// Command is the program that is run in the guest.
+	//
+	// On Linux guests, a command that is not an absolute path is run with
+	// "/bin/bash -c". On Windows guests, the command is run with "cmd.exe /c".
+	Command string `json:"command"`
+
+	// Args are the arguments passed to the command. Each argument is quoted
+	// so it is passed to the command as is, without being interpreted by the
+	// shell.
+	//
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env describes the environment variables set for the command.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	Env []common.KeyValuePair `json:"env,omitempty"`
+
+	// WorkingDir is the directory in the guest in which the command is run.
+	// When omitted, the command is run in the home directory of the guest
+	// user.
+	//
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// CredentialsSecretName is the name of the Secret, in the same Namespace
+	// as this resource, that contains the credentials of the guest user that
+	// runs the command. The Secret must have the "username" and "password"
+	// keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// Timeout describes how long the command may run before it is
+	// terminated. Defaults to five minutes.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// VirtualMachineGuestCommandStatus defines the observed state of a
+// VirtualMachineGuestCommand.
+type VirtualMachineGuestCommandStatus struct {
+	// PID describes the ID of the command's process in the guest.
+	//
+	// +optional
+	PID int64 `json:"pid,omitempty"`
+
+	// StartTime describes the time at which the command was started in the
+	// guest.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime describes the time at which the command exited, or was
+	// terminated, in the guest.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ExitCode describes the exit code of the command.
+	//
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Stdout describes the standard output of the command. The output is
+	// truncated to the first 4KiB.
+	//
+	// +optional
+	Stdout string `json:"stdout,omitempty"`
+
+	// StdoutTruncated is true when Stdout was truncated.
+	//
+	// +optional
+	StdoutTruncated bool `json:"stdoutTruncated,omitempty"`
+
+	// Stderr describes the standard error of the command. The output is
+	// truncated to the first 4KiB.
+	//
+	// +optional
+	Stderr string `json:"stderr,omitempty"`
+
+	// StderrTruncated is true when Stderr was truncated.
+	//
+	// +optional
+	StderrTruncated bool `json:"stderrTruncated,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineGuestCommand.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmguestcmd
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Command",type="string",JSONPath=".spec.command"
+// +kubebuilder:printcolumn:name="Exit-Code",type="integer",JSONPath=".status.exitCode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineGuestCommand is the schema for the virtualmachineguestcommands
+// API and represents a command that is run once in the guest of a
+// VirtualMachine through VM Tools.
+type VirtualMachineGuestCommand struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineGuestCommandSpec   `json:"spec,omitempty"`
+	Status VirtualMachineGuestCommandStatus `json:"status,omitempty"`
+}
+
+func (c *VirtualMachineGuestCommand) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+func (c *VirtualMachineGuestCommand) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineGuestCommandList contains a list of VirtualMachineGuestCommand
+// resources.
+type VirtualMachineGuestCommandList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineGuestCommand `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachineGuestCommand{},
+		&VirtualMachineGuestCommandList{},
+	)
+}
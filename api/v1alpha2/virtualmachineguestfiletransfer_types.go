@@ -0,0 +1,207 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineGuestFileTransferConditionCompleted is the Type for a
+	// VirtualMachineGuestFileTransfer resource's status condition.
+	//
+	// The condition's status is set to true only when the file has been
+	// copied.
+	VirtualMachineGuestFileTransferConditionCompleted = "GuestFileTransferCompleted"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineGuestFileTransfer.
+const (
+	// VirtualMachineGuestFileTransferVMNotFoundReason documents that the VM
+	// referenced by the VirtualMachineGuestFileTransfer does not exist.
+	VirtualMachineGuestFileTransferVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineGuestFileTransferVMNotCreatedReason documents that the VM
+	// referenced by the VirtualMachineGuestFileTransfer has not been created
+	// on the underlying infrastructure yet.
+	VirtualMachineGuestFileTransferVMNotCreatedReason = "VirtualMachineNotCreated"
+
+	// VirtualMachineGuestFileTransferObjectNotFoundReason documents that the
+	// Secret or ConfigMap from which the file is copied into the guest, or
+	// the key in that object, does not exist.
+	VirtualMachineGuestFileTransferObjectNotFoundReason = "ObjectNotFound"
+
+	// VirtualMachineGuestFileTransferSizeLimitExceededReason documents that
+	// the file is larger than the transfer's size limit and was not copied.
+	VirtualMachineGuestFileTransferSizeLimitExceededReason = "SizeLimitExceeded"
+
+	// VirtualMachineGuestFileTransferFailedReason documents that the file
+	// could not be copied, ex. because VM Tools is not running or the
+	// credentials are invalid.
+	VirtualMachineGuestFileTransferFailedReason = "Failed"
+)
+
+// VirtualMachineGuestFileTransferDirection describes the direction in which
+// a file is copied.
+type VirtualMachineGuestFileTransferDirection string
+
+const (
+	// VirtualMachineGuestFileTransferToGuest copies the content of an object
+	// into a file in the guest.
+	VirtualMachineGuestFileTransferToGuest VirtualMachineGuestFileTransferDirection = "ToGuest"
+
+	// VirtualMachineGuestFileTransferFromGuest copies a file in the guest into
+	// an object.
+	VirtualMachineGuestFileTransferFromGuest VirtualMachineGuestFileTransferDirection = "FromGuest"
+)
+
+// VirtualMachineGuestFileTransferObjectKind describes the kind of the object
+// whose content is copied to or from the guest.
+type VirtualMachineGuestFileTransferObjectKind string
+
+const (
+	VirtualMachineGuestFileTransferObjectKindSecret    VirtualMachineGuestFileTransferObjectKind = "Secret"
+	VirtualMachineGuestFileTransferObjectKindConfigMap VirtualMachineGuestFileTransferObjectKind = "ConfigMap"
+)
+
+// VirtualMachineGuestFileTransferObjectReference describes a key in a Secret
+// or ConfigMap whose value is the content of the copied file.
+type VirtualMachineGuestFileTransferObjectReference struct {
+	// Kind is the kind of the object. A file that is copied from the guest
+	// may only be copied into a ConfigMap.
+	//
+	// PersistentVolumeClaims are not supported since VM Operator cannot read
+	// or write the content of a volume without mounting it in a pod. To copy
+	// a file between a volume and the guest, copy it through a ConfigMap and
+	// a pod that mounts the volume.
+	//
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind VirtualMachineGuestFileTransferObjectKind `json:"kind"`
+
+	// Name is the name of the object, in the same Namespace as the
+	// VirtualMachineGuestFileTransfer.
+	//
+	// When the file is copied from the guest, the object is created if it
+	// does not exist.
+	//
+	// The user that creates the VirtualMachineGuestFileTransfer must be
+	// allowed to get the object when the file is copied into the guest, or
+	// to create and update the object when the file is copied from the
+	// guest.
+	Name string `json:"name"`
+
+	// Key is the key in the object's data whose value is the content of the
+	// file.
+	Key string `json:"key"`
+}
+
+// VirtualMachineGuestFileTransferSpec defines the desired state of a
+// VirtualMachineGuestFileTransfer.
+type VirtualMachineGuestFileTransferSpec struct {
+	// VMName is the name of the VirtualMachine resource, in the same
+	// Namespace as this resource, to or from whose guest the file is copied.
+	VMName string `json:"vmName"`
+
+	// CredentialsSecretName is the name of the Secret, in the same Namespace
+	// as this resource, that contains the credentials of the guest user that
+	// copies the file. The Secret must have the "username" and "password"
+	// keys, and the user that creates the VirtualMachineGuestFileTransfer
+	// must be allowed to get it.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// Direction describes whether the file is copied into or out of the
+	// guest.
+	//
+	// +kubebuilder:validation:Enum=ToGuest;FromGuest
+	Direction VirtualMachineGuestFileTransferDirection `json:"direction"`
+
+	// GuestPath is the absolute path of the file in the guest.
+	GuestPath string `json:"guestPath"`
+
+	// Object describes the Secret or ConfigMap key from which the file is
+	// copied into the guest, or into which the file is copied from the guest.
+	Object VirtualMachineGuestFileTransferObjectReference `json:"object"`
+
+	// Overwrite describes whether an existing file in the guest is replaced
+	// when the file is copied into the guest.
+	//
+	// +optional
+	Overwrite bool `json:"overwrite,omitempty"`
+
+	// SizeLimit describes the maximum size of the copied file. A file that
+	// is larger than the limit is not copied. Defaults to, and may not be
+	// greater than, 1Mi since that is the maximum size of a Secret or
+	// ConfigMap.
+	//
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// VirtualMachineGuestFileTransferStatus defines the observed state of a
+// VirtualMachineGuestFileTransfer.
+type VirtualMachineGuestFileTransferStatus struct {
+	// Size describes the size in bytes of the copied file.
+	//
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// CompletionTime describes the time at which the file was copied.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineGuestFileTransfer.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmguestfile
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Direction",type="string",JSONPath=".spec.direction"
+// +kubebuilder:printcolumn:name="Guest-Path",type="string",JSONPath=".spec.guestPath"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='GuestFileTransferCompleted')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineGuestFileTransfer is the schema for the
+// virtualmachineguestfiletransfers API and represents a file that is copied
+// once between a Secret or ConfigMap and the guest of a VirtualMachine
+// through VM Tools.
+type VirtualMachineGuestFileTransfer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineGuestFileTransferSpec   `json:"spec,omitempty"`
+	Status VirtualMachineGuestFileTransferStatus `json:"status,omitempty"`
+}
+
+func (t *VirtualMachineGuestFileTransfer) GetConditions() []metav1.Condition {
+	return t.Status.Conditions
+}
+
+func (t *VirtualMachineGuestFileTransfer) SetConditions(conditions []metav1.Condition) {
+	t.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineGuestFileTransferList contains a list of
+// VirtualMachineGuestFileTransfer resources.
+type VirtualMachineGuestFileTransferList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineGuestFileTransfer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachineGuestFileTransfer{},
+		&VirtualMachineGuestFileTransferList{},
+	)
+}
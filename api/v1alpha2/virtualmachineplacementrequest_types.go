@@ -0,0 +1,154 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachinePlacementRequestConditionCompleted is the Type for a
+	// VirtualMachinePlacementRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the placement dry run
+	// has completed, regardless of whether there are any recommendations.
+	VirtualMachinePlacementRequestConditionCompleted = "PlacementRequestCompleted"
+)
+
+// Condition.Reason for Conditions related to VirtualMachinePlacementRequest.
+const (
+	// VirtualMachinePlacementRequestFailedReason documents that the placement
+	// dry run could not be performed, ex. because the VirtualMachineClass or
+	// VirtualMachineImage does not exist.
+	VirtualMachinePlacementRequestFailedReason = "Failed"
+)
+
+// VirtualMachinePlacementRequestSpec defines the desired state of a
+// VirtualMachinePlacementRequest.
+type VirtualMachinePlacementRequestSpec struct {
+	// ClassName is the name of the VirtualMachineClass of the VM that would
+	// be placed.
+	ClassName string `json:"className"`
+
+	// ImageName is the name of the VirtualMachineImage or
+	// ClusterVirtualMachineImage of the VM that would be placed.
+	ImageName string `json:"imageName"`
+
+	// StorageClass is the name of the StorageClass of the VM that would be
+	// placed.
+	StorageClass string `json:"storageClass"`
+
+	// Zone is the name of the zone in which the VM would be placed. When
+	// omitted, all the zones of the Namespace are considered.
+	//
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// VirtualMachinePlacementRecommendation describes where a VM could be placed.
+type VirtualMachinePlacementRecommendation struct {
+	// ResourcePool is the managed object ID of the vSphere ResourcePool in
+	// which the VM would be created.
+	ResourcePool string `json:"resourcePool"`
+
+	// Host is the managed object ID of the ESXi host on which the VM would
+	// be created, when a host is required by the VM.
+	//
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Datastore is the managed object ID of the datastore on which the VM
+	// would be created, when recommended by DRS.
+	//
+	// +optional
+	Datastore string `json:"datastore,omitempty"`
+}
+
+// VirtualMachinePlacementZoneRecommendations describes the placement
+// recommendations within a zone.
+type VirtualMachinePlacementZoneRecommendations struct {
+	// Name is the name of the zone.
+	Name string `json:"name"`
+
+	// Recommendations describes where the VM could be placed in the zone,
+	// ordered by the preference of DRS.
+	//
+	// +optional
+	Recommendations []VirtualMachinePlacementRecommendation `json:"recommendations,omitempty"`
+}
+
+// VirtualMachinePlacementRequestStatus defines the observed state of a
+// VirtualMachinePlacementRequest.
+type VirtualMachinePlacementRequestStatus struct {
+	// Zones describes the placement recommendations of each zone in which
+	// the VM could be placed. Zones without any recommendations are omitted.
+	//
+	// +optional
+	Zones []VirtualMachinePlacementZoneRecommendations `json:"zones,omitempty"`
+
+	// Faults describes the DRS faults and errors that explain why the VM
+	// could not be placed in the candidate resource pools.
+	//
+	// +optional
+	Faults []string `json:"faults,omitempty"`
+
+	// CompletionTime describes the time at which the placement dry run
+	// completed.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachinePlacementRequest.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmplacement
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.className"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.imageName"
+// +kubebuilder:printcolumn:name="Zones",type="string",JSONPath=".status.zones[*].name"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='PlacementRequestCompleted')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachinePlacementRequest is the schema for the
+// virtualmachineplacementrequests API and represents a one time placement
+// dry run that reports where a VM with the given class, image and storage
+// class could be placed, or why it would not fit, without creating the VM.
+type VirtualMachinePlacementRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachinePlacementRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachinePlacementRequestStatus `json:"status,omitempty"`
+}
+
+func (r *VirtualMachinePlacementRequest) GetConditions() []metav1.Condition {
+	return r.Status.Conditions
+}
+
+func (r *VirtualMachinePlacementRequest) SetConditions(conditions []metav1.Condition) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachinePlacementRequestList contains a list of
+// VirtualMachinePlacementRequest resources.
+type VirtualMachinePlacementRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachinePlacementRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachinePlacementRequest{},
+		&VirtualMachinePlacementRequestList{},
+	)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineReplicaSetReplicasReadyCondition is the Type for a
+	// VirtualMachineReplicaSet resource's status condition.
+	//
+	// The condition's status is set to true only when all of the replica
+	// set's desired replicas are ready.
+	VirtualMachineReplicaSetReplicasReadyCondition = "ReplicasReady"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineReplicaSet.
+const (
+	// VirtualMachineReplicaSetScalingUpReason documents that the replica set
+	// has fewer replicas than desired.
+	VirtualMachineReplicaSetScalingUpReason = "ScalingUp"
+
+	// VirtualMachineReplicaSetScalingDownReason documents that the replica set
+	// has more replicas than desired.
+	VirtualMachineReplicaSetScalingDownReason = "ScalingDown"
+
+	// VirtualMachineReplicaSetWaitingForReplicasReadyReason documents that
+	// the replica set has the desired number of replicas but not all of them
+	// are ready.
+	VirtualMachineReplicaSetWaitingForReplicasReadyReason = "WaitingForReplicasReady"
+)
+
+// VirtualMachineTemplateObjectMeta describes the metadata given to the
+// VirtualMachine resources created from a VirtualMachineTemplateSpec.
+type VirtualMachineTemplateObjectMeta struct {
+	// Labels is a map of string keys and values applied to each
+	// VirtualMachine created from the template.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations is a map of string keys and values applied to each
+	// VirtualMachine created from the template.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// VirtualMachineTemplateSpec describes the VirtualMachine resources that are
+// created from a template.
+type VirtualMachineTemplateSpec struct {
+	// ObjectMeta describes the metadata given to the VirtualMachine resources
+	// created from this template.
+	//
+	// +optional
+	ObjectMeta VirtualMachineTemplateObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the desired state of the VirtualMachine resources created
+	// from this template.
+	//
+	// +optional
+	Spec VirtualMachineSpec `json:"spec,omitempty"`
+}
+
+// VirtualMachineReplicaSetSpec defines the desired state of a
+// VirtualMachineReplicaSet.
+type VirtualMachineReplicaSetSpec struct {
+	// Replicas is the number of desired replicas.
+	//
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over the VirtualMachine resources that are
+	// considered replicas of this replica set. It must match the labels of
+	// the template.
+	Selector *metav1.LabelSelector `json:"selector"`
+
+	// Template describes the VirtualMachine resources created when there are
+	// not enough replicas.
+	//
+	// The replicas are spread across the hosts of a zone when the template's
+	// spec.reserved.resourcePolicyName refers to a
+	// VirtualMachineSetResourcePolicy whose spec.clusterModuleGroups includes
+	// the name of this replica set. Replicas are then placed in the cluster
+	// module group with the same name as this replica set, unless the
+	// template already sets the vsphere-cluster-module-group annotation.
+	Template VirtualMachineTemplateSpec `json:"template"`
+}
+
+// VirtualMachineReplicaSetStatus defines the observed state of a
+// VirtualMachineReplicaSet.
+type VirtualMachineReplicaSetStatus struct {
+	// Replicas is the number of VirtualMachine resources that match the
+	// replica set's selector.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// AvailableReplicas is the number of replicas that have been created on
+	// the underlying infrastructure, i.e. the VirtualMachineCreated condition
+	// is true.
+	//
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// ReadyReplicas is the number of available replicas that are also ready.
+	// A replica is ready when its Ready condition, which is set by the VM's
+	// readiness probe, is true. Replicas without a readiness probe are ready
+	// once they are available.
+	//
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Selector is the string form of the replica set's label selector. It is
+	// used by the scale subresource.
+	//
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the
+	// replica set controller.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineReplicaSet.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmrs
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineReplicaSet is the schema for the virtualmachinereplicasets
+// API and maintains a stable set of identical VirtualMachine replicas.
+type VirtualMachineReplicaSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineReplicaSetSpec   `json:"spec,omitempty"`
+	Status VirtualMachineReplicaSetStatus `json:"status,omitempty"`
+}
+
+func (rs *VirtualMachineReplicaSet) GetConditions() []metav1.Condition {
+	return rs.Status.Conditions
+}
+
+func (rs *VirtualMachineReplicaSet) SetConditions(conditions []metav1.Condition) {
+	rs.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineReplicaSetList contains a list of VirtualMachineReplicaSet
+// resources.
+type VirtualMachineReplicaSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineReplicaSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachineReplicaSet{},
+		&VirtualMachineReplicaSetList{},
+	)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The names of the VM resources that may be limited by a
+// VirtualMachineResourceQuota. The usage of each VM is derived from the
+// hardware and policies of its VirtualMachineClass.
+const (
+	// VirtualMachineResourceCPU is the number of vCPUs.
+	VirtualMachineResourceCPU corev1.ResourceName = "cpu"
+
+	// VirtualMachineResourceMemory is the size of the memory.
+	VirtualMachineResourceMemory corev1.ResourceName = "memory"
+
+	// VirtualMachineResourceRequestsCPU is the CPU reservation.
+	VirtualMachineResourceRequestsCPU corev1.ResourceName = "requests.cpu"
+
+	// VirtualMachineResourceRequestsMemory is the memory reservation.
+	VirtualMachineResourceRequestsMemory corev1.ResourceName = "requests.memory"
+
+	// VirtualMachineResourceLimitsCPU is the CPU limit.
+	VirtualMachineResourceLimitsCPU corev1.ResourceName = "limits.cpu"
+
+	// VirtualMachineResourceLimitsMemory is the memory limit.
+	VirtualMachineResourceLimitsMemory corev1.ResourceName = "limits.memory"
+
+	// VirtualMachineResourceVGPUs is the number of vGPU devices.
+	VirtualMachineResourceVGPUs corev1.ResourceName = "vgpus"
+
+	// VirtualMachineResourceInstanceStorage is the size of the instance
+	// storage volumes.
+	VirtualMachineResourceInstanceStorage corev1.ResourceName = "instancestorage"
+)
+
+// VirtualMachineResourceQuotaSpec defines the desired state of a
+// VirtualMachineResourceQuota.
+type VirtualMachineResourceQuotaSpec struct {
+	// Hard is the set of enforced hard limits for each named VM resource.
+	// Creating a VM, or changing the class of a VM, is denied when the VMs in
+	// the namespace would use more of a resource than its limit.
+	//
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+}
+
+// VirtualMachineResourceQuotaStatus defines the observed state of a
+// VirtualMachineResourceQuota.
+type VirtualMachineResourceQuotaStatus struct {
+	// Hard is the set of enforced hard limits for each named VM resource.
+	//
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the current usage of each named VM resource by the VMs in the
+	// namespace, including the usage in Reservations.
+	//
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+
+	// Reservations is the usage reserved for the VMs that are being created,
+	// or whose class is being changed, until the change to the VM is observed
+	// or the reservation expires.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=vmName
+	Reservations []VirtualMachineResourceQuotaReservation `json:"reservations,omitempty"`
+}
+
+// VirtualMachineResourceQuotaReservation is the usage reserved for a VM that is
+// being created, or whose class is being changed.
+type VirtualMachineResourceQuotaReservation struct {
+	// VMName is the name of the VM the usage is reserved for.
+	VMName string `json:"vmName"`
+
+	// ClassName is the name of the VM's new VirtualMachineClass.
+	ClassName string `json:"className"`
+
+	// Resources is the usage reserved for the VM.
+	//
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+
+	// ExpirationTime is when the reservation expires.
+	ExpirationTime metav1.Time `json:"expirationTime"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmquota
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineResourceQuota is the schema for the
+// virtualmachineresourcequotas API and represents limits on the total CPU,
+// memory, vGPU and instance storage of the VirtualMachines in a namespace.
+type VirtualMachineResourceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineResourceQuotaSpec   `json:"spec,omitempty"`
+	Status VirtualMachineResourceQuotaStatus `json:"status,omitempty"`
+}
+
+func (q *VirtualMachineResourceQuota) NamespacedName() string {
+	return q.Namespace + "/" + q.Name
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineResourceQuotaList contains a list of
+// VirtualMachineResourceQuota.
+type VirtualMachineResourceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineResourceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachineResourceQuota{},
+		&VirtualMachineResourceQuotaList{},
+	)
+}
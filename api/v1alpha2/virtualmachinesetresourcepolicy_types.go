@@ -7,6 +7,63 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ResourcePoolSharesLevel describes the level of a ResourcePool's shares.
+type ResourcePoolSharesLevel string
+
+const (
+	// ResourcePoolSharesLevelLow describes half the shares of the Normal level.
+	ResourcePoolSharesLevelLow ResourcePoolSharesLevel = "Low"
+
+	// ResourcePoolSharesLevelNormal describes the default shares.
+	ResourcePoolSharesLevelNormal ResourcePoolSharesLevel = "Normal"
+
+	// ResourcePoolSharesLevelHigh describes twice the shares of the Normal
+	// level.
+	ResourcePoolSharesLevelHigh ResourcePoolSharesLevel = "High"
+
+	// ResourcePoolSharesLevelCustom describes an explicit number of shares.
+	ResourcePoolSharesLevelCustom ResourcePoolSharesLevel = "Custom"
+)
+
+// ResourcePoolShares describes the shares of a ResourcePool's resource. When
+// there is contention, the resource is divided between the sibling
+// ResourcePools in proportion to their shares.
+type ResourcePoolShares struct {
+	// Level describes the level of the shares.
+	//
+	// +kubebuilder:validation:Enum=Low;Normal;High;Custom
+	Level ResourcePoolSharesLevel `json:"level"`
+
+	// Shares describes the number of shares when the level is Custom, and is
+	// ignored otherwise.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Shares int32 `json:"shares,omitempty"`
+}
+
+// ResourcePoolSharesSpec describes the CPU and memory shares of a
+// ResourcePool.
+type ResourcePoolSharesSpec struct {
+	// +optional
+	CPU *ResourcePoolShares `json:"cpu,omitempty"`
+
+	// +optional
+	Memory *ResourcePoolShares `json:"memory,omitempty"`
+}
+
+// ResourcePoolExpandableReservationSpec describes whether the CPU and memory
+// reservations of a ResourcePool are expandable. The VMs in a ResourcePool
+// with an expandable reservation may use the unreserved resources of the
+// parent ResourcePool once the ResourcePool's reservation is used.
+type ResourcePoolExpandableReservationSpec struct {
+	// +optional
+	CPU *bool `json:"cpu,omitempty"`
+
+	// +optional
+	Memory *bool `json:"memory,omitempty"`
+}
+
 // ResourcePoolSpec defines a Logical Grouping of workloads that share resource
 // policies.
 type ResourcePoolSpec struct {
@@ -22,6 +79,48 @@ type ResourcePoolSpec struct {
 	// Limits describes the limit to resources available to the ResourcePool.
 	// +optional
 	Limits VirtualMachineResourceSpec `json:"limits,omitempty"`
+
+	// Shares describes the CPU and memory shares of the ResourcePool. The
+	// vSphere default, Normal, is used when not specified.
+	// +optional
+	Shares ResourcePoolSharesSpec `json:"shares,omitempty"`
+
+	// ExpandableReservation describes whether the CPU and memory reservations
+	// of the ResourcePool are expandable. The vSphere default, expandable, is
+	// used when not specified.
+	// +optional
+	ExpandableReservation ResourcePoolExpandableReservationSpec `json:"expandableReservation,omitempty"`
+}
+
+// VirtualMachineSetResourcePolicyHostAffinity describes the ESXi hosts on which
+// the VMs that use a resource policy run.
+type VirtualMachineSetResourcePolicyHostAffinity struct {
+	// NodeSelector selects the ESXi hosts by the labels of their Nodes.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+
+	// Required describes whether the VMs must run on the selected hosts. When
+	// false, DRS prefers to run the VMs on the selected hosts but may run them
+	// on the other hosts in the cluster.
+	//
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// VirtualMachineSetResourcePolicyTopologySpreadConstraint describes how the VMs
+// that use a resource policy are spread across a topology.
+type VirtualMachineSetResourcePolicyTopologySpreadConstraint struct {
+	// MaxSkew describes the maximum difference between the number of VMs in
+	// a topology domain and the minimum number of VMs in any of the domains.
+	// A VM is not placed in a domain if doing so would exceed the skew.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxSkew int32 `json:"maxSkew"`
+
+	// TopologyKey describes the topology across which the VMs are spread.
+	// Only the zone topology key, topology.kubernetes.io/zone, is supported.
+	//
+	// +kubebuilder:validation:Enum=topology.kubernetes.io/zone
+	TopologyKey string `json:"topologyKey"`
 }
 
 // VirtualMachineSetResourcePolicySpec defines the desired state of
@@ -30,12 +129,67 @@ type VirtualMachineSetResourcePolicySpec struct {
 	ResourcePool        ResourcePoolSpec `json:"resourcePool,omitempty"`
 	Folder              string           `json:"folder,omitempty"`
 	ClusterModuleGroups []string         `json:"clusterModuleGroups,omitempty"`
+
+	// HostAffinity describes the ESXi hosts on which the VMs that use this
+	// resource policy run. A DRS host group of the selected hosts, a DRS VM
+	// group of the VMs, and a VM-Host rule between them are maintained on
+	// each of the Namespace's clusters.
+	//
+	// +optional
+	HostAffinity *VirtualMachineSetResourcePolicyHostAffinity `json:"hostAffinity,omitempty"`
+
+	// TopologySpreadConstraint describes how the VMs that use this resource
+	// policy are spread across zones. When a VM is placed, the number of the
+	// other VMs that use this resource policy in each zone is considered so
+	// that the VM is placed in a zone with the fewest such VMs.
+	//
+	// +optional
+	TopologySpreadConstraint *VirtualMachineSetResourcePolicyTopologySpreadConstraint `json:"topologySpreadConstraint,omitempty"`
 }
 
 // VirtualMachineSetResourcePolicyStatus defines the observed state of
 // VirtualMachineSetResourcePolicy.
 type VirtualMachineSetResourcePolicyStatus struct {
 	ClusterModules []VSphereClusterModuleStatus `json:"clustermodules,omitempty"`
+
+	// ResourcePools describes the observed usage of the child ResourcePool in
+	// each of the Namespace's zones and clusters.
+	//
+	// +optional
+	ResourcePools []ResourcePoolStatus `json:"resourcePools,omitempty"`
+}
+
+// ResourcePoolResourceUsage describes the observed usage of a ResourcePool's
+// CPU or memory. CPU is in MHz and memory is in MiB.
+type ResourcePoolResourceUsage struct {
+	// Usage describes the amount of the resource used by the ResourcePool's
+	// VMs.
+	Usage int64 `json:"usage"`
+
+	// ReservationUsed describes the amount of the ResourcePool's reservation
+	// that is used by its VMs and child ResourcePools.
+	ReservationUsed int64 `json:"reservationUsed"`
+
+	// ReservationRemaining describes the amount of reservation that remains
+	// available to a VM powered on in the ResourcePool. This includes the
+	// unreserved resources of the parent ResourcePool when the reservation is
+	// expandable.
+	ReservationRemaining int64 `json:"reservationRemaining"`
+}
+
+// ResourcePoolStatus describes the observed state of a child ResourcePool.
+type ResourcePoolStatus struct {
+	// Zone describes the zone of the ResourcePool.
+	Zone string `json:"zone"`
+
+	// ClusterMoID describes the cluster of the ResourcePool.
+	ClusterMoID string `json:"clusterMoID"`
+
+	// CPU describes the CPU usage of the ResourcePool.
+	CPU ResourcePoolResourceUsage `json:"cpu"`
+
+	// Memory describes the memory usage of the ResourcePool.
+	Memory ResourcePoolResourceUsage `json:"memory"`
 }
 
 // VSphereClusterModuleStatus describes the observed state of a vSphere
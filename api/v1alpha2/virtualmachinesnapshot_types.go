@@ -0,0 +1,168 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineSnapshotConditionCreated is the Type for a
+	// VirtualMachineSnapshot resource's status condition.
+	//
+	// The condition's status is set to true only when the snapshot has been
+	// created on the underlying infrastructure.
+	VirtualMachineSnapshotConditionCreated = "SnapshotCreated"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineSnapshot.
+const (
+	// VirtualMachineSnapshotVMNotFoundReason documents that the VM referenced
+	// by the VirtualMachineSnapshot does not exist.
+	VirtualMachineSnapshotVMNotFoundReason = "VirtualMachineNotFound"
+
+	// VirtualMachineSnapshotVMNotCreatedReason documents that the VM
+	// referenced by the VirtualMachineSnapshot has not been created on the
+	// underlying infrastructure yet.
+	VirtualMachineSnapshotVMNotCreatedReason = "VirtualMachineNotCreated"
+
+	// VirtualMachineSnapshotCreateFailedReason documents that the snapshot
+	// could not be created on the underlying infrastructure.
+	VirtualMachineSnapshotCreateFailedReason = "CreateFailed"
+)
+
+// VirtualMachineSnapshotSpec defines the desired state of a
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotSpec struct {
+	// VMName is the name of the VirtualMachine resource, in the same
+	// Namespace as this snapshot, for which the snapshot is taken.
+	VMName string `json:"vmName"`
+
+	// Description is an optional description of the snapshot.
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Memory describes whether or not the snapshot includes a dump of the
+	// VM's memory. This only applies when the VM is powered on.
+	//
+	// Please note including the VM's memory in the snapshot allows the VM to
+	// be reverted to a running state, but increases the time it takes to
+	// create the snapshot as well as the size of the snapshot.
+	//
+	// +optional
+	Memory bool `json:"memory,omitempty"`
+
+	// Quiesce describes whether or not the guest file system is quiesced
+	// before the snapshot is taken. This requires VM Tools to be running in
+	// the guest and only applies when the VM is powered on.
+	//
+	// +optional
+	Quiesce bool `json:"quiesce,omitempty"`
+}
+
+// VirtualMachineSnapshotStatus defines the observed state of a
+// VirtualMachineSnapshot.
+type VirtualMachineSnapshotStatus struct {
+	// UniqueID describes a unique identifier for the snapshot that is
+	// provided by the underlying infrastructure provider, such as vSphere.
+	// It is recorded when the snapshot is created and identifies the
+	// snapshot when it is deleted.
+	//
+	// +optional
+	UniqueID string `json:"uniqueID,omitempty"`
+
+	// CreationTime describes the time at which the snapshot was created on
+	// the underlying infrastructure.
+	//
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// Ready is set to true only when the snapshot has been created on the
+	// underlying infrastructure.
+	//
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Conditions describes the observed conditions of the
+	// VirtualMachineSnapshot.
+	//
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VirtualMachineSnapshotTreeStatus describes the observed state of a single
+// snapshot in a VM's snapshot tree.
+type VirtualMachineSnapshotTreeStatus struct {
+	// Name describes the name of the snapshot.
+	//
+	// Snapshots created with a VirtualMachineSnapshot resource have the same
+	// name as that resource.
+	Name string `json:"name"`
+
+	// ID describes a unique identifier for the snapshot that is provided by
+	// the underlying infrastructure provider, such as vSphere.
+	ID string `json:"id"`
+
+	// Parent describes the ID of this snapshot's parent snapshot.
+	//
+	// This field is empty for snapshots at the root of the tree.
+	//
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// Size describes the amount of storage consumed by the snapshot.
+	//
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// CreationTime describes the time at which the snapshot was created.
+	//
+	// +optional
+	CreationTime metav1.Time `json:"creationTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmsnapshot
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".spec.vmName"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineSnapshot is the schema for the virtualmachinesnapshots API and
+// represents a point-in-time snapshot of a VirtualMachine.
+type VirtualMachineSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineSnapshotSpec   `json:"spec,omitempty"`
+	Status VirtualMachineSnapshotStatus `json:"status,omitempty"`
+}
+
+func (s *VirtualMachineSnapshot) GetConditions() []metav1.Condition {
+	return s.Status.Conditions
+}
+
+func (s *VirtualMachineSnapshot) SetConditions(conditions []metav1.Condition) {
+	s.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineSnapshotList contains a list of VirtualMachineSnapshot
+// resources.
+type VirtualMachineSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VirtualMachineSnapshot{},
+		&VirtualMachineSnapshotList{},
+	)
+}
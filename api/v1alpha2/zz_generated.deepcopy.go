@@ -13,6 +13,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -91,6 +92,26 @@ func (in *DynamicDirectPathIODevice) DeepCopy() *DynamicDirectPathIODevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestExecAction) DeepCopyInto(out *GuestExecAction) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestExecAction.
+func (in *GuestExecAction) DeepCopy() *GuestExecAction {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestExecAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GuestHeartbeatAction) DeepCopyInto(out *GuestHeartbeatAction) {
 	*out = *in
@@ -121,6 +142,62 @@ func (in *GuestInfoAction) DeepCopy() *GuestInfoAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetAction) DeepCopyInto(out *HTTPGetAction) {
+	*out = *in
+	out.Port = in.Port
+	if in.HTTPHeaders != nil {
+		in, out := &in.HTTPHeaders, &out.HTTPHeaders
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = new(HTTPStatusCodeRange)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetAction.
+func (in *HTTPGetAction) DeepCopy() *HTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPStatusCodeRange) DeepCopyInto(out *HTTPStatusCodeRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPStatusCodeRange.
+func (in *HTTPStatusCodeRange) DeepCopy() *HTTPStatusCodeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPStatusCodeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceStorage) DeepCopyInto(out *InstanceStorage) {
 	*out = *in
@@ -298,11 +375,93 @@ func (in *PersistentVolumeClaimVolumeSource) DeepCopy() *PersistentVolumeClaimVo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolExpandableReservationSpec) DeepCopyInto(out *ResourcePoolExpandableReservationSpec) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolExpandableReservationSpec.
+func (in *ResourcePoolExpandableReservationSpec) DeepCopy() *ResourcePoolExpandableReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolExpandableReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolResourceUsage) DeepCopyInto(out *ResourcePoolResourceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolResourceUsage.
+func (in *ResourcePoolResourceUsage) DeepCopy() *ResourcePoolResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolShares) DeepCopyInto(out *ResourcePoolShares) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolShares.
+func (in *ResourcePoolShares) DeepCopy() *ResourcePoolShares {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolShares)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolSharesSpec) DeepCopyInto(out *ResourcePoolSharesSpec) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(ResourcePoolShares)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(ResourcePoolShares)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolSharesSpec.
+func (in *ResourcePoolSharesSpec) DeepCopy() *ResourcePoolSharesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolSharesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourcePoolSpec) DeepCopyInto(out *ResourcePoolSpec) {
 	*out = *in
 	in.Reservations.DeepCopyInto(&out.Reservations)
 	in.Limits.DeepCopyInto(&out.Limits)
+	in.Shares.DeepCopyInto(&out.Shares)
+	in.ExpandableReservation.DeepCopyInto(&out.ExpandableReservation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolSpec.
@@ -315,6 +474,23 @@ func (in *ResourcePoolSpec) DeepCopy() *ResourcePoolSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolStatus) DeepCopyInto(out *ResourcePoolStatus) {
+	*out = *in
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolStatus.
+func (in *ResourcePoolStatus) DeepCopy() *ResourcePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPSocketAction) DeepCopyInto(out *TCPSocketAction) {
 	*out = *in
@@ -346,6 +522,26 @@ func (in *VGPUDevice) DeepCopy() *VGPUDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAffinityTerm) DeepCopyInto(out *VMAffinityTerm) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAffinityTerm.
+func (in *VMAffinityTerm) DeepCopy() *VMAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterModuleStatus) DeepCopyInto(out *VSphereClusterModuleStatus) {
 	*out = *in
@@ -433,6 +629,90 @@ func (in *VirtualMachineAdvancedSpec) DeepCopy() *VirtualMachineAdvancedSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAffinitySpec) DeepCopyInto(out *VirtualMachineAffinitySpec) {
+	*out = *in
+	if in.VMAffinity != nil {
+		in, out := &in.VMAffinity, &out.VMAffinity
+		*out = new(VirtualMachineAffinityVMAffinitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAntiAffinity != nil {
+		in, out := &in.VMAntiAffinity, &out.VMAntiAffinity
+		*out = new(VirtualMachineAffinityVMAffinitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAffinitySpec.
+func (in *VirtualMachineAffinitySpec) DeepCopy() *VirtualMachineAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineAffinityVMAffinitySpec) DeepCopyInto(out *VirtualMachineAffinityVMAffinitySpec) {
+	*out = *in
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]VMAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Preferred != nil {
+		in, out := &in.Preferred, &out.Preferred
+		*out = make([]VMAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineAffinityVMAffinitySpec.
+func (in *VirtualMachineAffinityVMAffinitySpec) DeepCopy() *VirtualMachineAffinityVMAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineAffinityVMAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootOptions) DeepCopyInto(out *VirtualMachineBootOptions) {
+	*out = *in
+	if in.BootOrder != nil {
+		in, out := &in.BootOrder, &out.BootOrder
+		*out = make([]VirtualMachineBootableDevice, len(*in))
+		copy(*out, *in)
+	}
+	if in.BootDelay != nil {
+		in, out := &in.BootDelay, &out.BootDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BootRetryDelay != nil {
+		in, out := &in.BootRetryDelay, &out.BootRetryDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootOptions.
+func (in *VirtualMachineBootOptions) DeepCopy() *VirtualMachineBootOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineBootstrapCloudInitSpec) DeepCopyInto(out *VirtualMachineBootstrapCloudInitSpec) {
 	*out = *in
@@ -566,7 +846,7 @@ func (in *VirtualMachineClass) DeepCopyInto(out *VirtualMachineClass) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineClass.
@@ -587,6 +867,26 @@ func (in *VirtualMachineClass) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineClassClusterStatus) DeepCopyInto(out *VirtualMachineClassClusterStatus) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineClassClusterStatus.
+func (in *VirtualMachineClassClusterStatus) DeepCopy() *VirtualMachineClassClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineClassClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineClassHardware) DeepCopyInto(out *VirtualMachineClassHardware) {
 	*out = *in
@@ -695,6 +995,20 @@ func (in *VirtualMachineClassSpec) DeepCopy() *VirtualMachineClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineClassStatus) DeepCopyInto(out *VirtualMachineClassStatus) {
 	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]VirtualMachineClassZoneStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineClassStatus.
@@ -708,152 +1022,192 @@ func (in *VirtualMachineClassStatus) DeepCopy() *VirtualMachineClassStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineConfigSpec) DeepCopyInto(out *VirtualMachineConfigSpec) {
+func (in *VirtualMachineClassZoneStatus) DeepCopyInto(out *VirtualMachineClassZoneStatus) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]VirtualMachineClassClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineConfigSpec.
-func (in *VirtualMachineConfigSpec) DeepCopy() *VirtualMachineConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineClassZoneStatus.
+func (in *VirtualMachineClassZoneStatus) DeepCopy() *VirtualMachineClassZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineConfigSpec)
+	out := new(VirtualMachineClassZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImage) DeepCopyInto(out *VirtualMachineImage) {
+func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImage.
-func (in *VirtualMachineImage) DeepCopy() *VirtualMachineImage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCloneSpec.
+func (in *VirtualMachineCloneSpec) DeepCopy() *VirtualMachineCloneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImage)
+	out := new(VirtualMachineCloneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImage) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineConfigSpec) DeepCopyInto(out *VirtualMachineConfigSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineConfigSpec.
+func (in *VirtualMachineConfigSpec) DeepCopy() *VirtualMachineConfigSpec {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(VirtualMachineConfigSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
+func (in *VirtualMachineCryptoSpec) DeepCopyInto(out *VirtualMachineCryptoSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachineImage, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
-func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCryptoSpec.
+func (in *VirtualMachineCryptoSpec) DeepCopy() *VirtualMachineCryptoSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageList)
+	out := new(VirtualMachineCryptoSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
+func (in *VirtualMachineCryptoStatus) DeepCopyInto(out *VirtualMachineCryptoStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
-func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCryptoStatus.
+func (in *VirtualMachineCryptoStatus) DeepCopy() *VirtualMachineCryptoStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageOSInfo)
+	out := new(VirtualMachineCryptoStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+func (in *VirtualMachineGuestCommand) DeepCopyInto(out *VirtualMachineGuestCommand) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
-func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestCommand.
+func (in *VirtualMachineGuestCommand) DeepCopy() *VirtualMachineGuestCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageProductInfo)
+	out := new(VirtualMachineGuestCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineGuestCommand) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+func (in *VirtualMachineGuestCommandList) DeepCopyInto(out *VirtualMachineGuestCommandList) {
 	*out = *in
-	out.ProviderRef = in.ProviderRef
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineGuestCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
-func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestCommandList.
+func (in *VirtualMachineGuestCommandList) DeepCopy() *VirtualMachineGuestCommandList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageSpec)
+	out := new(VirtualMachineGuestCommandList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineGuestCommandList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+func (in *VirtualMachineGuestCommandSpec) DeepCopyInto(out *VirtualMachineGuestCommandSpec) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.HardwareVersion != nil {
-		in, out := &in.HardwareVersion, &out.HardwareVersion
-		*out = new(int32)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	out.OSInfo = in.OSInfo
-	if in.OVFProperties != nil {
-		in, out := &in.OVFProperties, &out.OVFProperties
-		*out = make([]OVFProperty, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestCommandSpec.
+func (in *VirtualMachineGuestCommandSpec) DeepCopy() *VirtualMachineGuestCommandSpec {
+	if in == nil {
+		return nil
 	}
-	if in.VMwareSystemProperties != nil {
-		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+	out := new(VirtualMachineGuestCommandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestCommandStatus) DeepCopyInto(out *VirtualMachineGuestCommandStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
 	}
-	out.ProductInfo = in.ProductInfo
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -863,42 +1217,94 @@ func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
-func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestCommandStatus.
+func (in *VirtualMachineGuestCommandStatus) DeepCopy() *VirtualMachineGuestCommandStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageStatus)
+	out := new(VirtualMachineGuestCommandStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
+func (in *VirtualMachineGuestDiskStatus) DeepCopyInto(out *VirtualMachineGuestDiskStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.FreeSpace != nil {
+		in, out := &in.FreeSpace, &out.FreeSpace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestDiskStatus.
+func (in *VirtualMachineGuestDiskStatus) DeepCopy() *VirtualMachineGuestDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestFileTransfer) DeepCopyInto(out *VirtualMachineGuestFileTransfer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFileTransfer.
+func (in *VirtualMachineGuestFileTransfer) DeepCopy() *VirtualMachineGuestFileTransfer {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestFileTransfer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineGuestFileTransfer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestFileTransferList) DeepCopyInto(out *VirtualMachineGuestFileTransferList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachine, len(*in))
+		*out = make([]VirtualMachineGuestFileTransfer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineList.
-func (in *VirtualMachineList) DeepCopy() *VirtualMachineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFileTransferList.
+func (in *VirtualMachineGuestFileTransferList) DeepCopy() *VirtualMachineGuestFileTransferList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineList)
+	out := new(VirtualMachineGuestFileTransferList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineGuestFileTransferList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -906,309 +1312,974 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPOptionsStatus) {
+func (in *VirtualMachineGuestFileTransferObjectReference) DeepCopyInto(out *VirtualMachineGuestFileTransferObjectReference) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPOptionsStatus.
-func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkDHCPOptionsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFileTransferObjectReference.
+func (in *VirtualMachineGuestFileTransferObjectReference) DeepCopy() *VirtualMachineGuestFileTransferObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDHCPOptionsStatus)
+	out := new(VirtualMachineGuestFileTransferObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPStatus) {
+func (in *VirtualMachineGuestFileTransferSpec) DeepCopyInto(out *VirtualMachineGuestFileTransferSpec) {
 	*out = *in
-	in.IP4.DeepCopyInto(&out.IP4)
-	in.IP6.DeepCopyInto(&out.IP6)
+	out.Object = in.Object
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPStatus.
-func (in *VirtualMachineNetworkDHCPStatus) DeepCopy() *VirtualMachineNetworkDHCPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFileTransferSpec.
+func (in *VirtualMachineGuestFileTransferSpec) DeepCopy() *VirtualMachineGuestFileTransferSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDHCPStatus)
+	out := new(VirtualMachineGuestFileTransferSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkDNSStatus) DeepCopyInto(out *VirtualMachineNetworkDNSStatus) {
+func (in *VirtualMachineGuestFileTransferStatus) DeepCopyInto(out *VirtualMachineGuestFileTransferStatus) {
 	*out = *in
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	if in.SearchDomains != nil {
-		in, out := &in.SearchDomains, &out.SearchDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDNSStatus.
-func (in *VirtualMachineNetworkDNSStatus) DeepCopy() *VirtualMachineNetworkDNSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFileTransferStatus.
+func (in *VirtualMachineGuestFileTransferStatus) DeepCopy() *VirtualMachineGuestFileTransferStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkDNSStatus)
+	out := new(VirtualMachineGuestFileTransferStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteGatewayStatus) {
+func (in *VirtualMachineImage) DeepCopyInto(out *VirtualMachineImage) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteGatewayStatus.
-func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopy() *VirtualMachineNetworkIPRouteGatewayStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImage.
+func (in *VirtualMachineImage) DeepCopy() *VirtualMachineImage {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPRouteGatewayStatus)
+	out := new(VirtualMachineImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPRouteStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteStatus) {
+func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
 	*out = *in
-	out.Gateway = in.Gateway
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteStatus.
-func (in *VirtualMachineNetworkIPRouteStatus) DeepCopy() *VirtualMachineNetworkIPRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
+func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPRouteStatus)
+	out := new(VirtualMachineImageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkIPStackStatus) DeepCopyInto(out *VirtualMachineNetworkIPStackStatus) {
+func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
 	*out = *in
-	in.DHCP.DeepCopyInto(&out.DHCP)
-	in.DNS.DeepCopyInto(&out.DNS)
-	if in.IPRoutes != nil {
-		in, out := &in.IPRoutes, &out.IPRoutes
-		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.KernelConfig != nil {
-		in, out := &in.KernelConfig, &out.KernelConfig
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
+func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+	if in == nil {
+		return nil
 	}
+	out := new(VirtualMachineImageOSInfo)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPStackStatus.
-func (in *VirtualMachineNetworkIPStackStatus) DeepCopy() *VirtualMachineNetworkIPStackStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
+func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkIPStackStatus)
-	in.DeepCopyInto(out)
-	return out
+	out := new(VirtualMachineImageProductInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
+func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HardwareVersion != nil {
+		in, out := &in.HardwareVersion, &out.HardwareVersion
+		*out = new(int32)
+		**out = **in
+	}
+	out.OSInfo = in.OSInfo
+	if in.OVFProperties != nil {
+		in, out := &in.OVFProperties, &out.OVFProperties
+		*out = make([]OVFProperty, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VMwareSystemProperties != nil {
+		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+	out.ProductInfo = in.ProductInfo
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
+func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineList.
+func (in *VirtualMachineList) DeepCopy() *VirtualMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineLivenessProbeSpec) DeepCopyInto(out *VirtualMachineLivenessProbeSpec) {
+	*out = *in
+	in.VirtualMachineReadinessProbeSpec.DeepCopyInto(&out.VirtualMachineReadinessProbeSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineLivenessProbeSpec.
+func (in *VirtualMachineLivenessProbeSpec) DeepCopy() *VirtualMachineLivenessProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineLivenessProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPOptionsStatus) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPOptionsStatus.
+func (in *VirtualMachineNetworkDHCPOptionsStatus) DeepCopy() *VirtualMachineNetworkDHCPOptionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDHCPOptionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDHCPStatus) DeepCopyInto(out *VirtualMachineNetworkDHCPStatus) {
+	*out = *in
+	in.IP4.DeepCopyInto(&out.IP4)
+	in.IP6.DeepCopyInto(&out.IP6)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDHCPStatus.
+func (in *VirtualMachineNetworkDHCPStatus) DeepCopy() *VirtualMachineNetworkDHCPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDHCPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkDNSStatus) DeepCopyInto(out *VirtualMachineNetworkDNSStatus) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkDNSStatus.
+func (in *VirtualMachineNetworkDNSStatus) DeepCopy() *VirtualMachineNetworkDNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkDNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteGatewayStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteGatewayStatus.
+func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopy() *VirtualMachineNetworkIPRouteGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPRouteGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPRouteStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteStatus) {
+	*out = *in
+	out.Gateway = in.Gateway
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPRouteStatus.
+func (in *VirtualMachineNetworkIPRouteStatus) DeepCopy() *VirtualMachineNetworkIPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkIPStackStatus) DeepCopyInto(out *VirtualMachineNetworkIPStackStatus) {
+	*out = *in
+	in.DHCP.DeepCopyInto(&out.DHCP)
+	in.DNS.DeepCopyInto(&out.DNS)
+	if in.IPRoutes != nil {
+		in, out := &in.IPRoutes, &out.IPRoutes
+		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelConfig != nil {
+		in, out := &in.KernelConfig, &out.KernelConfig
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkIPStackStatus.
+func (in *VirtualMachineNetworkIPStackStatus) DeepCopy() *VirtualMachineNetworkIPStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkIPStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPAddrStatus) {
+	*out = *in
+	in.Lifetime.DeepCopyInto(&out.Lifetime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPAddrStatus.
+func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPAddrStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceIPAddrStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPStatus) {
+	*out = *in
+	if in.AutoConfigurationEnabled != nil {
+		in, out := &in.AutoConfigurationEnabled, &out.AutoConfigurationEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.DHCP.DeepCopyInto(&out.DHCP)
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]VirtualMachineNetworkInterfaceIPAddrStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPStatus.
+func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSpec) {
+	*out = *in
+	out.Network = in.Network
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSpec.
+func (in *VirtualMachineNetworkInterfaceSpec) DeepCopy() *VirtualMachineNetworkInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceStatus) {
+	*out = *in
+	in.IP.DeepCopyInto(&out.IP)
+	in.DNS.DeepCopyInto(&out.DNS)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceStatus.
+func (in *VirtualMachineNetworkInterfaceStatus) DeepCopy() *VirtualMachineNetworkInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkRouteSpec) DeepCopyInto(out *VirtualMachineNetworkRouteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteSpec.
+func (in *VirtualMachineNetworkRouteSpec) DeepCopy() *VirtualMachineNetworkRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkRouteStatus) DeepCopyInto(out *VirtualMachineNetworkRouteStatus) {
+	*out = *in
+	if in.IPRoutes != nil {
+		in, out := &in.IPRoutes, &out.IPRoutes
+		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteStatus.
+func (in *VirtualMachineNetworkRouteStatus) DeepCopy() *VirtualMachineNetworkRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkSpec) DeepCopyInto(out *VirtualMachineNetworkSpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkSpec.
+func (in *VirtualMachineNetworkSpec) DeepCopy() *VirtualMachineNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkStatus) DeepCopyInto(out *VirtualMachineNetworkStatus) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.VirtualMachineNetworkIPStackStatus.DeepCopyInto(&out.VirtualMachineNetworkIPStackStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkStatus.
+func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRecommendation) DeepCopyInto(out *VirtualMachinePlacementRecommendation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRecommendation.
+func (in *VirtualMachinePlacementRecommendation) DeepCopy() *VirtualMachinePlacementRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequest) DeepCopyInto(out *VirtualMachinePlacementRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequest.
+func (in *VirtualMachinePlacementRequest) DeepCopy() *VirtualMachinePlacementRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePlacementRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequestList) DeepCopyInto(out *VirtualMachinePlacementRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePlacementRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestList.
+func (in *VirtualMachinePlacementRequestList) DeepCopy() *VirtualMachinePlacementRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePlacementRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequestSpec) DeepCopyInto(out *VirtualMachinePlacementRequestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestSpec.
+func (in *VirtualMachinePlacementRequestSpec) DeepCopy() *VirtualMachinePlacementRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementRequestStatus) DeepCopyInto(out *VirtualMachinePlacementRequestStatus) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]VirtualMachinePlacementZoneRecommendations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Faults != nil {
+		in, out := &in.Faults, &out.Faults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementRequestStatus.
+func (in *VirtualMachinePlacementRequestStatus) DeepCopy() *VirtualMachinePlacementRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePlacementZoneRecommendations) DeepCopyInto(out *VirtualMachinePlacementZoneRecommendations) {
+	*out = *in
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]VirtualMachinePlacementRecommendation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePlacementZoneRecommendations.
+func (in *VirtualMachinePlacementZoneRecommendations) DeepCopy() *VirtualMachinePlacementZoneRecommendations {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePlacementZoneRecommendations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequest) DeepCopyInto(out *VirtualMachinePublishRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequest.
+func (in *VirtualMachinePublishRequest) DeepCopy() *VirtualMachinePublishRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePublishRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestList) DeepCopyInto(out *VirtualMachinePublishRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestList.
+func (in *VirtualMachinePublishRequestList) DeepCopy() *VirtualMachinePublishRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachinePublishRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPAddrStatus) {
+func (in *VirtualMachinePublishRequestSource) DeepCopyInto(out *VirtualMachinePublishRequestSource) {
 	*out = *in
-	in.Lifetime.DeepCopyInto(&out.Lifetime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPAddrStatus.
-func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPAddrStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestSource.
+func (in *VirtualMachinePublishRequestSource) DeepCopy() *VirtualMachinePublishRequestSource {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceIPAddrStatus)
+	out := new(VirtualMachinePublishRequestSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPStatus) {
+func (in *VirtualMachinePublishRequestSpec) DeepCopyInto(out *VirtualMachinePublishRequestSpec) {
 	*out = *in
-	if in.AutoConfigurationEnabled != nil {
-		in, out := &in.AutoConfigurationEnabled, &out.AutoConfigurationEnabled
-		*out = new(bool)
+	out.Source = in.Source
+	out.Target = in.Target
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
 		**out = **in
 	}
-	in.DHCP.DeepCopyInto(&out.DHCP)
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]VirtualMachineNetworkInterfaceIPAddrStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceIPStatus.
-func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopy() *VirtualMachineNetworkInterfaceIPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestSpec.
+func (in *VirtualMachinePublishRequestSpec) DeepCopy() *VirtualMachinePublishRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceIPStatus)
+	out := new(VirtualMachinePublishRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSpec) {
+func (in *VirtualMachinePublishRequestStatus) DeepCopyInto(out *VirtualMachinePublishRequestStatus) {
 	*out = *in
-	out.Network = in.Network
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.MTU != nil {
-		in, out := &in.MTU, &out.MTU
-		*out = new(int64)
+	if in.SourceRef != nil {
+		in, out := &in.SourceRef, &out.SourceRef
+		*out = new(VirtualMachinePublishRequestSource)
 		**out = **in
 	}
-	if in.Nameservers != nil {
-		in, out := &in.Nameservers, &out.Nameservers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Routes != nil {
-		in, out := &in.Routes, &out.Routes
-		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
-		copy(*out, *in)
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		**out = **in
 	}
-	if in.SearchDomains != nil {
-		in, out := &in.SearchDomains, &out.SearchDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSpec.
-func (in *VirtualMachineNetworkInterfaceSpec) DeepCopy() *VirtualMachineNetworkInterfaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestStatus.
+func (in *VirtualMachinePublishRequestStatus) DeepCopy() *VirtualMachinePublishRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceSpec)
+	out := new(VirtualMachinePublishRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkInterfaceStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceStatus) {
+func (in *VirtualMachinePublishRequestTarget) DeepCopyInto(out *VirtualMachinePublishRequestTarget) {
 	*out = *in
-	in.IP.DeepCopyInto(&out.IP)
-	in.DNS.DeepCopyInto(&out.DNS)
+	out.Item = in.Item
+	out.Location = in.Location
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceStatus.
-func (in *VirtualMachineNetworkInterfaceStatus) DeepCopy() *VirtualMachineNetworkInterfaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTarget.
+func (in *VirtualMachinePublishRequestTarget) DeepCopy() *VirtualMachinePublishRequestTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkInterfaceStatus)
+	out := new(VirtualMachinePublishRequestTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkRouteSpec) DeepCopyInto(out *VirtualMachineNetworkRouteSpec) {
+func (in *VirtualMachinePublishRequestTargetItem) DeepCopyInto(out *VirtualMachinePublishRequestTargetItem) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteSpec.
-func (in *VirtualMachineNetworkRouteSpec) DeepCopy() *VirtualMachineNetworkRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetItem.
+func (in *VirtualMachinePublishRequestTargetItem) DeepCopy() *VirtualMachinePublishRequestTargetItem {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkRouteSpec)
+	out := new(VirtualMachinePublishRequestTargetItem)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkRouteStatus) DeepCopyInto(out *VirtualMachineNetworkRouteStatus) {
+func (in *VirtualMachinePublishRequestTargetLocation) DeepCopyInto(out *VirtualMachinePublishRequestTargetLocation) {
 	*out = *in
-	if in.IPRoutes != nil {
-		in, out := &in.IPRoutes, &out.IPRoutes
-		*out = make([]VirtualMachineNetworkIPRouteStatus, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkRouteStatus.
-func (in *VirtualMachineNetworkRouteStatus) DeepCopy() *VirtualMachineNetworkRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetLocation.
+func (in *VirtualMachinePublishRequestTargetLocation) DeepCopy() *VirtualMachinePublishRequestTargetLocation {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkRouteStatus)
+	out := new(VirtualMachinePublishRequestTargetLocation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkSpec) DeepCopyInto(out *VirtualMachineNetworkSpec) {
+func (in *VirtualMachineQuickStatsStatus) DeepCopyInto(out *VirtualMachineQuickStatsStatus) {
 	*out = *in
-	if in.Interfaces != nil {
-		in, out := &in.Interfaces, &out.Interfaces
-		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SampleTime != nil {
+		in, out := &in.SampleTime, &out.SampleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GuestMemoryUsage != nil {
+		in, out := &in.GuestMemoryUsage, &out.GuestMemoryUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.HostMemoryUsage != nil {
+		in, out := &in.HostMemoryUsage, &out.HostMemoryUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.BalloonedMemory != nil {
+		in, out := &in.BalloonedMemory, &out.BalloonedMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.SwappedMemory != nil {
+		in, out := &in.SwappedMemory, &out.SwappedMemory
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkSpec.
-func (in *VirtualMachineNetworkSpec) DeepCopy() *VirtualMachineNetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineQuickStatsStatus.
+func (in *VirtualMachineQuickStatsStatus) DeepCopy() *VirtualMachineQuickStatsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkSpec)
+	out := new(VirtualMachineQuickStatsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineNetworkStatus) DeepCopyInto(out *VirtualMachineNetworkStatus) {
+func (in *VirtualMachineReadinessProbeSpec) DeepCopyInto(out *VirtualMachineReadinessProbeSpec) {
 	*out = *in
-	if in.Interfaces != nil {
-		in, out := &in.Interfaces, &out.Interfaces
-		*out = make([]VirtualMachineNetworkInterfaceStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(TCPSocketAction)
+		**out = **in
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(GuestExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestHeartbeat != nil {
+		in, out := &in.GuestHeartbeat, &out.GuestHeartbeat
+		*out = new(GuestHeartbeatAction)
+		**out = **in
+	}
+	if in.GuestInfo != nil {
+		in, out := &in.GuestInfo, &out.GuestInfo
+		*out = make([]GuestInfoAction, len(*in))
+		copy(*out, *in)
 	}
-	in.VirtualMachineNetworkIPStackStatus.DeepCopyInto(&out.VirtualMachineNetworkIPStackStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkStatus.
-func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReadinessProbeSpec.
+func (in *VirtualMachineReadinessProbeSpec) DeepCopy() *VirtualMachineReadinessProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineNetworkStatus)
+	out := new(VirtualMachineReadinessProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequest) DeepCopyInto(out *VirtualMachinePublishRequest) {
+func (in *VirtualMachineReplicaSet) DeepCopyInto(out *VirtualMachineReplicaSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1216,18 +2287,18 @@ func (in *VirtualMachinePublishRequest) DeepCopyInto(out *VirtualMachinePublishR
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequest.
-func (in *VirtualMachinePublishRequest) DeepCopy() *VirtualMachinePublishRequest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSet.
+func (in *VirtualMachineReplicaSet) DeepCopy() *VirtualMachineReplicaSet {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequest)
+	out := new(VirtualMachineReplicaSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachinePublishRequest) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineReplicaSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1235,31 +2306,31 @@ func (in *VirtualMachinePublishRequest) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestList) DeepCopyInto(out *VirtualMachinePublishRequestList) {
+func (in *VirtualMachineReplicaSetList) DeepCopyInto(out *VirtualMachineReplicaSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachinePublishRequest, len(*in))
+		*out = make([]VirtualMachineReplicaSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestList.
-func (in *VirtualMachinePublishRequestList) DeepCopy() *VirtualMachinePublishRequestList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetList.
+func (in *VirtualMachineReplicaSetList) DeepCopy() *VirtualMachineReplicaSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestList)
+	out := new(VirtualMachineReplicaSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachinePublishRequestList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineReplicaSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1267,165 +2338,204 @@ func (in *VirtualMachinePublishRequestList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestSource) DeepCopyInto(out *VirtualMachinePublishRequestSource) {
+func (in *VirtualMachineReplicaSetSpec) DeepCopyInto(out *VirtualMachineReplicaSetSpec) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestSource.
-func (in *VirtualMachinePublishRequestSource) DeepCopy() *VirtualMachinePublishRequestSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetSpec.
+func (in *VirtualMachineReplicaSetSpec) DeepCopy() *VirtualMachineReplicaSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestSource)
+	out := new(VirtualMachineReplicaSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestSpec) DeepCopyInto(out *VirtualMachinePublishRequestSpec) {
+func (in *VirtualMachineReplicaSetStatus) DeepCopyInto(out *VirtualMachineReplicaSetStatus) {
 	*out = *in
-	out.Source = in.Source
-	out.Target = in.Target
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
-		*out = new(int64)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestSpec.
-func (in *VirtualMachinePublishRequestSpec) DeepCopy() *VirtualMachinePublishRequestSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReplicaSetStatus.
+func (in *VirtualMachineReplicaSetStatus) DeepCopy() *VirtualMachineReplicaSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestSpec)
+	out := new(VirtualMachineReplicaSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestStatus) DeepCopyInto(out *VirtualMachinePublishRequestStatus) {
+func (in *VirtualMachineReservedSpec) DeepCopyInto(out *VirtualMachineReservedSpec) {
 	*out = *in
-	if in.SourceRef != nil {
-		in, out := &in.SourceRef, &out.SourceRef
-		*out = new(VirtualMachinePublishRequestSource)
-		**out = **in
-	}
-	if in.TargetRef != nil {
-		in, out := &in.TargetRef, &out.TargetRef
-		*out = new(VirtualMachinePublishRequestTarget)
-		**out = **in
-	}
-	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	in.LastAttemptTime.DeepCopyInto(&out.LastAttemptTime)
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestStatus.
-func (in *VirtualMachinePublishRequestStatus) DeepCopy() *VirtualMachinePublishRequestStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReservedSpec.
+func (in *VirtualMachineReservedSpec) DeepCopy() *VirtualMachineReservedSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestStatus)
+	out := new(VirtualMachineReservedSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestTarget) DeepCopyInto(out *VirtualMachinePublishRequestTarget) {
+func (in *VirtualMachineResourceQuota) DeepCopyInto(out *VirtualMachineResourceQuota) {
 	*out = *in
-	out.Item = in.Item
-	out.Location = in.Location
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTarget.
-func (in *VirtualMachinePublishRequestTarget) DeepCopy() *VirtualMachinePublishRequestTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceQuota.
+func (in *VirtualMachineResourceQuota) DeepCopy() *VirtualMachineResourceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestTarget)
-	in.DeepCopyInto(out)
-	return out
+	out := new(VirtualMachineResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineResourceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestTargetItem) DeepCopyInto(out *VirtualMachinePublishRequestTargetItem) {
+func (in *VirtualMachineResourceQuotaList) DeepCopyInto(out *VirtualMachineResourceQuotaList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineResourceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetItem.
-func (in *VirtualMachinePublishRequestTargetItem) DeepCopy() *VirtualMachinePublishRequestTargetItem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceQuotaList.
+func (in *VirtualMachineResourceQuotaList) DeepCopy() *VirtualMachineResourceQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestTargetItem)
+	out := new(VirtualMachineResourceQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineResourceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachinePublishRequestTargetLocation) DeepCopyInto(out *VirtualMachinePublishRequestTargetLocation) {
+func (in *VirtualMachineResourceQuotaReservation) DeepCopyInto(out *VirtualMachineResourceQuotaReservation) {
 	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetLocation.
-func (in *VirtualMachinePublishRequestTargetLocation) DeepCopy() *VirtualMachinePublishRequestTargetLocation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceQuotaReservation.
+func (in *VirtualMachineResourceQuotaReservation) DeepCopy() *VirtualMachineResourceQuotaReservation {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachinePublishRequestTargetLocation)
+	out := new(VirtualMachineResourceQuotaReservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReadinessProbeSpec) DeepCopyInto(out *VirtualMachineReadinessProbeSpec) {
+func (in *VirtualMachineResourceQuotaSpec) DeepCopyInto(out *VirtualMachineResourceQuotaSpec) {
 	*out = *in
-	if in.TCPSocket != nil {
-		in, out := &in.TCPSocket, &out.TCPSocket
-		*out = new(TCPSocketAction)
-		**out = **in
-	}
-	if in.GuestHeartbeat != nil {
-		in, out := &in.GuestHeartbeat, &out.GuestHeartbeat
-		*out = new(GuestHeartbeatAction)
-		**out = **in
-	}
-	if in.GuestInfo != nil {
-		in, out := &in.GuestInfo, &out.GuestInfo
-		*out = make([]GuestInfoAction, len(*in))
-		copy(*out, *in)
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReadinessProbeSpec.
-func (in *VirtualMachineReadinessProbeSpec) DeepCopy() *VirtualMachineReadinessProbeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceQuotaSpec.
+func (in *VirtualMachineResourceQuotaSpec) DeepCopy() *VirtualMachineResourceQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReadinessProbeSpec)
+	out := new(VirtualMachineResourceQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineReservedSpec) DeepCopyInto(out *VirtualMachineReservedSpec) {
+func (in *VirtualMachineResourceQuotaStatus) DeepCopyInto(out *VirtualMachineResourceQuotaStatus) {
 	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]VirtualMachineResourceQuotaReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineReservedSpec.
-func (in *VirtualMachineReservedSpec) DeepCopy() *VirtualMachineReservedSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineResourceQuotaStatus.
+func (in *VirtualMachineResourceQuotaStatus) DeepCopy() *VirtualMachineResourceQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineReservedSpec)
+	out := new(VirtualMachineResourceQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1596,6 +2706,26 @@ func (in *VirtualMachineSetResourcePolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSetResourcePolicyHostAffinity) DeepCopyInto(out *VirtualMachineSetResourcePolicyHostAffinity) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSetResourcePolicyHostAffinity.
+func (in *VirtualMachineSetResourcePolicyHostAffinity) DeepCopy() *VirtualMachineSetResourcePolicyHostAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSetResourcePolicyHostAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSetResourcePolicyList) DeepCopyInto(out *VirtualMachineSetResourcePolicyList) {
 	*out = *in
@@ -1637,6 +2767,16 @@ func (in *VirtualMachineSetResourcePolicySpec) DeepCopyInto(out *VirtualMachineS
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(VirtualMachineSetResourcePolicyHostAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraint != nil {
+		in, out := &in.TopologySpreadConstraint, &out.TopologySpreadConstraint
+		*out = new(VirtualMachineSetResourcePolicyTopologySpreadConstraint)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSetResourcePolicySpec.
@@ -1657,6 +2797,11 @@ func (in *VirtualMachineSetResourcePolicyStatus) DeepCopyInto(out *VirtualMachin
 		*out = make([]VSphereClusterModuleStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcePools != nil {
+		in, out := &in.ResourcePools, &out.ResourcePools
+		*out = make([]ResourcePoolStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSetResourcePolicyStatus.
@@ -1669,6 +2814,142 @@ func (in *VirtualMachineSetResourcePolicyStatus) DeepCopy() *VirtualMachineSetRe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSetResourcePolicyTopologySpreadConstraint) DeepCopyInto(out *VirtualMachineSetResourcePolicyTopologySpreadConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSetResourcePolicyTopologySpreadConstraint.
+func (in *VirtualMachineSetResourcePolicyTopologySpreadConstraint) DeepCopy() *VirtualMachineSetResourcePolicyTopologySpreadConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSetResourcePolicyTopologySpreadConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshot) DeepCopyInto(out *VirtualMachineSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshot.
+func (in *VirtualMachineSnapshot) DeepCopy() *VirtualMachineSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotList) DeepCopyInto(out *VirtualMachineSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotList.
+func (in *VirtualMachineSnapshotList) DeepCopy() *VirtualMachineSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotSpec) DeepCopyInto(out *VirtualMachineSnapshotSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotSpec.
+func (in *VirtualMachineSnapshotSpec) DeepCopy() *VirtualMachineSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotStatus) DeepCopyInto(out *VirtualMachineSnapshotStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotStatus.
+func (in *VirtualMachineSnapshotStatus) DeepCopy() *VirtualMachineSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSnapshotTreeStatus) DeepCopyInto(out *VirtualMachineSnapshotTreeStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSnapshotTreeStatus.
+func (in *VirtualMachineSnapshotTreeStatus) DeepCopy() *VirtualMachineSnapshotTreeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSnapshotTreeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -1694,6 +2975,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineReadinessProbeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(VirtualMachineLivenessProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(VirtualMachineReadinessProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(VirtualMachineAdvancedSpec)
@@ -1704,6 +2995,26 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineReservedSpec)
 		**out = **in
 	}
+	if in.Clone != nil {
+		in, out := &in.Clone, &out.Clone
+		*out = new(VirtualMachineCloneSpec)
+		**out = **in
+	}
+	if in.Crypto != nil {
+		in, out := &in.Crypto, &out.Crypto
+		*out = new(VirtualMachineCryptoSpec)
+		**out = **in
+	}
+	if in.BootOptions != nil {
+		in, out := &in.BootOptions, &out.BootOptions
+		*out = new(VirtualMachineBootOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(VirtualMachineAffinitySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSpec.
@@ -1744,7 +3055,9 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]VirtualMachineVolumeStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ChangeBlockTracking != nil {
 		in, out := &in.ChangeBlockTracking, &out.ChangeBlockTracking
@@ -1755,6 +3068,34 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		in, out := &in.LastRestartTime, &out.LastRestartTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]VirtualMachineSnapshotTreeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Crypto != nil {
+		in, out := &in.Crypto, &out.Crypto
+		*out = new(VirtualMachineCryptoStatus)
+		**out = **in
+	}
+	if in.GuestDisks != nil {
+		in, out := &in.GuestDisks, &out.GuestDisks
+		*out = make([]VirtualMachineGuestDiskStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GuestDisksSampleTime != nil {
+		in, out := &in.GuestDisksSampleTime, &out.GuestDisksSampleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.QuickStats != nil {
+		in, out := &in.QuickStats, &out.QuickStats
+		*out = new(VirtualMachineQuickStatsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineStatus.
@@ -1788,6 +3129,52 @@ func (in *VirtualMachineTemplate) DeepCopy() *VirtualMachineTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTemplateObjectMeta) DeepCopyInto(out *VirtualMachineTemplateObjectMeta) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTemplateObjectMeta.
+func (in *VirtualMachineTemplateObjectMeta) DeepCopy() *VirtualMachineTemplateObjectMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTemplateObjectMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTemplateSpec) DeepCopyInto(out *VirtualMachineTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTemplateSpec.
+func (in *VirtualMachineTemplateSpec) DeepCopy() *VirtualMachineTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineVolume) DeepCopyInto(out *VirtualMachineVolume) {
 	*out = *in
@@ -1827,6 +3214,11 @@ func (in *VirtualMachineVolumeSource) DeepCopy() *VirtualMachineVolumeSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineVolumeStatus) DeepCopyInto(out *VirtualMachineVolumeStatus) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineVolumeStatus.
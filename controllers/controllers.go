@@ -14,9 +14,15 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/providerconfigmap"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestcommand"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestfiletransfer"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineplacementrequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineresourcequota"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesetresourcepolicy"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinewebconsolerequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/volume"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -40,12 +46,30 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 	if err := virtualmachineclass.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineClass controller")
 	}
+	if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineReplicaSet controller")
+	}
 	if err := virtualmachineservice.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineService controller")
 	}
 	if err := virtualmachinesetresourcepolicy.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineSetResourcePolicy controller")
 	}
+	if err := virtualmachinesnapshot.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineSnapshot controller")
+	}
+	if err := virtualmachineguestcommand.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineGuestCommand controller")
+	}
+	if err := virtualmachineguestfiletransfer.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineGuestFileTransfer controller")
+	}
+	if err := virtualmachineplacementrequest.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachinePlacementRequest controller")
+	}
+	if err := virtualmachineresourcequota.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineResourceQuota controller")
+	}
 	if err := virtualmachinewebconsolerequest.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineWebConsoleRequest controller")
 	}
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -34,6 +35,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmwatcher"
 )
 
 const (
@@ -44,6 +46,10 @@ const (
 	// indicates a VM pointing to that VM Class should be reconciled by this
 	// controller.
 	vmClassControllerName = "vmoperator.vmware.com/vsphere"
+
+	// waitForIPRequeueDelay is how long to wait before reconciling a powered on VM
+	// without an IP address again.
+	waitForIPRequeueDelay = 2 * time.Minute
 )
 
 var (
@@ -70,6 +76,11 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 		return err
 	}
 
+	vmWatcher, err := vmwatcher.AddToManager(ctx, mgr, ctx.VMProviderA2)
+	if err != nil {
+		return err
+	}
+
 	r := NewReconciler(
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
@@ -102,6 +113,10 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 	builder = builder.Watches(&vmopv1.VirtualMachineClass{},
 		handler.EnqueueRequestsFromMapFunc(classToVMMapperFn(ctx, r.Client)))
 
+	// Reconcile the VMs whose VC VM power state, guest IPs, heartbeat, or tasks changed.
+	builder = builder.WatchesRawSource(&source.Channel{Source: vmWatcher.Events()},
+		&handler.EnqueueRequestForObject{})
+
 	return builder.Complete(r)
 }
 
@@ -246,12 +261,9 @@ func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Resu
 }
 
 // Determine if we should request a non-zero requeue delay in order to trigger a non-rate limited reconcile
-// at some point in the future.  Use this delay-based reconcile to trigger a specific reconcile to discovery the VM IP
-// address rather than relying on the resync period to do.
-//
-// TODO: It would be much preferable to determine that a non-error resync is required at the source of the determination that
-// TODO: the VM IP isn't available rather than up here in the reconcile loop.  However, in the interest of time, we are making
-// TODO: this determination here and will have to refactor at some later date.
+// at some point in the future.  The VM watcher enqueues the VM when its IP address changes, so the
+// delay-based reconcile to discover the VM IP address is only a slow safety net in case a change is
+// missed, for example while the watch is restarted.
 func requeueDelay(ctx *context.VirtualMachineContextA2) time.Duration {
 	// If the VM is in Creating phase, the reconciler has run out of threads to Create VMs on the provider. Do not queue
 	// immediately to avoid exponential backoff.
@@ -262,7 +274,7 @@ func requeueDelay(ctx *context.VirtualMachineContextA2) time.Duration {
 	if ctx.VM.Status.PowerState == vmopv1.VirtualMachinePowerStateOn {
 		network := ctx.VM.Status.Network
 		if network == nil || (network.PrimaryIP4 == "" && network.PrimaryIP6 == "") {
-			return 10 * time.Second
+			return waitForIPRequeueDelay
 		}
 	}
 
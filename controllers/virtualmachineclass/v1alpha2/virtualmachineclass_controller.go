@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -16,11 +17,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
 )
 
+// compatibilityRequeueDelay is how often the zones and clusters that can run the VMs of a
+// VirtualMachineClass are recomputed, since the hosts and zones are not watched.
+const compatibilityRequeueDelay = 5 * time.Minute
+
 // AddToManager adds this package's controller to the provided manager.
 func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
 	var (
@@ -35,6 +42,7 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
 		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProviderA2,
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -46,23 +54,28 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 func NewReconciler(
 	client client.Client,
 	logger logr.Logger,
-	recorder record.Recorder) *Reconciler {
+	recorder record.Recorder,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) *Reconciler {
 	return &Reconciler{
-		Client:   client,
-		Logger:   logger,
-		Recorder: recorder,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
 	}
 }
 
 // Reconciler reconciles a VirtualMachineClass object.
 type Reconciler struct {
 	client.Client
-	Logger   logr.Logger
-	Recorder record.Recorder
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider vmprovider.VirtualMachineProviderInterfaceA2
 }
 
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=availabilityzones,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
 func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	vmClass := &vmopv1.VirtualMachineClass{}
@@ -99,9 +112,32 @@ func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: compatibilityRequeueDelay}, nil
 }
 
+// ReconcileNormal computes which zones and clusters can run VMs of the VirtualMachineClass.
 func (r *Reconciler) ReconcileNormal(vmClassCtx *context.VirtualMachineClassContextA2) error {
+	vmClass := vmClassCtx.VMClass
+
+	if err := r.VMProvider.ComputeVirtualMachineClassCompatibility(vmClassCtx, vmClass); err != nil {
+		conditions.MarkFalse(vmClass,
+			vmopv1.VirtualMachineClassConditionReady,
+			vmopv1.VirtualMachineClassCompatibilityFailedReason,
+			"%v", err)
+		r.Recorder.EmitEvent(vmClass, "Compatibility", err, false)
+		return err
+	}
+
+	for _, zone := range vmClass.Status.Zones {
+		if zone.Compatible {
+			conditions.MarkTrue(vmClass, vmopv1.VirtualMachineClassConditionReady)
+			return nil
+		}
+	}
+
+	conditions.MarkFalse(vmClass,
+		vmopv1.VirtualMachineClassConditionReady,
+		vmopv1.VirtualMachineClassNoCompatibleZoneReason,
+		"no zone can run this class")
 	return nil
 }
@@ -4,14 +4,18 @@
 package v1alpha2_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -47,10 +51,26 @@ func intgTests() {
 				},
 			},
 		}
+
+		intgFakeVMProvider.Lock()
+		defer intgFakeVMProvider.Unlock()
+		intgFakeVMProvider.ComputeVirtualMachineClassCompatibilityFn = func(_ context.Context, vmClass *vmopv1.VirtualMachineClass) error {
+			vmClass.Status.Zones = []vmopv1.VirtualMachineClassZoneStatus{
+				{
+					Name:       "zone-1",
+					Compatible: true,
+					Clusters: []vmopv1.VirtualMachineClassClusterStatus{
+						{MoID: "domain-c1", Compatible: true},
+					},
+				},
+			}
+			return nil
+		}
 	})
 
 	AfterEach(func() {
 		ctx.AfterEach()
+		intgFakeVMProvider.Reset()
 	})
 
 	Context("Reconcile", func() {
@@ -63,7 +83,19 @@ func intgTests() {
 			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
 		})
 
-		It("noop", func() {
+		It("publishes the zones that can run the class", func() {
+			Eventually(func() bool {
+				obj := &vmopv1.VirtualMachineClass{}
+				if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmClass), obj); err != nil {
+					return false
+				}
+				vmClass = obj
+				return conditions.IsTrue(vmClass, vmopv1.VirtualMachineClassConditionReady)
+			}).Should(BeTrue(), "waiting for VirtualMachineClass to be ready")
+
+			Expect(vmClass.Status.Zones).To(HaveLen(1))
+			Expect(vmClass.Status.Zones[0].Name).To(Equal("zone-1"))
+			Expect(vmClass.Status.Zones[0].Compatible).To(BeTrue())
 		})
 	})
 }
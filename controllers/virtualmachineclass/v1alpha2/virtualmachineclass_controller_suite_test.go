@@ -8,15 +8,23 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+
 	virtualmachineclass "github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass/v1alpha2"
+	ctrlContext "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
-	"github.com/vmware-tanzu/vm-operator/pkg/manager"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
+var intgFakeVMProvider = providerfake.NewVMProviderA2()
+
 var suite = builder.NewTestSuiteForControllerWithFSS(
 	virtualmachineclass.AddToManager,
-	manager.InitializeProvidersNoopFn,
+	func(ctx *ctrlContext.ControllerManagerContext, _ ctrlmgr.Manager) error {
+		ctx.VMProviderA2 = intgFakeVMProvider
+		return nil
+	},
 	map[string]bool{lib.VMServiceV1Alpha2FSS: true})
 
 func TestVirtualMachineClass(t *testing.T) {
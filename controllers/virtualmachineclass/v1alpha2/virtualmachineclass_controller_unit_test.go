@@ -4,6 +4,9 @@
 package v1alpha2_test
 
 import (
+	"context"
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -13,7 +16,9 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 
 	virtualmachineclass "github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -26,9 +31,12 @@ func unitTestsReconcile() {
 		initObjects []client.Object
 		ctx         *builder.UnitTestContextForController
 
-		reconciler *virtualmachineclass.Reconciler
-		vmClassCtx *vmopContext.VirtualMachineClassContextA2
-		vmClass    *vmopv1.VirtualMachineClass
+		reconciler     *virtualmachineclass.Reconciler
+		fakeVMProvider *providerfake.VMProviderA2
+		vmClassCtx     *vmopContext.VirtualMachineClassContextA2
+		vmClass        *vmopv1.VirtualMachineClass
+		zones          []vmopv1.VirtualMachineClassZoneStatus
+		computeErr     error
 	)
 
 	BeforeEach(func() {
@@ -37,6 +45,17 @@ func unitTestsReconcile() {
 				Name: "dummy-vmclass",
 			},
 		}
+
+		zones = []vmopv1.VirtualMachineClassZoneStatus{
+			{
+				Name:       "zone-1",
+				Compatible: true,
+				Clusters: []vmopv1.VirtualMachineClassClusterStatus{
+					{MoID: "domain-c1", Compatible: true},
+				},
+			},
+		}
+		computeErr = nil
 	})
 
 	JustBeforeEach(func() {
@@ -45,7 +64,13 @@ func unitTestsReconcile() {
 			ctx.Client,
 			ctx.Logger,
 			ctx.Recorder,
+			ctx.VMProviderA2,
 		)
+		fakeVMProvider = ctx.VMProviderA2.(*providerfake.VMProviderA2)
+		fakeVMProvider.ComputeVirtualMachineClassCompatibilityFn = func(_ context.Context, vmClass *vmopv1.VirtualMachineClass) error {
+			vmClass.Status.Zones = zones
+			return computeErr
+		}
 
 		vmClassCtx = &vmopContext.VirtualMachineClassContextA2{
 			Context: ctx,
@@ -54,14 +79,53 @@ func unitTestsReconcile() {
 		}
 	})
 
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
 	Context("ReconcileNormal", func() {
 		BeforeEach(func() {
 			initObjects = append(initObjects, vmClass)
 		})
 
-		It("returns success", func() {
+		It("sets the zones and marks the class ready", func() {
 			err := reconciler.ReconcileNormal(vmClassCtx)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(vmClass.Status.Zones).To(Equal(zones))
+			Expect(conditions.IsTrue(vmClass, vmopv1.VirtualMachineClassConditionReady)).To(BeTrue())
+		})
+
+		When("no zone can run the class", func() {
+			BeforeEach(func() {
+				zones[0].Compatible = false
+				zones[0].Clusters[0].Compatible = false
+				zones[0].Clusters[0].Reasons = []string{`vGPU profile "grid_p40-1q" is not available`}
+			})
+
+			It("marks the class not ready", func() {
+				err := reconciler.ReconcileNormal(vmClassCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.IsFalse(vmClass, vmopv1.VirtualMachineClassConditionReady)).To(BeTrue())
+				Expect(conditions.GetReason(vmClass, vmopv1.VirtualMachineClassConditionReady)).
+					To(Equal(vmopv1.VirtualMachineClassNoCompatibleZoneReason))
+			})
+		})
+
+		When("the compatibility cannot be computed", func() {
+			BeforeEach(func() {
+				computeErr = errors.New("compute error")
+			})
+
+			It("returns the error and marks the class not ready", func() {
+				err := reconciler.ReconcileNormal(vmClassCtx)
+				Expect(err).To(MatchError(computeErr))
+				Expect(conditions.IsFalse(vmClass, vmopv1.VirtualMachineClassConditionReady)).To(BeTrue())
+				Expect(conditions.GetReason(vmClass, vmopv1.VirtualMachineClassConditionReady)).
+					To(Equal(vmopv1.VirtualMachineClassCompatibilityFailedReason))
+			})
 		})
 	})
 }
@@ -0,0 +1,21 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineguestcommand
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestcommand/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/lib"
+)
+
+// AddToManager adds the controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	// The VirtualMachineGuestCommand API only exists in v1alpha2.
+	if !lib.IsVMServiceV1Alpha2FSSEnabled() {
+		return nil
+	}
+	return v1alpha2.AddToManager(ctx, mgr)
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+)
+
+const (
+	// runningRequeueDelay is how long to wait before checking again whether
+	// a running command has exited.
+	runningRequeueDelay = 5 * time.Second
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineGuestCommand{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProviderA2,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToGuestCommandMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmToGuestCommandMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachineGuestCommands in response to an event on the VirtualMachine resource.
+func vmToGuestCommandMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given VirtualMachine, return reconcile requests
+	// for those VirtualMachineGuestCommands that reference the VM.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+		logger := ctx.Logger.WithValues("name", vm.Name, "namespace", vm.Namespace)
+
+		guestCmdList := &vmopv1.VirtualMachineGuestCommandList{}
+		if err := c.List(ctx, guestCmdList, client.InNamespace(vm.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineGuestCommands for reconciliation due to VirtualMachine watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, guestCmd := range guestCmdList.Items {
+			// Only enqueue the commands that have not been started yet. Started
+			// commands are periodically requeued until they complete.
+			if guestCmd.Spec.VMName == vm.Name && guestCmd.Status.StartTime == nil {
+				key := client.ObjectKey{Namespace: guestCmd.Namespace, Name: guestCmd.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachineGuestCommand reconcile requests due to VirtualMachine watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) *Reconciler {
+
+	return &Reconciler{
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineGuestCommand object.
+type Reconciler struct {
+	client.Client
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider vmprovider.VirtualMachineProviderInterfaceA2
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestcommands,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestcommands/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	guestCmd := &vmopv1.VirtualMachineGuestCommand{}
+	if err := r.Get(ctx, req.NamespacedName, guestCmd); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	guestCmdCtx := &context.VirtualMachineGuestCommandContextA2{
+		Context:      ctx,
+		Logger:       ctrl.Log.WithName("VirtualMachineGuestCommand").WithValues("name", req.NamespacedName),
+		GuestCommand: guestCmd,
+	}
+
+	if !guestCmd.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(guestCmd, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", guestCmdCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, guestCmd); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			guestCmdCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return r.ReconcileNormal(guestCmdCtx)
+}
+
+// getVM gets the VM referenced by the command. A nil VM is returned if
+// the VM does not exist.
+func (r *Reconciler) getVM(ctx *context.VirtualMachineGuestCommandContextA2) (*vmopv1.VirtualMachine, error) {
+	vm := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: ctx.GuestCommand.Namespace, Name: ctx.GuestCommand.Spec.VMName}
+	if err := r.Get(ctx, key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return vm, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachineGuestCommandContextA2) (_ ctrl.Result, reterr error) {
+	if ctx.GuestCommand.Status.CompletionTime != nil {
+		// The command is only run once: once it has completed there is nothing
+		// left to reconcile.
+		return ctrl.Result{}, nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineGuestCommand")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineGuestCommand")
+	}()
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if vm == nil {
+		conditions.MarkFalse(ctx.GuestCommand,
+			vmopv1.VirtualMachineGuestCommandConditionCompleted,
+			vmopv1.VirtualMachineGuestCommandVMNotFoundReason,
+			"VirtualMachine %s not found", ctx.GuestCommand.Spec.VMName)
+		// The VM watch will trigger another reconcile once the VM exists.
+		return ctrl.Result{}, nil
+	}
+	ctx.VM = vm
+
+	if vm.Status.UniqueID == "" {
+		conditions.MarkFalse(ctx.GuestCommand,
+			vmopv1.VirtualMachineGuestCommandConditionCompleted,
+			vmopv1.VirtualMachineGuestCommandVMNotCreatedReason,
+			"VirtualMachine %s has not been created", vm.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Make the VM the owner of the command so the command resource is garbage
+	// collected when the VM is deleted.
+	if err := controllerutil.SetOwnerReference(ctx.VM, ctx.GuestCommand, r.Scheme()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.VMProvider.RunGuestCommand(ctx, ctx.VM, ctx.GuestCommand); err != nil {
+		ctx.Logger.Error(err, "Failed to run VirtualMachineGuestCommand")
+		conditions.MarkFalse(ctx.GuestCommand,
+			vmopv1.VirtualMachineGuestCommandConditionCompleted,
+			vmopv1.VirtualMachineGuestCommandFailedReason,
+			"%v", err)
+		r.Recorder.EmitEvent(ctx.GuestCommand, "Run", err, false)
+		return ctrl.Result{}, err
+	}
+
+	status := &ctx.GuestCommand.Status
+	switch {
+	case status.CompletionTime == nil:
+		conditions.MarkFalse(ctx.GuestCommand,
+			vmopv1.VirtualMachineGuestCommandConditionCompleted,
+			vmopv1.VirtualMachineGuestCommandRunningReason,
+			"Command is running with PID %d", status.PID)
+		return ctrl.Result{RequeueAfter: runningRequeueDelay}, nil
+
+	case status.ExitCode == nil:
+		conditions.MarkFalse(ctx.GuestCommand,
+			vmopv1.VirtualMachineGuestCommandConditionCompleted,
+			vmopv1.VirtualMachineGuestCommandTimedOutReason,
+			"Command was terminated because it did not exit before its timeout")
+		r.Recorder.EmitEvent(ctx.GuestCommand, "Run", errors.New("command timed out"), false)
+
+	default:
+		conditions.MarkTrue(ctx.GuestCommand, vmopv1.VirtualMachineGuestCommandConditionCompleted)
+		r.Recorder.EmitEvent(ctx.GuestCommand, "Run", nil, false)
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachineGuestCommand controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx      *builder.IntegrationTestContext
+		vm       *vmopv1.VirtualMachine
+		guestCmd *vmopv1.VirtualMachineGuestCommand
+	)
+
+	getGuestCommand := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineGuestCommand {
+		guestCmd := &vmopv1.VirtualMachineGuestCommand{}
+		if err := ctx.Client.Get(ctx, objKey, guestCmd); err != nil {
+			return nil
+		}
+		return guestCmd
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", ctx.Namespace)
+		guestCmd = builder.DummyVirtualMachineGuestCommandA2(ctx.Namespace, "dummy-guest-cmd", vm.Name)
+
+		fakeVMProvider.Lock()
+		defer fakeVMProvider.Unlock()
+		fakeVMProvider.RunGuestCommandFn = func(_ context.Context, _ *vmopv1.VirtualMachine, guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+			now := metav1.Now()
+			guestCmd.Status.PID = 42
+			guestCmd.Status.StartTime = &now
+			guestCmd.Status.CompletionTime = &now
+			guestCmd.Status.ExitCode = pointer.Int32(0)
+			guestCmd.Status.Stdout = "up 42 days"
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "vm-42"
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, guestCmd)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, guestCmd)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("runs the command", func() {
+			Eventually(func() bool {
+				guestCmd = getGuestCommand(ctx, client.ObjectKeyFromObject(guestCmd))
+				return guestCmd != nil && conditions.IsTrue(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)
+			}).Should(BeTrue(), "waiting for VirtualMachineGuestCommand to complete")
+			Expect(guestCmd.Status.PID).To(BeEquivalentTo(42))
+			Expect(guestCmd.Status.ExitCode).To(HaveValue(BeEquivalentTo(0)))
+			Expect(guestCmd.Status.Stdout).To(Equal("up 42 days"))
+		})
+	})
+}
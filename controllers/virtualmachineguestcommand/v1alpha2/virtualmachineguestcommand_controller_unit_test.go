@@ -0,0 +1,187 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestcommand/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking VirtualMachineGuestCommand Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler  *v1alpha2.Reconciler
+		guestCmdCtx *vmopContext.VirtualMachineGuestCommandContextA2
+		guestCmd    *vmopv1.VirtualMachineGuestCommand
+		vm          *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", "dummy-ns")
+		vm.Status.UniqueID = "vm-42"
+
+		guestCmd = builder.DummyVirtualMachineGuestCommandA2(vm.Namespace, "dummy-guest-cmd", vm.Name)
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProviderA2,
+		)
+		fakeVMProvider = ctx.VMProviderA2.(*providerfake.VMProviderA2)
+
+		guestCmdCtx = &vmopContext.VirtualMachineGuestCommandContextA2{
+			Context:      ctx,
+			Logger:       ctx.Logger.WithName(guestCmd.Name),
+			GuestCommand: guestCmd,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, guestCmd)
+			})
+
+			It("marks the command as not completed", func() {
+				_, err := reconciler.ReconcileNormal(guestCmdCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(guestCmd.Status.StartTime).To(BeNil())
+				Expect(conditions.IsFalse(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).To(BeTrue())
+				Expect(conditions.GetReason(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).
+					To(Equal(vmopv1.VirtualMachineGuestCommandVMNotFoundReason))
+			})
+		})
+
+		When("the VM has not been created on vSphere", func() {
+			BeforeEach(func() {
+				vm.Status.UniqueID = ""
+				initObjects = append(initObjects, guestCmd, vm)
+			})
+
+			It("marks the command as not completed", func() {
+				_, err := reconciler.ReconcileNormal(guestCmdCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(guestCmd.Status.StartTime).To(BeNil())
+				Expect(conditions.GetReason(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).
+					To(Equal(vmopv1.VirtualMachineGuestCommandVMNotCreatedReason))
+			})
+		})
+
+		When("the VM exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, guestCmd, vm)
+			})
+
+			It("runs the command", func() {
+				result, err := reconciler.ReconcileNormal(guestCmdCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(guestCmd.Status.ExitCode).ToNot(BeNil())
+				Expect(guestCmd.Status.CompletionTime).ToNot(BeNil())
+				Expect(conditions.IsTrue(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).To(BeTrue())
+				Expect(guestCmd.OwnerReferences).To(HaveLen(1))
+				Expect(guestCmd.OwnerReferences[0].Name).To(Equal(vm.Name))
+			})
+
+			It("does not run the command again once completed", func() {
+				now := metav1.Now()
+				guestCmd.Status.CompletionTime = &now
+				fakeVMProvider.RunGuestCommandFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestCommand) error {
+					return errors.New("should not be called")
+				}
+				_, err := reconciler.ReconcileNormal(guestCmdCtx)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			When("the command is still running", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.RunGuestCommandFn = func(_ context.Context, _ *vmopv1.VirtualMachine, guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+						now := metav1.Now()
+						guestCmd.Status.PID = 42
+						guestCmd.Status.StartTime = &now
+						return nil
+					}
+				})
+
+				It("requeues the command", func() {
+					result, err := reconciler.ReconcileNormal(guestCmdCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(conditions.GetReason(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).
+						To(Equal(vmopv1.VirtualMachineGuestCommandRunningReason))
+				})
+			})
+
+			When("the command timed out", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.RunGuestCommandFn = func(_ context.Context, _ *vmopv1.VirtualMachine, guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+						now := metav1.Now()
+						guestCmd.Status.PID = 42
+						guestCmd.Status.StartTime = &now
+						guestCmd.Status.CompletionTime = &now
+						return nil
+					}
+				})
+
+				It("marks the command as timed out", func() {
+					result, err := reconciler.ReconcileNormal(guestCmdCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(conditions.IsFalse(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).To(BeTrue())
+					Expect(conditions.GetReason(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).
+						To(Equal(vmopv1.VirtualMachineGuestCommandTimedOutReason))
+				})
+			})
+
+			When("the provider returns an error", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.RunGuestCommandFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestCommand) error {
+						return errors.New("run error")
+					}
+				})
+
+				It("returns the error", func() {
+					_, err := reconciler.ReconcileNormal(guestCmdCtx)
+					Expect(err).To(MatchError("run error"))
+					Expect(guestCmd.Status.CompletionTime).To(BeNil())
+					Expect(conditions.GetReason(guestCmd, vmopv1.VirtualMachineGuestCommandConditionCompleted)).
+						To(Equal(vmopv1.VirtualMachineGuestCommandFailedReason))
+				})
+			})
+		})
+	})
+}
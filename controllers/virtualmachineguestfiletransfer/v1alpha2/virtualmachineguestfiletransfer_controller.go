@@ -0,0 +1,369 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+)
+
+const (
+	// DefaultSizeLimit is the size limit of a transfer that does not specify
+	// a limit, and is the maximum size of a Secret or ConfigMap.
+	DefaultSizeLimit = 1024 * 1024
+
+	// objectNotFoundRequeueDelay is how long to wait before checking again
+	// whether the Secret or ConfigMap copied into the guest exists. These
+	// objects are not cached so they are not watched.
+	objectNotFoundRequeueDelay = 10 * time.Second
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineGuestFileTransfer{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProviderA2,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToFileTransferMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmToFileTransferMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachineGuestFileTransfers in response to an event on the VirtualMachine resource.
+func vmToFileTransferMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given VirtualMachine, return reconcile requests
+	// for those VirtualMachineGuestFileTransfers that reference the VM.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+		logger := ctx.Logger.WithValues("name", vm.Name, "namespace", vm.Namespace)
+
+		fileTransferList := &vmopv1.VirtualMachineGuestFileTransferList{}
+		if err := c.List(ctx, fileTransferList, client.InNamespace(vm.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineGuestFileTransfers for reconciliation due to VirtualMachine watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, fileTransfer := range fileTransferList.Items {
+			// Only enqueue the transfers that have not completed yet.
+			if fileTransfer.Spec.VMName == vm.Name && fileTransfer.Status.CompletionTime == nil {
+				key := client.ObjectKey{Namespace: fileTransfer.Namespace, Name: fileTransfer.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachineGuestFileTransfer reconcile requests due to VirtualMachine watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) *Reconciler {
+
+	return &Reconciler{
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineGuestFileTransfer object.
+type Reconciler struct {
+	client.Client
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider vmprovider.VirtualMachineProviderInterfaceA2
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestfiletransfers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestfiletransfers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	fileTransfer := &vmopv1.VirtualMachineGuestFileTransfer{}
+	if err := r.Get(ctx, req.NamespacedName, fileTransfer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	fileTransferCtx := &context.VirtualMachineGuestFileTransferContextA2{
+		Context:      ctx,
+		Logger:       ctrl.Log.WithName("VirtualMachineGuestFileTransfer").WithValues("name", req.NamespacedName),
+		FileTransfer: fileTransfer,
+	}
+
+	if !fileTransfer.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(fileTransfer, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", fileTransferCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, fileTransfer); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			fileTransferCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return r.ReconcileNormal(fileTransferCtx)
+}
+
+// getVM gets the VM referenced by the transfer. A nil VM is returned if
+// the VM does not exist.
+func (r *Reconciler) getVM(ctx *context.VirtualMachineGuestFileTransferContextA2) (*vmopv1.VirtualMachine, error) {
+	vm := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: ctx.FileTransfer.Namespace, Name: ctx.FileTransfer.Spec.VMName}
+	if err := r.Get(ctx, key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return vm, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachineGuestFileTransferContextA2) (_ ctrl.Result, reterr error) {
+	if ctx.FileTransfer.Status.CompletionTime != nil ||
+		conditions.GetReason(ctx.FileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted) ==
+			vmopv1.VirtualMachineGuestFileTransferSizeLimitExceededReason {
+		// The file is only copied once, and a file that exceeded the size
+		// limit will not be copied.
+		return ctrl.Result{}, nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineGuestFileTransfer")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineGuestFileTransfer")
+	}()
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if vm == nil {
+		conditions.MarkFalse(ctx.FileTransfer,
+			vmopv1.VirtualMachineGuestFileTransferConditionCompleted,
+			vmopv1.VirtualMachineGuestFileTransferVMNotFoundReason,
+			"VirtualMachine %s not found", ctx.FileTransfer.Spec.VMName)
+		// The VM watch will trigger another reconcile once the VM exists.
+		return ctrl.Result{}, nil
+	}
+	ctx.VM = vm
+
+	if vm.Status.UniqueID == "" {
+		conditions.MarkFalse(ctx.FileTransfer,
+			vmopv1.VirtualMachineGuestFileTransferConditionCompleted,
+			vmopv1.VirtualMachineGuestFileTransferVMNotCreatedReason,
+			"VirtualMachine %s has not been created", vm.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Make the VM the owner of the transfer so the transfer resource is
+	// garbage collected when the VM is deleted.
+	if err := controllerutil.SetOwnerReference(ctx.VM, ctx.FileTransfer, r.Scheme()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	sizeLimit := int64(DefaultSizeLimit)
+	if l := ctx.FileTransfer.Spec.SizeLimit; l != nil {
+		sizeLimit = l.Value()
+	}
+
+	if ctx.FileTransfer.Spec.Direction == vmopv1.VirtualMachineGuestFileTransferFromGuest {
+		return ctrl.Result{}, r.copyFromGuest(ctx, sizeLimit)
+	}
+	return r.copyToGuest(ctx, sizeLimit)
+}
+
+func (r *Reconciler) copyToGuest(ctx *context.VirtualMachineGuestFileTransferContextA2, sizeLimit int64) (ctrl.Result, error) {
+	content, found, err := r.getObjectContent(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !found {
+		ref := ctx.FileTransfer.Spec.Object
+		conditions.MarkFalse(ctx.FileTransfer,
+			vmopv1.VirtualMachineGuestFileTransferConditionCompleted,
+			vmopv1.VirtualMachineGuestFileTransferObjectNotFoundReason,
+			"%s %s with key %s not found", ref.Kind, ref.Name, ref.Key)
+		return ctrl.Result{RequeueAfter: objectNotFoundRequeueDelay}, nil
+	}
+
+	size := int64(len(content))
+	if size > sizeLimit {
+		r.markSizeLimitExceeded(ctx, size, sizeLimit)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.VMProvider.CopyFileToGuest(ctx, ctx.VM, ctx.FileTransfer, content); err != nil {
+		r.markFailed(ctx, err)
+		return ctrl.Result{}, err
+	}
+
+	r.markCompleted(ctx, size)
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) copyFromGuest(ctx *context.VirtualMachineGuestFileTransferContextA2, sizeLimit int64) error {
+	content, size, err := r.VMProvider.CopyFileFromGuest(ctx, ctx.VM, ctx.FileTransfer, sizeLimit)
+	if err != nil {
+		if size > sizeLimit {
+			r.markSizeLimitExceeded(ctx, size, sizeLimit)
+			return nil
+		}
+		r.markFailed(ctx, err)
+		return err
+	}
+
+	if err := r.setObjectContent(ctx, content); err != nil {
+		r.markFailed(ctx, err)
+		return err
+	}
+
+	r.markCompleted(ctx, size)
+	return nil
+}
+
+// getObjectContent returns the value of the key in the referenced Secret or
+// ConfigMap, and false if the object or key does not exist.
+func (r *Reconciler) getObjectContent(ctx *context.VirtualMachineGuestFileTransferContextA2) ([]byte, bool, error) {
+	ref := ctx.FileTransfer.Spec.Object
+	key := client.ObjectKey{Namespace: ctx.FileTransfer.Namespace, Name: ref.Name}
+
+	switch ref.Kind {
+	case vmopv1.VirtualMachineGuestFileTransferObjectKindSecret:
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, key, secret); err != nil {
+			return nil, false, client.IgnoreNotFound(err)
+		}
+		content, ok := secret.Data[ref.Key]
+		return content, ok, nil
+
+	case vmopv1.VirtualMachineGuestFileTransferObjectKindConfigMap:
+		cm := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, cm); err != nil {
+			return nil, false, client.IgnoreNotFound(err)
+		}
+		if content, ok := cm.Data[ref.Key]; ok {
+			return []byte(content), true, nil
+		}
+		content, ok := cm.BinaryData[ref.Key]
+		return content, ok, nil
+	}
+
+	return nil, false, fmt.Errorf("unsupported object kind %q", ref.Kind)
+}
+
+// setObjectContent sets the value of the key in the referenced ConfigMap, creating
+// the object if it does not exist. The webhook has checked that the user that
+// requested the transfer may create and update the object. Files are never copied
+// into a Secret since the operator may only read Secrets.
+func (r *Reconciler) setObjectContent(ctx *context.VirtualMachineGuestFileTransferContextA2, content []byte) error {
+	ref := ctx.FileTransfer.Spec.Object
+	objectMeta := metav1.ObjectMeta{Namespace: ctx.FileTransfer.Namespace, Name: ref.Name}
+
+	switch ref.Kind {
+	case vmopv1.VirtualMachineGuestFileTransferObjectKindConfigMap:
+		cm := &corev1.ConfigMap{ObjectMeta: objectMeta}
+		_, err := controllerutil.CreateOrPatch(ctx, r.Client, cm, func() error {
+			// A ConfigMap key may only be in one of the maps, and only
+			// UTF-8 content may be stored in Data.
+			delete(cm.Data, ref.Key)
+			delete(cm.BinaryData, ref.Key)
+			if utf8.Valid(content) {
+				if cm.Data == nil {
+					cm.Data = map[string]string{}
+				}
+				cm.Data[ref.Key] = string(content)
+			} else {
+				if cm.BinaryData == nil {
+					cm.BinaryData = map[string][]byte{}
+				}
+				cm.BinaryData[ref.Key] = content
+			}
+			return nil
+		})
+		return err
+	}
+
+	return fmt.Errorf("unsupported object kind %q", ref.Kind)
+}
+
+func (r *Reconciler) markCompleted(ctx *context.VirtualMachineGuestFileTransferContextA2, size int64) {
+	now := metav1.Now()
+	ctx.FileTransfer.Status.Size = size
+	ctx.FileTransfer.Status.CompletionTime = &now
+	conditions.MarkTrue(ctx.FileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)
+	r.Recorder.EmitEvent(ctx.FileTransfer, "Transfer", nil, false)
+}
+
+func (r *Reconciler) markFailed(ctx *context.VirtualMachineGuestFileTransferContextA2, err error) {
+	ctx.Logger.Error(err, "Failed to transfer VirtualMachineGuestFileTransfer file")
+	conditions.MarkFalse(ctx.FileTransfer,
+		vmopv1.VirtualMachineGuestFileTransferConditionCompleted,
+		vmopv1.VirtualMachineGuestFileTransferFailedReason,
+		"%v", err)
+	r.Recorder.EmitEvent(ctx.FileTransfer, "Transfer", err, false)
+}
+
+func (r *Reconciler) markSizeLimitExceeded(ctx *context.VirtualMachineGuestFileTransferContextA2, size, sizeLimit int64) {
+	ctx.FileTransfer.Status.Size = size
+	conditions.MarkFalse(ctx.FileTransfer,
+		vmopv1.VirtualMachineGuestFileTransferConditionCompleted,
+		vmopv1.VirtualMachineGuestFileTransferSizeLimitExceededReason,
+		"File size %d bytes exceeds the size limit of %d bytes", size, sizeLimit)
+	r.Recorder.EmitEvent(ctx.FileTransfer, "Transfer", errors.New("file size limit exceeded"), false)
+}
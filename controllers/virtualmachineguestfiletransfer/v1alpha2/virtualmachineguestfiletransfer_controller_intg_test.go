@@ -0,0 +1,89 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachineGuestFileTransfer controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx          *builder.IntegrationTestContext
+		vm           *vmopv1.VirtualMachine
+		fileTransfer *vmopv1.VirtualMachineGuestFileTransfer
+	)
+
+	getFileTransfer := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineGuestFileTransfer {
+		fileTransfer := &vmopv1.VirtualMachineGuestFileTransfer{}
+		if err := ctx.Client.Get(ctx, objKey, fileTransfer); err != nil {
+			return nil
+		}
+		return fileTransfer
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", ctx.Namespace)
+		fileTransfer = builder.DummyVirtualMachineGuestFileTransferA2(ctx.Namespace, "dummy-file-transfer", vm.Name)
+		fileTransfer.Spec.Direction = vmopv1.VirtualMachineGuestFileTransferFromGuest
+
+		fakeVMProvider.Lock()
+		defer fakeVMProvider.Unlock()
+		fakeVMProvider.CopyFileFromGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, _ int64) ([]byte, int64, error) {
+			return []byte("diagnostics"), int64(len("diagnostics")), nil
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "vm-42"
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, fileTransfer)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, fileTransfer)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("copies the file from the guest", func() {
+			Eventually(func() bool {
+				fileTransfer = getFileTransfer(ctx, client.ObjectKeyFromObject(fileTransfer))
+				return fileTransfer != nil && conditions.IsTrue(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)
+			}).Should(BeTrue(), "waiting for VirtualMachineGuestFileTransfer to complete")
+			Expect(fileTransfer.Status.Size).To(BeEquivalentTo(len("diagnostics")))
+
+			cm := &corev1.ConfigMap{}
+			key := client.ObjectKey{Namespace: ctx.Namespace, Name: fileTransfer.Spec.Object.Name}
+			Expect(ctx.Client.Get(ctx, key, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue(fileTransfer.Spec.Object.Key, "diagnostics"))
+		})
+	})
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineguestfiletransfer/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking VirtualMachineGuestFileTransfer Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler      *v1alpha2.Reconciler
+		fileTransferCtx *vmopContext.VirtualMachineGuestFileTransferContextA2
+		fileTransfer    *vmopv1.VirtualMachineGuestFileTransfer
+		vm              *vmopv1.VirtualMachine
+		configMap       *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", "dummy-ns")
+		vm.Status.UniqueID = "vm-42"
+
+		fileTransfer = builder.DummyVirtualMachineGuestFileTransferA2(vm.Namespace, "dummy-file-transfer", vm.Name)
+
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fileTransfer.Spec.Object.Name,
+				Namespace: vm.Namespace,
+			},
+			Data: map[string]string{
+				fileTransfer.Spec.Object.Key: "hello=world",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProviderA2,
+		)
+		fakeVMProvider = ctx.VMProviderA2.(*providerfake.VMProviderA2)
+
+		fileTransferCtx = &vmopContext.VirtualMachineGuestFileTransferContextA2{
+			Context:      ctx,
+			Logger:       ctx.Logger.WithName(fileTransfer.Name),
+			FileTransfer: fileTransfer,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	getReason := func() string {
+		return conditions.GetReason(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, fileTransfer, configMap)
+			})
+
+			It("marks the transfer as not completed", func() {
+				_, err := reconciler.ReconcileNormal(fileTransferCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.IsFalse(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)).To(BeTrue())
+				Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferVMNotFoundReason))
+			})
+		})
+
+		When("the VM has not been created on vSphere", func() {
+			BeforeEach(func() {
+				vm.Status.UniqueID = ""
+				initObjects = append(initObjects, fileTransfer, configMap, vm)
+			})
+
+			It("marks the transfer as not completed", func() {
+				_, err := reconciler.ReconcileNormal(fileTransferCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferVMNotCreatedReason))
+			})
+		})
+
+		When("copying to the guest", func() {
+			var (
+				copiedContent []byte
+			)
+
+			BeforeEach(func() {
+				copiedContent = nil
+				initObjects = append(initObjects, fileTransfer, vm)
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.CopyFileToGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, content []byte) error {
+					copiedContent = content
+					return nil
+				}
+			})
+
+			When("the ConfigMap exists", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, configMap)
+				})
+
+				It("copies the file", func() {
+					result, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(string(copiedContent)).To(Equal("hello=world"))
+					Expect(fileTransfer.Status.Size).To(BeEquivalentTo(len("hello=world")))
+					Expect(fileTransfer.Status.CompletionTime).ToNot(BeNil())
+					Expect(conditions.IsTrue(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)).To(BeTrue())
+					Expect(fileTransfer.OwnerReferences).To(HaveLen(1))
+					Expect(fileTransfer.OwnerReferences[0].Name).To(Equal(vm.Name))
+				})
+
+				It("does not copy the file again once completed", func() {
+					now := metav1.Now()
+					fileTransfer.Status.CompletionTime = &now
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(copiedContent).To(BeNil())
+				})
+
+				When("the content exceeds the size limit", func() {
+					BeforeEach(func() {
+						limit := resource.MustParse("4")
+						fileTransfer.Spec.SizeLimit = &limit
+					})
+
+					It("does not copy the file", func() {
+						_, err := reconciler.ReconcileNormal(fileTransferCtx)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(copiedContent).To(BeNil())
+						Expect(fileTransfer.Status.CompletionTime).To(BeNil())
+						Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferSizeLimitExceededReason))
+
+						By("not retrying the transfer", func() {
+							fakeVMProvider.CopyFileToGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, _ []byte) error {
+								return errors.New("should not be called")
+							}
+							_, err := reconciler.ReconcileNormal(fileTransferCtx)
+							Expect(err).ToNot(HaveOccurred())
+						})
+					})
+				})
+
+				When("the provider returns an error", func() {
+					JustBeforeEach(func() {
+						fakeVMProvider.CopyFileToGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, _ []byte) error {
+							return errors.New("copy error")
+						}
+					})
+
+					It("returns the error", func() {
+						_, err := reconciler.ReconcileNormal(fileTransferCtx)
+						Expect(err).To(MatchError("copy error"))
+						Expect(fileTransfer.Status.CompletionTime).To(BeNil())
+						Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferFailedReason))
+					})
+				})
+			})
+
+			When("the ConfigMap has binary data", func() {
+				BeforeEach(func() {
+					configMap.Data = nil
+					configMap.BinaryData = map[string][]byte{fileTransfer.Spec.Object.Key: {0xff, 0xfe}}
+					initObjects = append(initObjects, configMap)
+				})
+
+				It("copies the file", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(copiedContent).To(Equal([]byte{0xff, 0xfe}))
+				})
+			})
+
+			When("the Secret exists", func() {
+				BeforeEach(func() {
+					fileTransfer.Spec.Object.Kind = vmopv1.VirtualMachineGuestFileTransferObjectKindSecret
+					initObjects = append(initObjects, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fileTransfer.Spec.Object.Name,
+							Namespace: vm.Namespace,
+						},
+						Data: map[string][]byte{
+							fileTransfer.Spec.Object.Key: []byte("secret"),
+						},
+					})
+				})
+
+				It("copies the file", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(copiedContent)).To(Equal("secret"))
+					Expect(conditions.IsTrue(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)).To(BeTrue())
+				})
+			})
+
+			When("the ConfigMap does not exist", func() {
+				It("requeues the transfer", func() {
+					result, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(copiedContent).To(BeNil())
+					Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferObjectNotFoundReason))
+				})
+			})
+
+			When("the ConfigMap does not have the key", func() {
+				BeforeEach(func() {
+					configMap.Data = map[string]string{"other-key": "other"}
+					initObjects = append(initObjects, configMap)
+				})
+
+				It("requeues the transfer", func() {
+					result, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferObjectNotFoundReason))
+				})
+			})
+		})
+
+		When("copying from the guest", func() {
+			var (
+				guestContent []byte
+			)
+
+			BeforeEach(func() {
+				guestContent = []byte("diagnostics")
+				fileTransfer.Spec.Direction = vmopv1.VirtualMachineGuestFileTransferFromGuest
+				initObjects = append(initObjects, fileTransfer, vm)
+			})
+
+			JustBeforeEach(func() {
+				fakeVMProvider.CopyFileFromGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, sizeLimit int64) ([]byte, int64, error) {
+					size := int64(len(guestContent))
+					if size > sizeLimit {
+						return nil, size, errors.New("size limit exceeded")
+					}
+					return guestContent, size, nil
+				}
+			})
+
+			It("creates the ConfigMap with the file", func() {
+				_, err := reconciler.ReconcileNormal(fileTransferCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fileTransfer.Status.Size).To(BeEquivalentTo(len(guestContent)))
+				Expect(fileTransfer.Status.CompletionTime).ToNot(BeNil())
+				Expect(conditions.IsTrue(fileTransfer, vmopv1.VirtualMachineGuestFileTransferConditionCompleted)).To(BeTrue())
+
+				cm := &corev1.ConfigMap{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(configMap), cm)).To(Succeed())
+				Expect(cm.Data).To(HaveKeyWithValue(fileTransfer.Spec.Object.Key, "diagnostics"))
+			})
+
+			When("the file is binary", func() {
+				BeforeEach(func() {
+					guestContent = []byte{0x1f, 0x8b, 0xff}
+					initObjects = append(initObjects, configMap)
+				})
+
+				It("updates the ConfigMap binary data with the file", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					cm := &corev1.ConfigMap{}
+					Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(configMap), cm)).To(Succeed())
+					Expect(cm.Data).ToNot(HaveKey(fileTransfer.Spec.Object.Key))
+					Expect(cm.BinaryData).To(HaveKeyWithValue(fileTransfer.Spec.Object.Key, guestContent))
+				})
+			})
+
+			When("the object is a Secret", func() {
+				BeforeEach(func() {
+					fileTransfer.Spec.Object.Kind = vmopv1.VirtualMachineGuestFileTransferObjectKindSecret
+					initObjects = append(initObjects, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fileTransfer.Spec.Object.Name,
+							Namespace: vm.Namespace,
+						},
+						Data: map[string][]byte{
+							"other-key": []byte("other"),
+						},
+					})
+				})
+
+				It("does not write the file into the Secret", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(fileTransfer.Status.CompletionTime).To(BeNil())
+					Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferFailedReason))
+
+					secret := &corev1.Secret{}
+					key := client.ObjectKey{Namespace: vm.Namespace, Name: fileTransfer.Spec.Object.Name}
+					Expect(ctx.Client.Get(ctx, key, secret)).To(Succeed())
+					Expect(secret.Data).ToNot(HaveKey(fileTransfer.Spec.Object.Key))
+					Expect(secret.Data).To(HaveKeyWithValue("other-key", []byte("other")))
+				})
+			})
+
+			When("the file exceeds the size limit", func() {
+				BeforeEach(func() {
+					limit := resource.MustParse("4")
+					fileTransfer.Spec.SizeLimit = &limit
+				})
+
+				It("does not copy the file", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fileTransfer.Status.Size).To(BeEquivalentTo(len(guestContent)))
+					Expect(fileTransfer.Status.CompletionTime).To(BeNil())
+					Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferSizeLimitExceededReason))
+
+					cm := &corev1.ConfigMap{}
+					err = ctx.Client.Get(ctx, client.ObjectKeyFromObject(configMap), cm)
+					Expect(client.IgnoreNotFound(err)).To(Succeed())
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			When("the provider returns an error", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.CopyFileFromGuestFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineGuestFileTransfer, _ int64) ([]byte, int64, error) {
+						return nil, 0, errors.New("copy error")
+					}
+				})
+
+				It("returns the error", func() {
+					_, err := reconciler.ReconcileNormal(fileTransferCtx)
+					Expect(err).To(MatchError("copy error"))
+					Expect(fileTransfer.Status.CompletionTime).To(BeNil())
+					Expect(getReason()).To(Equal(vmopv1.VirtualMachineGuestFileTransferFailedReason))
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachinePlacementRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProviderA2,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) *Reconciler {
+
+	return &Reconciler{
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachinePlacementRequest object.
+type Reconciler struct {
+	client.Client
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider vmprovider.VirtualMachineProviderInterfaceA2
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=topology.tanzu.vmware.com,resources=availabilityzones,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	placementReq := &vmopv1.VirtualMachinePlacementRequest{}
+	if err := r.Get(ctx, req.NamespacedName, placementReq); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	placementReqCtx := &context.VirtualMachinePlacementRequestContextA2{
+		Context:          ctx,
+		Logger:           ctrl.Log.WithName("VirtualMachinePlacementRequest").WithValues("name", req.NamespacedName),
+		PlacementRequest: placementReq,
+	}
+
+	if !placementReq.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(placementReq, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", placementReqCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, placementReq); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			placementReqCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	return ctrl.Result{}, r.ReconcileNormal(placementReqCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachinePlacementRequestContextA2) error {
+	if ctx.PlacementRequest.Status.CompletionTime != nil {
+		// The placement dry run is only performed once.
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachinePlacementRequest")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachinePlacementRequest")
+	}()
+
+	if err := r.VMProvider.PlaceVirtualMachine(ctx, ctx.PlacementRequest); err != nil {
+		r.markFailed(ctx, err)
+		return err
+	}
+
+	r.markCompleted(ctx)
+	return nil
+}
+
+func (r *Reconciler) markCompleted(ctx *context.VirtualMachinePlacementRequestContextA2) {
+	now := metav1.Now()
+	ctx.PlacementRequest.Status.CompletionTime = &now
+	conditions.MarkTrue(ctx.PlacementRequest, vmopv1.VirtualMachinePlacementRequestConditionCompleted)
+	r.Recorder.EmitEvent(ctx.PlacementRequest, "Placement", nil, false)
+}
+
+func (r *Reconciler) markFailed(ctx *context.VirtualMachinePlacementRequestContextA2, err error) {
+	ctx.Logger.Error(err, "Failed to perform VirtualMachinePlacementRequest placement dry run")
+	conditions.MarkFalse(ctx.PlacementRequest,
+		vmopv1.VirtualMachinePlacementRequestConditionCompleted,
+		vmopv1.VirtualMachinePlacementRequestFailedReason,
+		"%v", err)
+	r.Recorder.EmitEvent(ctx.PlacementRequest, "Placement", err, false)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachinePlacementRequest controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx          *builder.IntegrationTestContext
+		placementReq *vmopv1.VirtualMachinePlacementRequest
+	)
+
+	getPlacementRequest := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachinePlacementRequest {
+		placementReq := &vmopv1.VirtualMachinePlacementRequest{}
+		if err := ctx.Client.Get(ctx, objKey, placementReq); err != nil {
+			return nil
+		}
+		return placementReq
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		placementReq = builder.DummyVirtualMachinePlacementRequestA2(ctx.Namespace, "dummy-placement-request")
+
+		fakeVMProvider.Lock()
+		defer fakeVMProvider.Unlock()
+		fakeVMProvider.PlaceVirtualMachineFn = func(_ context.Context, placementReq *vmopv1.VirtualMachinePlacementRequest) error {
+			placementReq.Status.Faults = []string{"insufficient resources"}
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, placementReq)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, placementReq)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("reports the placement dry run result", func() {
+			Eventually(func() bool {
+				placementReq = getPlacementRequest(ctx, client.ObjectKeyFromObject(placementReq))
+				return placementReq != nil && conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionCompleted)
+			}).Should(BeTrue(), "waiting for VirtualMachinePlacementRequest to complete")
+			Expect(placementReq.Status.Faults).To(ConsistOf("insufficient resources"))
+			Expect(placementReq.Status.CompletionTime).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineplacementrequest/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking VirtualMachinePlacementRequest Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler      *v1alpha2.Reconciler
+		placementReqCtx *vmopContext.VirtualMachinePlacementRequestContextA2
+		placementReq    *vmopv1.VirtualMachinePlacementRequest
+		placeCalls      int
+	)
+
+	BeforeEach(func() {
+		placementReq = builder.DummyVirtualMachinePlacementRequestA2("dummy-ns", "dummy-placement-request")
+		initObjects = append(initObjects, placementReq)
+		placeCalls = 0
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProviderA2,
+		)
+		fakeVMProvider = ctx.VMProviderA2.(*providerfake.VMProviderA2)
+		fakeVMProvider.PlaceVirtualMachineFn = func(_ context.Context, placementReq *vmopv1.VirtualMachinePlacementRequest) error {
+			placeCalls++
+			placementReq.Status.Zones = []vmopv1.VirtualMachinePlacementZoneRecommendations{
+				{
+					Name: "zone-1",
+					Recommendations: []vmopv1.VirtualMachinePlacementRecommendation{
+						{ResourcePool: "resgroup-42"},
+					},
+				},
+			}
+			return nil
+		}
+
+		placementReqCtx = &vmopContext.VirtualMachinePlacementRequestContextA2{
+			Context:          ctx,
+			Logger:           ctx.Logger.WithName(placementReq.Name),
+			PlacementRequest: placementReq,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+
+		It("performs the placement dry run", func() {
+			Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+			Expect(placeCalls).To(Equal(1))
+			Expect(placementReq.Status.Zones).To(HaveLen(1))
+			Expect(placementReq.Status.Zones[0].Name).To(Equal("zone-1"))
+			Expect(placementReq.Status.CompletionTime).ToNot(BeNil())
+			Expect(conditions.IsTrue(placementReq, vmopv1.VirtualMachinePlacementRequestConditionCompleted)).To(BeTrue())
+		})
+
+		It("does not perform the placement dry run again once completed", func() {
+			now := metav1.Now()
+			placementReq.Status.CompletionTime = &now
+			Expect(reconciler.ReconcileNormal(placementReqCtx)).To(Succeed())
+			Expect(placeCalls).To(BeZero())
+		})
+
+		When("the provider returns an error", func() {
+			JustBeforeEach(func() {
+				fakeVMProvider.PlaceVirtualMachineFn = func(_ context.Context, _ *vmopv1.VirtualMachinePlacementRequest) error {
+					return errors.New("placement error")
+				}
+			})
+
+			It("returns the error", func() {
+				err := reconciler.ReconcileNormal(placementReqCtx)
+				Expect(err).To(MatchError("placement error"))
+				Expect(placementReq.Status.CompletionTime).To(BeNil())
+				Expect(conditions.IsFalse(placementReq, vmopv1.VirtualMachinePlacementRequestConditionCompleted)).To(BeTrue())
+				Expect(conditions.GetReason(placementReq, vmopv1.VirtualMachinePlacementRequestConditionCompleted)).
+					To(Equal(vmopv1.VirtualMachinePlacementRequestFailedReason))
+			})
+		})
+	})
+}
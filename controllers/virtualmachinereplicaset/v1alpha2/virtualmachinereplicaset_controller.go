@@ -0,0 +1,490 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	apierrorsutil "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// expectationsTimeout is how long the controller waits for its cache to
+	// observe the replicas it created or deleted before it scales the replica
+	// set again regardless.
+	expectationsTimeout = 5 * time.Minute
+
+	// expectationsRequeueDelay is how long to wait before reconciling a replica
+	// set whose created or deleted replicas have not been observed yet.
+	expectationsRequeueDelay = 10 * time.Second
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineReplicaSet{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Owns(&vmopv1.VirtualMachine{}).
+		Complete(r)
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+		expectations: &expectations{
+			items: map[types.NamespacedName]*replicaExpectations{},
+		},
+	}
+}
+
+// Reconciler reconciles a VirtualMachineReplicaSet object.
+type Reconciler struct {
+	client.Client
+	Logger   logr.Logger
+	Recorder record.Recorder
+
+	expectations *expectations
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesetresourcepolicies,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	vmReplicaSet := &vmopv1.VirtualMachineReplicaSet{}
+	if err := r.Get(ctx, req.NamespacedName, vmReplicaSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.expectations.delete(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmReplicaSetCtx := &context.VirtualMachineReplicaSetContextA2{
+		Context:      ctx,
+		Logger:       ctrl.Log.WithName("VirtualMachineReplicaSet").WithValues("name", req.NamespacedName),
+		VMReplicaSet: vmReplicaSet,
+	}
+
+	patchHelper, err := patch.NewHelper(vmReplicaSet, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", vmReplicaSetCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmReplicaSet); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmReplicaSetCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmReplicaSet.DeletionTimestamp.IsZero() {
+		// The replicas are owned by the replica set and are garbage collected
+		// once it is deleted.
+		r.expectations.delete(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	return r.ReconcileNormal(vmReplicaSetCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachineReplicaSetContextA2) (ctrl.Result, error) {
+	ctx.Logger.Info("Reconciling VirtualMachineReplicaSet")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineReplicaSet")
+	}()
+
+	rs := ctx.VMReplicaSet
+
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to parse selector")
+	}
+
+	vms, err := r.getReplicas(ctx, selector)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var desired int
+	if rs.Spec.Replicas != nil {
+		desired = int(*rs.Spec.Replicas)
+	}
+
+	r.updateStatus(ctx, selector, vms, desired)
+
+	// The replicas are listed from the cache, which may not have observed the
+	// replicas created or deleted by a previous reconcile yet. Scaling from a
+	// stale list would create or delete too many replicas.
+	key := client.ObjectKeyFromObject(rs)
+	if !r.expectations.satisfied(ctx, key, vms) {
+		ctx.Logger.Info("Waiting for created and deleted replicas to be observed")
+		return ctrl.Result{RequeueAfter: expectationsRequeueDelay}, nil
+	}
+
+	var syncErr error
+	switch diff := desired - len(vms); {
+	case diff > 0:
+		syncErr = r.createReplicas(ctx, diff)
+	case diff < 0:
+		syncErr = r.deleteReplicas(ctx, vms, -diff)
+	}
+
+	return ctrl.Result{}, syncErr
+}
+
+// getReplicas returns the VMs that match the selector and are controlled by
+// the replica set. VMs that are being deleted are not included.
+func (r *Reconciler) getReplicas(
+	ctx *context.VirtualMachineReplicaSetContextA2,
+	selector labels.Selector) ([]vmopv1.VirtualMachine, error) {
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := r.List(ctx, vmList,
+		client.InNamespace(ctx.VMReplicaSet.Namespace),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "failed to list VirtualMachines")
+	}
+
+	var vms []vmopv1.VirtualMachine
+	for _, vm := range vmList.Items {
+		if !vm.DeletionTimestamp.IsZero() || !metav1.IsControlledBy(&vm, ctx.VMReplicaSet) {
+			continue
+		}
+		vms = append(vms, vm)
+	}
+
+	return vms, nil
+}
+
+// getClusterModuleGroup returns the name of the cluster module group the
+// replicas are placed in so they are spread across the hosts of a zone. An
+// empty string is returned when the replicas should not be added to a group
+// by the controller.
+func (r *Reconciler) getClusterModuleGroup(ctx *context.VirtualMachineReplicaSetContextA2) (string, error) {
+	template := ctx.VMReplicaSet.Spec.Template
+
+	if _, ok := template.ObjectMeta.Annotations[pkg.ClusterModuleNameKey]; ok {
+		return "", nil
+	}
+
+	reserved := template.Spec.Reserved
+	if reserved == nil || reserved.ResourcePolicyName == "" {
+		return "", nil
+	}
+
+	resourcePolicy := &vmopv1.VirtualMachineSetResourcePolicy{}
+	key := client.ObjectKey{Namespace: ctx.VMReplicaSet.Namespace, Name: reserved.ResourcePolicyName}
+	if err := r.Get(ctx, key, resourcePolicy); err != nil {
+		return "", errors.Wrapf(err, "failed to get VirtualMachineSetResourcePolicy %s", key)
+	}
+
+	for _, group := range resourcePolicy.Spec.ClusterModuleGroups {
+		if group == ctx.VMReplicaSet.Name {
+			return group, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *Reconciler) createReplicas(ctx *context.VirtualMachineReplicaSetContextA2, count int) (reterr error) {
+	rs := ctx.VMReplicaSet
+
+	defer func() {
+		r.Recorder.EmitEvent(rs, "CreateReplicas", reterr, true)
+	}()
+
+	clusterModuleGroup, err := r.getClusterModuleGroup(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx.Logger.Info("Creating replicas", "count", count)
+
+	var (
+		errs    []error
+		created []string
+	)
+	defer func() {
+		r.expectations.expect(client.ObjectKeyFromObject(rs), created, nil)
+	}()
+
+	for i := 0; i < count; i++ {
+		vm := &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: rs.Name + "-",
+				Namespace:    rs.Namespace,
+				Labels:       map[string]string{},
+				Annotations:  map[string]string{},
+			},
+			Spec: *rs.Spec.Template.Spec.DeepCopy(),
+		}
+
+		for k, v := range rs.Spec.Template.ObjectMeta.Labels {
+			vm.Labels[k] = v
+		}
+		for k, v := range rs.Spec.Template.ObjectMeta.Annotations {
+			vm.Annotations[k] = v
+		}
+		if clusterModuleGroup != "" {
+			vm.Annotations[pkg.ClusterModuleNameKey] = clusterModuleGroup
+		}
+
+		if err := controllerutil.SetControllerReference(rs, vm, r.Scheme()); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, vm); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to create VirtualMachine"))
+			continue
+		}
+		created = append(created, vm.Name)
+
+		ctx.Logger.V(4).Info("Created replica", "vmName", vm.Name)
+	}
+
+	return apierrorsutil.NewAggregate(errs)
+}
+
+func (r *Reconciler) deleteReplicas(
+	ctx *context.VirtualMachineReplicaSetContextA2,
+	vms []vmopv1.VirtualMachine,
+	count int) (reterr error) {
+
+	defer func() {
+		r.Recorder.EmitEvent(ctx.VMReplicaSet, "DeleteReplicas", reterr, true)
+	}()
+
+	ctx.Logger.Info("Deleting replicas", "count", count)
+
+	// Prefer deleting the replicas that have not been created yet, then the
+	// ones that are not ready, and then the newest ones.
+	sort.SliceStable(vms, func(i, j int) bool {
+		if a, b := isAvailable(&vms[i]), isAvailable(&vms[j]); a != b {
+			return !a
+		}
+		if a, b := isReady(&vms[i]), isReady(&vms[j]); a != b {
+			return !a
+		}
+		return vms[j].CreationTimestamp.Before(&vms[i].CreationTimestamp)
+	})
+
+	var (
+		errs    []error
+		deleted []string
+	)
+	defer func() {
+		r.expectations.expect(client.ObjectKeyFromObject(ctx.VMReplicaSet), nil, deleted)
+	}()
+
+	for i := 0; i < count && i < len(vms); i++ {
+		vm := &vms[i]
+		if err := r.Delete(ctx, vm); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete VirtualMachine %s", vm.Name))
+			continue
+		}
+		deleted = append(deleted, vm.Name)
+
+		ctx.Logger.V(4).Info("Deleted replica", "vmName", vm.Name)
+	}
+
+	return apierrorsutil.NewAggregate(errs)
+}
+
+func (r *Reconciler) updateStatus(
+	ctx *context.VirtualMachineReplicaSetContextA2,
+	selector labels.Selector,
+	vms []vmopv1.VirtualMachine,
+	desired int) {
+
+	rs := ctx.VMReplicaSet
+
+	var available, ready int32
+	for i := range vms {
+		if isAvailable(&vms[i]) {
+			available++
+			if isReady(&vms[i]) {
+				ready++
+			}
+		}
+	}
+
+	rs.Status.Replicas = int32(len(vms))
+	rs.Status.AvailableReplicas = available
+	rs.Status.ReadyReplicas = ready
+	rs.Status.Selector = selector.String()
+	rs.Status.ObservedGeneration = rs.Generation
+
+	switch {
+	case len(vms) < desired:
+		conditions.MarkFalse(rs,
+			vmopv1.VirtualMachineReplicaSetReplicasReadyCondition,
+			vmopv1.VirtualMachineReplicaSetScalingUpReason,
+			"Scaling up to %d replicas (actual %d)", desired, len(vms))
+	case len(vms) > desired:
+		conditions.MarkFalse(rs,
+			vmopv1.VirtualMachineReplicaSetReplicasReadyCondition,
+			vmopv1.VirtualMachineReplicaSetScalingDownReason,
+			"Scaling down to %d replicas (actual %d)", desired, len(vms))
+	case int(ready) < desired:
+		conditions.MarkFalse(rs,
+			vmopv1.VirtualMachineReplicaSetReplicasReadyCondition,
+			vmopv1.VirtualMachineReplicaSetWaitingForReplicasReadyReason,
+			"%d of %d replicas are ready", ready, desired)
+	default:
+		conditions.MarkTrue(rs, vmopv1.VirtualMachineReplicaSetReplicasReadyCondition)
+	}
+}
+
+// isAvailable returns true if the VM has been created on the underlying
+// infrastructure.
+func isAvailable(vm *vmopv1.VirtualMachine) bool {
+	return conditions.IsTrue(vm, vmopv1.VirtualMachineConditionCreated)
+}
+
+// isReady returns true if the VM's readiness probe reports the VM is ready.
+// VMs without a readiness probe are ready once they are available.
+func isReady(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.ReadinessProbe == nil {
+		return isAvailable(vm)
+	}
+	return isAvailable(vm) && conditions.IsTrue(vm, vmopv1.ReadyConditionType)
+}
+
+// replicaExpectations are the names of the replicas a replica set created or
+// deleted that have not been observed in the cache yet.
+type replicaExpectations struct {
+	creates   map[string]struct{}
+	deletes   map[string]struct{}
+	timestamp time.Time
+}
+
+// expectations records the replicas created and deleted by the controller so
+// a replica set is not scaled again until the cache has caught up.
+type expectations struct {
+	mu    sync.Mutex
+	items map[types.NamespacedName]*replicaExpectations
+}
+
+// expect records the replicas of the replica set that were created and deleted.
+func (e *expectations) expect(key types.NamespacedName, creates, deletes []string) {
+	if len(creates) == 0 && len(deletes) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	item, ok := e.items[key]
+	if !ok {
+		item = &replicaExpectations{
+			creates: map[string]struct{}{},
+			deletes: map[string]struct{}{},
+		}
+		e.items[key] = item
+	}
+	for _, name := range creates {
+		item.creates[name] = struct{}{}
+	}
+	for _, name := range deletes {
+		item.deletes[name] = struct{}{}
+	}
+	item.timestamp = time.Now()
+}
+
+// satisfied returns true if the replicas listed from the cache include all the
+// created replicas and none of the deleted replicas of the replica set, or if
+// the expectations have timed out.
+func (e *expectations) satisfied(
+	ctx *context.VirtualMachineReplicaSetContextA2,
+	key types.NamespacedName,
+	vms []vmopv1.VirtualMachine) bool {
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	item, ok := e.items[key]
+	if !ok {
+		return true
+	}
+
+	observed := make(map[string]struct{}, len(vms))
+	for i := range vms {
+		observed[vms[i].Name] = struct{}{}
+	}
+	for name := range item.creates {
+		if _, ok := observed[name]; ok {
+			delete(item.creates, name)
+		}
+	}
+	for name := range item.deletes {
+		if _, ok := observed[name]; !ok {
+			delete(item.deletes, name)
+		}
+	}
+
+	if len(item.creates) != 0 || len(item.deletes) != 0 {
+		if time.Since(item.timestamp) < expectationsTimeout {
+			return false
+		}
+		ctx.Logger.Info("Timed out waiting for created and deleted replicas to be observed",
+			"creates", len(item.creates), "deletes", len(item.deletes))
+	}
+
+	delete(e.items, key)
+	return true
+}
+
+// delete removes the expectations of the replica set.
+func (e *expectations) delete(key types.NamespacedName) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.items, key)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachineReplicaSet controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx          *builder.IntegrationTestContext
+		vmReplicaSet *vmopv1.VirtualMachineReplicaSet
+	)
+
+	getVMReplicaSet := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineReplicaSet {
+		vmReplicaSet := &vmopv1.VirtualMachineReplicaSet{}
+		if err := ctx.Client.Get(ctx, objKey, vmReplicaSet); err != nil {
+			return nil
+		}
+		return vmReplicaSet
+	}
+
+	getReplicas := func(ctx *builder.IntegrationTestContext) []vmopv1.VirtualMachine {
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := ctx.Client.List(ctx, vmList, client.InNamespace(ctx.Namespace)); err != nil {
+			return nil
+		}
+
+		var vms []vmopv1.VirtualMachine
+		for _, vm := range vmList.Items {
+			if vm.DeletionTimestamp.IsZero() && metav1.IsControlledBy(&vm, vmReplicaSet) {
+				vms = append(vms, vm)
+			}
+		}
+		return vms
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vmReplicaSet = builder.DummyVirtualMachineReplicaSetA2(ctx.Namespace, "dummy-rs")
+		vmReplicaSet.Spec.Replicas = pointer.Int32(2)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vmReplicaSet)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmReplicaSet)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("creates and deletes replicas", func() {
+			Eventually(func() int {
+				return len(getReplicas(ctx))
+			}).Should(Equal(2), "waiting for the replicas to be created")
+
+			Eventually(func() int32 {
+				if rs := getVMReplicaSet(ctx, client.ObjectKeyFromObject(vmReplicaSet)); rs != nil {
+					return rs.Status.Replicas
+				}
+				return 0
+			}).Should(BeEquivalentTo(2), "waiting for the replica set status to be updated")
+
+			vmReplicaSet = getVMReplicaSet(ctx, client.ObjectKeyFromObject(vmReplicaSet))
+			Expect(vmReplicaSet).ToNot(BeNil())
+			vmReplicaSet.Spec.Replicas = pointer.Int32(1)
+			Expect(ctx.Client.Update(ctx, vmReplicaSet)).To(Succeed())
+
+			Eventually(func() int {
+				return len(getReplicas(ctx))
+			}).Should(Equal(1), "waiting for the replica to be deleted")
+		})
+	})
+}
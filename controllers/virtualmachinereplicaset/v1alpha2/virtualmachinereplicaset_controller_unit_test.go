@@ -0,0 +1,288 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking VirtualMachineReplicaSet Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler      *v1alpha2.Reconciler
+		vmReplicaSetCtx *vmopContext.VirtualMachineReplicaSetContextA2
+		vmReplicaSet    *vmopv1.VirtualMachineReplicaSet
+	)
+
+	// newReplica returns a VM controlled by the replica set.
+	newReplica := func(name string, created, ready bool) *vmopv1.VirtualMachine {
+		vm := builder.DummyBasicVirtualMachineA2(name, vmReplicaSet.Namespace)
+		vm.Labels = vmReplicaSet.Spec.Template.ObjectMeta.Labels
+		Expect(controllerutil.SetControllerReference(vmReplicaSet, vm, builder.NewScheme())).To(Succeed())
+		if created {
+			conditions.MarkTrue(vm, vmopv1.VirtualMachineConditionCreated)
+		}
+		if ready {
+			vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+			conditions.MarkTrue(vm, vmopv1.ReadyConditionType)
+		}
+		return vm
+	}
+
+	getReplicas := func() []vmopv1.VirtualMachine {
+		vmList := &vmopv1.VirtualMachineList{}
+		ExpectWithOffset(1, ctx.Client.List(ctx, vmList, client.InNamespace(vmReplicaSet.Namespace))).To(Succeed())
+		return vmList.Items
+	}
+
+	reconcileNormal := func() error {
+		_, err := reconciler.ReconcileNormal(vmReplicaSetCtx)
+		return err
+	}
+
+	BeforeEach(func() {
+		vmReplicaSet = builder.DummyVirtualMachineReplicaSetA2("dummy-ns", "dummy-rs")
+		vmReplicaSet.UID = "dummy-rs-uid"
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+
+		vmReplicaSetCtx = &vmopContext.VirtualMachineReplicaSetContextA2{
+			Context:      ctx,
+			Logger:       ctx.Logger.WithName(vmReplicaSet.Name),
+			VMReplicaSet: vmReplicaSet,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("there are fewer replicas than desired", func() {
+			BeforeEach(func() {
+				vmReplicaSet.Spec.Replicas = pointer.Int32(3)
+				vmReplicaSet.Spec.Template.ObjectMeta.Annotations = map[string]string{"foo": "bar"}
+				initObjects = append(initObjects, vmReplicaSet, newReplica("dummy-rs-0", true, false))
+			})
+
+			It("creates the missing replicas from the template", func() {
+				Expect(reconcileNormal()).To(Succeed())
+
+				vms := getReplicas()
+				Expect(vms).To(HaveLen(3))
+				for _, vm := range vms {
+					Expect(metav1.IsControlledBy(&vm, vmReplicaSet)).To(BeTrue())
+					Expect(vm.Labels).To(HaveKeyWithValue("app", vmReplicaSet.Name))
+					Expect(vm.Spec.ImageName).To(Equal(vmReplicaSet.Spec.Template.Spec.ImageName))
+					Expect(vm.Spec.ClassName).To(Equal(vmReplicaSet.Spec.Template.Spec.ClassName))
+					if vm.Name != "dummy-rs-0" {
+						Expect(vm.Annotations).To(HaveKeyWithValue("foo", "bar"))
+						Expect(vm.Annotations).ToNot(HaveKey(pkg.ClusterModuleNameKey))
+					}
+				}
+
+				Expect(vmReplicaSet.Status.Replicas).To(BeEquivalentTo(1))
+				Expect(conditions.GetReason(vmReplicaSet, vmopv1.VirtualMachineReplicaSetReplicasReadyCondition)).
+					To(Equal(vmopv1.VirtualMachineReplicaSetScalingUpReason))
+			})
+
+			When("the created replicas have not been observed yet", func() {
+				JustBeforeEach(func() {
+					Expect(reconcileNormal()).To(Succeed())
+
+					// Remove the created replicas so the next list does not
+					// return them, as if they were not in the cache yet.
+					for _, vm := range getReplicas() {
+						if vm.Name != "dummy-rs-0" {
+							Expect(ctx.Client.Delete(ctx, &vm)).To(Succeed())
+						}
+					}
+				})
+
+				It("does not create more replicas", func() {
+					result, err := reconciler.ReconcileNormal(vmReplicaSetCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(getReplicas()).To(HaveLen(1))
+				})
+			})
+		})
+
+		When("the template references a resource policy with a cluster module group for the replica set", func() {
+			var resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+
+			BeforeEach(func() {
+				resourcePolicy = builder.DummyVirtualMachineSetResourcePolicy2A2("dummy-policy", vmReplicaSet.Namespace)
+				resourcePolicy.Spec.ClusterModuleGroups = []string{vmReplicaSet.Name}
+				vmReplicaSet.Spec.Template.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{
+					ResourcePolicyName: resourcePolicy.Name,
+				}
+				initObjects = append(initObjects, vmReplicaSet, resourcePolicy)
+			})
+
+			It("adds the replicas to the cluster module group", func() {
+				Expect(reconcileNormal()).To(Succeed())
+
+				vms := getReplicas()
+				Expect(vms).To(HaveLen(1))
+				Expect(vms[0].Annotations).To(HaveKeyWithValue(pkg.ClusterModuleNameKey, vmReplicaSet.Name))
+				Expect(vms[0].Spec.Reserved.ResourcePolicyName).To(Equal(resourcePolicy.Name))
+			})
+
+			When("the template sets the cluster module group annotation", func() {
+				BeforeEach(func() {
+					vmReplicaSet.Spec.Template.ObjectMeta.Annotations = map[string]string{
+						pkg.ClusterModuleNameKey: "other-group",
+					}
+				})
+
+				It("does not change the annotation", func() {
+					Expect(reconcileNormal()).To(Succeed())
+
+					vms := getReplicas()
+					Expect(vms).To(HaveLen(1))
+					Expect(vms[0].Annotations).To(HaveKeyWithValue(pkg.ClusterModuleNameKey, "other-group"))
+				})
+			})
+
+			When("the resource policy does not exist", func() {
+				BeforeEach(func() {
+					initObjects = []client.Object{vmReplicaSet}
+				})
+
+				It("returns an error", func() {
+					err := reconcileNormal()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to get VirtualMachineSetResourcePolicy"))
+					Expect(getReplicas()).To(BeEmpty())
+				})
+			})
+		})
+
+		When("there are more replicas than desired", func() {
+			BeforeEach(func() {
+				notReady := newReplica("not-ready", true, false)
+				notReady.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{}
+
+				vmReplicaSet.Spec.Replicas = pointer.Int32(1)
+				initObjects = append(initObjects, vmReplicaSet,
+					newReplica("ready", true, true),
+					newReplica("not-created", false, false),
+					notReady)
+			})
+
+			It("deletes the replicas that are not created or not ready first", func() {
+				Expect(reconcileNormal()).To(Succeed())
+
+				vms := getReplicas()
+				Expect(vms).To(HaveLen(1))
+				Expect(vms[0].Name).To(Equal("ready"))
+
+				Expect(conditions.GetReason(vmReplicaSet, vmopv1.VirtualMachineReplicaSetReplicasReadyCondition)).
+					To(Equal(vmopv1.VirtualMachineReplicaSetScalingDownReason))
+			})
+
+			When("the deleted replicas have not been observed yet", func() {
+				JustBeforeEach(func() {
+					Expect(reconcileNormal()).To(Succeed())
+
+					// Add back a deleted replica, as if its deletion was not in
+					// the cache yet.
+					Expect(ctx.Client.Create(ctx, newReplica("not-created", false, false))).To(Succeed())
+				})
+
+				It("does not delete more replicas", func() {
+					result, err := reconciler.ReconcileNormal(vmReplicaSetCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(getReplicas()).To(HaveLen(2))
+				})
+			})
+		})
+
+		When("there are VMs matching the selector that are not controlled by the replica set", func() {
+			BeforeEach(func() {
+				vm := builder.DummyBasicVirtualMachineA2("not-owned", vmReplicaSet.Namespace)
+				vm.Labels = vmReplicaSet.Spec.Template.ObjectMeta.Labels
+				initObjects = append(initObjects, vmReplicaSet, vm)
+			})
+
+			It("does not count them as replicas", func() {
+				Expect(reconcileNormal()).To(Succeed())
+				Expect(getReplicas()).To(HaveLen(2))
+				Expect(vmReplicaSet.Status.Replicas).To(BeZero())
+			})
+		})
+
+		When("there are the desired number of replicas", func() {
+			BeforeEach(func() {
+				vmReplicaSet.Generation = 2
+				vmReplicaSet.Spec.Replicas = pointer.Int32(3)
+				initObjects = append(initObjects, vmReplicaSet,
+					newReplica("ready", true, true),
+					newReplica("created-no-probe", true, false),
+					newReplica("not-created", false, false))
+			})
+
+			It("reports the available and ready replicas", func() {
+				Expect(reconcileNormal()).To(Succeed())
+				Expect(getReplicas()).To(HaveLen(3))
+
+				Expect(vmReplicaSet.Status.Replicas).To(BeEquivalentTo(3))
+				Expect(vmReplicaSet.Status.AvailableReplicas).To(BeEquivalentTo(2))
+				Expect(vmReplicaSet.Status.ReadyReplicas).To(BeEquivalentTo(2))
+				Expect(vmReplicaSet.Status.Selector).To(Equal("app=" + vmReplicaSet.Name))
+				Expect(vmReplicaSet.Status.ObservedGeneration).To(BeEquivalentTo(2))
+				Expect(conditions.GetReason(vmReplicaSet, vmopv1.VirtualMachineReplicaSetReplicasReadyCondition)).
+					To(Equal(vmopv1.VirtualMachineReplicaSetWaitingForReplicasReadyReason))
+			})
+
+			When("all the replicas are ready", func() {
+				BeforeEach(func() {
+					initObjects = []client.Object{vmReplicaSet,
+						newReplica("ready-0", true, true),
+						newReplica("ready-1", true, true),
+						newReplica("ready-2", true, true)}
+				})
+
+				It("marks the replicas as ready", func() {
+					Expect(reconcileNormal()).To(Succeed())
+					Expect(vmReplicaSet.Status.ReadyReplicas).To(BeEquivalentTo(3))
+					Expect(conditions.IsTrue(vmReplicaSet, vmopv1.VirtualMachineReplicaSetReplicasReadyCondition)).To(BeTrue())
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/quota"
+)
+
+// usageRequeueDelay is how often the usage reported in the status is refreshed, so
+// that changes to the VirtualMachineClasses of the VMs are picked up.
+const usageRequeueDelay = 5 * time.Minute
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineResourceQuota{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToResourceQuotaMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmToResourceQuotaMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachineResourceQuotas in response to an event on the VirtualMachine resource.
+func vmToResourceQuotaMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given VirtualMachine, return reconcile requests
+	// for the VirtualMachineResourceQuotas in its namespace.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+		logger := ctx.Logger.WithValues("name", vm.Name, "namespace", vm.Namespace)
+
+		quotaList := &vmopv1.VirtualMachineResourceQuotaList{}
+		if err := c.List(ctx, quotaList, client.InNamespace(vm.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineResourceQuotas for reconciliation due to VirtualMachine watch")
+			return nil
+		}
+
+		reconcileRequests := make([]reconcile.Request, 0, len(quotaList.Items))
+		for _, q := range quotaList.Items {
+			key := client.ObjectKey{Namespace: q.Namespace, Name: q.Name}
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachineResourceQuota reconcile requests due to VirtualMachine watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger) *Reconciler {
+
+	return &Reconciler{
+		Client: client,
+		Logger: logger,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineResourceQuota object.
+type Reconciler struct {
+	client.Client
+	Logger logr.Logger
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+	if err := r.Get(ctx, req.NamespacedName, resourceQuota); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	resourceQuotaCtx := &context.VirtualMachineResourceQuotaContextA2{
+		Context:       ctx,
+		Logger:        r.Logger.WithValues("name", req.NamespacedName),
+		ResourceQuota: resourceQuota,
+	}
+
+	if !resourceQuota.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(resourceQuota, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", resourceQuotaCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, resourceQuota); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			resourceQuotaCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if err := r.ReconcileNormal(resourceQuotaCtx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Requeue when the first reservation expires so its usage is released.
+	requeueAfter := usageRequeueDelay
+	for _, reservation := range resourceQuota.Status.Reservations {
+		if d := time.Until(reservation.ExpirationTime.Time); d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// ReconcileNormal sets the hard limits and the current usage of the limited resources
+// by the VMs in the namespace in the status. The usage reserved by the webhook for a
+// VM is kept until the VM is observed with the reserved class or the reservation
+// expires, since the cache may not have the VM yet.
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachineResourceQuotaContextA2) error {
+	ctx.Logger.V(4).Info("Reconciling VirtualMachineResourceQuota")
+
+	resourceQuota := ctx.ResourceQuota
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := r.List(ctx, vmList, client.InNamespace(resourceQuota.Namespace)); err != nil {
+		ctx.Logger.Error(err, "Failed to list the VMs in the namespace")
+		return err
+	}
+
+	used, err := quota.NamespaceUsage(ctx, r.Client, resourceQuota.Namespace)
+	if err != nil {
+		ctx.Logger.Error(err, "Failed to compute the usage of the VMs in the namespace")
+		return err
+	}
+
+	vmClassNames := make(map[string]string, len(vmList.Items))
+	for _, vm := range vmList.Items {
+		vmClassNames[vm.Name] = vm.Spec.ClassName
+	}
+
+	now := time.Now()
+	var reservations []vmopv1.VirtualMachineResourceQuotaReservation
+	for _, reservation := range resourceQuota.Status.Reservations {
+		if !reservation.ExpirationTime.After(now) {
+			continue
+		}
+		if className, ok := vmClassNames[reservation.VMName]; ok && className == reservation.ClassName {
+			continue
+		}
+		reservations = append(reservations, reservation)
+		used = quota.Add(used, reservation.Resources)
+	}
+
+	resourceQuota.Status.Hard = resourceQuota.Spec.Hard.DeepCopy()
+	resourceQuota.Status.Used = make(corev1.ResourceList, len(resourceQuota.Spec.Hard))
+	for name := range resourceQuota.Spec.Hard {
+		resourceQuota.Status.Used[name] = used[name]
+	}
+	resourceQuota.Status.Reservations = reservations
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachineResourceQuota controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx           *builder.IntegrationTestContext
+		resourceQuota *vmopv1.VirtualMachineResourceQuota
+	)
+
+	getResourceQuota := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineResourceQuota {
+		resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+		if err := ctx.Client.Get(ctx, objKey, resourceQuota); err != nil {
+			return nil
+		}
+		return resourceQuota
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		resourceQuota = builder.DummyVirtualMachineResourceQuotaA2(ctx.Namespace, "dummy-quota")
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, resourceQuota)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, resourceQuota)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("reports the hard limits and the usage", func() {
+			Eventually(func() bool {
+				resourceQuota = getResourceQuota(ctx, client.ObjectKeyFromObject(resourceQuota))
+				return resourceQuota != nil && len(resourceQuota.Status.Hard) != 0
+			}).Should(BeTrue(), "waiting for VirtualMachineResourceQuota status")
+			Expect(resourceQuota.Status.Used).To(HaveLen(len(resourceQuota.Spec.Hard)))
+			for _, q := range resourceQuota.Status.Used {
+				Expect(q.IsZero()).To(BeTrue())
+			}
+		})
+	})
+}
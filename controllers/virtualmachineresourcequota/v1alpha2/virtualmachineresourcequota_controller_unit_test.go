@@ -0,0 +1,138 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineresourcequota/v1alpha2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking VirtualMachineResourceQuota Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+	const ns = "dummy-ns"
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler       *v1alpha2.Reconciler
+		resourceQuotaCtx *vmopContext.VirtualMachineResourceQuotaContextA2
+		resourceQuota    *vmopv1.VirtualMachineResourceQuota
+	)
+
+	BeforeEach(func() {
+		resourceQuota = builder.DummyVirtualMachineResourceQuotaA2(ns, "dummy-quota")
+
+		vmClass := builder.DummyVirtualMachineClassA2()
+		vmClass.Name = "dummy-class"
+		vmClass.Namespace = ns
+
+		vm1 := builder.DummyBasicVirtualMachineA2("dummy-vm-1", ns)
+		vm1.Spec.ClassName = vmClass.Name
+		vm2 := builder.DummyBasicVirtualMachineA2("dummy-vm-2", ns)
+		vm2.Spec.ClassName = vmClass.Name
+
+		initObjects = append(initObjects, resourceQuota, vmClass, vm1, vm2)
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+		)
+
+		resourceQuotaCtx = &vmopContext.VirtualMachineResourceQuotaContextA2{
+			Context:       ctx,
+			Logger:        ctx.Logger.WithName(resourceQuota.Name),
+			ResourceQuota: resourceQuota,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		It("reports the hard limits and the usage of the VMs", func() {
+			Expect(reconciler.ReconcileNormal(resourceQuotaCtx)).To(Succeed())
+
+			Expect(resourceQuota.Status.Hard).To(Equal(resourceQuota.Spec.Hard))
+			Expect(resourceQuota.Status.Used).To(HaveLen(2))
+			cpu := resourceQuota.Status.Used[vmopv1.VirtualMachineResourceCPU]
+			Expect(cpu.Value()).To(BeEquivalentTo(4))
+			memory := resourceQuota.Status.Used[vmopv1.VirtualMachineResourceMemory]
+			Expect(memory.Cmp(resource.MustParse("8Gi"))).To(BeZero())
+		})
+
+		When("the webhook reserved usage for VMs", func() {
+			newReservation := func(vmName, className string, expiration time.Time) vmopv1.VirtualMachineResourceQuotaReservation {
+				return vmopv1.VirtualMachineResourceQuotaReservation{
+					VMName:    vmName,
+					ClassName: className,
+					Resources: corev1.ResourceList{
+						vmopv1.VirtualMachineResourceCPU: resource.MustParse("2"),
+					},
+					ExpirationTime: metav1.NewTime(expiration),
+				}
+			}
+
+			BeforeEach(func() {
+				expiration := time.Now().Add(time.Minute)
+				resourceQuota.Status.Reservations = []vmopv1.VirtualMachineResourceQuotaReservation{
+					// Created concurrently and not in the cache yet.
+					newReservation("dummy-vm-3", "dummy-class", expiration),
+					newReservation("dummy-vm-4", "dummy-class", expiration),
+					// In the cache with the reserved class.
+					newReservation("dummy-vm-1", "dummy-class", expiration),
+					// In the cache with its old class.
+					newReservation("dummy-vm-2", "larger-class", expiration),
+					newReservation("dummy-vm-5", "dummy-class", time.Now().Add(-time.Second)),
+				}
+			})
+
+			It("keeps the usage of the reservations that are not observed and have not expired", func() {
+				Expect(reconciler.ReconcileNormal(resourceQuotaCtx)).To(Succeed())
+
+				Expect(resourceQuota.Status.Reservations).To(HaveLen(3))
+				Expect(resourceQuota.Status.Reservations[0].VMName).To(Equal("dummy-vm-3"))
+				Expect(resourceQuota.Status.Reservations[1].VMName).To(Equal("dummy-vm-4"))
+				Expect(resourceQuota.Status.Reservations[2].VMName).To(Equal("dummy-vm-2"))
+				cpu := resourceQuota.Status.Used[vmopv1.VirtualMachineResourceCPU]
+				Expect(cpu.Value()).To(BeEquivalentTo(10))
+			})
+		})
+
+		When("the quota limits a resource that no VM uses", func() {
+			BeforeEach(func() {
+				resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceVGPUs] = resource.MustParse("2")
+			})
+
+			It("reports no usage of the resource", func() {
+				Expect(reconciler.ReconcileNormal(resourceQuotaCtx)).To(Succeed())
+				vgpus := resourceQuota.Status.Used[vmopv1.VirtualMachineResourceVGPUs]
+				Expect(vgpus.IsZero()).To(BeTrue())
+			})
+		})
+	})
+}
@@ -7,13 +7,19 @@ import (
 	goctx "context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -23,6 +29,10 @@ import (
 
 const (
 	finalizerName = "virtualmachinesetresourcepolicy.vmoperator.vmware.com"
+
+	// resourcePoolUsageRequeueDelay is how often the usage of the child ResourcePools
+	// reported in the status is refreshed.
+	resourcePoolUsageRequeueDelay = 2 * time.Minute
 )
 
 // AddToManager adds this package's controller to the provided manager.
@@ -38,11 +48,47 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 		ctx.VMProviderA2,
 	)
 
+	// The usage reported in the status changes with every sample, so only changes to the
+	// spec trigger a reconcile and the usage is refreshed by the periodic requeue.
 	return ctrl.NewControllerManagedBy(mgr).
-		For(controlledType).
+		For(controlledType, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(nodeToResourcePolicyMapperFn(ctx, r.Client)),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
 		Complete(r)
 }
 
+// nodeToResourcePolicyMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachineSetResourcePolicies in response to an event on a Node resource. A Node's
+// labels determine if its host is selected by a host affinity.
+func nodeToResourcePolicyMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given Node, return reconcile requests for those
+	// VirtualMachineSetResourcePolicies that have a host affinity.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		logger := ctx.Logger.WithValues("name", o.GetName())
+
+		resourcePolicyList := &vmopv1.VirtualMachineSetResourcePolicyList{}
+		if err := c.List(ctx, resourcePolicyList); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineSetResourcePolicies for reconciliation due to Node watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, resourcePolicy := range resourcePolicyList.Items {
+			if resourcePolicy.Spec.HostAffinity != nil {
+				key := client.ObjectKey{Namespace: resourcePolicy.Namespace, Name: resourcePolicy.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachineSetResourcePolicy reconcile requests due to Node watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
 func NewReconciler(
 	client client.Client,
 	logger logr.Logger,
@@ -130,6 +176,7 @@ func (r *Reconciler) ReconcileDelete(ctx *context.VirtualMachineSetResourcePolic
 
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesetresourcepolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesetresourcepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 
 func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	rp := &vmopv1.VirtualMachineSetResourcePolicy{}
@@ -160,5 +207,9 @@ func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Resu
 		return ctrl.Result{}, r.ReconcileDelete(rpCtx)
 	}
 
-	return ctrl.Result{}, r.ReconcileNormal(rpCtx)
+	if err := r.ReconcileNormal(rpCtx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: resourcePoolUsageRequeueDelay}, nil
 }
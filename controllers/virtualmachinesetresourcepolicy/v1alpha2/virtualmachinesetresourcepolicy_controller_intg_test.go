@@ -103,4 +103,42 @@ func intgTests() {
 			})
 		})
 	})
+
+	Context("Reconcile when the usage changes with every sample", func() {
+		var calls atomic.Int64
+
+		BeforeEach(func() {
+			calls.Store(0)
+
+			intgFakeVMProvider.Lock()
+			intgFakeVMProvider.CreateOrUpdateVirtualMachineSetResourcePolicyFn = func(_ context.Context, rp *vmopv1.VirtualMachineSetResourcePolicy) error {
+				usage := calls.Add(1)
+				rp.Status.ResourcePools = []vmopv1.ResourcePoolStatus{
+					{
+						ClusterMoID: "domain-c1",
+						CPU:         vmopv1.ResourcePoolResourceUsage{Usage: usage},
+					},
+				}
+				return nil
+			}
+			intgFakeVMProvider.Unlock()
+		})
+
+		AfterEach(func() {
+			Expect(ctx.Client.Delete(ctx, resourcePolicy)).To(Succeed())
+		})
+
+		It("Does not reconcile again when only the status changes", func() {
+			Expect(ctx.Client.Create(ctx, resourcePolicy)).To(Succeed())
+
+			Eventually(func() []vmopv1.ResourcePoolStatus {
+				if rp := getResourcePolicy(ctx, resourcePolicyKey); rp != nil {
+					return rp.Status.ResourcePools
+				}
+				return nil
+			}).ShouldNot(BeEmpty())
+
+			Consistently(calls.Load, "2s").Should(BeNumerically("<=", 2))
+		})
+	})
 }
@@ -0,0 +1,262 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	goctx "context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+)
+
+const (
+	finalizerName = "virtualmachinesnapshot.vmoperator.vmware.com"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineSnapshot{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProviderA2,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Watches(&vmopv1.VirtualMachine{},
+			handler.EnqueueRequestsFromMapFunc(vmToSnapshotMapperFn(ctx, r.Client))).
+		Complete(r)
+}
+
+// vmToSnapshotMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachineSnapshots in response to an event on the VirtualMachine resource.
+func vmToSnapshotMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given VirtualMachine, return reconcile requests
+	// for those VirtualMachineSnapshots that reference the VM.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		vm := o.(*vmopv1.VirtualMachine)
+		logger := ctx.Logger.WithValues("name", vm.Name, "namespace", vm.Namespace)
+
+		snapshotList := &vmopv1.VirtualMachineSnapshotList{}
+		if err := c.List(ctx, snapshotList, client.InNamespace(vm.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachineSnapshots for reconciliation due to VirtualMachine watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, snapshot := range snapshotList.Items {
+			// Only enqueue the snapshots that still have work to do.
+			if snapshot.Spec.VMName == vm.Name && !snapshot.Status.Ready {
+				key := client.ObjectKey{Namespace: snapshot.Namespace, Name: snapshot.Name}
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+			}
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachineSnapshot reconcile requests due to VirtualMachine watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
+func NewReconciler(
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) *Reconciler {
+
+	return &Reconciler{
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineSnapshot object.
+type Reconciler struct {
+	client.Client
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider vmprovider.VirtualMachineProviderInterfaceA2
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx goctx.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, vmSnapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmSnapshotCtx := &context.VirtualMachineSnapshotContextA2{
+		Context:    ctx,
+		Logger:     ctrl.Log.WithName("VirtualMachineSnapshot").WithValues("name", req.NamespacedName),
+		VMSnapshot: vmSnapshot,
+	}
+
+	patchHelper, err := patch.NewHelper(vmSnapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for %s", vmSnapshotCtx.String())
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmSnapshot); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmSnapshotCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmSnapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.ReconcileDelete(vmSnapshotCtx)
+	}
+
+	return ctrl.Result{}, r.ReconcileNormal(vmSnapshotCtx)
+}
+
+// getVM gets the VM referenced by the snapshot. A nil VM is returned if
+// the VM does not exist.
+func (r *Reconciler) getVM(ctx *context.VirtualMachineSnapshotContextA2) (*vmopv1.VirtualMachine, error) {
+	vm := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: ctx.VMSnapshot.Namespace, Name: ctx.VMSnapshot.Spec.VMName}
+	if err := r.Get(ctx, key, vm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return vm, nil
+}
+
+func (r *Reconciler) ReconcileDelete(ctx *context.VirtualMachineSnapshotContextA2) (reterr error) {
+	if !controllerutil.ContainsFinalizer(ctx.VMSnapshot, finalizerName) {
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot Deletion")
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The snapshot is deleted along with the VM so there is nothing to do when
+	// the VM is already gone or being deleted.
+	if vm != nil && vm.DeletionTimestamp.IsZero() && ctx.VMSnapshot.Status.UniqueID != "" {
+		ctx.VM = vm
+
+		defer func() {
+			r.Recorder.EmitEvent(ctx.VMSnapshot, "Delete", reterr, false)
+		}()
+
+		if err := r.VMProvider.DeleteSnapshot(ctx, ctx.VM, ctx.VMSnapshot); err != nil {
+			ctx.Logger.Error(err, "Failed to delete VirtualMachineSnapshot")
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(ctx.VMSnapshot, finalizerName)
+	ctx.Logger.Info("Finished Reconciling VirtualMachineSnapshot Deletion")
+	return nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *context.VirtualMachineSnapshotContextA2) (reterr error) {
+	if !controllerutil.ContainsFinalizer(ctx.VMSnapshot, finalizerName) {
+		// The finalizer must be present before proceeding in order to ensure that the snapshot will
+		// be cleaned up. Return immediately after here to let the patcher helper update the
+		// object, and then we'll proceed on the next reconciliation.
+		controllerutil.AddFinalizer(ctx.VMSnapshot, finalizerName)
+		return nil
+	}
+
+	if ctx.VMSnapshot.Status.Ready {
+		// The snapshot is a point-in-time operation: once it has been taken there is nothing
+		// left to reconcile.
+		return nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineSnapshot")
+	defer func() {
+		ctx.Logger.Info("Finished Reconciling VirtualMachineSnapshot")
+	}()
+
+	vm, err := r.getVM(ctx)
+	if err != nil {
+		return err
+	}
+	if vm == nil {
+		conditions.MarkFalse(ctx.VMSnapshot,
+			vmopv1.VirtualMachineSnapshotConditionCreated,
+			vmopv1.VirtualMachineSnapshotVMNotFoundReason,
+			"VirtualMachine %s not found", ctx.VMSnapshot.Spec.VMName)
+		// The VM watch will trigger another reconcile once the VM exists.
+		return nil
+	}
+	ctx.VM = vm
+
+	if vm.Status.UniqueID == "" {
+		conditions.MarkFalse(ctx.VMSnapshot,
+			vmopv1.VirtualMachineSnapshotConditionCreated,
+			vmopv1.VirtualMachineSnapshotVMNotCreatedReason,
+			"VirtualMachine %s has not been created", vm.Name)
+		return nil
+	}
+
+	// Make the VM the owner of the snapshot so the snapshot resource is garbage
+	// collected when the VM is deleted.
+	if err := controllerutil.SetOwnerReference(ctx.VM, ctx.VMSnapshot, r.Scheme()); err != nil {
+		return err
+	}
+
+	defer func() {
+		r.Recorder.EmitEvent(ctx.VMSnapshot, "Create", reterr, false)
+	}()
+
+	if err := r.VMProvider.CreateSnapshot(ctx, ctx.VM, ctx.VMSnapshot); err != nil {
+		ctx.Logger.Error(err, "Failed to create VirtualMachineSnapshot")
+		conditions.MarkFalse(ctx.VMSnapshot,
+			vmopv1.VirtualMachineSnapshotConditionCreated,
+			vmopv1.VirtualMachineSnapshotCreateFailedReason,
+			"%v", err)
+		return err
+	}
+
+	conditions.MarkTrue(ctx.VMSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)
+	ctx.VMSnapshot.Status.Ready = true
+
+	return nil
+}
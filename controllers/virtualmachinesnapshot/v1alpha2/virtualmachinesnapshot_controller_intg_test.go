@@ -0,0 +1,95 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe("Invoking VirtualMachineSnapshot controller tests", intgTestsReconcile)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx        *builder.IntegrationTestContext
+		vm         *vmopv1.VirtualMachine
+		vmSnapshot *vmopv1.VirtualMachineSnapshot
+	)
+
+	getVMSnapshot := func(ctx *builder.IntegrationTestContext, objKey client.ObjectKey) *vmopv1.VirtualMachineSnapshot {
+		vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+		if err := ctx.Client.Get(ctx, objKey, vmSnapshot); err != nil {
+			return nil
+		}
+		return vmSnapshot
+	}
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", ctx.Namespace)
+		vmSnapshot = builder.DummyVirtualMachineSnapshotA2(ctx.Namespace, "dummy-snapshot", vm.Name)
+
+		fakeVMProvider.Lock()
+		defer fakeVMProvider.Unlock()
+		fakeVMProvider.CreateSnapshotFn = func(_ context.Context, _ *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+			vmSnapshot.Status.UniqueID = "snapshot-42"
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("Reconcile", func() {
+		BeforeEach(func() {
+			Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+			vm.Status.UniqueID = "vm-42"
+			Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			err := ctx.Client.Delete(ctx, vmSnapshot)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+			err = ctx.Client.Delete(ctx, vm)
+			Expect(err == nil || k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("creates the snapshot", func() {
+			Eventually(func() bool {
+				vmSnapshot = getVMSnapshot(ctx, client.ObjectKeyFromObject(vmSnapshot))
+				return vmSnapshot != nil && vmSnapshot.Status.Ready
+			}).Should(BeTrue(), "waiting for VirtualMachineSnapshot to be ready")
+			Expect(vmSnapshot.Status.UniqueID).To(Equal("snapshot-42"))
+			Expect(vmSnapshot.Finalizers).To(ContainElement(finalizerName))
+		})
+
+		It("removes the finalizer on delete", func() {
+			Eventually(func() bool {
+				vmSnapshot = getVMSnapshot(ctx, client.ObjectKeyFromObject(vmSnapshot))
+				return vmSnapshot != nil && vmSnapshot.Status.Ready
+			}).Should(BeTrue())
+
+			Expect(ctx.Client.Delete(ctx, vmSnapshot)).To(Succeed())
+			Eventually(func() bool {
+				return getVMSnapshot(ctx, client.ObjectKeyFromObject(vmSnapshot)) == nil
+			}).Should(BeTrue(), "waiting for VirtualMachineSnapshot to be deleted")
+		})
+	})
+}
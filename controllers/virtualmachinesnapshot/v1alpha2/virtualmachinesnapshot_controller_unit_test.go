@@ -0,0 +1,208 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinesnapshot/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
+	vmopContext "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const finalizerName = "virtualmachinesnapshot.vmoperator.vmware.com"
+
+func unitTests() {
+	Describe("Invoking VirtualMachineSnapshot Reconcile", unitTestsReconcile)
+}
+
+func unitTestsReconcile() {
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler    *v1alpha2.Reconciler
+		vmSnapshotCtx *vmopContext.VirtualMachineSnapshotContextA2
+		vmSnapshot    *vmopv1.VirtualMachineSnapshot
+		vm            *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = builder.DummyBasicVirtualMachineA2("dummy-vm", "dummy-ns")
+		vm.Status.UniqueID = "vm-42"
+
+		vmSnapshot = builder.DummyVirtualMachineSnapshotA2(vm.Namespace, "dummy-snapshot", vm.Name)
+		vmSnapshot.Finalizers = []string{finalizerName}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = v1alpha2.NewReconciler(
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProviderA2,
+		)
+		fakeVMProvider = ctx.VMProviderA2.(*providerfake.VMProviderA2)
+
+		vmSnapshotCtx = &vmopContext.VirtualMachineSnapshotContextA2{
+			Context:    ctx,
+			Logger:     ctx.Logger.WithName(vmSnapshot.Name),
+			VMSnapshot: vmSnapshot,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+		fakeVMProvider.Reset()
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the finalizer is missing", func() {
+			BeforeEach(func() {
+				vmSnapshot.Finalizers = nil
+				initObjects = append(initObjects, vmSnapshot, vm)
+			})
+
+			It("adds the finalizer", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(controllerutil.ContainsFinalizer(vmSnapshot, finalizerName)).To(BeTrue())
+				Expect(vmSnapshot.Status.Ready).To(BeFalse())
+			})
+		})
+
+		When("the VM does not exist", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vmSnapshot)
+			})
+
+			It("marks the snapshot as not created", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.Status.Ready).To(BeFalse())
+				Expect(conditions.IsFalse(vmSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)).To(BeTrue())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)).
+					To(Equal(vmopv1.VirtualMachineSnapshotVMNotFoundReason))
+			})
+		})
+
+		When("the VM has not been created on vSphere", func() {
+			BeforeEach(func() {
+				vm.Status.UniqueID = ""
+				initObjects = append(initObjects, vmSnapshot, vm)
+			})
+
+			It("marks the snapshot as not created", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.Status.Ready).To(BeFalse())
+				Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)).
+					To(Equal(vmopv1.VirtualMachineSnapshotVMNotCreatedReason))
+			})
+		})
+
+		When("the VM exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vmSnapshot, vm)
+			})
+
+			It("creates the snapshot", func() {
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+				Expect(vmSnapshot.Status.Ready).To(BeTrue())
+				Expect(vmSnapshot.Status.UniqueID).ToNot(BeEmpty())
+				Expect(conditions.IsTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)).To(BeTrue())
+				Expect(vmSnapshot.OwnerReferences).To(HaveLen(1))
+				Expect(vmSnapshot.OwnerReferences[0].Name).To(Equal(vm.Name))
+			})
+
+			It("does not create the snapshot again once ready", func() {
+				vmSnapshot.Status.Ready = true
+				fakeVMProvider.CreateSnapshotFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+					return errors.New("should not be called")
+				}
+				Expect(reconciler.ReconcileNormal(vmSnapshotCtx)).To(Succeed())
+			})
+
+			When("the provider returns an error", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.CreateSnapshotFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+						return errors.New("create error")
+					}
+				})
+
+				It("returns the error", func() {
+					err := reconciler.ReconcileNormal(vmSnapshotCtx)
+					Expect(err).To(MatchError("create error"))
+					Expect(vmSnapshot.Status.Ready).To(BeFalse())
+					Expect(conditions.GetReason(vmSnapshot, vmopv1.VirtualMachineSnapshotConditionCreated)).
+						To(Equal(vmopv1.VirtualMachineSnapshotCreateFailedReason))
+				})
+			})
+		})
+	})
+
+	Context("ReconcileDelete", func() {
+		var deleteCalled bool
+
+		BeforeEach(func() {
+			deleteCalled = false
+			vmSnapshot.Status.UniqueID = "snapshot-1"
+			vmSnapshot.DeletionTimestamp = &metav1.Time{}
+		})
+
+		JustBeforeEach(func() {
+			fakeVMProvider.DeleteSnapshotFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+				deleteCalled = true
+				return nil
+			}
+		})
+
+		When("the VM exists", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, vm)
+			})
+
+			It("deletes the snapshot and removes the finalizer", func() {
+				Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+				Expect(deleteCalled).To(BeTrue())
+				Expect(vmSnapshot.Finalizers).ToNot(ContainElement(finalizerName))
+			})
+
+			When("the provider returns an error", func() {
+				JustBeforeEach(func() {
+					fakeVMProvider.DeleteSnapshotFn = func(_ context.Context, _ *vmopv1.VirtualMachine, _ *vmopv1.VirtualMachineSnapshot) error {
+						return errors.New("delete error")
+					}
+				})
+
+				It("keeps the finalizer", func() {
+					Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(MatchError("delete error"))
+					Expect(vmSnapshot.Finalizers).To(ContainElement(finalizerName))
+				})
+			})
+		})
+
+		When("the VM does not exist", func() {
+			It("removes the finalizer", func() {
+				Expect(reconciler.ReconcileDelete(vmSnapshotCtx)).To(Succeed())
+				Expect(deleteCalled).To(BeFalse())
+				Expect(vmSnapshot.Finalizers).ToNot(ContainElement(finalizerName))
+			})
+		})
+	})
+}
@@ -39,7 +39,7 @@ import (
 )
 
 const (
-	AttributeFirstClassDiskUUID = "diskUUID"
+	AttributeFirstClassDiskUUID = constants.CNSAttachmentDiskUUIDKey
 )
 
 // AddToManager adds this package's controller to the provided manager.
@@ -93,9 +93,51 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr manager.Manager) er
 		return err
 	}
 
+	// Watch for changes for PersistentVolumeClaim, and enqueue the VirtualMachines that reference the
+	// PersistentVolumeClaim in their volumes so that resize requests are noticed.
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}),
+		handler.EnqueueRequestsFromMapFunc(pvcToVMMapperFn(ctx, r.Client)))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// pvcToVMMapperFn returns a mapper function that can be used to queue reconcile requests
+// for the VirtualMachines in response to an event on the PersistentVolumeClaim resource.
+func pvcToVMMapperFn(ctx *context.ControllerManagerContext, c client.Client) func(_ goctx.Context, o client.Object) []reconcile.Request {
+	// For a given PersistentVolumeClaim, return reconcile requests
+	// for those VirtualMachines that reference the claim in their volumes.
+	return func(_ goctx.Context, o client.Object) []reconcile.Request {
+		pvc := o.(*corev1.PersistentVolumeClaim)
+		logger := ctx.Logger.WithValues("name", pvc.Name, "namespace", pvc.Namespace)
+
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := c.List(ctx, vmList, client.InNamespace(pvc.Namespace)); err != nil {
+			logger.Error(err, "Failed to list VirtualMachines for reconciliation due to PersistentVolumeClaim watch")
+			return nil
+		}
+
+		var reconcileRequests []reconcile.Request
+		for _, vm := range vmList.Items {
+			for _, volume := range vm.Spec.Volumes {
+				if claim := volume.PersistentVolumeClaim; claim != nil && claim.ClaimName == pvc.Name {
+					key := client.ObjectKey{Namespace: vm.Namespace, Name: vm.Name}
+					reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: key})
+					break
+				}
+			}
+		}
+
+		if len(reconcileRequests) > 0 {
+			logger.V(4).Info("Returning VirtualMachine reconcile requests due to PersistentVolumeClaim watch",
+				"requests", reconcileRequests)
+		}
+		return reconcileRequests
+	}
+}
+
 func NewReconciler(
 	client client.Client,
 	logger logr.Logger,
@@ -519,9 +561,14 @@ func (r *Reconciler) processAttachments(
 	orphanedAttachments []cnsv1alpha1.CnsNodeVmAttachment) error {
 
 	var volumeStatus []vmopv1.VirtualMachineVolumeStatus
-	var createErrs []error
+	var createErrs, resizeErrs []error
 	var hasPendingAttachment bool
 
+	prevVolumeStatus := make(map[string]vmopv1.VirtualMachineVolumeStatus, len(ctx.VM.Status.Volumes))
+	for _, status := range ctx.VM.Status.Volumes {
+		prevVolumeStatus[status.Name] = status
+	}
+
 	// When creating a VM, try to attach the volumes in the VM Spec.Volumes order since that is a reasonable
 	// expectation and the customization like cloud-init may assume that order. There isn't quite a good way
 	// to determine from here if the VM is being created so use the power state to infer it. This is mostly
@@ -543,7 +590,11 @@ func (r *Reconciler) processAttachments(
 			// but the old code didn't and let's match that behavior until we need to do otherwise.
 			// Also, the CNS attachment controller doesn't reconcile Spec changes once the volume
 			// is attached.
-			volumeStatus = append(volumeStatus, attachmentToVolumeStatus(volume.Name, attachment))
+			status := attachmentToVolumeStatus(volume.Name, attachment)
+			if err := r.processVolumeResize(ctx, volume, &status, prevVolumeStatus[volume.Name]); err != nil {
+				resizeErrs = append(resizeErrs, err)
+			}
+			volumeStatus = append(volumeStatus, status)
 			hasPendingAttachment = hasPendingAttachment || !attachment.Status.Attached
 			continue
 		}
@@ -603,7 +654,85 @@ func (r *Reconciler) processAttachments(
 	})
 	ctx.VM.Status.Volumes = volumeStatus
 
-	return k8serrors.NewAggregate(createErrs)
+	return k8serrors.NewAggregate(append(createErrs, resizeErrs...))
+}
+
+// processVolumeResize expands the attached disk of the volume once the CSI driver has finished
+// expanding the volume's backing storage through CNS, and updates the volume's status with the
+// disk's capacity and the progress of the resize. The disk is never expanded beyond the capacity
+// in the PersistentVolumeClaim's status so the VM's reconfigure cannot race the CSI expansion.
+func (r *Reconciler) processVolumeResize(
+	ctx *context.VolumeContextA2,
+	volume vmopv1.VirtualMachineVolume,
+	volumeStatus *vmopv1.VirtualMachineVolumeStatus,
+	prevVolumeStatus vmopv1.VirtualMachineVolumeStatus) error {
+
+	if !volumeStatus.Attached || volumeStatus.DiskUUID == "" {
+		return nil
+	}
+
+	if volume.PersistentVolumeClaim.InstanceVolumeClaim != nil {
+		// Instance storage volumes cannot be resized.
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvcKey := client.ObjectKey{Namespace: ctx.VM.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}
+	if err := r.Get(ctx, pvcKey, pvc); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get PersistentVolumeClaim %s", pvcKey.Name)
+	}
+
+	// The capacity in the PVC's status is the size of the volume's backing storage. It is only
+	// updated by the CSI driver once it has finished expanding the volume.
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+
+	// The capacity from the prior status is the size the attached disk was last expanded to.
+	if prevVolumeStatus.Capacity == nil || prevVolumeStatus.DiskUUID != volumeStatus.DiskUUID {
+		volumeStatus.Capacity = &capacity
+		return nil
+	}
+	volumeStatus.Capacity = prevVolumeStatus.Capacity
+
+	// Wait while the CSI driver is still expanding the volume. The PVC watch triggers another
+	// reconcile when the PVC's status is updated.
+	requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if isPVCResizing(pvc) || (ok && requested.Cmp(capacity) > 0) {
+		volumeStatus.ResizeState = vmopv1.VirtualMachineVolumeResizeStateInProgress
+		return nil
+	}
+
+	if capacity.Cmp(*volumeStatus.Capacity) <= 0 {
+		return nil
+	}
+
+	ctx.Logger.Info("Expanding volume", "volume", volume.Name,
+		"capacity", volumeStatus.Capacity.String(), "newCapacity", capacity.String())
+
+	err := r.VMProvider.ExpandVirtualMachineDisk(ctx, ctx.VM, volumeStatus.DiskUUID, capacity)
+	r.recorder.EmitEvent(ctx.VM, "VolumeResize", err, false)
+	if err != nil {
+		volumeStatus.ResizeState = vmopv1.VirtualMachineVolumeResizeStateFailed
+		volumeStatus.Error = err.Error()
+		return errors.Wrapf(err, "failed to expand volume %s", volume.Name)
+	}
+
+	volumeStatus.Capacity = &capacity
+	return nil
+}
+
+func isPVCResizing(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, c := range pvc.Status.Conditions {
+		if c.Type == corev1.PersistentVolumeClaimResizing && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *Reconciler) createCNSAttachment(
@@ -570,6 +570,143 @@ func unitTestsReconcile() {
 			})
 		})
 
+		When("VM Spec.Volumes has CNS volume whose PVC is resized", func() {
+			var pvc *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				vmVol = *vmVolumeWithPVC1
+				vm.Spec.Volumes = append(vm.Spec.Volumes, vmVol)
+
+				attachment := cnsAttachmentForVMVolume(vm, vmVol)
+				attachment.Status.Attached = true
+				attachment.Status.AttachmentMetadata = map[string]string{
+					volume.AttributeFirstClassDiskUUID: dummyDiskUUID,
+				}
+
+				capacity := resource.MustParse("10Gi")
+				vm.Status.Volumes = []vmopv1.VirtualMachineVolumeStatus{
+					{
+						Name:     vmVol.Name,
+						Attached: true,
+						DiskUUID: dummyDiskUUID,
+						Capacity: &capacity,
+					},
+				}
+
+				pvc = &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      vmVol.PersistentVolumeClaim.ClaimName,
+						Namespace: vm.Namespace,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("20Gi"),
+							},
+						},
+					},
+					Status: corev1.PersistentVolumeClaimStatus{
+						Phase: corev1.ClaimBound,
+						Capacity: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("10Gi"),
+						},
+					},
+				}
+
+				initObjects = append(initObjects, attachment, pvc)
+			})
+
+			It("waits for the CSI driver to expand the volume", func() {
+				fakeVMProvider.ExpandVirtualMachineDiskFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine, _ string, _ resource.Quantity) error {
+					return errors.New("unexpected expand")
+				}
+
+				err := reconciler.ReconcileNormal(volCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(vm.Status.Volumes).To(HaveLen(1))
+				Expect(vm.Status.Volumes[0].Capacity.String()).To(Equal("10Gi"))
+				Expect(vm.Status.Volumes[0].ResizeState).To(Equal(vmopv1.VirtualMachineVolumeResizeStateInProgress))
+			})
+
+			When("the CSI driver is still resizing the volume", func() {
+				BeforeEach(func() {
+					pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+					pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+						{
+							Type:   corev1.PersistentVolumeClaimResizing,
+							Status: corev1.ConditionTrue,
+						},
+					}
+				})
+
+				It("waits to expand the attached disk", func() {
+					fakeVMProvider.ExpandVirtualMachineDiskFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine, _ string, _ resource.Quantity) error {
+						return errors.New("unexpected expand")
+					}
+
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(vm.Status.Volumes).To(HaveLen(1))
+					Expect(vm.Status.Volumes[0].Capacity.String()).To(Equal("10Gi"))
+					Expect(vm.Status.Volumes[0].ResizeState).To(Equal(vmopv1.VirtualMachineVolumeResizeStateInProgress))
+				})
+			})
+
+			When("the CSI driver has expanded the volume", func() {
+				BeforeEach(func() {
+					pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+				})
+
+				It("expands the attached disk", func() {
+					var expandedDiskUUID string
+					var expandedCapacity resource.Quantity
+					fakeVMProvider.ExpandVirtualMachineDiskFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine, diskUUID string, capacity resource.Quantity) error {
+						expandedDiskUUID = diskUUID
+						expandedCapacity = capacity
+						return nil
+					}
+
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(expandedDiskUUID).To(Equal(dummyDiskUUID))
+					Expect(expandedCapacity.String()).To(Equal("20Gi"))
+
+					Expect(vm.Status.Volumes).To(HaveLen(1))
+					Expect(vm.Status.Volumes[0].Capacity).ToNot(BeNil())
+					Expect(vm.Status.Volumes[0].Capacity.String()).To(Equal("20Gi"))
+					Expect(vm.Status.Volumes[0].ResizeState).To(BeEmpty())
+
+					By("does not expand the disk again", func() {
+						fakeVMProvider.ExpandVirtualMachineDiskFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine, _ string, _ resource.Quantity) error {
+							return errors.New("unexpected expand")
+						}
+
+						err := reconciler.ReconcileNormal(volCtx)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Status.Volumes[0].Capacity.String()).To(Equal("20Gi"))
+					})
+				})
+
+				It("returns error when expanding the disk fails", func() {
+					fakeVMProvider.ExpandVirtualMachineDiskFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine, _ string, _ resource.Quantity) error {
+						return errors.New("expand failed")
+					}
+
+					err := reconciler.ReconcileNormal(volCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("expand failed"))
+
+					Expect(vm.Status.Volumes).To(HaveLen(1))
+					Expect(vm.Status.Volumes[0].Capacity.String()).To(Equal("10Gi"))
+					Expect(vm.Status.Volumes[0].ResizeState).To(Equal(vmopv1.VirtualMachineVolumeResizeStateFailed))
+					Expect(vm.Status.Volumes[0].Error).To(Equal("expand failed"))
+				})
+			})
+		})
+
 		When("VM Spec.Volumes has CNS volume with an existing CnsNodeVmAttachment for a different VM", func() {
 
 			When("CnsNodeVmAttachment has OwnerRef of different VM", func() {
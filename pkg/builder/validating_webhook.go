@@ -152,6 +152,7 @@ func (h *validatingWebhookHandler) Handle(_ goctx.Context, req admission.Request
 		Op:                  req.Operation,
 		Obj:                 obj,
 		OldObj:              oldObj,
+		DryRun:              req.DryRun != nil && *req.DryRun,
 		UserInfo:            req.UserInfo,
 		IsPrivilegedAccount: IsPrivilegedAccount(h.WebhookContext, req.UserInfo),
 		Logger:              h.WebhookContext.Logger.WithName(obj.GetNamespace()).WithName(obj.GetName()),
@@ -0,0 +1,25 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// VirtualMachineGuestCommandContextA2 is the context used for VirtualMachineGuestCommandControllers.
+type VirtualMachineGuestCommandContextA2 struct {
+	context.Context
+	Logger       logr.Logger
+	GuestCommand *vmopv1.VirtualMachineGuestCommand
+	VM           *vmopv1.VirtualMachine
+}
+
+func (v *VirtualMachineGuestCommandContextA2) String() string {
+	return fmt.Sprintf("%s %s/%s", v.GuestCommand.GroupVersionKind(), v.GuestCommand.Namespace, v.GuestCommand.Name)
+}
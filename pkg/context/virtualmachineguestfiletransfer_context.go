@@ -0,0 +1,25 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// VirtualMachineGuestFileTransferContextA2 is the context used for VirtualMachineGuestFileTransferControllers.
+type VirtualMachineGuestFileTransferContextA2 struct {
+	context.Context
+	Logger       logr.Logger
+	FileTransfer *vmopv1.VirtualMachineGuestFileTransfer
+	VM           *vmopv1.VirtualMachine
+}
+
+func (v *VirtualMachineGuestFileTransferContextA2) String() string {
+	return fmt.Sprintf("%s %s/%s", v.FileTransfer.GroupVersionKind(), v.FileTransfer.Namespace, v.FileTransfer.Name)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// VirtualMachinePlacementRequestContextA2 is the context used for VirtualMachinePlacementRequestControllers.
+type VirtualMachinePlacementRequestContextA2 struct {
+	context.Context
+	Logger           logr.Logger
+	PlacementRequest *vmopv1.VirtualMachinePlacementRequest
+}
+
+func (v *VirtualMachinePlacementRequestContextA2) String() string {
+	return fmt.Sprintf("%s %s/%s", v.PlacementRequest.GroupVersionKind(), v.PlacementRequest.Namespace, v.PlacementRequest.Name)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// VirtualMachineResourceQuotaContextA2 is the context used for VirtualMachineResourceQuotaControllers.
+type VirtualMachineResourceQuotaContextA2 struct {
+	context.Context
+	Logger        logr.Logger
+	ResourceQuota *vmopv1.VirtualMachineResourceQuota
+}
+
+func (v *VirtualMachineResourceQuotaContextA2) String() string {
+	return fmt.Sprintf("%s %s/%s", v.ResourceQuota.GroupVersionKind(), v.ResourceQuota.Namespace, v.ResourceQuota.Name)
+}
@@ -30,6 +30,10 @@ type WebhookRequestContext struct {
 	// Operation is the operation.
 	Op admissionv1.Operation
 
+	// DryRun is true if the request is a dry run, in which case the webhook
+	// must not have any side effects.
+	DryRun bool
+
 	// IsPrivilegedAccount is if this request is from a privileged account (currently
 	// that's either kube-admin or the pod's system account).
 	IsPrivilegedAccount bool
@@ -19,6 +19,7 @@ type ProbeContext struct {
 	Logger        logr.Logger
 	PatchHelper   *patch.Helper
 	VM            *vmopv1.VirtualMachine
+	ProbeSpec     *vmopv1.VirtualMachineReadinessProbeSpec
 	ProbeType     string
 	PeriodSeconds int32
 }
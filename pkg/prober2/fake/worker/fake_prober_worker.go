@@ -55,6 +55,7 @@ func (w *FakeWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*context.Pro
 		Context:       goctx.Background(),
 		Logger:        ctrl.Log.WithName("fake-probe").WithValues("vmName", vm.NamespacedName()),
 		VM:            vm,
+		ProbeSpec:     vm.Spec.ReadinessProbe,
 		ProbeType:     "fake-probe",
 		PeriodSeconds: vm.Spec.ReadinessProbe.PeriodSeconds,
 	}, nil
@@ -0,0 +1,53 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	goctx "context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+)
+
+// execProber implements the Probe interface.
+type execProber struct {
+	prober vmProviderGuestExecProber
+}
+
+// NewExecProber creates a new exec prober which implements the Probe interface to run commands in the guest.
+func NewExecProber(prober vmProviderGuestExecProber) Probe {
+	return &execProber{
+		prober: prober,
+	}
+}
+
+func (ep execProber) Probe(ctx *context.ProbeContext) (Result, error) {
+	p := ctx.ProbeSpec
+
+	var timeout time.Duration
+	if p.TimeoutSeconds <= 0 {
+		timeout = defaultConnectTimeout
+	} else {
+		timeout = time.Duration(p.TimeoutSeconds) * time.Second
+	}
+
+	execCtx, cancel := goctx.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	exitCode, err := ep.prober.RunGuestProbeCommand(execCtx, ctx.VM, p.Exec)
+	if err != nil {
+		if errors.Is(err, goctx.DeadlineExceeded) {
+			return Failure, fmt.Errorf("exec probe timed out after %s: %w", timeout, err)
+		}
+		return Unknown, err
+	}
+
+	if exitCode != 0 {
+		return Failure, fmt.Errorf("exec probe failed with exit code %d", exitCode)
+	}
+
+	return Success, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	goctx "context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+)
+
+type fakeGuestExecProvider struct {
+	exitCode int32
+	err      error
+	hang     bool
+	action   *vmopv1.GuestExecAction
+}
+
+func (f *fakeGuestExecProvider) RunGuestProbeCommand(ctx goctx.Context, _ *vmopv1.VirtualMachine, action *vmopv1.GuestExecAction) (int32, error) {
+	f.action = action
+	if f.hang {
+		<-ctx.Done()
+		return 0, fmt.Errorf("guest command did not exit: %w", ctx.Err())
+	}
+	return f.exitCode, f.err
+}
+
+var _ = Describe("Exec probe", func() {
+	var (
+		vm           *vmopv1.VirtualMachine
+		fakeProvider *fakeGuestExecProvider
+		testProbe    Probe
+
+		err error
+		res Result
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+				ReadinessProbe: &vmopv1.VirtualMachineReadinessProbeSpec{
+					Exec: &vmopv1.GuestExecAction{
+						Command:               "/usr/bin/systemctl",
+						Args:                  []string{"is-active", "nginx"},
+						CredentialsSecretName: "guest-credentials",
+					},
+					TimeoutSeconds: 1,
+				},
+			},
+		}
+
+		fakeProvider = &fakeGuestExecProvider{}
+		testProbe = NewExecProber(fakeProvider)
+	})
+
+	JustBeforeEach(func() {
+		probeCtx := &context.ProbeContext{
+			Context:   goctx.Background(),
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
+		}
+
+		res, err = testProbe.Probe(probeCtx)
+	})
+
+	It("succeeds when the command exits with zero", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+		Expect(fakeProvider.action).To(Equal(vm.Spec.ReadinessProbe.Exec))
+	})
+
+	Context("command exits with non-zero", func() {
+		BeforeEach(func() { fakeProvider.exitCode = 3 })
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("exit code 3")))
+			Expect(res).To(Equal(Failure))
+		})
+	})
+
+	Context("command does not exit before the timeout", func() {
+		BeforeEach(func() { fakeProvider.hang = true })
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("timed out")))
+			Expect(res).To(Equal(Failure))
+		})
+	})
+
+	Context("provider returns an error", func() {
+		BeforeEach(func() { fakeProvider.err = fmt.Errorf("fake error") })
+
+		It("returns unknown", func() {
+			Expect(err).To(MatchError(ContainSubstring("fake error")))
+			Expect(res).To(Equal(Unknown))
+		})
+	})
+})
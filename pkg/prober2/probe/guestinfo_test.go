@@ -54,8 +54,9 @@ var _ = Describe("Guest info probe", func() {
 		}
 
 		probeCtx := &context.ProbeContext{
-			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
-			VM:     vm,
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
 		}
 
 		res, err = prober.Probe(probeCtx)
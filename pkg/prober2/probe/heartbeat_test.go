@@ -55,8 +55,9 @@ var _ = Describe("Guest heartbeat probe", func() {
 
 	JustBeforeEach(func() {
 		probeCtx := &context.ProbeContext{
-			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
-			VM:     vm,
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
 		}
 
 		res, err = testVMwareToolsProbe.Probe(probeCtx)
@@ -0,0 +1,129 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+)
+
+const (
+	// defaultMinStatusCode and defaultMaxStatusCode bound the status codes for which an HTTP probe
+	// succeeds when the probe does not specify them. We use the same range as the kubernetes container probe.
+	defaultMinStatusCode = http.StatusOK
+	defaultMaxStatusCode = http.StatusBadRequest - 1
+
+	// maxResponseBodyBytes is the maximum number of bytes of the response body that are read.
+	maxResponseBodyBytes = 10 * 1024
+)
+
+// httpProber implements the Probe interface.
+type httpProber struct{}
+
+// NewHTTPProber creates a new http prober which implements the Probe interface to execute HTTP GET probes.
+func NewHTTPProber() Probe {
+	return &httpProber{}
+}
+
+func (pr httpProber) Probe(ctx *context.ProbeContext) (Result, error) {
+	vm := ctx.VM
+	p := ctx.ProbeSpec
+	action := p.HTTPGet
+
+	portNum, err := findPort(vm, action.Port, corev1.ProtocolTCP)
+	if err != nil {
+		return Failure, err
+	}
+
+	host := action.Host
+	if host == "" {
+		ctx.Logger.V(4).Info("HTTPGet Host not specified, using VM IP", "probe", ctx.String())
+		if vm.Status.Network != nil {
+			host = vm.Status.Network.PrimaryIP4
+			if host == "" {
+				host = vm.Status.Network.PrimaryIP6
+			}
+		}
+		if host == "" {
+			return Failure, fmt.Errorf("VM %s doesn't have an IP assigned", vm.NamespacedName())
+		}
+	}
+
+	var timeout time.Duration
+	if p.TimeoutSeconds <= 0 {
+		timeout = defaultConnectTimeout
+	} else {
+		timeout = time.Duration(p.TimeoutSeconds) * time.Second
+	}
+
+	path := action.Path
+	if path == "" {
+		path = "/"
+	}
+	reqURL, err := url.Parse(path)
+	if err != nil {
+		return Failure, err
+	}
+	reqURL.Scheme = "http"
+	if action.Scheme == vmopv1.URISchemeHTTPS {
+		reqURL.Scheme = "https"
+	}
+	reqURL.Host = net.JoinHostPort(host, strconv.Itoa(portNum))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return Failure, err
+	}
+	for _, h := range action.HTTPHeaders {
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+		} else {
+			req.Header.Add(h.Name, h.Value)
+		}
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			//nolint:gosec // The user explicitly opts out of verifying the VM's certificate.
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: action.InsecureSkipTLSVerify},
+			DisableKeepAlives: true,
+		},
+		// Do not follow redirects so that the redirect status code is checked against the range.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Failure, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodyBytes))
+
+	minStatusCode, maxStatusCode := defaultMinStatusCode, defaultMaxStatusCode
+	if r := action.StatusCodes; r != nil {
+		minStatusCode, maxStatusCode = int(r.Min), int(r.Max)
+	}
+
+	if resp.StatusCode < minStatusCode || resp.StatusCode > maxStatusCode {
+		return Failure, fmt.Errorf("HTTP probe failed with status code %d, expected status code in range %d-%d",
+			resp.StatusCode, minStatusCode, maxStatusCode)
+	}
+
+	return Success, nil
+}
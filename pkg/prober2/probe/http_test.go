@@ -0,0 +1,192 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package probe
+
+import (
+	goctx "context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+)
+
+var _ = Describe("HTTP probe", func() {
+	var (
+		vm            *vmopv1.VirtualMachine
+		testHTTPProbe Probe
+
+		testServer *httptest.Server
+		testHost   string
+		testPort   int
+		action     *vmopv1.HTTPGetAction
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			if r.Header.Get("X-Probe") != "" && r.Header.Get("X-Probe") != "vm-operator" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/redirect":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Network: &vmopv1.VirtualMachineNetworkStatus{},
+			},
+		}
+
+		testServer = httptest.NewServer(handler)
+		testHTTPProbe = NewHTTPProber()
+	})
+
+	JustBeforeEach(func() {
+		host, port, err := net.SplitHostPort(testServer.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		testHost = host
+		testPort, err = strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		action = &vmopv1.HTTPGetAction{
+			Path: "/healthz",
+			Host: testHost,
+			Port: intstr.FromInt(testPort),
+		}
+		vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+			HTTPGet:       action,
+			PeriodSeconds: 1,
+		}
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	probe := func() (Result, error) {
+		probeCtx := &context.ProbeContext{
+			Context:   goctx.Background(),
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
+		}
+		return testHTTPProbe.Probe(probeCtx)
+	}
+
+	It("HTTP probe succeeds when the status code is in the default range", func() {
+		res, err := probe()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTP probe succeeds with empty host", func() {
+		vm.Status.Network.PrimaryIP4 = testHost
+		action.Host = ""
+
+		res, err := probe()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTP probe fails when the VM does not have an IP and the host is empty", func() {
+		action.Host = ""
+
+		res, err := probe()
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe sends the headers", func() {
+		action.HTTPHeaders = []vmopv1.HTTPHeader{{Name: "X-Probe", Value: "not-vm-operator"}}
+
+		res, err := probe()
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("status code 400"))
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe fails when the status code is not in the default range", func() {
+		action.Path = "/unhealthy"
+
+		res, err := probe()
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe succeeds when the status code is in the specified range", func() {
+		action.Path = "/unhealthy"
+		action.StatusCodes = &vmopv1.HTTPStatusCodeRange{Min: 200, Max: 503}
+
+		res, err := probe()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).To(Equal(Success))
+	})
+
+	It("HTTP probe does not follow redirects", func() {
+		action.Path = "/redirect"
+		action.StatusCodes = &vmopv1.HTTPStatusCodeRange{Min: 200, Max: 299}
+
+		res, err := probe()
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("status code 302"))
+		Expect(res).To(Equal(Failure))
+	})
+
+	It("HTTP probe fails when nothing is listening on the port", func() {
+		testServer.Close()
+
+		res, err := probe()
+		Expect(err).Should(HaveOccurred())
+		Expect(res).To(Equal(Failure))
+	})
+
+	Context("HTTPS", func() {
+		BeforeEach(func() {
+			testServer.Close()
+			testServer = httptest.NewTLSServer(handler)
+		})
+
+		JustBeforeEach(func() {
+			action.Scheme = vmopv1.URISchemeHTTPS
+		})
+
+		It("HTTP probe fails when the certificate cannot be verified", func() {
+			res, err := probe()
+			Expect(err).Should(HaveOccurred())
+			Expect(res).To(Equal(Failure))
+		})
+
+		It("HTTP probe succeeds when TLS verification is skipped", func() {
+			action.InsecureSkipTLSVerify = true
+
+			res, err := probe()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).To(Equal(Success))
+		})
+	})
+})
@@ -37,14 +37,20 @@ type vmProviderGuestHeartbeatProber interface {
 type vmProviderGuestInfoProber interface {
 	GetVirtualMachineGuestInfo(ctx goctx.Context, vm *vmopv1.VirtualMachine) (map[string]string, error)
 }
+type vmProviderGuestExecProber interface {
+	RunGuestProbeCommand(ctx goctx.Context, vm *vmopv1.VirtualMachine, action *vmopv1.GuestExecAction) (int32, error)
+}
 type vmProviderProber interface {
 	vmProviderGuestHeartbeatProber
 	vmProviderGuestInfoProber
+	vmProviderGuestExecProber
 }
 
 // Prober contains the different type of probes.
 type Prober struct {
 	TCPProbe       Probe
+	HTTPGetProbe   Probe
+	ExecProbe      Probe
 	GuestHeartbeat Probe
 	GuestInfo      Probe
 }
@@ -53,6 +59,8 @@ type Prober struct {
 func NewProber(vmProvider vmProviderProber) *Prober {
 	return &Prober{
 		TCPProbe:       NewTCPProber(),
+		HTTPGetProbe:   NewHTTPProber(),
+		ExecProbe:      NewExecProber(vmProvider),
 		GuestHeartbeat: NewGuestHeartbeatProber(vmProvider),
 		GuestInfo:      NewGuestInfoProber(vmProvider),
 	}
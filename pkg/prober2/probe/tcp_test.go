@@ -57,8 +57,9 @@ var _ = Describe("TCP probe", func() {
 	It("TCP probe succeeds, with TCP host set in VM spec ", func() {
 		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(testHost, testPort)
 		probeCtx := &context.ProbeContext{
-			VM:     vm,
-			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
 		}
 
 		res, err := testTCPProbe.Probe(probeCtx)
@@ -70,8 +71,9 @@ var _ = Describe("TCP probe", func() {
 		vm.Status.Network.PrimaryIP4 = testHost
 		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe("", testPort)
 		probeCtx := &context.ProbeContext{
-			VM:     vm,
-			Logger: ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
+			Logger:    ctrl.Log.WithName("Probe").WithValues("name", vm.NamespacedName()),
 		}
 
 		res, err := testTCPProbe.Probe(probeCtx)
@@ -82,7 +84,8 @@ var _ = Describe("TCP probe", func() {
 	It("TCP probe fails", func() {
 		vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(testHost, 10001)
 		probeCtx := &context.ProbeContext{
-			VM: vm,
+			VM:        vm,
+			ProbeSpec: vm.Spec.ReadinessProbe,
 		}
 
 		res, err := testTCPProbe.Probe(probeCtx)
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +20,7 @@ import (
 	"github.com/go-logr/logr"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
 	"github.com/vmware-tanzu/vm-operator/pkg/prober2/worker"
@@ -29,6 +31,8 @@ import (
 const (
 	proberManagerName       = "virtualmachine-prober-manager"
 	readinessProbeQueueName = "readinessProbeQueue"
+	livenessProbeQueueName  = "livenessProbeQueue"
+	startupProbeQueueName   = "startupProbeQueue"
 
 	// defaultPeriodSeconds represents the default value for the frequency (in seconds) to perform the probe.
 	// We use the same default value as the kubernetes container probe.
@@ -37,6 +41,12 @@ const (
 	// the number of readiness workers.
 	// TODO: find a way to calibrate it.
 	numberOfReadinessWorkers = 5
+
+	// the number of liveness workers.
+	numberOfLivenessWorkers = 5
+
+	// the number of startup workers.
+	numberOfStartupWorkers = 5
 )
 
 // Manager represents a prober manager interface.
@@ -50,7 +60,10 @@ type Manager interface {
 type manager struct {
 	client         client.Client
 	readinessQueue workqueue.DelayingInterface
+	livenessQueue  workqueue.DelayingInterface
+	startupQueue   workqueue.DelayingInterface
 	prober         *probe.Prober
+	vmProvider     vmprovider.VirtualMachineProviderInterfaceA2
 	log            logr.Logger
 	recorder       vmoprecord.Recorder
 
@@ -59,8 +72,32 @@ type manager struct {
 	// We will use AddAfter to add an item to the queue, which will insert the item to a heap first
 	// if the time duration set in the AddAfter is not zero. vmReadinessProbeList can be used to avoid
 	// adding VMs to the readiness queue when this VM is already in the heap but not in the queue.
+	// readinessResults tracks the consecutive results of each VM's readiness probe.
 	readinessMutex       sync.Mutex
 	vmReadinessProbeList map[string]vmopv1.VirtualMachineReadinessProbeSpec
+	readinessResults     *worker.ResultCounts
+
+	// vmLivenessProbeList serves the same purpose for the liveness queue as vmReadinessProbeList,
+	// and livenessResults tracks the consecutive results of each VM's liveness probe.
+	livenessMutex       sync.Mutex
+	vmLivenessProbeList map[string]vmopv1.VirtualMachineLivenessProbeSpec
+	livenessResults     *worker.ResultCounts
+
+	// vmStartupProbeList and startupResults serve the same purposes for the startup queue, and
+	// startedVMs tracks the VMs whose startup probe has succeeded.
+	startupMutex       sync.Mutex
+	vmStartupProbeList map[string]vmopv1.VirtualMachineReadinessProbeSpec
+	startupResults     *worker.ResultCounts
+	startedVMs         *worker.StartedVMs
+
+	// vmPoweredOnTimes tracks when each VM was first seen powered on, and is used to delay
+	// probes by their initial delay.
+	poweredOnMutex   sync.Mutex
+	vmPoweredOnTimes map[string]time.Time
+
+	// startTime is when the manager was created. A VM that was already powered on and ready
+	// then has started, since startedVMs does not survive a restart or failover.
+	startTime time.Time
 }
 
 // NewManger initializes a prober manager.
@@ -72,10 +109,21 @@ func NewManger(
 	probeManager := &manager{
 		client:               client,
 		readinessQueue:       workqueue.NewNamedDelayingQueue(readinessProbeQueueName),
+		livenessQueue:        workqueue.NewNamedDelayingQueue(livenessProbeQueueName),
+		startupQueue:         workqueue.NewNamedDelayingQueue(startupProbeQueueName),
 		prober:               probe.NewProber(vmProvider),
+		vmProvider:           vmProvider,
 		log:                  ctrl.Log.WithName(proberManagerName),
 		recorder:             record,
 		vmReadinessProbeList: make(map[string]vmopv1.VirtualMachineReadinessProbeSpec),
+		readinessResults:     worker.NewResultCounts(),
+		vmLivenessProbeList:  make(map[string]vmopv1.VirtualMachineLivenessProbeSpec),
+		livenessResults:      worker.NewResultCounts(),
+		vmStartupProbeList:   make(map[string]vmopv1.VirtualMachineReadinessProbeSpec),
+		startupResults:       worker.NewResultCounts(),
+		startedVMs:           worker.NewStartedVMs(),
+		vmPoweredOnTimes:     make(map[string]time.Time),
+		startTime:            time.Now(),
 	}
 	return probeManager
 }
@@ -98,11 +146,18 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Add to prober manager", "vm", vmName)
 
+	m.addToReadinessProbeList(vm)
+	m.addToLivenessProbeList(vm)
+	m.addToStartupProbeList(vm)
+}
+
+func (m *manager) addToReadinessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
 	m.readinessMutex.Lock()
 	defer m.readinessMutex.Unlock()
 
-	if vm.Spec.ReadinessProbe != nil &&
-		(vm.Spec.ReadinessProbe.TCPSocket != nil || vm.Spec.ReadinessProbe.GuestHeartbeat != nil || len(vm.Spec.ReadinessProbe.GuestInfo) != 0) {
+	if worker.HasProbeAction(vm.Spec.ReadinessProbe) {
 		// if the VM is not in the list, or its readiness probe spec has been updated, immediately add it to the queue
 		// otherwise, ignore it.
 		if oldProbe, ok := m.vmReadinessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, vm.Spec.ReadinessProbe) {
@@ -110,21 +165,102 @@ func (m *manager) AddToProberManager(vm *vmopv1.VirtualMachine) {
 			return
 		}
 
+		// Results counted against the previous probe spec do not count against the updated one.
+		m.readinessResults.Reset(vmName)
 		m.readinessQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
 		m.vmReadinessProbeList[vmName] = *vm.Spec.ReadinessProbe
 	} else {
 		delete(m.vmReadinessProbeList, vmName)
+		m.readinessResults.Reset(vmName)
 	}
 }
 
+func (m *manager) addToLivenessProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
+	m.livenessMutex.Lock()
+	defer m.livenessMutex.Unlock()
+
+	if vm.Spec.LivenessProbe != nil && worker.HasProbeAction(&vm.Spec.LivenessProbe.VirtualMachineReadinessProbeSpec) {
+		if oldProbe, ok := m.vmLivenessProbeList[vmName]; ok && reflect.DeepEqual(oldProbe, *vm.Spec.LivenessProbe) {
+			m.log.V(4).Info("VM is already in the liveness probe list and its probe spec is not updated, skip it", "vm", vmName)
+			return
+		}
+
+		// Failures counted against the previous probe spec do not count against the updated one.
+		m.livenessResults.Reset(vmName)
+		m.livenessQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
+		m.vmLivenessProbeList[vmName] = *vm.Spec.LivenessProbe
+	} else {
+		delete(m.vmLivenessProbeList, vmName)
+		m.livenessResults.Reset(vmName)
+	}
+}
+
+func (m *manager) addToStartupProbeList(vm *vmopv1.VirtualMachine) {
+	vmName := vm.NamespacedName()
+
+	m.startupMutex.Lock()
+	defer m.startupMutex.Unlock()
+
+	if worker.HasProbeAction(vm.Spec.StartupProbe) {
+		oldProbe, ok := m.vmStartupProbeList[vmName]
+		if ok && reflect.DeepEqual(oldProbe, *vm.Spec.StartupProbe) {
+			m.log.V(4).Info("VM is already in the startup probe list and its probe spec is not updated, skip it", "vm", vmName)
+			return
+		}
+
+		if !ok && m.readyBeforeStart(vm) {
+			m.log.V(4).Info("VM was powered on and ready before the prober manager started, treat it as started", "vm", vmName)
+			m.startedVMs.Set(vmName)
+		}
+
+		// A VM that has already started is not required to pass the updated probe until it is restarted.
+		m.startupResults.Reset(vmName)
+		m.startupQueue.Add(client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace})
+		m.vmStartupProbeList[vmName] = *vm.Spec.StartupProbe
+	} else {
+		delete(m.vmStartupProbeList, vmName)
+		m.startupResults.Reset(vmName)
+		m.startedVMs.Delete(vmName)
+	}
+}
+
+// readyBeforeStart returns true if the VM was powered on and ready before the manager was
+// created, in which case its startup probe succeeded before the restart or failover.
+func (m *manager) readyBeforeStart(vm *vmopv1.VirtualMachine) bool {
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		return false
+	}
+
+	c := conditions.Get(vm, vmopv1.ReadyConditionType)
+	return c != nil && c.Status == metav1.ConditionTrue && c.LastTransitionTime.Time.Before(m.startTime)
+}
+
 // RemoveFromProberManager removes a VM from the prober manager.
 func (m *manager) RemoveFromProberManager(vm *vmopv1.VirtualMachine) {
 	vmName := vm.NamespacedName()
 	m.log.V(4).Info("Remove from prober manager", "vm", vmName)
 
 	m.readinessMutex.Lock()
-	defer m.readinessMutex.Unlock()
 	delete(m.vmReadinessProbeList, vmName)
+	m.readinessResults.Reset(vmName)
+	m.readinessMutex.Unlock()
+
+	m.livenessMutex.Lock()
+	delete(m.vmLivenessProbeList, vmName)
+	m.livenessResults.Reset(vmName)
+	m.livenessMutex.Unlock()
+
+	m.startupMutex.Lock()
+	delete(m.vmStartupProbeList, vmName)
+	m.startupResults.Reset(vmName)
+	m.startedVMs.Delete(vmName)
+	m.startupMutex.Unlock()
+
+	m.poweredOnMutex.Lock()
+	delete(m.vmPoweredOnTimes, vmName)
+	m.poweredOnMutex.Unlock()
 }
 
 // Start starts the probe manager.
@@ -135,13 +271,29 @@ func (m *manager) Start(ctx goctx.Context) error {
 	m.log.Info("Starting readiness workers", "count", numberOfReadinessWorkers)
 	m.workersWG.Add(numberOfReadinessWorkers)
 	for i := 0; i < numberOfReadinessWorkers; i++ {
-		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.client, m.recorder)
+		readinessWorker := worker.NewReadinessWorker(m.readinessQueue, m.prober, m.readinessResults, m.startedVMs, m.client, m.recorder)
 		m.worker(readinessWorker)
 	}
 
+	m.log.Info("Starting liveness workers", "count", numberOfLivenessWorkers)
+	m.workersWG.Add(numberOfLivenessWorkers)
+	for i := 0; i < numberOfLivenessWorkers; i++ {
+		livenessWorker := worker.NewLivenessWorker(m.livenessQueue, m.prober, m.vmProvider, m.livenessResults, m.startedVMs, m.client, m.recorder)
+		m.worker(livenessWorker)
+	}
+
+	m.log.Info("Starting startup workers", "count", numberOfStartupWorkers)
+	m.workersWG.Add(numberOfStartupWorkers)
+	for i := 0; i < numberOfStartupWorkers; i++ {
+		startupWorker := worker.NewStartupWorker(m.startupQueue, m.prober, m.vmProvider, m.startupResults, m.startedVMs, m.client, m.recorder)
+		m.worker(startupWorker)
+	}
+
 	<-ctx.Done()
 
 	m.readinessQueue.ShutDown()
+	m.livenessQueue.ShutDown()
+	m.startupQueue.ShutDown()
 	m.workersWG.Wait()
 	return nil
 }
@@ -200,14 +352,45 @@ func (m *manager) processItemFromQueue(w worker.Worker) bool {
 // processVMProbe processes the Probe specified in VM spec.
 func (m *manager) processVMProbe(w worker.Worker, ctx *context.ProbeContext) error {
 	if ctx.VM.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		m.poweredOnMutex.Lock()
+		delete(m.vmPoweredOnTimes, ctx.VM.NamespacedName())
+		m.poweredOnMutex.Unlock()
+
 		// If a vm is not powered on, we don't run probes against it and translate probe result to failure.
 		// Populate the Condition and update the VM status.
 		ctx.Logger.V(4).Info("the VirtualMachine is not powered on")
 		return w.ProcessProbeResult(ctx, probe.Failure, fmt.Errorf("virtual machine is not powered on"))
 	}
+
+	if !m.initialDelayElapsed(ctx) {
+		ctx.Logger.V(4).Info("the initial delay of the probe has not elapsed, skip running the probe")
+		return nil
+	}
+
 	return w.DoProbe(ctx)
 }
 
+// initialDelayElapsed returns true if the initial delay of the probe has elapsed since the VM was
+// first seen powered on, or was last restarted.
+func (m *manager) initialDelayElapsed(ctx *context.ProbeContext) bool {
+	vmName := ctx.VM.NamespacedName()
+
+	m.poweredOnMutex.Lock()
+	poweredOnTime, ok := m.vmPoweredOnTimes[vmName]
+	if !ok {
+		poweredOnTime = time.Now()
+		m.vmPoweredOnTimes[vmName] = poweredOnTime
+	}
+	m.poweredOnMutex.Unlock()
+
+	if t := ctx.VM.Status.LastRestartTime; t != nil && t.After(poweredOnTime) {
+		poweredOnTime = t.Time
+	}
+
+	initialDelay := time.Duration(ctx.ProbeSpec.InitialDelaySeconds) * time.Second
+	return time.Since(poweredOnTime) >= initialDelay
+}
+
 // addItemToQueue adds the vm to the queue. If immediate is true, immediately add the item.
 // Otherwise, add to queue after a time period.
 func (m *manager) addItemToQueue(queue workqueue.DelayingInterface, ctx *context.ProbeContext, item client.ObjectKey, immediate bool) {
@@ -7,6 +7,7 @@ import (
 	goctx "context"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -186,6 +187,28 @@ var _ = Describe("VirtualMachine probes", func() {
 						})
 					})
 				})
+
+				It("Should not run the probe until the initial delay has elapsed", func() {
+					vm.Spec.ReadinessProbe.InitialDelaySeconds = 2 * periodSeconds
+					Expect(fakeClient.Update(ctx, vm)).To(Succeed())
+
+					probed := false
+					fakeWorker.DoProbeFn = func(ctx *context.ProbeContext) error {
+						probed = true
+						return nil
+					}
+					Expect(testManager.processItemFromQueue(fakeWorker)).To(BeFalse())
+					Expect(probed).To(BeFalse())
+
+					checkProbeQueueLenEventually(2*periodSeconds, 1)
+					Eventually(func() bool {
+						if testManager.readinessQueue.Len() == 0 {
+							testManager.readinessQueue.Add(vmKey)
+						}
+						Expect(testManager.processItemFromQueue(fakeWorker)).To(BeFalse())
+						return probed
+					}, 4*periodSeconds, periodSeconds).Should(BeTrue())
+				})
 			})
 		})
 	})
@@ -260,6 +283,146 @@ var _ = Describe("VirtualMachine probes", func() {
 				testManager.readinessMutex.Unlock()
 			})
 		})
+
+		It("Should reset the failure count of the readiness probe if its spec is updated", func() {
+			testManager.AddToProberManager(vm)
+			Expect(testManager.readinessResults.Record(vm.NamespacedName(), probe.Failure)).To(Equal(int32(1)))
+
+			vm.Spec.ReadinessProbe.FailureThreshold = 5
+			testManager.AddToProberManager(vm)
+			Expect(testManager.readinessResults.Record(vm.NamespacedName(), probe.Failure)).To(Equal(int32(1)))
+		})
+
+		When("VM specifies a liveness probe", func() {
+			BeforeEach(func() {
+				vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+					VirtualMachineReadinessProbeSpec: vmopv1.VirtualMachineReadinessProbeSpec{
+						GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+						PeriodSeconds:    periodSeconds,
+						FailureThreshold: 3,
+					},
+				}
+			})
+
+			It("Should add to the liveness queue and list", func() {
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.livenessQueue.Len()).To(Equal(1))
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).Should(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+
+			It("Should not add to the liveness queue again if the VM's probe spec is not updated", func() {
+				testManager.AddToProberManager(vm)
+				item, _ := testManager.livenessQueue.Get()
+				testManager.livenessQueue.Done(item)
+
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessQueue.Len()).To(Equal(0))
+			})
+
+			It("Should reset the failure count if the VM's probe spec is updated", func() {
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessResults.Record(vm.NamespacedName(), probe.Failure)).To(Equal(int32(1)))
+
+				vm.Spec.LivenessProbe.FailureThreshold = 5
+				testManager.AddToProberManager(vm)
+				Expect(testManager.livenessResults.Record(vm.NamespacedName(), probe.Failure)).To(Equal(int32(1)))
+			})
+
+			It("Should remove from the liveness list when the VM is removed from the manager", func() {
+				testManager.AddToProberManager(vm)
+				testManager.RemoveFromProberManager(vm)
+
+				testManager.livenessMutex.Lock()
+				Expect(testManager.vmLivenessProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.livenessMutex.Unlock()
+			})
+		})
+
+		When("VM specifies a startup probe", func() {
+			BeforeEach(func() {
+				vm.Spec.StartupProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+					GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
+					PeriodSeconds:  periodSeconds,
+				}
+			})
+
+			It("Should add to the startup queue and list", func() {
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.startupQueue.Len()).To(Equal(1))
+				testManager.startupMutex.Lock()
+				Expect(testManager.vmStartupProbeList).Should(HaveKey(vm.NamespacedName()))
+				testManager.startupMutex.Unlock()
+			})
+
+			When("the VM was powered on and ready before the manager started", func() {
+				var readyTime time.Time
+
+				BeforeEach(func() {
+					readyTime = time.Now().Add(-time.Hour)
+				})
+
+				JustBeforeEach(func() {
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+					vm.Status.Conditions = []metav1.Condition{
+						{
+							Type:               vmopv1.ReadyConditionType,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(readyTime),
+						},
+					}
+				})
+
+				It("Should treat the VM as started", func() {
+					testManager.AddToProberManager(vm)
+					Expect(testManager.startedVMs.Has(vm.NamespacedName())).To(BeTrue())
+				})
+
+				When("the VM became ready after the manager started", func() {
+					BeforeEach(func() {
+						readyTime = time.Now().Add(time.Minute)
+					})
+
+					It("Should not treat the VM as started", func() {
+						testManager.AddToProberManager(vm)
+						Expect(testManager.startedVMs.Has(vm.NamespacedName())).To(BeFalse())
+					})
+				})
+
+				It("Should not treat the VM as started when the VM is powered off", func() {
+					vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+					testManager.AddToProberManager(vm)
+					Expect(testManager.startedVMs.Has(vm.NamespacedName())).To(BeFalse())
+				})
+			})
+
+			It("Should forget the VM has started when the startup probe is removed", func() {
+				testManager.AddToProberManager(vm)
+				testManager.startedVMs.Set(vm.NamespacedName())
+
+				vm.Spec.StartupProbe = nil
+				testManager.AddToProberManager(vm)
+
+				Expect(testManager.startedVMs.Has(vm.NamespacedName())).To(BeFalse())
+				testManager.startupMutex.Lock()
+				Expect(testManager.vmStartupProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.startupMutex.Unlock()
+			})
+
+			It("Should remove from the startup list when the VM is removed from the manager", func() {
+				testManager.AddToProberManager(vm)
+				testManager.startedVMs.Set(vm.NamespacedName())
+				testManager.RemoveFromProberManager(vm)
+
+				Expect(testManager.startedVMs.Has(vm.NamespacedName())).To(BeFalse())
+				testManager.startupMutex.Lock()
+				Expect(testManager.vmStartupProbeList).ShouldNot(HaveKey(vm.NamespacedName()))
+				testManager.startupMutex.Unlock()
+			})
+		})
 	})
 })
 
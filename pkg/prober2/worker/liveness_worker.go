@@ -0,0 +1,175 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	goctx "context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/pkg/errors"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
+	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// restartedReason and restartFailedReason represent reasons for liveness and startup probe events.
+	restartedReason     string = "Restarted"
+	restartFailedReason string = "RestartFailed"
+)
+
+// vmProviderRestarter is the provider method used to restart a VM.
+type vmProviderRestarter interface {
+	RestartVirtualMachine(ctx goctx.Context, vm *vmopv1.VirtualMachine) error
+}
+
+// livenessWorker implements Worker interface.
+type livenessWorker struct {
+	queue     workqueue.DelayingInterface
+	prober    *probe.Prober
+	restarter vmProviderRestarter
+	results   *ResultCounts
+	started   *StartedVMs
+	client    client.Client
+	recorder  vmoprecord.Recorder
+}
+
+// NewLivenessWorker creates a new liveness worker to run liveness probes.
+func NewLivenessWorker(
+	queue workqueue.DelayingInterface,
+	prober *probe.Prober,
+	restarter vmProviderRestarter,
+	results *ResultCounts,
+	started *StartedVMs,
+	client client.Client,
+	recorder vmoprecord.Recorder,
+) Worker {
+	return &livenessWorker{
+		queue:     queue,
+		prober:    prober,
+		restarter: restarter,
+		results:   results,
+		started:   started,
+		client:    client,
+		recorder:  recorder,
+	}
+}
+
+func (w *livenessWorker) GetQueue() workqueue.DelayingInterface {
+	return w.queue
+}
+
+// CreateProbeContext creates a probe context for liveness probe.
+func (w *livenessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*context.ProbeContext, error) {
+	p := vm.Spec.LivenessProbe
+
+	if p == nil || !HasProbeAction(&p.VirtualMachineReadinessProbeSpec) {
+		return nil, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &context.ProbeContext{
+		Context:       goctx.Background(),
+		Logger:        ctrl.Log.WithName("liveness-probe").WithValues("vmName", vm.NamespacedName()),
+		PatchHelper:   patchHelper,
+		VM:            vm,
+		ProbeSpec:     &p.VirtualMachineReadinessProbeSpec,
+		ProbeType:     "liveness",
+		PeriodSeconds: p.PeriodSeconds,
+	}, nil
+}
+
+// ProcessProbeResult counts the consecutive failures of the liveness probe and
+// restarts the VM once the failure threshold is reached. Any result other than
+// a failure resets the count. A VM that is not powered on is not restarted.
+func (w *livenessWorker) ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error {
+	vm := ctx.VM
+	vmName := vm.NamespacedName()
+
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn || res != probe.Failure {
+		w.results.Reset(vmName)
+		return nil
+	}
+
+	threshold := failureThreshold(ctx.ProbeSpec)
+	failures := w.results.Record(vmName, res)
+	if failures < threshold {
+		ctx.Logger.V(4).Info("liveness probe failed", "failures", failures, "failureThreshold", threshold)
+		return nil
+	}
+	w.results.Reset(vmName)
+
+	msg := fmt.Sprintf("liveness probe failed %d times", failures)
+	if resErr != nil {
+		msg = fmt.Sprintf("%s: %v", msg, resErr)
+	}
+
+	return restartVM(ctx, w.restarter, w.started, w.recorder, msg)
+}
+
+// DoProbe runs the liveness probe once the VM's startup probe, if any, has succeeded.
+func (w *livenessWorker) DoProbe(ctx *context.ProbeContext) error {
+	if startupPending(ctx.VM, w.started) {
+		ctx.Logger.V(4).Info("startup probe has not succeeded, skip running the liveness probe")
+		w.results.Reset(ctx.VM.NamespacedName())
+		return nil
+	}
+
+	res, err := w.runProbe(ctx)
+	if err != nil {
+		ctx.Logger.Error(err, "liveness probe fails", "result", res)
+	}
+	return w.ProcessProbeResult(ctx, res, err)
+}
+
+// runProbe runs a specific type of probe based on the VM probe spec.
+func (w *livenessWorker) runProbe(ctx *context.ProbeContext) (probe.Result, error) {
+	if p := getProbe(w.prober, ctx.ProbeSpec); p != nil {
+		return p.Probe(ctx)
+	}
+
+	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s liveness probe", ctx.VM.NamespacedName())
+}
+
+// restartVM restarts the VM, updates its restart status, and forgets that its
+// startup probe has succeeded so that the probe is run again.
+func restartVM(
+	ctx *context.ProbeContext,
+	restarter vmProviderRestarter,
+	started *StartedVMs,
+	recorder vmoprecord.Recorder,
+	msg string) error {
+
+	vm := ctx.VM
+
+	ctx.Logger.Info("Restarting VM", "reason", msg, "restartMode", vm.Spec.RestartMode)
+	if err := restarter.RestartVirtualMachine(ctx, vm); err != nil {
+		recorder.Warnf(vm, restartFailedReason, "Failed to restart VM after %s: %v", msg, err)
+		return errors.Wrapf(err, "failed to restart VM")
+	}
+	started.Delete(vm.NamespacedName())
+
+	now := metav1.Now()
+	vm.Status.LastRestartTime = &now
+	vm.Status.RestartCount++
+	recorder.Eventf(vm, restartedReason, "Restarted VM after %s", msg)
+
+	if err := ctx.PatchHelper.Patch(ctx, vm); err != nil {
+		return errors.Wrapf(err, "patched failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	goctx "context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+	fakeprobe "github.com/vmware-tanzu/vm-operator/pkg/prober2/fake/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine liveness probes", func() {
+	var (
+		testWorker Worker
+
+		vm    *vmopv1.VirtualMachine
+		vmKey client.ObjectKey
+		ctx   *context.ProbeContext
+
+		fakeClient         client.Client
+		fakeEvents         chan string
+		fakeVMProvider     *fake.VMProviderA2
+		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		results            *ResultCounts
+		restarts           int
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName: "dummy-vmclass",
+				LivenessProbe: &vmopv1.VirtualMachineLivenessProbeSpec{
+					VirtualMachineReadinessProbeSpec: *getVirtualMachineHeartbeatProbe(),
+				},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+		vm.Spec.LivenessProbe.FailureThreshold = 2
+		vmKey = client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace}
+
+		fakeClient = builder.NewFakeClient(vm)
+		eventRecorder := clientgorecord.NewFakeRecorder(1024)
+		fakeEvents = eventRecorder.Events
+
+		restarts = 0
+		fakeVMProvider = &fake.VMProviderA2{}
+		fakeVMProvider.RestartVirtualMachineFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine) error {
+			restarts++
+			return nil
+		}
+
+		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHeartbeatProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+			return probe.Failure, errors.New("heartbeat error")
+		}
+		prober := &probe.Prober{
+			GuestHeartbeat: fakeHeartbeatProbe,
+		}
+
+		results = NewResultCounts()
+		queue := workqueue.NewNamedDelayingQueue("test")
+		testWorker = NewLivenessWorker(queue, prober, fakeVMProvider, results, NewStartedVMs(), fakeClient, record.New(eventRecorder))
+	})
+
+	doProbe := func() {
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		var err error
+		ctx, err = testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		Expect(testWorker.DoProbe(ctx)).To(Succeed())
+	}
+
+	It("Should not create a probe context when the VM does not have a liveness probe", func() {
+		vm.Spec.LivenessProbe = nil
+		ctx, err := testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).To(BeNil())
+	})
+
+	It("Should not restart the VM before the failure threshold is reached", func() {
+		doProbe()
+
+		Expect(restarts).To(BeZero())
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		Expect(vm.Status.RestartCount).To(BeZero())
+		Expect(fakeEvents).ShouldNot(Receive())
+	})
+
+	It("Should restart the VM when the failure threshold is reached", func() {
+		doProbe()
+		doProbe()
+
+		Expect(restarts).To(Equal(1))
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+		Expect(vm.Status.LastRestartTime).ToNot(BeNil())
+		Expect(fakeEvents).Should(Receive(And(ContainSubstring(restartedReason), ContainSubstring("heartbeat error"))))
+
+		By("Should count the failures again after the restart", func() {
+			doProbe()
+			Expect(restarts).To(Equal(1))
+			doProbe()
+			Expect(restarts).To(Equal(2))
+			Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+			Expect(vm.Status.RestartCount).To(Equal(int32(2)))
+		})
+	})
+
+	It("Should reset the failure count when the probe succeeds", func() {
+		doProbe()
+
+		fakeHeartbeatProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+			return probe.Success, nil
+		}
+		doProbe()
+
+		fakeHeartbeatProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+			return probe.Failure, nil
+		}
+		doProbe()
+
+		Expect(restarts).To(BeZero())
+	})
+
+	It("Should not restart the VM when it is not powered on", func() {
+		vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+		Expect(fakeClient.Status().Update(goctx.Background(), vm)).To(Succeed())
+
+		for i := 0; i < 3; i++ {
+			doProbe()
+		}
+
+		Expect(restarts).To(BeZero())
+	})
+
+	It("Should return an error and emit an event when the restart fails", func() {
+		fakeVMProvider.RestartVirtualMachineFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine) error {
+			return errors.New("restart error")
+		}
+
+		doProbe()
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		ctx, err := testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testWorker.DoProbe(ctx)).To(MatchError(ContainSubstring("restart error")))
+
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		Expect(vm.Status.RestartCount).To(BeZero())
+		Expect(fakeEvents).Should(Receive(ContainSubstring(restartFailedReason)))
+	})
+})
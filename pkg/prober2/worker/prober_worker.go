@@ -12,6 +12,14 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
 )
 
+const (
+	// defaultSuccessThreshold and defaultFailureThreshold are the number of consecutive successes
+	// and failures of a probe after which its result is acted upon when the probe does not specify them.
+	// We use the same default values as the kubernetes container probe.
+	defaultSuccessThreshold = 1
+	defaultFailureThreshold = 3
+)
+
 // Worker represents a prober worker interface.
 type Worker interface {
 	GetQueue() workqueue.DelayingInterface
@@ -19,3 +27,30 @@ type Worker interface {
 	DoProbe(ctx *context.ProbeContext) error
 	ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error
 }
+
+// HasProbeAction returns true if the probe specifies an action.
+func HasProbeAction(p *vmopv1.VirtualMachineReadinessProbeSpec) bool {
+	return p != nil &&
+		(p.TCPSocket != nil || p.HTTPGet != nil || p.Exec != nil || p.GuestHeartbeat != nil || len(p.GuestInfo) != 0)
+}
+
+// startupPending returns true if the VM has a startup probe that has not succeeded.
+func startupPending(vm *vmopv1.VirtualMachine, started *StartedVMs) bool {
+	return HasProbeAction(vm.Spec.StartupProbe) && !started.Has(vm.NamespacedName())
+}
+
+// successThreshold returns the success threshold of the probe.
+func successThreshold(p *vmopv1.VirtualMachineReadinessProbeSpec) int32 {
+	if p.SuccessThreshold <= 0 {
+		return defaultSuccessThreshold
+	}
+	return p.SuccessThreshold
+}
+
+// failureThreshold returns the failure threshold of the probe.
+func failureThreshold(p *vmopv1.VirtualMachineReadinessProbeSpec) int32 {
+	if p.FailureThreshold <= 0 {
+		return defaultFailureThreshold
+	}
+	return p.FailureThreshold
+}
@@ -23,16 +23,19 @@ import (
 )
 
 const (
-	// readyReason, notReadyReason and unknownReason represent reasons for probe events and Condition.
-	readyReason    string = "Ready"
-	notReadyReason string = "NotReady"
-	unknownReason  string = "Unknown"
+	// readyReason, notReadyReason, unknownReason and notStartedReason represent reasons for probe events and Condition.
+	readyReason      string = "Ready"
+	notReadyReason   string = "NotReady"
+	unknownReason    string = "Unknown"
+	notStartedReason string = "NotStarted"
 )
 
 // readinessWorker implements Worker interface.
 type readinessWorker struct {
 	queue    workqueue.DelayingInterface
 	prober   *probe.Prober
+	results  *ResultCounts
+	started  *StartedVMs
 	client   client.Client
 	recorder vmoprecord.Recorder
 }
@@ -41,12 +44,16 @@ type readinessWorker struct {
 func NewReadinessWorker(
 	queue workqueue.DelayingInterface,
 	prober *probe.Prober,
+	results *ResultCounts,
+	started *StartedVMs,
 	client client.Client,
 	recorder vmoprecord.Recorder,
 ) Worker {
 	return &readinessWorker{
 		queue:    queue,
 		prober:   prober,
+		results:  results,
+		started:  started,
 		client:   client,
 		recorder: recorder,
 	}
@@ -60,7 +67,7 @@ func (w *readinessWorker) GetQueue() workqueue.DelayingInterface {
 func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*context.ProbeContext, error) {
 	p := vm.Spec.ReadinessProbe
 
-	if p.TCPSocket == nil && p.GuestHeartbeat == nil && len(p.GuestInfo) == 0 {
+	if !HasProbeAction(p) {
 		return nil, nil
 	}
 
@@ -74,17 +81,50 @@ func (w *readinessWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*contex
 		Logger:        ctrl.Log.WithName("readiness-probe").WithValues("vmName", vm.NamespacedName()),
 		PatchHelper:   patchHelper,
 		VM:            vm,
+		ProbeSpec:     p,
 		ProbeType:     "readiness",
 		PeriodSeconds: p.PeriodSeconds,
 	}, nil
 }
 
-// ProcessProbeResult processes probe results to get ReadyCondition and
-// sets the ReadyCondition in vm status if the new condition status is a transition.
+// ProcessProbeResult processes probe results to get ReadyCondition and sets the ReadyCondition in vm status.
+// The status of the ReadyCondition only transitions once the probe has returned the same result the success
+// or failure threshold number of consecutive times. An unknown result is counted as a failure. A VM that is
+// not powered on is not ready regardless of the thresholds.
 func (w *readinessWorker) ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error {
 	vm := ctx.VM
+	vmName := vm.NamespacedName()
 	condition := w.getCondition(res, resErr)
 
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		w.results.Reset(vmName)
+		return w.setCondition(ctx, condition)
+	}
+
+	threshold := failureThreshold(ctx.ProbeSpec)
+	if res == probe.Success {
+		threshold = successThreshold(ctx.ProbeSpec)
+	} else {
+		res = probe.Failure
+	}
+	count := w.results.Record(vmName, res)
+
+	// A VM without a ReadyCondition is not ready, so only becoming ready is subject to the threshold.
+	c := conditions.Get(vm, condition.Type)
+	transition := (c == nil && condition.Status == metav1.ConditionTrue) || (c != nil && c.Status != condition.Status)
+	if transition && count < threshold {
+		ctx.Logger.V(4).Info("readiness probe result has not reached the threshold",
+			"result", res, "count", count, "threshold", threshold)
+		return nil
+	}
+
+	return w.setCondition(ctx, condition)
+}
+
+// setCondition sets the ReadyCondition in vm status, and sends an event if the condition status is a transition.
+func (w *readinessWorker) setCondition(ctx *context.ProbeContext, condition *metav1.Condition) error {
+	vm := ctx.VM
+
 	// We only send event when either the condition type is added or its status changes, not
 	// if either its reason, severity, or message changes.
 	if c := conditions.Get(vm, condition.Type); c == nil || c.Status != condition.Status {
@@ -111,7 +151,16 @@ func (w *readinessWorker) ProcessProbeResult(ctx *context.ProbeContext, res prob
 	return nil
 }
 
+// DoProbe runs the readiness probe once the VM's startup probe, if any, has succeeded. The VM is not
+// ready while its startup probe has not succeeded.
 func (w *readinessWorker) DoProbe(ctx *context.ProbeContext) error {
+	if startupPending(ctx.VM, w.started) {
+		ctx.Logger.V(4).Info("startup probe has not succeeded, skip running the readiness probe")
+		w.results.Reset(ctx.VM.NamespacedName())
+		return w.setCondition(ctx, conditions.FalseCondition(vmopv1.ReadyConditionType, notStartedReason,
+			"startup probe has not succeeded"))
+	}
+
 	res, err := w.runProbe(ctx)
 	if err != nil {
 		ctx.Logger.Error(err, "readiness probe fails", "result", res)
@@ -120,19 +169,25 @@ func (w *readinessWorker) DoProbe(ctx *context.ProbeContext) error {
 }
 
 // getProbe returns a specific type of probe method.
-func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
+func getProbe(prober *probe.Prober, probeSpec *vmopv1.VirtualMachineReadinessProbeSpec) probe.Probe {
 	if probeSpec == nil {
 		return nil
 	}
 
 	if probeSpec.TCPSocket != nil {
-		return w.prober.TCPProbe
+		return prober.TCPProbe
+	}
+	if probeSpec.HTTPGet != nil {
+		return prober.HTTPGetProbe
+	}
+	if probeSpec.Exec != nil {
+		return prober.ExecProbe
 	}
 	if probeSpec.GuestHeartbeat != nil {
-		return w.prober.GuestHeartbeat
+		return prober.GuestHeartbeat
 	}
 	if len(probeSpec.GuestInfo) != 0 {
-		return w.prober.GuestInfo
+		return prober.GuestInfo
 	}
 
 	return nil
@@ -140,7 +195,7 @@ func (w *readinessWorker) getProbe(probeSpec *vmopv1.VirtualMachineReadinessProb
 
 // runProbe runs a specific type of probe based on the VM probe spec.
 func (w *readinessWorker) runProbe(ctx *context.ProbeContext) (probe.Result, error) {
-	if p := w.getProbe(ctx.VM.Spec.ReadinessProbe); p != nil {
+	if p := getProbe(w.prober, ctx.ProbeSpec); p != nil {
 		return p.Probe(ctx)
 	}
 
@@ -40,6 +40,9 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		fakeEvents         chan string
 		fakeTCPProbe       *fakeprobe.FakeProbe
 		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		fakeHTTPGetProbe   *fakeprobe.FakeProbe
+		fakeExecProbe      *fakeprobe.FakeProbe
+		started            *StartedVMs
 	)
 
 	BeforeEach(func() {
@@ -63,11 +66,16 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		queue := workqueue.NewNamedDelayingQueue("test")
 		fakeTCPProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHTTPGetProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeExecProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
 		prober := &probe.Prober{
 			TCPProbe:       fakeTCPProbe,
+			HTTPGetProbe:   fakeHTTPGetProbe,
+			ExecProbe:      fakeExecProbe,
 			GuestHeartbeat: fakeHeartbeatProbe,
 		}
-		testWorker = NewReadinessWorker(queue, prober, fakeClient, fakeRecorder)
+		started = NewStartedVMs()
+		testWorker = NewReadinessWorker(queue, prober, NewResultCounts(), started, fakeClient, fakeRecorder)
 	})
 
 	checkReadyCondition := func(c client.Client, objKey client.ObjectKey, expectedCondition metav1.ConditionStatus) {
@@ -160,6 +168,103 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 		})
 	})
 
+	Context("VM has readiness probe with thresholds", func() {
+		var (
+			probeResult probe.Result
+		)
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = getVirtualMachineReadinessTCPProbe(10001)
+			vm.Spec.ReadinessProbe.SuccessThreshold = 2
+			vm.Spec.ReadinessProbe.FailureThreshold = 2
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOn
+			Expect(fakeClient.Create(goctx.Background(), vm)).Should(Succeed())
+
+			fakeTCPProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+				return probeResult, nil
+			}
+		})
+
+		doProbe := func() {
+			Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+		}
+
+		It("Should set ReadyCondition as false on the first failure when the VM does not have the condition", func() {
+			probeResult = probe.Failure
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+		})
+
+		It("Should only set ReadyCondition as true after the success threshold is reached", func() {
+			probeResult = probe.Success
+			doProbe()
+			Expect(conditions.Get(vm, vmopv1.ReadyConditionType)).To(BeNil())
+
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+			By("Should only set ReadyCondition as false after the failure threshold is reached", func() {
+				probeResult = probe.Failure
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+				probeResult = probe.Unknown
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionUnknown)
+			})
+
+			By("Should reset the count when the result changes", func() {
+				probeResult = probe.Success
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionUnknown)
+
+				probeResult = probe.Failure
+				doProbe()
+				probeResult = probe.Success
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionUnknown)
+			})
+		})
+
+		It("Should set ReadyCondition as false regardless of the threshold when the VM is not powered on", func() {
+			probeResult = probe.Success
+			doProbe()
+			doProbe()
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+			Expect(fakeClient.Status().Update(goctx.Background(), vm)).To(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(testWorker.ProcessProbeResult(ctx, probe.Failure, fmt.Errorf("virtual machine is not powered on"))).To(Succeed())
+			checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+		})
+
+		When("VM has a startup probe", func() {
+			BeforeEach(func() {
+				vm.Spec.StartupProbe = getVirtualMachineHeartbeatProbe()
+				Expect(fakeClient.Update(goctx.Background(), vm)).Should(Succeed())
+			})
+
+			It("Should not run the readiness probe until the startup probe succeeds", func() {
+				probeResult = probe.Success
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionFalse)
+				Expect(conditions.Get(vm, vmopv1.ReadyConditionType).Reason).To(Equal(notStartedReason))
+
+				started.Set(vm.NamespacedName())
+				doProbe()
+				doProbe()
+				checkReadyCondition(fakeClient, vmKey, metav1.ConditionTrue)
+			})
+		})
+	})
+
 	Context("Guest heartbeat Probe", func() {
 
 		BeforeEach(func() {
@@ -184,6 +289,67 @@ var _ = Describe("VirtualMachine readiness probes", func() {
 			Expect(condition.Message).To(ContainSubstring("heartbeat error"))
 		})
 	})
+
+	Context("HTTP GET Probe", func() {
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				HTTPGet: &vmopv1.HTTPGetAction{
+					Port: intstr.FromInt(80),
+				},
+				PeriodSeconds: 1,
+			}
+			Expect(fakeClient.Create(goctx.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Just need to test for probe selection.
+		It("Should update ReadyCondition when probe fails", func() {
+			fakeHTTPGetProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("http error")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(ContainSubstring("http error"))
+		})
+	})
+
+	Context("Exec Probe", func() {
+
+		BeforeEach(func() {
+			vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				Exec: &vmopv1.GuestExecAction{
+					Command:               "/usr/bin/true",
+					CredentialsSecretName: "guest-credentials",
+				},
+				PeriodSeconds: 1,
+			}
+			Expect(fakeClient.Create(goctx.Background(), vm)).Should(Succeed())
+			Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).Should(Succeed())
+			var err error
+			ctx, err = testWorker.CreateProbeContext(vm)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		// Just need to test for probe selection.
+		It("Should update ReadyCondition when probe fails", func() {
+			fakeExecProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+				return probe.Failure, fmt.Errorf("exec probe failed with exit code 1")
+			}
+
+			Expect(testWorker.DoProbe(ctx)).Should(Succeed())
+			Expect(fakeClient.Get(ctx, vmKey, vm)).Should(Succeed())
+			condition := conditions.Get(vm, vmopv1.ReadyConditionType)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(ContainSubstring("exit code 1"))
+		})
+	})
 })
 
 func TestReadinessProbeWorker(t *testing.T) {
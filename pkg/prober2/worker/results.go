@@ -0,0 +1,96 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
+)
+
+type resultCount struct {
+	result probe.Result
+	count  int32
+}
+
+// ResultCounts tracks the number of consecutive times a VM's probe has
+// returned the same result. It is safe for concurrent use by multiple
+// workers.
+type ResultCounts struct {
+	mutex  sync.Mutex
+	counts map[string]resultCount
+}
+
+// NewResultCounts returns a new ResultCounts.
+func NewResultCounts() *ResultCounts {
+	return &ResultCounts{
+		counts: make(map[string]resultCount),
+	}
+}
+
+// Record records the result of a VM's probe and returns the number of
+// consecutive times the probe has returned that result.
+func (r *ResultCounts) Record(vmName string, res probe.Result) int32 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	c := r.counts[vmName]
+	if c.result != res {
+		c = resultCount{result: res}
+	}
+	c.count++
+	r.counts[vmName] = c
+
+	return c.count
+}
+
+// Reset forgets the results recorded for a VM's probe.
+func (r *ResultCounts) Reset(vmName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.counts, vmName)
+}
+
+// StartedVMs tracks the VMs whose startup probe has succeeded since the VM
+// was last powered on. It is safe for concurrent use by multiple workers.
+type StartedVMs struct {
+	mutex sync.Mutex
+	vms   map[string]struct{}
+}
+
+// NewStartedVMs returns a new StartedVMs.
+func NewStartedVMs() *StartedVMs {
+	return &StartedVMs{
+		vms: make(map[string]struct{}),
+	}
+}
+
+// Set records that the startup probe of a VM has succeeded.
+func (s *StartedVMs) Set(vmName string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.vms[vmName] = struct{}{}
+}
+
+// Has returns true if the startup probe of a VM has succeeded.
+func (s *StartedVMs) Has(vmName string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.vms[vmName]
+	return ok
+}
+
+// Delete forgets that the startup probe of a VM has succeeded, and returns
+// true if it had.
+func (s *StartedVMs) Delete(vmName string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.vms[vmName]
+	delete(s.vms, vmName)
+	return ok
+}
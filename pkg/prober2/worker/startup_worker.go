@@ -0,0 +1,145 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	goctx "context"
+	"fmt"
+
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	patch "github.com/vmware-tanzu/vm-operator/pkg/patch2"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
+	vmoprecord "github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+// startupWorker implements Worker interface.
+type startupWorker struct {
+	queue     workqueue.DelayingInterface
+	prober    *probe.Prober
+	restarter vmProviderRestarter
+	results   *ResultCounts
+	started   *StartedVMs
+	client    client.Client
+	recorder  vmoprecord.Recorder
+}
+
+// NewStartupWorker creates a new startup worker to run startup probes.
+func NewStartupWorker(
+	queue workqueue.DelayingInterface,
+	prober *probe.Prober,
+	restarter vmProviderRestarter,
+	results *ResultCounts,
+	started *StartedVMs,
+	client client.Client,
+	recorder vmoprecord.Recorder,
+) Worker {
+	return &startupWorker{
+		queue:     queue,
+		prober:    prober,
+		restarter: restarter,
+		results:   results,
+		started:   started,
+		client:    client,
+		recorder:  recorder,
+	}
+}
+
+func (w *startupWorker) GetQueue() workqueue.DelayingInterface {
+	return w.queue
+}
+
+// CreateProbeContext creates a probe context for startup probe.
+func (w *startupWorker) CreateProbeContext(vm *vmopv1.VirtualMachine) (*context.ProbeContext, error) {
+	p := vm.Spec.StartupProbe
+
+	if !HasProbeAction(p) {
+		return nil, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vm, w.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &context.ProbeContext{
+		Context:       goctx.Background(),
+		Logger:        ctrl.Log.WithName("startup-probe").WithValues("vmName", vm.NamespacedName()),
+		PatchHelper:   patchHelper,
+		VM:            vm,
+		ProbeSpec:     p,
+		ProbeType:     "startup",
+		PeriodSeconds: p.PeriodSeconds,
+	}, nil
+}
+
+// ProcessProbeResult records that the VM has started once the startup probe
+// succeeds, and restarts the VM once the probe has failed the failure threshold
+// number of consecutive times. A VM that is not powered on has not started,
+// and is not restarted.
+func (w *startupWorker) ProcessProbeResult(ctx *context.ProbeContext, res probe.Result, resErr error) error {
+	vm := ctx.VM
+	vmName := vm.NamespacedName()
+
+	if vm.Status.PowerState != vmopv1.VirtualMachinePowerStateOn {
+		w.results.Reset(vmName)
+		w.started.Delete(vmName)
+		return nil
+	}
+
+	switch res {
+	case probe.Success:
+		w.results.Reset(vmName)
+		w.started.Set(vmName)
+		ctx.Logger.Info("VM startup probe succeeded")
+		return nil
+	case probe.Failure:
+	default: // probe.Unknown
+		w.results.Reset(vmName)
+		return nil
+	}
+
+	threshold := failureThreshold(ctx.ProbeSpec)
+	failures := w.results.Record(vmName, res)
+	if failures < threshold {
+		ctx.Logger.V(4).Info("startup probe failed", "failures", failures, "failureThreshold", threshold)
+		return nil
+	}
+	w.results.Reset(vmName)
+
+	msg := fmt.Sprintf("startup probe failed %d times", failures)
+	if resErr != nil {
+		msg = fmt.Sprintf("%s: %v", msg, resErr)
+	}
+
+	return restartVM(ctx, w.restarter, w.started, w.recorder, msg)
+}
+
+// DoProbe runs the startup probe until it succeeds. The VM stays in the startup
+// queue afterwards so that the probe is run again after the VM is powered off
+// or restarted.
+func (w *startupWorker) DoProbe(ctx *context.ProbeContext) error {
+	if w.started.Has(ctx.VM.NamespacedName()) {
+		return nil
+	}
+
+	res, err := w.runProbe(ctx)
+	if err != nil {
+		ctx.Logger.Error(err, "startup probe fails", "result", res)
+	}
+	return w.ProcessProbeResult(ctx, res, err)
+}
+
+// runProbe runs a specific type of probe based on the VM probe spec.
+func (w *startupWorker) runProbe(ctx *context.ProbeContext) (probe.Result, error) {
+	if p := getProbe(w.prober, ctx.ProbeSpec); p != nil {
+		return p.Probe(ctx)
+	}
+
+	return probe.Unknown, fmt.Errorf("unknown action specified for VM %s startup probe", ctx.VM.NamespacedName())
+}
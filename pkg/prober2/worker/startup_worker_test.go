@@ -0,0 +1,136 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	goctx "context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgorecord "k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/context"
+	fakeprobe "github.com/vmware-tanzu/vm-operator/pkg/prober2/fake/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/prober2/probe"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine startup probes", func() {
+	var (
+		testWorker Worker
+
+		vm    *vmopv1.VirtualMachine
+		vmKey client.ObjectKey
+		ctx   *context.ProbeContext
+
+		fakeClient         client.Client
+		fakeEvents         chan string
+		fakeVMProvider     *fake.VMProviderA2
+		fakeHeartbeatProbe *fakeprobe.FakeProbe
+		started            *StartedVMs
+		probes             int
+		restarts           int
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				ClassName:    "dummy-vmclass",
+				StartupProbe: getVirtualMachineHeartbeatProbe(),
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				PowerState: vmopv1.VirtualMachinePowerStateOn,
+			},
+		}
+		vm.Spec.StartupProbe.FailureThreshold = 2
+		vmKey = client.ObjectKey{Name: vm.Name, Namespace: vm.Namespace}
+
+		fakeClient = builder.NewFakeClient(vm)
+		eventRecorder := clientgorecord.NewFakeRecorder(1024)
+		fakeEvents = eventRecorder.Events
+
+		restarts = 0
+		fakeVMProvider = &fake.VMProviderA2{}
+		fakeVMProvider.RestartVirtualMachineFn = func(_ goctx.Context, _ *vmopv1.VirtualMachine) error {
+			restarts++
+			return nil
+		}
+
+		probes = 0
+		fakeHeartbeatProbe = fakeprobe.NewFakeProbe().(*fakeprobe.FakeProbe)
+		fakeHeartbeatProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+			probes++
+			return probe.Failure, errors.New("heartbeat error")
+		}
+		prober := &probe.Prober{
+			GuestHeartbeat: fakeHeartbeatProbe,
+		}
+
+		started = NewStartedVMs()
+		queue := workqueue.NewNamedDelayingQueue("test")
+		testWorker = NewStartupWorker(queue, prober, fakeVMProvider, NewResultCounts(), started, fakeClient, record.New(eventRecorder))
+	})
+
+	doProbe := func() {
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		var err error
+		ctx, err = testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		Expect(testWorker.DoProbe(ctx)).To(Succeed())
+	}
+
+	It("Should not create a probe context when the VM does not have a startup probe", func() {
+		vm.Spec.StartupProbe = nil
+		ctx, err := testWorker.CreateProbeContext(vm)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).To(BeNil())
+	})
+
+	It("Should mark the VM as started when the probe succeeds", func() {
+		fakeHeartbeatProbe.ProbeFn = func(ctx *context.ProbeContext) (probe.Result, error) {
+			probes++
+			return probe.Success, nil
+		}
+
+		doProbe()
+		Expect(started.Has(vm.NamespacedName())).To(BeTrue())
+
+		By("Should not run the probe once the VM has started", func() {
+			doProbe()
+			Expect(probes).To(Equal(1))
+		})
+
+		By("Should forget the VM has started when it is not powered on", func() {
+			vm.Status.PowerState = vmopv1.VirtualMachinePowerStateOff
+			Expect(testWorker.ProcessProbeResult(ctx, probe.Failure, errors.New("virtual machine is not powered on"))).To(Succeed())
+			Expect(started.Has(vm.NamespacedName())).To(BeFalse())
+		})
+	})
+
+	It("Should restart the VM when the failure threshold is reached", func() {
+		doProbe()
+		Expect(restarts).To(BeZero())
+
+		doProbe()
+		Expect(restarts).To(Equal(1))
+		Expect(started.Has(vm.NamespacedName())).To(BeFalse())
+		Expect(fakeClient.Get(goctx.Background(), vmKey, vm)).To(Succeed())
+		Expect(vm.Status.RestartCount).To(Equal(int32(1)))
+		Expect(fakeEvents).Should(Receive(And(ContainSubstring(restartedReason), ContainSubstring("startup probe failed"))))
+	})
+})
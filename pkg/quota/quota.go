@@ -0,0 +1,176 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// ReservationTTL is how long the usage reserved for a VM in the status of a
+// VirtualMachineResourceQuota is kept when the change to the VM is not observed,
+// for example because another admission webhook denied the request.
+const ReservationTTL = time.Minute
+
+// SupportedResources are the names of the VM resources that may be limited by a
+// VirtualMachineResourceQuota.
+var SupportedResources = []corev1.ResourceName{
+	vmopv1.VirtualMachineResourceCPU,
+	vmopv1.VirtualMachineResourceMemory,
+	vmopv1.VirtualMachineResourceRequestsCPU,
+	vmopv1.VirtualMachineResourceRequestsMemory,
+	vmopv1.VirtualMachineResourceLimitsCPU,
+	vmopv1.VirtualMachineResourceLimitsMemory,
+	vmopv1.VirtualMachineResourceVGPUs,
+	vmopv1.VirtualMachineResourceInstanceStorage,
+}
+
+// IsSupportedResource returns true if the named resource may be limited by a
+// VirtualMachineResourceQuota.
+func IsSupportedResource(name corev1.ResourceName) bool {
+	for _, r := range SupportedResources {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassUsage returns the resources used by a VM of the class.
+func ClassUsage(vmClass *vmopv1.VirtualMachineClass) corev1.ResourceList {
+	hw := vmClass.Spec.Hardware
+	res := vmClass.Spec.Policies.Resources
+
+	instanceStorage := resource.NewQuantity(0, resource.BinarySI)
+	for _, vol := range hw.InstanceStorage.Volumes {
+		instanceStorage.Add(vol.Size)
+	}
+
+	return corev1.ResourceList{
+		vmopv1.VirtualMachineResourceCPU:             *resource.NewQuantity(hw.Cpus, resource.DecimalSI),
+		vmopv1.VirtualMachineResourceMemory:          hw.Memory.DeepCopy(),
+		vmopv1.VirtualMachineResourceRequestsCPU:     res.Requests.Cpu.DeepCopy(),
+		vmopv1.VirtualMachineResourceRequestsMemory:  res.Requests.Memory.DeepCopy(),
+		vmopv1.VirtualMachineResourceLimitsCPU:       res.Limits.Cpu.DeepCopy(),
+		vmopv1.VirtualMachineResourceLimitsMemory:    res.Limits.Memory.DeepCopy(),
+		vmopv1.VirtualMachineResourceVGPUs:           *resource.NewQuantity(int64(len(hw.Devices.VGPUDevices)), resource.DecimalSI),
+		vmopv1.VirtualMachineResourceInstanceStorage: *instanceStorage,
+	}
+}
+
+// NamespaceUsage returns the resources used by the VMs in the namespace. A VM whose
+// VirtualMachineClass does not exist does not use any resources.
+func NamespaceUsage(
+	ctx context.Context,
+	client ctrlclient.Client,
+	namespace string) (corev1.ResourceList, error) {
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := client.List(ctx, vmList, ctrlclient.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	used := corev1.ResourceList{}
+	classUsage := map[string]corev1.ResourceList{}
+
+	for _, vm := range vmList.Items {
+		if vm.Spec.ClassName == "" {
+			continue
+		}
+
+		usage, ok := classUsage[vm.Spec.ClassName]
+		if !ok {
+			vmClass := &vmopv1.VirtualMachineClass{}
+			key := ctrlclient.ObjectKey{Name: vm.Spec.ClassName, Namespace: namespace}
+			if err := client.Get(ctx, key, vmClass); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return nil, err
+				}
+			} else {
+				usage = ClassUsage(vmClass)
+			}
+			classUsage[vm.Spec.ClassName] = usage
+		}
+
+		used = Add(used, usage)
+	}
+
+	return used, nil
+}
+
+// Add returns the sum of the resources in a and b.
+func Add(a, b corev1.ResourceList) corev1.ResourceList {
+	sum := corev1.ResourceList{}
+	for name, q := range a {
+		sum[name] = q.DeepCopy()
+	}
+	for name, q := range b {
+		if s, ok := sum[name]; ok {
+			s.Add(q)
+			sum[name] = s
+		} else {
+			sum[name] = q.DeepCopy()
+		}
+	}
+	return sum
+}
+
+// Subtract returns the resources in a less the resources in b.
+func Subtract(a, b corev1.ResourceList) corev1.ResourceList {
+	diff := corev1.ResourceList{}
+	for name, q := range a {
+		diff[name] = q.DeepCopy()
+	}
+	for name, q := range b {
+		d := diff[name]
+		d.Sub(q)
+		diff[name] = d
+	}
+	return diff
+}
+
+// Exceeded returns the names, in sorted order, of the resources in hard that the
+// requested resources increase beyond the limit when added to the used resources.
+func Exceeded(hard, used, requested corev1.ResourceList) []corev1.ResourceName {
+	var exceeded []corev1.ResourceName
+	for name, limit := range hard {
+		req, ok := requested[name]
+		if !ok || req.Sign() <= 0 {
+			continue
+		}
+
+		total := req.DeepCopy()
+		if u, ok := used[name]; ok {
+			total.Add(u)
+		}
+		if total.Cmp(limit) > 0 {
+			exceeded = append(exceeded, name)
+		}
+	}
+
+	sort.Slice(exceeded, func(i, j int) bool { return exceeded[i] < exceeded[j] })
+	return exceeded
+}
+
+// FormatResources returns the named resources as a sorted, comma separated list
+// of name=quantity pairs, for example "cpu=4,memory=8Gi".
+func FormatResources(list corev1.ResourceList, names []corev1.ResourceName) string {
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		q := list[name]
+		parts = append(parts, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/quota"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("ClassUsage", func() {
+
+	It("returns the usage of a VM of the class", func() {
+		vmClass := builder.DummyVirtualMachineClassA2()
+		vmClass.Spec.Hardware.Devices.VGPUDevices = []vmopv1.VGPUDevice{{ProfileName: "profile-a"}}
+		vmClass.Spec.Hardware.InstanceStorage = builder.DummyInstanceStorageA2()
+
+		usage := quota.ClassUsage(vmClass)
+		Expect(usage).To(HaveLen(len(quota.SupportedResources)))
+
+		expected := map[corev1.ResourceName]string{
+			vmopv1.VirtualMachineResourceCPU:             "2",
+			vmopv1.VirtualMachineResourceMemory:          "4Gi",
+			vmopv1.VirtualMachineResourceRequestsCPU:     "1Gi",
+			vmopv1.VirtualMachineResourceRequestsMemory:  "2Gi",
+			vmopv1.VirtualMachineResourceLimitsCPU:       "2Gi",
+			vmopv1.VirtualMachineResourceLimitsMemory:    "4Gi",
+			vmopv1.VirtualMachineResourceVGPUs:           "1",
+			vmopv1.VirtualMachineResourceInstanceStorage: "768Gi",
+		}
+		for name, value := range expected {
+			q := usage[name]
+			Expect(q.Cmp(resource.MustParse(value))).To(BeZero(), string(name))
+		}
+	})
+})
+
+var _ = Describe("NamespaceUsage", func() {
+	const ns = "quota-ns"
+
+	var (
+		ctx         context.Context
+		client      ctrlclient.Client
+		initObjects []ctrlclient.Object
+	)
+
+	newVM := func(name, className string) *vmopv1.VirtualMachine {
+		vm := builder.DummyBasicVirtualMachineA2(name, ns)
+		vm.Spec.ClassName = className
+		return vm
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		vmClass := builder.DummyVirtualMachineClassA2()
+		vmClass.Name = "small"
+		vmClass.Namespace = ns
+		initObjects = []ctrlclient.Object{
+			vmClass,
+			newVM("vm-1", "small"),
+			newVM("vm-2", "small"),
+			newVM("vm-3", "does-not-exist"),
+			newVM("vm-4", ""),
+		}
+	})
+
+	JustBeforeEach(func() {
+		client = builder.NewFakeClient(initObjects...)
+	})
+
+	AfterEach(func() {
+		initObjects = nil
+	})
+
+	It("sums the usage of the VMs with an existing class", func() {
+		used, err := quota.NamespaceUsage(ctx, client, ns)
+		Expect(err).ToNot(HaveOccurred())
+
+		cpu := used[vmopv1.VirtualMachineResourceCPU]
+		Expect(cpu.Value()).To(BeEquivalentTo(4))
+		memory := used[vmopv1.VirtualMachineResourceMemory]
+		Expect(memory.Cmp(resource.MustParse("8Gi"))).To(BeZero())
+	})
+
+	It("returns no usage for another namespace", func() {
+		used, err := quota.NamespaceUsage(ctx, client, "other-ns")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(used).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Add and Subtract", func() {
+
+	It("adds and subtracts the resources", func() {
+		a := corev1.ResourceList{
+			vmopv1.VirtualMachineResourceCPU:    resource.MustParse("4"),
+			vmopv1.VirtualMachineResourceMemory: resource.MustParse("8Gi"),
+		}
+		b := corev1.ResourceList{
+			vmopv1.VirtualMachineResourceCPU:   resource.MustParse("2"),
+			vmopv1.VirtualMachineResourceVGPUs: resource.MustParse("1"),
+		}
+
+		sum := quota.Add(a, b)
+		Expect(sum).To(HaveLen(3))
+		cpu := sum[vmopv1.VirtualMachineResourceCPU]
+		Expect(cpu.Value()).To(BeEquivalentTo(6))
+
+		diff := quota.Subtract(a, b)
+		Expect(diff).To(HaveLen(3))
+		cpu = diff[vmopv1.VirtualMachineResourceCPU]
+		Expect(cpu.Value()).To(BeEquivalentTo(2))
+		vgpus := diff[vmopv1.VirtualMachineResourceVGPUs]
+		Expect(vgpus.Value()).To(BeEquivalentTo(-1))
+	})
+})
+
+var _ = Describe("Exceeded", func() {
+	var (
+		hard, used, requested corev1.ResourceList
+	)
+
+	BeforeEach(func() {
+		hard = corev1.ResourceList{
+			vmopv1.VirtualMachineResourceCPU:    resource.MustParse("4"),
+			vmopv1.VirtualMachineResourceMemory: resource.MustParse("8Gi"),
+			vmopv1.VirtualMachineResourceVGPUs:  resource.MustParse("0"),
+		}
+		used = corev1.ResourceList{
+			vmopv1.VirtualMachineResourceCPU:    resource.MustParse("2"),
+			vmopv1.VirtualMachineResourceMemory: resource.MustParse("4Gi"),
+		}
+		requested = corev1.ResourceList{
+			vmopv1.VirtualMachineResourceCPU:    resource.MustParse("2"),
+			vmopv1.VirtualMachineResourceMemory: resource.MustParse("4Gi"),
+			vmopv1.VirtualMachineResourceVGPUs:  resource.MustParse("0"),
+		}
+	})
+
+	It("returns nothing when the request fits", func() {
+		Expect(quota.Exceeded(hard, used, requested)).To(BeEmpty())
+	})
+
+	It("returns the sorted names of the exceeded resources", func() {
+		requested[vmopv1.VirtualMachineResourceCPU] = resource.MustParse("3")
+		requested[vmopv1.VirtualMachineResourceMemory] = resource.MustParse("5Gi")
+		requested[vmopv1.VirtualMachineResourceVGPUs] = resource.MustParse("1")
+		Expect(quota.Exceeded(hard, used, requested)).To(Equal([]corev1.ResourceName{
+			vmopv1.VirtualMachineResourceCPU,
+			vmopv1.VirtualMachineResourceMemory,
+			vmopv1.VirtualMachineResourceVGPUs,
+		}))
+	})
+
+	It("ignores the resources that are already over the limit but not requested", func() {
+		used[vmopv1.VirtualMachineResourceCPU] = resource.MustParse("8")
+		delete(requested, vmopv1.VirtualMachineResourceCPU)
+		Expect(quota.Exceeded(hard, used, requested)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("FormatResources", func() {
+
+	It("formats the named resources", func() {
+		list := corev1.ResourceList{
+			vmopv1.VirtualMachineResourceMemory: resource.MustParse("8Gi"),
+			vmopv1.VirtualMachineResourceCPU:    resource.MustParse("4"),
+		}
+		names := []corev1.ResourceName{vmopv1.VirtualMachineResourceMemory, vmopv1.VirtualMachineResourceCPU}
+		Expect(quota.FormatResources(list, names)).To(Equal("cpu=4,memory=8Gi"))
+	})
+})
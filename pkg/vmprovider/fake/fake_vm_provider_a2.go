@@ -11,6 +11,8 @@ import (
 	"github.com/vmware/govmomi/vapi/library"
 	vimTypes "github.com/vmware/govmomi/vim25/types"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -35,6 +37,18 @@ type funcsA2 struct {
 	GetVirtualMachineGuestInfoFn       func(ctx context.Context, vm *vmopv1.VirtualMachine) (map[string]string, error)
 	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
 	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (int32, error)
+	RestartVirtualMachineFn            func(ctx context.Context, vm *vmopv1.VirtualMachine) error
+	ExpandVirtualMachineDiskFn         func(ctx context.Context, vm *vmopv1.VirtualMachine, diskUUID string, capacity resource.Quantity) error
+	CreateSnapshotFn                   func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	DeleteSnapshotFn                   func(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error
+	RunGuestCommandFn                  func(ctx context.Context, vm *vmopv1.VirtualMachine, guestCmd *vmopv1.VirtualMachineGuestCommand) error
+	RunGuestProbeCommandFn             func(ctx context.Context, vm *vmopv1.VirtualMachine, action *vmopv1.GuestExecAction) (int32, error)
+	CopyFileToGuestFn                  func(ctx context.Context, vm *vmopv1.VirtualMachine, fileTransfer *vmopv1.VirtualMachineGuestFileTransfer, content []byte) error
+	CopyFileFromGuestFn                func(ctx context.Context, vm *vmopv1.VirtualMachine, fileTransfer *vmopv1.VirtualMachineGuestFileTransfer, sizeLimit int64) ([]byte, int64, error)
+	WatchVirtualMachinesFn             func(ctx context.Context, onUpdate func(moIDs []string)) error
+	PlaceVirtualMachineFn              func(ctx context.Context, placementReq *vmopv1.VirtualMachinePlacementRequest) error
+
+	ComputeVirtualMachineClassCompatibilityFn func(ctx context.Context, vmClass *vmopv1.VirtualMachineClass) error
 
 	// ListItemsFromContentLibraryFn              func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider) ([]string, error)
 	// GetVirtualMachineImageFromContentLibraryFn func(ctx context.Context, contentLibrary *vmopv1.ContentLibraryProvider, itemID string,
@@ -149,6 +163,116 @@ func (s *VMProviderA2) GetVirtualMachineHardwareVersion(ctx context.Context, vm
 	return 15, nil
 }
 
+func (s *VMProviderA2) RestartVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.RestartVirtualMachineFn != nil {
+		return s.RestartVirtualMachineFn(ctx, vm)
+	}
+	return nil
+}
+
+func (s *VMProviderA2) ExpandVirtualMachineDisk(ctx context.Context, vm *vmopv1.VirtualMachine, diskUUID string, capacity resource.Quantity) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.ExpandVirtualMachineDiskFn != nil {
+		return s.ExpandVirtualMachineDiskFn(ctx, vm, diskUUID, capacity)
+	}
+	return nil
+}
+
+func (s *VMProviderA2) CreateSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.CreateSnapshotFn != nil {
+		return s.CreateSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	vmSnapshot.Status.UniqueID = "snapshot-" + vmSnapshot.Name
+	return nil
+}
+
+func (s *VMProviderA2) DeleteSnapshot(ctx context.Context, vm *vmopv1.VirtualMachine, vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.DeleteSnapshotFn != nil {
+		return s.DeleteSnapshotFn(ctx, vm, vmSnapshot)
+	}
+	return nil
+}
+
+func (s *VMProviderA2) RunGuestCommand(ctx context.Context, vm *vmopv1.VirtualMachine, guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.RunGuestCommandFn != nil {
+		return s.RunGuestCommandFn(ctx, vm, guestCmd)
+	}
+	now := metav1.Now()
+	exitCode := int32(0)
+	guestCmd.Status.PID = 1
+	guestCmd.Status.StartTime = &now
+	guestCmd.Status.CompletionTime = &now
+	guestCmd.Status.ExitCode = &exitCode
+	return nil
+}
+
+func (s *VMProviderA2) RunGuestProbeCommand(ctx context.Context, vm *vmopv1.VirtualMachine, action *vmopv1.GuestExecAction) (int32, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.RunGuestProbeCommandFn != nil {
+		return s.RunGuestProbeCommandFn(ctx, vm, action)
+	}
+	return 0, nil
+}
+
+func (s *VMProviderA2) CopyFileToGuest(ctx context.Context, vm *vmopv1.VirtualMachine, fileTransfer *vmopv1.VirtualMachineGuestFileTransfer, content []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.CopyFileToGuestFn != nil {
+		return s.CopyFileToGuestFn(ctx, vm, fileTransfer, content)
+	}
+	return nil
+}
+
+func (s *VMProviderA2) CopyFileFromGuest(ctx context.Context, vm *vmopv1.VirtualMachine, fileTransfer *vmopv1.VirtualMachineGuestFileTransfer, sizeLimit int64) ([]byte, int64, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.CopyFileFromGuestFn != nil {
+		return s.CopyFileFromGuestFn(ctx, vm, fileTransfer, sizeLimit)
+	}
+	return nil, 0, nil
+}
+
+func (s *VMProviderA2) WatchVirtualMachines(ctx context.Context, onUpdate func(moIDs []string)) error {
+	// Do not hold the lock while watching since this blocks until the context is cancelled.
+	s.Lock()
+	watchFn := s.WatchVirtualMachinesFn
+	s.Unlock()
+
+	if watchFn != nil {
+		return watchFn(ctx, onUpdate)
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (s *VMProviderA2) PlaceVirtualMachine(ctx context.Context, placementReq *vmopv1.VirtualMachinePlacementRequest) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.PlaceVirtualMachineFn != nil {
+		return s.PlaceVirtualMachineFn(ctx, placementReq)
+	}
+	return nil
+}
+
+func (s *VMProviderA2) ComputeVirtualMachineClassCompatibility(ctx context.Context, vmClass *vmopv1.VirtualMachineClass) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.ComputeVirtualMachineClassCompatibilityFn != nil {
+		return s.ComputeVirtualMachineClassCompatibilityFn(ctx, vmClass)
+	}
+	return nil
+}
+
 func (s *VMProviderA2) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	s.Lock()
 	defer s.Unlock()
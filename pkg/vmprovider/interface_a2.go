@@ -8,6 +8,7 @@ import (
 
 	"github.com/vmware/govmomi/vapi/library"
 	vimTypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -25,6 +26,18 @@ type VirtualMachineProviderInterfaceA2 interface {
 	GetVirtualMachineGuestInfo(ctx context.Context, vm *v1alpha2.VirtualMachine) (map[string]string, error)
 	GetVirtualMachineWebMKSTicket(ctx context.Context, vm *v1alpha2.VirtualMachine, pubKey string) (string, error)
 	GetVirtualMachineHardwareVersion(ctx context.Context, vm *v1alpha2.VirtualMachine) (int32, error)
+	RestartVirtualMachine(ctx context.Context, vm *v1alpha2.VirtualMachine) error
+	ExpandVirtualMachineDisk(ctx context.Context, vm *v1alpha2.VirtualMachine, diskUUID string, capacity resource.Quantity) error
+	CreateSnapshot(ctx context.Context, vm *v1alpha2.VirtualMachine, vmSnapshot *v1alpha2.VirtualMachineSnapshot) error
+	DeleteSnapshot(ctx context.Context, vm *v1alpha2.VirtualMachine, vmSnapshot *v1alpha2.VirtualMachineSnapshot) error
+	RunGuestCommand(ctx context.Context, vm *v1alpha2.VirtualMachine, guestCmd *v1alpha2.VirtualMachineGuestCommand) error
+	RunGuestProbeCommand(ctx context.Context, vm *v1alpha2.VirtualMachine, action *v1alpha2.GuestExecAction) (int32, error)
+	CopyFileToGuest(ctx context.Context, vm *v1alpha2.VirtualMachine, fileTransfer *v1alpha2.VirtualMachineGuestFileTransfer, content []byte) error
+	CopyFileFromGuest(ctx context.Context, vm *v1alpha2.VirtualMachine, fileTransfer *v1alpha2.VirtualMachineGuestFileTransfer, sizeLimit int64) ([]byte, int64, error)
+	WatchVirtualMachines(ctx context.Context, onUpdate func(moIDs []string)) error
+	PlaceVirtualMachine(ctx context.Context, placementReq *v1alpha2.VirtualMachinePlacementRequest) error
+
+	ComputeVirtualMachineClassCompatibility(ctx context.Context, vmClass *v1alpha2.VirtualMachineClass) error
 
 	CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *v1alpha2.VirtualMachineSetResourcePolicy) error
 	IsVirtualMachineSetResourcePolicyReady(ctx context.Context, availabilityZoneName string, resourcePolicy *v1alpha2.VirtualMachineSetResourcePolicy) (bool, error)
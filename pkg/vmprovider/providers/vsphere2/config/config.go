@@ -5,6 +5,7 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
 	"strings"
 
@@ -42,6 +43,30 @@ type VSphereVMProviderConfig struct {
 	Network   string
 }
 
+// VCenterConfig represents an additional vCenter in the provider ConfigMap. The VMs in its zones
+// or namespaces are managed with a separate client to this vCenter instead of the default vCenter
+// from the VcPNID key. A zone takes precedence over a namespace. All the zones of a namespace must
+// be in the same vCenter since the namespace has one Folder.
+type VCenterConfig struct {
+	// Name identifies the vCenter.
+	Name string `json:"name"`
+	// VcPNID and VcPort are the address of the vCenter. The port defaults to DefaultVCPort.
+	VcPNID string `json:"vcPNID"`
+	VcPort string `json:"vcPort,omitempty"`
+	// VcCredsSecretName is the name of the Secret in the VM Operator namespace with the
+	// credentials for the vCenter.
+	VcCredsSecretName string `json:"vcCredsSecretName"`
+	// Datacenter is the MoID of the Datacenter in the vCenter.
+	Datacenter string `json:"datacenter"`
+	// ResourcePool and Folder are the MoIDs used instead of the ResourcePool and Folder keys when
+	// the vCenter does not have zones.
+	ResourcePool string `json:"resourcePool,omitempty"`
+	Folder       string `json:"folder,omitempty"`
+	// Zones and Namespaces are the availability zones and the namespaces whose VMs are in the vCenter.
+	Zones      []string `json:"zones,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
 const (
 	DefaultVCPort = "443"
 
@@ -59,6 +84,7 @@ const (
 	useInventoryKey          = "UseInventoryAsContentSource"
 	insecureSkipTLSVerifyKey = "InsecureSkipTLSVerify"
 	caFilePathKey            = "CAFilePath"
+	vCentersKey              = "VCenters"
 	ContentSourceKey         = "ContentSource"
 
 	NetworkConfigMapName = "vmoperator-network-config"
@@ -134,6 +160,71 @@ func ConfigMapToProviderConfig( //nolint: revive // Ignore linter error about st
 	return ret, nil
 }
 
+// ConfigMapToVCenterConfigs converts the VCenters key in the VM provider ConfigMap, a JSON
+// list of VCenterConfig, to the configs of the additional vCenters.
+func ConfigMapToVCenterConfigs(configMap *corev1.ConfigMap) ([]VCenterConfig, error) {
+	data, ok := configMap.Data[vCentersKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	var vCenters []VCenterConfig
+	if err := json.Unmarshal([]byte(data), &vCenters); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse value of %s", vCentersKey)
+	}
+
+	names := map[string]struct{}{}
+	for i := range vCenters {
+		vc := &vCenters[i]
+
+		if vc.Name == "" {
+			return nil, errors.Errorf("%s entry %d is missing the name", vCentersKey, i)
+		}
+		if _, ok := names[vc.Name]; ok {
+			return nil, errors.Errorf("%s has duplicate vCenter name %q", vCentersKey, vc.Name)
+		}
+		names[vc.Name] = struct{}{}
+
+		if vc.VcPNID == "" {
+			return nil, errors.Errorf("%s vCenter %q is missing the vcPNID", vCentersKey, vc.Name)
+		}
+		if vc.VcCredsSecretName == "" {
+			return nil, errors.Errorf("%s vCenter %q is missing the vcCredsSecretName", vCentersKey, vc.Name)
+		}
+		if vc.VcPort == "" {
+			vc.VcPort = DefaultVCPort
+		}
+	}
+
+	return vCenters, nil
+}
+
+// VCenterNameForZoneAndNamespace returns the name of the vCenter of the zone, or the namespace
+// if no vCenter has the zone. An empty name is returned for the default vCenter.
+func VCenterNameForZoneAndNamespace(vCenters []VCenterConfig, zone, namespace string) string {
+	if zone != "" {
+		for _, vc := range vCenters {
+			for _, z := range vc.Zones {
+				if z == zone {
+					return vc.Name
+				}
+			}
+		}
+	}
+
+	if namespace != "" {
+		for _, vc := range vCenters {
+			for _, ns := range vc.Namespaces {
+				if ns == namespace {
+					return vc.Name
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
 func configMapToProviderCredentials(
 	client ctrlruntime.Client,
 	configMap *corev1.ConfigMap) (*credentials.VSphereVMProviderCredentials, error) {
@@ -228,6 +319,89 @@ func GetProviderConfig(
 	return providerConfig, nil
 }
 
+// GetVCenterConfigs returns the configs of the additional vCenters in the vSphere Provider ConfigMap.
+func GetVCenterConfigs(
+	ctx context.Context,
+	client ctrlruntime.Client) ([]VCenterConfig, error) {
+
+	configMap, err := getProviderConfigMap(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConfigMapToVCenterConfigs(configMap)
+}
+
+// GetProviderConfigForVCenter returns a provider config for the named vCenter. The config of
+// an additional vCenter has the address, credentials, and Datacenter of that vCenter, and the
+// other settings of the default vCenter. An empty name returns the default vCenter's config.
+func GetProviderConfigForVCenter(
+	ctx context.Context,
+	client ctrlruntime.Client,
+	vcName string) (*VSphereVMProviderConfig, error) {
+
+	if vcName == "" {
+		return GetProviderConfig(ctx, client)
+	}
+
+	configMap, err := getProviderConfigMap(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	vCenters, err := ConfigMapToVCenterConfigs(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	var vc *VCenterConfig
+	for i := range vCenters {
+		if vCenters[i].Name == vcName {
+			vc = &vCenters[i]
+			break
+		}
+	}
+	if vc == nil {
+		return nil, errors.Errorf("vCenter %q not found in the %s key of the provider ConfigMap", vcName, vCentersKey)
+	}
+
+	vcCreds, err := credentials.GetProviderCredentials(client, configMap.Namespace, vc.VcCredsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfig, err := ConfigMapToProviderConfig(configMap, vcCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfig.VcPNID = vc.VcPNID
+	providerConfig.VcPort = vc.VcPort
+	providerConfig.Datacenter = vc.Datacenter
+	providerConfig.ResourcePool = vc.ResourcePool
+	providerConfig.Folder = vc.Folder
+	// These refer to objects in the default vCenter.
+	providerConfig.Datastore = ""
+	providerConfig.Network = ""
+
+	return providerConfig, nil
+}
+
+// SetVCenterConfigsInConfigMap sets the VCenters key of the ConfigMap.
+// Used only in testing.
+func SetVCenterConfigsInConfigMap(configMap *corev1.ConfigMap, vCenters []VCenterConfig) error {
+	data, err := json.Marshal(vCenters)
+	if err != nil {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[vCentersKey] = string(data)
+	return nil
+}
+
 func setConfigMapData(configMap *corev1.ConfigMap, config *VSphereVMProviderConfig, vcCredsSecretName string) {
 	if configMap.Data == nil {
 		configMap.Data = map[string]string{}
@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/credentials"
@@ -64,6 +65,74 @@ func configTests() {
 		})
 	})
 
+	Describe("GetProviderConfigForVCenter", func() {
+
+		var (
+			vCenter config.VCenterConfig
+		)
+
+		BeforeEach(func() {
+			vCenter = config.VCenterConfig{
+				Name:              "other-vc",
+				VcPNID:            "other-vc.vmware.com",
+				VcCredsSecretName: "vmop-vcsim-dummy-creds",
+				Datacenter:        "datacenter-43",
+				ResourcePool:      "resourcepool-43",
+				Namespaces:        []string{"other-ns"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			configMap := &corev1.ConfigMap{}
+			configMapKey := client.ObjectKey{Name: config.ProviderConfigMapName, Namespace: ctx.PodNamespace}
+			Expect(ctx.Client.Get(ctx, configMapKey, configMap)).To(Succeed())
+			Expect(config.SetVCenterConfigsInConfigMap(configMap, []config.VCenterConfig{vCenter})).To(Succeed())
+			Expect(ctx.Client.Update(ctx, configMap)).To(Succeed())
+		})
+
+		It("returns the default vCenter's config for an empty name", func() {
+			defaultConfig, err := config.GetProviderConfig(ctx, ctx.Client)
+			Expect(err).ToNot(HaveOccurred())
+
+			providerConfig, err := config.GetProviderConfigForVCenter(ctx, ctx.Client, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(providerConfig).To(Equal(defaultConfig))
+		})
+
+		It("returns the vCenter's config", func() {
+			defaultConfig, err := config.GetProviderConfig(ctx, ctx.Client)
+			Expect(err).ToNot(HaveOccurred())
+
+			providerConfig, err := config.GetProviderConfigForVCenter(ctx, ctx.Client, vCenter.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(providerConfig.VcPNID).To(Equal(vCenter.VcPNID))
+			Expect(providerConfig.VcPort).To(Equal(config.DefaultVCPort))
+			Expect(providerConfig.VcCreds).To(Equal(defaultConfig.VcCreds))
+			Expect(providerConfig.Datacenter).To(Equal(vCenter.Datacenter))
+			Expect(providerConfig.ResourcePool).To(Equal(vCenter.ResourcePool))
+			Expect(providerConfig.CAFilePath).To(Equal(defaultConfig.CAFilePath))
+			Expect(providerConfig.StorageClassRequired).To(Equal(defaultConfig.StorageClassRequired))
+		})
+
+		It("returns an error for an unknown vCenter", func() {
+			providerConfig, err := config.GetProviderConfigForVCenter(ctx, ctx.Client, "bogus")
+			Expect(err).To(MatchError(ContainSubstring(`vCenter "bogus" not found`)))
+			Expect(providerConfig).To(BeNil())
+		})
+
+		Context("when the vCenter's secret doesn't exist", func() {
+			BeforeEach(func() {
+				vCenter.VcCredsSecretName = "bogus"
+			})
+
+			It("returns an error", func() {
+				providerConfig, err := config.GetProviderConfigForVCenter(ctx, ctx.Client, vCenter.Name)
+				Expect(err).To(HaveOccurred())
+				Expect(providerConfig).To(BeNil())
+			})
+		})
+	})
+
 	Describe("UpdateVcInConfigMap", func() {
 
 		Context("UpdateVcInConfigMap", func() {
@@ -208,3 +277,86 @@ var _ = Describe("ConfigMapToProviderConfig", func() {
 		})
 	})
 })
+
+var _ = Describe("ConfigMapToVCenterConfigs", func() {
+
+	var (
+		configMap *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		configMap = config.ProviderConfigToConfigMap("dummy-ns", &config.VSphereVMProviderConfig{VcPNID: "my-vc.vmware.com"}, "dummy-secrets")
+	})
+
+	It("returns no vCenters when the VCenters key is unset", func() {
+		vCenters, err := config.ConfigMapToVCenterConfigs(configMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vCenters).To(BeEmpty())
+	})
+
+	It("returns the vCenters with the default port", func() {
+		configMap.Data["VCenters"] = `[{"name": "vc-1", "vcPNID": "vc-1.vmware.com", "vcCredsSecretName": "vc-1-creds", "zones": ["zone-1"]},
+			{"name": "vc-2", "vcPNID": "vc-2.vmware.com", "vcPort": "8443", "vcCredsSecretName": "vc-2-creds", "namespaces": ["ns-2"]}]`
+
+		vCenters, err := config.ConfigMapToVCenterConfigs(configMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vCenters).To(Equal([]config.VCenterConfig{
+			{
+				Name:              "vc-1",
+				VcPNID:            "vc-1.vmware.com",
+				VcPort:            config.DefaultVCPort,
+				VcCredsSecretName: "vc-1-creds",
+				Zones:             []string{"zone-1"},
+			},
+			{
+				Name:              "vc-2",
+				VcPNID:            "vc-2.vmware.com",
+				VcPort:            "8443",
+				VcCredsSecretName: "vc-2-creds",
+				Namespaces:        []string{"ns-2"},
+			},
+		}))
+	})
+
+	DescribeTable("returns an error for an invalid VCenters key",
+		func(data, expectedErr string) {
+			configMap.Data["VCenters"] = data
+			_, err := config.ConfigMapToVCenterConfigs(configMap)
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("not JSON", "bogus", "unable to parse value of VCenters"),
+		Entry("missing name", `[{"vcPNID": "vc-1.vmware.com", "vcCredsSecretName": "vc-1-creds"}]`, "missing the name"),
+		Entry("missing PNID", `[{"name": "vc-1", "vcCredsSecretName": "vc-1-creds"}]`, "missing the vcPNID"),
+		Entry("missing secret", `[{"name": "vc-1", "vcPNID": "vc-1.vmware.com"}]`, "missing the vcCredsSecretName"),
+		Entry("duplicate name", `[{"name": "vc-1", "vcPNID": "vc-1.vmware.com", "vcCredsSecretName": "vc-1-creds"},
+			{"name": "vc-1", "vcPNID": "vc-2.vmware.com", "vcCredsSecretName": "vc-2-creds"}]`, `duplicate vCenter name "vc-1"`),
+	)
+})
+
+var _ = Describe("VCenterNameForZoneAndNamespace", func() {
+
+	vCenters := []config.VCenterConfig{
+		{
+			Name:       "vc-1",
+			Zones:      []string{"zone-1"},
+			Namespaces: []string{"ns-1"},
+		},
+		{
+			Name:       "vc-2",
+			Zones:      []string{"zone-2"},
+			Namespaces: []string{"ns-2"},
+		},
+	}
+
+	DescribeTable("returns the name of the vCenter",
+		func(zone, namespace, expectedName string) {
+			Expect(config.VCenterNameForZoneAndNamespace(vCenters, zone, namespace)).To(Equal(expectedName))
+		},
+		Entry("zone", "zone-2", "", "vc-2"),
+		Entry("namespace", "", "ns-1", "vc-1"),
+		Entry("zone takes precedence over the namespace", "zone-2", "ns-1", "vc-2"),
+		Entry("namespace when the zone is not in a vCenter", "zone-3", "ns-2", "vc-2"),
+		Entry("default vCenter", "zone-3", "ns-3", ""),
+		Entry("default vCenter when empty", "", "", ""),
+	)
+})
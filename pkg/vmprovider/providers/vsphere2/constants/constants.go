@@ -50,11 +50,20 @@ const (
 	PCIPassthruMMIOSizeExtraConfigKey = "pciPassthru.64bitMMIOSizeGB" //nolint:gosec
 	PCIPassthruMMIOSizeDefault        = "512"
 
+	// CNSAttachmentDiskUUIDKey is the key of the attached disk's UUID in the attachment
+	// metadata of a CnsNodeVmAttachment's status.
+	CNSAttachmentDiskUUIDKey = "diskUUID"
+
 	// MinSupportedHWVersionForPVC is the supported virtual hardware version for persistent volumes.
 	MinSupportedHWVersionForPVC = 15
 	// MinSupportedHWVersionForPCIPassthruDevices is the supported virtual hardware version for NVidia PCI devices.
 	MinSupportedHWVersionForPCIPassthruDevices = 17
 
+	// AffinityRulesAnnotation is the annotation key set on a VM once DRS VM-VM rules
+	// may have been created for its affinity, so that the rules of VMs without
+	// affinity are not looked up.
+	AffinityRulesAnnotation = pkg.VMOperatorKey + "/affinity-rules"
+
 	// FirmwareOverrideAnnotation is the annotation key used for firmware override.
 	FirmwareOverrideAnnotation = pkg.VMOperatorKey + "/firmware"
 
@@ -0,0 +1,399 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostaffinity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+)
+
+// ruleName returns the name of the VM-Host rule of the resource policy's host affinity, after which
+// its DRS groups are also named. Since a "/" is not allowed in a Namespace or resource policy name,
+// the names of different policies never collide.
+func ruleName(resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) string {
+	return fmt.Sprintf("vmoperator/%s/%s", resourcePolicy.Namespace, resourcePolicy.Name)
+}
+
+func hostGroupName(resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) string {
+	return ruleName(resourcePolicy) + "/hosts"
+}
+
+func vmGroupName(resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) string {
+	return ruleName(resourcePolicy) + "/vms"
+}
+
+// GetSelectedHosts returns the hosts in the cluster whose Nodes are selected by the host affinity.
+// A host's Node is the Node whose name is the host's FQDN.
+func GetSelectedHosts(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	cluster *object.ClusterComputeResource,
+	hostAffinity *vmopv1.VirtualMachineSetResourcePolicyHostAffinity) ([]types.ManagedObjectReference, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(hostAffinity.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host affinity node selector: %w", err)
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := k8sClient.List(ctx, nodeList, ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	if len(nodeList.Items) == 0 {
+		return nil, nil
+	}
+
+	nodeNames := make(map[string]struct{}, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodeNames[node.Name] = struct{}{}
+	}
+
+	var cr mo.ComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"host"}, &cr); err != nil {
+		return nil, err
+	}
+
+	var hosts []types.ManagedObjectReference
+	for _, host := range cr.Host {
+		hostFQDN, err := vcenter.GetESXHostFQDN(ctx, cluster.Client(), host.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := nodeNames[hostFQDN]; ok {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts, nil
+}
+
+// Exists returns true if the host group, VM group, and VM-Host rule of the resource policy's host
+// affinity exist on the cluster, or if the resource policy does not have a host affinity.
+func Exists(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) (bool, error) {
+
+	if resourcePolicy.Spec.HostAffinity == nil {
+		return true, nil
+	}
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	hostGroup, vmGroup := findGroups(config, resourcePolicy)
+	rule := findRule(config, resourcePolicy)
+
+	return hostGroup != nil && vmGroup != nil && rule != nil, nil
+}
+
+// CreateOrUpdate creates or updates the host group of the hosts selected by the resource policy's
+// host affinity, the VM group, and the VM-Host rule between them on the cluster. The VMs already in
+// the VM group are kept.
+func CreateOrUpdate(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
+
+	hostAffinity := resourcePolicy.Spec.HostAffinity
+
+	hosts, err := GetSelectedHosts(ctx, k8sClient, cluster, hostAffinity)
+	if err != nil {
+		return err
+	}
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	hostGroup, vmGroup := findGroups(config, resourcePolicy)
+
+	var groupSpecs []types.ClusterGroupSpec
+
+	if hostGroup == nil {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+			Info: &types.ClusterHostGroup{
+				ClusterGroupInfo: types.ClusterGroupInfo{Name: hostGroupName(resourcePolicy), UserCreated: types.NewBool(true)},
+				Host:             hosts,
+			},
+		})
+	} else if !sameMoRefs(hostGroup.Host, hosts) {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationEdit},
+			Info: &types.ClusterHostGroup{
+				ClusterGroupInfo: hostGroup.ClusterGroupInfo,
+				Host:             hosts,
+			},
+		})
+	}
+
+	if vmGroup == nil {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+			Info: &types.ClusterVmGroup{
+				ClusterGroupInfo: types.ClusterGroupInfo{Name: vmGroupName(resourcePolicy), UserCreated: types.NewBool(true)},
+			},
+		})
+	}
+
+	// The groups must exist before the rule that references them.
+	if len(groupSpecs) != 0 {
+		if err := vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{GroupSpec: groupSpecs}); err != nil {
+			return err
+		}
+	}
+
+	rule := &types.ClusterVmHostRuleInfo{
+		ClusterRuleInfo: types.ClusterRuleInfo{
+			Name:        ruleName(resourcePolicy),
+			Enabled:     types.NewBool(true),
+			Mandatory:   types.NewBool(hostAffinity.Required),
+			UserCreated: types.NewBool(true),
+		},
+		VmGroupName:         vmGroupName(resourcePolicy),
+		AffineHostGroupName: hostGroupName(resourcePolicy),
+	}
+
+	var ruleSpec types.ClusterRuleSpec
+
+	if existingRule := findRule(config, resourcePolicy); existingRule == nil {
+		ruleSpec.Operation = types.ArrayUpdateOperationAdd
+	} else if existingRule.Mandatory == nil || *existingRule.Mandatory != hostAffinity.Required {
+		ruleSpec.Operation = types.ArrayUpdateOperationEdit
+		rule.Key = existingRule.Key
+	} else {
+		return nil
+	}
+	ruleSpec.Info = rule
+
+	return vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{RulesSpec: []types.ClusterRuleSpec{ruleSpec}})
+}
+
+// Delete deletes the VM-Host rule, host group, and VM group of the resource policy's host affinity
+// from the cluster.
+func Delete(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The rule must be deleted before the groups that it references.
+	if rule := findRule(config, resourcePolicy); rule != nil {
+		ruleSpec := types.ClusterRuleSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{
+				Operation: types.ArrayUpdateOperationRemove,
+				RemoveKey: rule.Key,
+			},
+		}
+		if err := vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{RulesSpec: []types.ClusterRuleSpec{ruleSpec}}); err != nil {
+			return err
+		}
+	}
+
+	var groupSpecs []types.ClusterGroupSpec
+	hostGroup, vmGroup := findGroups(config, resourcePolicy)
+	if hostGroup != nil {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{
+				Operation: types.ArrayUpdateOperationRemove,
+				RemoveKey: hostGroup.Name,
+			},
+		})
+	}
+	if vmGroup != nil {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{
+				Operation: types.ArrayUpdateOperationRemove,
+				RemoveKey: vmGroup.Name,
+			},
+		})
+	}
+
+	if len(groupSpecs) == 0 {
+		return nil
+	}
+
+	return vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{GroupSpec: groupSpecs})
+}
+
+// AddVM adds the VM to the VM group of the resource policy's host affinity on the cluster.
+func AddVM(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	vmRef types.ManagedObjectReference) error {
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, vmGroup := findGroups(config, resourcePolicy)
+	if vmGroup == nil {
+		return fmt.Errorf("DRS VM group %s not found", vmGroupName(resourcePolicy))
+	}
+
+	for _, ref := range vmGroup.Vm {
+		if ref.Value == vmRef.Value {
+			return nil
+		}
+	}
+
+	return editVMGroup(ctx, cluster, vmGroup, append(vmGroup.Vm, vmRef))
+}
+
+// RemoveVM removes the VM from the VM group of the resource policy's host affinity on the cluster.
+func RemoveVM(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	vmRef types.ManagedObjectReference) error {
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, vmGroup := findGroups(config, resourcePolicy)
+	if vmGroup == nil {
+		return nil
+	}
+
+	vms := make([]types.ManagedObjectReference, 0, len(vmGroup.Vm))
+	for _, ref := range vmGroup.Vm {
+		if ref.Value != vmRef.Value {
+			vms = append(vms, ref)
+		}
+	}
+
+	if len(vms) == len(vmGroup.Vm) {
+		return nil
+	}
+
+	return editVMGroup(ctx, cluster, vmGroup, vms)
+}
+
+// IsVMCompliant returns true if the VM runs on one of the hosts in the host group of the resource
+// policy's host affinity on the cluster.
+func IsVMCompliant(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	vcVM *object.VirtualMachine) (bool, error) {
+
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	hostGroup, _ := findGroups(config, resourcePolicy)
+	if hostGroup == nil {
+		return false, nil
+	}
+
+	host, err := vcVM.HostSystem(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ref := range hostGroup.Host {
+		if ref.Value == host.Reference().Value {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func findGroups(
+	config *types.ClusterConfigInfoEx,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) (*types.ClusterHostGroup, *types.ClusterVmGroup) {
+
+	var hostGroup *types.ClusterHostGroup
+	var vmGroup *types.ClusterVmGroup
+
+	for _, group := range config.Group {
+		switch g := group.(type) {
+		case *types.ClusterHostGroup:
+			if g.Name == hostGroupName(resourcePolicy) {
+				hostGroup = g
+			}
+		case *types.ClusterVmGroup:
+			if g.Name == vmGroupName(resourcePolicy) {
+				vmGroup = g
+			}
+		}
+	}
+
+	return hostGroup, vmGroup
+}
+
+func findRule(
+	config *types.ClusterConfigInfoEx,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) *types.ClusterVmHostRuleInfo {
+
+	for _, rule := range config.Rule {
+		if r, ok := rule.(*types.ClusterVmHostRuleInfo); ok && r.Name == ruleName(resourcePolicy) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func editVMGroup(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	vmGroup *types.ClusterVmGroup,
+	vms []types.ManagedObjectReference) error {
+
+	groupSpec := types.ClusterGroupSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationEdit},
+		Info: &types.ClusterVmGroup{
+			ClusterGroupInfo: vmGroup.ClusterGroupInfo,
+			Vm:               vms,
+		},
+	}
+
+	return vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{GroupSpec: []types.ClusterGroupSpec{groupSpec}})
+}
+
+func sameMoRefs(a, b []types.ManagedObjectReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	moIDs := make(map[string]struct{}, len(a))
+	for _, ref := range a {
+		moIDs[ref.Value] = struct{}{}
+	}
+	for _, ref := range b {
+		if _, ok := moIDs[ref.Value]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
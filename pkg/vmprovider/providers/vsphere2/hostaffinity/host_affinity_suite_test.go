@@ -0,0 +1,26 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostaffinity_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func vcSimTests() {
+	Describe("Host Affinity", hostAffinityTests)
+}
+
+var suite = builder.NewTestSuite()
+
+func TestHostAffinity(t *testing.T) {
+	suite.Register(t, "vSphere Provider Host Affinity Suite", nil, vcSimTests)
+}
+
+var _ = BeforeSuite(suite.BeforeSuite)
+
+var _ = AfterSuite(suite.AfterSuite)
@@ -0,0 +1,201 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostaffinity_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/hostaffinity"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func hostAffinityTests() {
+
+	var (
+		ctx            *builder.TestContextForVCSim
+		cluster        *object.ClusterComputeResource
+		vcVM           *object.VirtualMachine
+		vmHost         types.ManagedObjectReference
+		otherHosts     []types.ManagedObjectReference
+		resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+	)
+
+	BeforeEach(func() {
+		// Hosts only have a FQDN with instance storage.
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true, WithInstanceStorage: true})
+		cluster = ctx.GetSingleClusterCompute()
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+		host, err := vcVM.HostSystem(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		vmHost = host.Reference()
+
+		var cr mo.ComputeResource
+		Expect(cluster.Properties(ctx, cluster.Reference(), []string{"host"}, &cr)).To(Succeed())
+		Expect(len(cr.Host)).To(BeNumerically(">", 1))
+
+		otherHosts = nil
+		for _, h := range cr.Host {
+			hostFQDN, err := vcenter.GetESXHostFQDN(ctx, ctx.VCClient.Client, h.Value)
+			Expect(err).ToNot(HaveOccurred())
+
+			license := "none"
+			if h.Value == vmHost.Value {
+				license = "db"
+			} else {
+				otherHosts = append(otherHosts, h)
+			}
+
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   hostFQDN,
+					Labels: map[string]string{"license": license},
+				},
+			}
+			Expect(ctx.Client.Create(ctx, node)).To(Succeed())
+		}
+
+		resourcePolicy = builder.DummyVirtualMachineSetResourcePolicy2A2("host-affinity-policy", "host-affinity-ns")
+		resourcePolicy.Spec.HostAffinity = &vmopv1.VirtualMachineSetResourcePolicyHostAffinity{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"license": "db"}},
+			Required:     true,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getConfig := func() *types.ClusterConfigInfoEx {
+		config, err := cluster.Configuration(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		return config
+	}
+
+	getHostGroup := func() *types.ClusterHostGroup {
+		for _, group := range getConfig().Group {
+			if g, ok := group.(*types.ClusterHostGroup); ok && g.Name == "vmoperator/host-affinity-ns/host-affinity-policy/hosts" {
+				return g
+			}
+		}
+		return nil
+	}
+
+	getVMGroup := func() *types.ClusterVmGroup {
+		for _, group := range getConfig().Group {
+			if g, ok := group.(*types.ClusterVmGroup); ok && g.Name == "vmoperator/host-affinity-ns/host-affinity-policy/vms" {
+				return g
+			}
+		}
+		return nil
+	}
+
+	getRule := func() *types.ClusterVmHostRuleInfo {
+		for _, rule := range getConfig().Rule {
+			if r, ok := rule.(*types.ClusterVmHostRuleInfo); ok && r.Name == "vmoperator/host-affinity-ns/host-affinity-policy" {
+				return r
+			}
+		}
+		return nil
+	}
+
+	It("GetSelectedHosts returns the hosts whose Nodes are selected", func() {
+		hosts, err := hostaffinity.GetSelectedHosts(ctx, ctx.Client, cluster, resourcePolicy.Spec.HostAffinity)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hosts).To(ConsistOf(vmHost))
+	})
+
+	It("Exists returns true when the policy does not have a host affinity", func() {
+		resourcePolicy.Spec.HostAffinity = nil
+		exists, err := hostaffinity.Exists(ctx, cluster, resourcePolicy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+	})
+
+	It("Creates, updates, and deletes the DRS groups and rule", func() {
+		exists, err := hostaffinity.Exists(ctx, cluster, resourcePolicy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+
+		exists, err = hostaffinity.Exists(ctx, cluster, resourcePolicy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		hostGroup := getHostGroup()
+		Expect(hostGroup).ToNot(BeNil())
+		Expect(hostGroup.Host).To(ConsistOf(vmHost))
+		Expect(getVMGroup()).ToNot(BeNil())
+
+		rule := getRule()
+		Expect(rule).ToNot(BeNil())
+		Expect(*rule.Mandatory).To(BeTrue())
+		Expect(rule.VmGroupName).To(Equal("vmoperator/host-affinity-ns/host-affinity-policy/vms"))
+		Expect(rule.AffineHostGroupName).To(Equal("vmoperator/host-affinity-ns/host-affinity-policy/hosts"))
+
+		By("Changed node selector updates the host group", func() {
+			resourcePolicy.Spec.HostAffinity.NodeSelector.MatchLabels["license"] = "none"
+			Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+			Expect(getHostGroup().Host).To(ConsistOf(otherHosts))
+		})
+
+		By("Preferred host affinity updates the rule to not be mandatory", func() {
+			resourcePolicy.Spec.HostAffinity.Required = false
+			Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+			Expect(*getRule().Mandatory).To(BeFalse())
+		})
+
+		By("Delete deletes the DRS groups and rule", func() {
+			Expect(hostaffinity.Delete(ctx, cluster, resourcePolicy)).To(Succeed())
+			Expect(getRule()).To(BeNil())
+			Expect(getHostGroup()).To(BeNil())
+			Expect(getVMGroup()).To(BeNil())
+		})
+	})
+
+	It("Adds and removes the VM from the VM group", func() {
+		Expect(hostaffinity.AddVM(ctx, cluster, resourcePolicy, vcVM.Reference())).To(MatchError(ContainSubstring("not found")))
+
+		Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+
+		Expect(hostaffinity.AddVM(ctx, cluster, resourcePolicy, vcVM.Reference())).To(Succeed())
+		Expect(getVMGroup().Vm).To(ConsistOf(vcVM.Reference()))
+
+		By("Adding again does not duplicate the VM", func() {
+			Expect(hostaffinity.AddVM(ctx, cluster, resourcePolicy, vcVM.Reference())).To(Succeed())
+			Expect(getVMGroup().Vm).To(HaveLen(1))
+		})
+
+		Expect(hostaffinity.RemoveVM(ctx, cluster, resourcePolicy, vcVM.Reference())).To(Succeed())
+		Expect(getVMGroup().Vm).To(BeEmpty())
+	})
+
+	It("IsVMCompliant returns if the VM is on a selected host", func() {
+		Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+
+		compliant, err := hostaffinity.IsVMCompliant(ctx, cluster, resourcePolicy, vcVM)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(compliant).To(BeTrue())
+
+		resourcePolicy.Spec.HostAffinity.NodeSelector.MatchLabels["license"] = "none"
+		Expect(hostaffinity.CreateOrUpdate(ctx, ctx.Client, cluster, resourcePolicy)).To(Succeed())
+
+		compliant, err = hostaffinity.IsVMCompliant(ctx, cluster, resourcePolicy, vcVM)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(compliant).To(BeFalse())
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+)
+
+// affinityTermDomains are the zones and clusters of the VMs selected by an affinity term.
+type affinityTermDomains struct {
+	virtualmachine.AffinityTerm
+
+	zones    map[string]struct{}
+	clusters map[string]struct{}
+}
+
+// satisfiedBy returns true if placing the VM in the zone and cluster satisfies the term. A term
+// that does not select any placed VMs is always satisfied. A host topology anti-affinity term is
+// honored by a DRS rule within the cluster so it does not restrict the cluster.
+func (d affinityTermDomains) satisfiedBy(zoneName, clusterMoID string) bool {
+	var inDomain bool
+
+	switch d.TopologyKey {
+	case vmopv1.VirtualMachineAffinityTopologyKeyZone:
+		if len(d.zones) == 0 {
+			return true
+		}
+		_, inDomain = d.zones[zoneName]
+	case vmopv1.VirtualMachineAffinityTopologyKeyHost:
+		if len(d.clusters) == 0 || d.AntiAffinity {
+			return true
+		}
+		_, inDomain = d.clusters[clusterMoID]
+	default:
+		return true
+	}
+
+	return inDomain != d.AntiAffinity
+}
+
+// getAffinityTermDomains returns the zones and clusters of the VMs selected by the VM's
+// affinity terms.
+func getAffinityTermDomains(
+	vmCtx context.VirtualMachineContextA2,
+	client ctrlclient.Client,
+	vcClient *vim25.Client) ([]affinityTermDomains, error) {
+
+	terms := virtualmachine.GetAffinityTerms(vmCtx.VM)
+	termDomains := make([]affinityTermDomains, 0, len(terms))
+
+	for _, term := range terms {
+		vms, err := virtualmachine.GetAffinityTermVMs(vmCtx, client, term)
+		if err != nil {
+			return nil, err
+		}
+
+		d := affinityTermDomains{
+			AffinityTerm: term,
+			zones:        map[string]struct{}{},
+			clusters:     map[string]struct{}{},
+		}
+
+		for _, vm := range vms {
+			switch term.TopologyKey {
+			case vmopv1.VirtualMachineAffinityTopologyKeyZone:
+				if zoneName := vm.Labels[topology.KubernetesTopologyZoneLabelKey]; zoneName != "" {
+					d.zones[zoneName] = struct{}{}
+				}
+			case vmopv1.VirtualMachineAffinityTopologyKeyHost:
+				if vm.Status.UniqueID == "" {
+					continue
+				}
+
+				vcVM := object.NewVirtualMachine(vcClient,
+					types.ManagedObjectReference{Type: "VirtualMachine", Value: vm.Status.UniqueID})
+				cluster, err := virtualmachine.GetVMClusterComputeResource(vmCtx, vcVM)
+				if err != nil {
+					vmCtx.Logger.Error(err, "Skipping VM selected by affinity term since failed to get its cluster",
+						"term", term.Name, "vmName", vm.Name)
+					continue
+				}
+				d.clusters[cluster.Reference().Value] = struct{}{}
+			}
+		}
+
+		termDomains = append(termDomains, d)
+	}
+
+	return termDomains, nil
+}
+
+// filterCandidatesByAffinity returns the candidates that satisfy the VM's required affinity terms,
+// and the subset of those that also satisfy the VM's preferred affinity terms.
+func filterCandidatesByAffinity(
+	vmCtx context.VirtualMachineContextA2,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	candidates map[string][]string) (required map[string][]string, preferred map[string][]string, err error) {
+
+	termDomains, err := getAffinityTermDomains(vmCtx, client, vcClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(termDomains) == 0 {
+		return candidates, candidates, nil
+	}
+
+	var needsCluster bool
+	for _, d := range termDomains {
+		if len(d.clusters) != 0 {
+			needsCluster = true
+			break
+		}
+	}
+
+	required = map[string][]string{}
+	preferred = map[string][]string{}
+
+	for zoneName, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			var clusterMoID string
+			if needsCluster {
+				rpMoRef := types.ManagedObjectReference{Type: "ResourcePool", Value: rpMoID}
+				cluster, err := rpMoIDToCluster(vmCtx, vcClient, rpMoRef)
+				if err != nil {
+					vmCtx.Logger.Error(err, "failed to get CCR from RP", "zone", zoneName, "rpMoID", rpMoID)
+					continue
+				}
+				clusterMoID = cluster.Reference().Value
+			}
+
+			satisfied, satisfiedPreferred := true, true
+			for _, d := range termDomains {
+				if !d.satisfiedBy(zoneName, clusterMoID) {
+					if d.Required {
+						satisfied = false
+						break
+					}
+					satisfiedPreferred = false
+				}
+			}
+
+			if satisfied {
+				required[zoneName] = append(required[zoneName], rpMoID)
+				if satisfiedPreferred {
+					preferred[zoneName] = append(preferred[zoneName], rpMoID)
+				}
+			}
+		}
+	}
+
+	return required, preferred, nil
+}
+
+// candidatesEqual returns true if both candidates have the same ResourcePools in each zone. Since the
+// preferred candidates are a subset of the candidates, only the number of ResourcePools is compared.
+func candidatesEqual(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for zoneName, rpMoIDs := range a {
+		if len(rpMoIDs) != len(b[zoneName]) {
+			return false
+		}
+	}
+
+	return true
+}
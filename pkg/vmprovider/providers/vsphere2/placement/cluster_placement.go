@@ -17,9 +17,10 @@ import (
 
 // Recommendation is the info about a placement recommendation.
 type Recommendation struct {
-	PoolMoRef types.ManagedObjectReference
-	HostMoRef *types.ManagedObjectReference
-	// TODO: Datastore, whatever else as we need it.
+	PoolMoRef      types.ManagedObjectReference
+	HostMoRef      *types.ManagedObjectReference
+	DatastoreMoRef *types.ManagedObjectReference
+	// TODO: Whatever else as we need it.
 }
 
 func relocateSpecToRecommendation(relocateSpec *types.VirtualMachineRelocateSpec) *Recommendation {
@@ -36,11 +37,34 @@ func relocateSpecToRecommendation(relocateSpec *types.VirtualMachineRelocateSpec
 
 func clusterPlacementActionToRecommendation(action types.ClusterClusterInitialPlacementAction) *Recommendation {
 	return &Recommendation{
-		PoolMoRef: action.Pool,
-		HostMoRef: action.TargetHost,
+		PoolMoRef:      action.Pool,
+		HostMoRef:      action.TargetHost,
+		DatastoreMoRef: configSpecDatastore(action.ConfigSpec),
 	}
 }
 
+// configSpecDatastore returns the datastore of the first disk in the ConfigSpec of a
+// placement action. The ConfigSpec is only returned when a datastore recommendation
+// was required.
+func configSpecDatastore(configSpec *types.VirtualMachineConfigSpec) *types.ManagedObjectReference {
+	if configSpec == nil {
+		return nil
+	}
+
+	for _, change := range configSpec.DeviceChange {
+		dspec := change.GetVirtualDeviceConfigSpec()
+		if disk, ok := dspec.Device.(*types.VirtualDisk); ok {
+			if backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo); ok {
+				if ds := backing.GetVirtualDeviceFileBackingInfo().Datastore; ds != nil {
+					return ds
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func CheckPlacementRelocateSpec(spec *types.VirtualMachineRelocateSpec) error {
 	if spec == nil {
 		return fmt.Errorf("RelocateSpec is nil")
@@ -107,15 +131,18 @@ func CloneVMRelocateSpec(
 	return rSpec, nil
 }
 
-// PlaceVMForCreate determines the suitable placement candidates in the cluster.
+// PlaceVMForCreate determines the suitable placement candidates in the cluster. When hosts is not
+// empty, only those hosts in the cluster are considered.
 func PlaceVMForCreate(
 	ctx goctx.Context,
 	cluster *object.ClusterComputeResource,
-	configSpec *types.VirtualMachineConfigSpec) ([]Recommendation, error) {
+	configSpec *types.VirtualMachineConfigSpec,
+	hosts []types.ManagedObjectReference) ([]Recommendation, error) {
 
 	placementSpec := types.PlacementSpec{
 		PlacementType: string(types.PlacementSpecPlacementTypeCreate),
 		ConfigSpec:    configSpec,
+		Hosts:         hosts,
 	}
 
 	resp, err := cluster.PlaceVm(ctx, placementSpec)
@@ -148,7 +175,7 @@ func ClusterPlaceVMForCreate(
 	vcClient *vim25.Client,
 	resourcePoolsMoRefs []types.ManagedObjectReference,
 	configSpec *types.VirtualMachineConfigSpec,
-	needsHost bool) ([]Recommendation, error) {
+	needsHost, needsDatastore bool) ([]Recommendation, error) {
 
 	// Work around PlaceVmsXCluster bug that crashes vpxd when ConfigSpec.Files is nil.
 	cs := *configSpec
@@ -161,7 +188,8 @@ func ClusterPlaceVMForCreate(
 				ConfigSpec: cs,
 			},
 		},
-		HostRecommRequired: &needsHost,
+		HostRecommRequired:      &needsHost,
+		DatastoreRecommRequired: &needsDatastore,
 	}
 
 	vmCtx.Logger.V(6).Info("PlaceVmxCluster request", "placementSpec", placementSpec)
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/hostaffinity"
+)
+
+// filterCandidatesByHostAffinity returns the hosts selected by the host affinity in the cluster of
+// each candidate. When the host affinity is required, the candidates whose cluster does not have any
+// selected hosts are removed.
+func filterCandidatesByHostAffinity(
+	vmCtx context.VirtualMachineContextA2,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	candidates map[string][]string,
+	hostAffinity *vmopv1.VirtualMachineSetResourcePolicyHostAffinity) (map[string][]string, map[string][]types.ManagedObjectReference, error) {
+
+	filtered := map[string][]string{}
+	clusterHosts := map[string][]types.ManagedObjectReference{}
+
+	for zoneName, rpMoIDs := range candidates {
+		for _, rpMoID := range rpMoIDs {
+			rpMoRef := types.ManagedObjectReference{Type: "ResourcePool", Value: rpMoID}
+			cluster, err := rpMoIDToCluster(vmCtx, vcClient, rpMoRef)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get CCR from RP", "zone", zoneName, "rpMoID", rpMoID)
+				continue
+			}
+			clusterMoID := cluster.Reference().Value
+
+			hosts, ok := clusterHosts[clusterMoID]
+			if !ok {
+				hosts, err = hostaffinity.GetSelectedHosts(vmCtx, client, cluster, hostAffinity)
+				if err != nil {
+					return nil, nil, err
+				}
+				clusterHosts[clusterMoID] = hosts
+			}
+
+			if len(hosts) == 0 && hostAffinity.Required {
+				vmCtx.Logger.V(4).Info("Skipping candidate since its cluster has no hosts selected by the host affinity",
+					"zone", zoneName, "clusterMoID", clusterMoID, "rpMoID", rpMoID)
+				continue
+			}
+
+			filtered[zoneName] = append(filtered[zoneName], rpMoID)
+		}
+	}
+
+	return filtered, clusterHosts, nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// inFlightPlacementTimeout is how long the zone selected for a VM is counted before the VM's zone
+// label is observed. The VM is placed again if its zone label is not persisted.
+const inFlightPlacementTimeout = 5 * time.Minute
+
+// inFlightPlacement is the zone selected for a VM whose zone label has not been observed yet.
+type inFlightPlacement struct {
+	zoneName  string
+	timestamp time.Time
+}
+
+// spreadPlacements serializes the placement of the VMs of each resource policy with a topology
+// spread constraint, and records the zones selected for the VMs until their zone labels are
+// observed. Otherwise, VMs that are placed concurrently, or before the labels of the previously
+// placed VMs are in the cache, would not see each other and could be placed in the same zone.
+var spreadPlacements = struct {
+	sync.Mutex
+	locks    map[string]*sync.Mutex
+	inFlight map[string]map[string]inFlightPlacement
+}{
+	locks:    map[string]*sync.Mutex{},
+	inFlight: map[string]map[string]inFlightPlacement{},
+}
+
+func spreadPlacementsKey(resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) string {
+	return resourcePolicy.Namespace + "/" + resourcePolicy.Name
+}
+
+// lockSpreadPlacement locks the placement of the VMs of the resource policy, and returns the
+// function that unlocks it.
+func lockSpreadPlacement(resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) func() {
+	key := spreadPlacementsKey(resourcePolicy)
+
+	spreadPlacements.Lock()
+	lock, ok := spreadPlacements.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		spreadPlacements.locks[key] = lock
+	}
+	spreadPlacements.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// addInFlightPlacement records the zone selected for the VM of the resource policy.
+func addInFlightPlacement(
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	vmName, zoneName string) {
+
+	key := spreadPlacementsKey(resourcePolicy)
+
+	spreadPlacements.Lock()
+	defer spreadPlacements.Unlock()
+
+	if spreadPlacements.inFlight[key] == nil {
+		spreadPlacements.inFlight[key] = map[string]inFlightPlacement{}
+	}
+	spreadPlacements.inFlight[key][vmName] = inFlightPlacement{zoneName: zoneName, timestamp: time.Now()}
+}
+
+// getInFlightPlacements returns the zones selected for the VMs of the resource policy whose zone
+// labels are not in the list of VMs. The records of the VMs whose zone labels are in the list, that
+// no longer exist, or that have timed out are removed.
+func getInFlightPlacements(
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	vms []vmopv1.VirtualMachine) map[string]string {
+
+	key := spreadPlacementsKey(resourcePolicy)
+
+	spreadPlacements.Lock()
+	defer spreadPlacements.Unlock()
+
+	records := spreadPlacements.inFlight[key]
+	if len(records) == 0 {
+		return nil
+	}
+
+	unlabeled := make(map[string]struct{}, len(vms))
+	for _, vm := range vms {
+		if vm.Labels[topology.KubernetesTopologyZoneLabelKey] == "" {
+			unlabeled[vm.Name] = struct{}{}
+		}
+	}
+
+	zoneNames := make(map[string]string, len(records))
+	for vmName, record := range records {
+		if _, ok := unlabeled[vmName]; !ok || time.Since(record.timestamp) > inFlightPlacementTimeout {
+			delete(records, vmName)
+			continue
+		}
+		zoneNames[vmName] = record.zoneName
+	}
+
+	if len(records) == 0 {
+		delete(spreadPlacements.inFlight, key)
+	}
+
+	return zoneNames
+}
+
+// getZoneMemberCounts returns the number of the other VMs that use the resource policy in each of
+// the candidate zones. A VM is counted once its zone has been selected, even if its zone label has
+// not been observed yet.
+func getZoneMemberCounts(
+	vmCtx context.VirtualMachineContextA2,
+	client ctrlclient.Client,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	candidates map[string][]string) (map[string]int, error) {
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := client.List(vmCtx, vmList, ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+		return nil, err
+	}
+
+	zoneMemberCounts := make(map[string]int, len(candidates))
+	for zoneName := range candidates {
+		zoneMemberCounts[zoneName] = 0
+	}
+
+	var members []vmopv1.VirtualMachine
+	for _, vm := range vmList.Items {
+		if vm.Spec.Reserved != nil && vm.Spec.Reserved.ResourcePolicyName == resourcePolicy.Name {
+			members = append(members, vm)
+		}
+	}
+
+	inFlightZoneNames := getInFlightPlacements(resourcePolicy, members)
+
+	for _, vm := range members {
+		if vm.Name == vmCtx.VM.Name {
+			continue
+		}
+
+		zoneName := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
+		if zoneName == "" {
+			zoneName = inFlightZoneNames[vm.Name]
+		}
+		if _, ok := zoneMemberCounts[zoneName]; ok {
+			zoneMemberCounts[zoneName]++
+		}
+	}
+
+	vmCtx.Logger.V(5).Info("Topology spread zone member counts", "counts", zoneMemberCounts)
+
+	return zoneMemberCounts, nil
+}
+
+// spreadCandidates returns the candidates in the zones where placing the VM would not make the
+// zone's number of members exceed the minimum number of members in any of the zones by more than
+// maxSkew. The candidates are grouped by the zone's number of members, in ascending order.
+func spreadCandidates(
+	candidates map[string][]string,
+	zoneMemberCounts map[string]int,
+	maxSkew int32) []map[string][]string {
+
+	minCount := -1
+	for _, count := range zoneMemberCounts {
+		if minCount == -1 || count < minCount {
+			minCount = count
+		}
+	}
+
+	countCandidates := map[int]map[string][]string{}
+	for zoneName, rpMoIDs := range candidates {
+		count := zoneMemberCounts[zoneName]
+		if count+1-minCount > int(maxSkew) {
+			continue
+		}
+
+		if countCandidates[count] == nil {
+			countCandidates[count] = map[string][]string{}
+		}
+		countCandidates[count][zoneName] = rpMoIDs
+	}
+
+	counts := make([]int, 0, len(countCandidates))
+	for count := range countCandidates {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+
+	candidateSets := make([]map[string][]string, 0, len(counts))
+	for _, count := range counts {
+		candidateSets = append(candidateSets, countCandidates[count])
+	}
+
+	return candidateSets
+}
+
+// fewestMemberZones returns the zones that have the fewest members.
+func fewestMemberZones(zoneNames []string, zoneMemberCounts map[string]int) []string {
+	var fewest []string
+
+	for _, zoneName := range zoneNames {
+		if len(fewest) != 0 {
+			if count, minCount := zoneMemberCounts[zoneName], zoneMemberCounts[fewest[0]]; count > minCount {
+				continue
+			} else if count < minCount {
+				fewest = fewest[:0]
+			}
+		}
+		fewest = append(fewest, zoneName)
+	}
+
+	return fewest
+}
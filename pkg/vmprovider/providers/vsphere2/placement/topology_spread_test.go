@@ -0,0 +1,54 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package placement_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/placement"
+)
+
+var _ = Describe("MakePlacementDecision with zone member counts", func() {
+
+	var recommendations map[string][]placement.Recommendation
+
+	BeforeEach(func() {
+		recommendations = map[string][]placement.Recommendation{}
+		for _, zoneName := range []string{"zone1", "zone2", "zone3"} {
+			recommendations[zoneName] = []placement.Recommendation{
+				{PoolMoRef: types.ManagedObjectReference{Type: "ResourcePool", Value: zoneName + "-rp"}},
+			}
+		}
+	})
+
+	It("selects the zone with the fewest members", func() {
+		zoneMemberCounts := map[string]int{"zone1": 2, "zone2": 1, "zone3": 2}
+
+		for i := 0; i < 10; i++ {
+			zoneName, rec := placement.MakePlacementDecision(recommendations, zoneMemberCounts)
+			Expect(zoneName).To(Equal("zone2"))
+			Expect(rec).To(BeElementOf(recommendations[zoneName]))
+		}
+	})
+
+	It("selects one of the zones tied for the fewest members", func() {
+		zoneMemberCounts := map[string]int{"zone1": 1, "zone2": 0, "zone3": 0}
+
+		for i := 0; i < 10; i++ {
+			zoneName, _ := placement.MakePlacementDecision(recommendations, zoneMemberCounts)
+			Expect(zoneName).To(BeElementOf("zone2", "zone3"))
+		}
+	})
+
+	It("only considers the zones with recommendations", func() {
+		delete(recommendations, "zone2")
+		zoneMemberCounts := map[string]int{"zone1": 2, "zone2": 0, "zone3": 1}
+
+		zoneName, _ := placement.MakePlacementDecision(recommendations, zoneMemberCounts)
+		Expect(zoneName).To(Equal("zone3"))
+	})
+})
@@ -13,6 +13,7 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
@@ -25,10 +26,12 @@ import (
 type Result struct {
 	ZonePlacement            bool
 	InstanceStoragePlacement bool
+	ZoneRelocation           bool
 	ZoneName                 string
 	HostMoRef                *types.ManagedObjectReference
 	PoolMoRef                types.ManagedObjectReference
-	// TODO: Datastore, whatever else as we need it.
+	DatastoreMoRef           *types.ManagedObjectReference
+	// TODO: Whatever else as we need it.
 }
 
 func doesVMNeedPlacement(vmCtx context.VirtualMachineContextA2) (res Result, needZonePlacement, needInstanceStoragePlacement bool) {
@@ -38,6 +41,11 @@ func doesVMNeedPlacement(vmCtx context.VirtualMachineContextA2) (res Result, nee
 		if zoneName := vmCtx.VM.Labels[topology.KubernetesTopologyZoneLabelKey]; zoneName != "" {
 			// Zone has already been selected.
 			res.ZoneName = zoneName
+
+			// The zone of an existing VM was changed so the VM needs to be placed in the new zone.
+			if curZoneName := vmCtx.VM.Status.Zone; curZoneName != "" && curZoneName != zoneName {
+				res.ZoneRelocation = true
+			}
 		} else {
 			// VM does not have a zone already assigned so we need to select one.
 			needZonePlacement = true
@@ -87,13 +95,15 @@ func lookupChildRPs(
 	return childRPMoIDs
 }
 
-// getPlacementCandidates determines the candidate resource pools for VM placement.
+// getPlacementCandidates determines the candidate resource pools for VM placement. When
+// zoneNames is not nil, only the named zones are candidates.
 func getPlacementCandidates(
 	vmCtx context.VirtualMachineContextA2,
 	client ctrlclient.Client,
 	vcClient *vim25.Client,
 	zonePlacement bool,
-	childRPName string) (map[string][]string, error) {
+	childRPName string,
+	zoneNames []string) (map[string][]string, error) {
 
 	var zones []topologyv1.AvailabilityZone
 
@@ -115,9 +125,23 @@ func getPlacementCandidates(
 		zones = append(zones, zone)
 	}
 
+	var candidateZones map[string]struct{}
+	if zoneNames != nil {
+		candidateZones = make(map[string]struct{}, len(zoneNames))
+		for _, zoneName := range zoneNames {
+			candidateZones[zoneName] = struct{}{}
+		}
+	}
+
 	candidates := map[string][]string{}
 
 	for _, zone := range zones {
+		if candidateZones != nil {
+			if _, ok := candidateZones[zone.Name]; !ok {
+				continue
+			}
+		}
+
 		nsInfo, ok := zone.Spec.Namespaces[vmCtx.VM.Namespace]
 		if !ok {
 			continue
@@ -159,14 +183,18 @@ func rpMoIDToCluster(
 	return object.NewClusterComputeResource(vcClient, cluster.Reference()), nil
 }
 
-// getPlacementRecommendations calls DRS PlaceVM to determine clusters suitable for placement.
+// getPlacementRecommendations calls DRS PlaceVM to determine clusters suitable for placement. The
+// errors of the candidates that could not be placed are returned along with the recommendations.
+// When clusterHosts has an entry for a candidate's cluster, only those hosts are considered.
 func getPlacementRecommendations(
 	vmCtx context.VirtualMachineContextA2,
 	vcClient *vim25.Client,
 	candidates map[string][]string,
-	configSpec *types.VirtualMachineConfigSpec) map[string][]Recommendation {
+	configSpec *types.VirtualMachineConfigSpec,
+	clusterHosts map[string][]types.ManagedObjectReference) (map[string][]Recommendation, []error) {
 
 	recommendations := map[string][]Recommendation{}
+	var errs []error
 
 	for zoneName, rpMoIDs := range candidates {
 		for _, rpMoID := range rpMoIDs {
@@ -175,19 +203,22 @@ func getPlacementRecommendations(
 			cluster, err := rpMoIDToCluster(vmCtx, vcClient, rpMoRef)
 			if err != nil {
 				vmCtx.Logger.Error(err, "failed to get CCR from RP", "zone", zoneName, "rpMoID", rpMoID)
+				errs = append(errs, fmt.Errorf("zone %s ResourcePool %s: %w", zoneName, rpMoID, err))
 				continue
 			}
 
-			recs, err := PlaceVMForCreate(vmCtx, cluster, configSpec)
+			recs, err := PlaceVMForCreate(vmCtx, cluster, configSpec, clusterHosts[cluster.Reference().Value])
 			if err != nil {
 				vmCtx.Logger.Error(err, "PlaceVM failed", "zone", zoneName,
 					"clusterMoID", cluster.Reference().Value, "rpMoID", rpMoID)
+				errs = append(errs, fmt.Errorf("zone %s ResourcePool %s: PlaceVM failed: %w", zoneName, rpMoID, err))
 				continue
 			}
 
 			if len(recs) == 0 {
 				vmCtx.Logger.Info("No placement recommendations", "zone", zoneName,
 					"clusterMoID", cluster.Reference().Value, "rpMoID", rpMoID)
+				errs = append(errs, fmt.Errorf("zone %s ResourcePool %s: no placement recommendations", zoneName, rpMoID))
 				continue
 			}
 
@@ -205,16 +236,18 @@ func getPlacementRecommendations(
 
 	vmCtx.Logger.V(5).Info("Placement recommendations", "recommendations", recommendations)
 
-	return recommendations
+	return recommendations, errs
 }
 
 // getZonalPlacementRecommendations calls DRS PlaceVmsXCluster to determine clusters suitable for placement.
+// When dryRun is true DRS is called even when there is only one candidate so the recommendation, or the
+// faults of why the candidate is not suitable, come from DRS.
 func getZonalPlacementRecommendations(
 	vmCtx context.VirtualMachineContextA2,
 	vcClient *vim25.Client,
 	candidates map[string][]string,
 	configSpec *types.VirtualMachineConfigSpec,
-	needsHost bool) map[string][]Recommendation {
+	needsHost, needsDatastore, dryRun bool) (map[string][]Recommendation, []error) {
 
 	rpMOToZone := map[types.ManagedObjectReference]string{}
 	var candidateRPMoRefs []types.ManagedObjectReference
@@ -229,14 +262,14 @@ func getZonalPlacementRecommendations(
 
 	var recs []Recommendation
 
-	if len(candidateRPMoRefs) == 1 {
+	if len(candidateRPMoRefs) == 1 && !needsDatastore && !dryRun {
 		// If there is only one candidate, we might be able to skip some work.
 
 		if needsHost {
 			// This is a hack until PlaceVmsXCluster() supports instance storage disks.
 			vmCtx.Logger.Info("Falling back into non-zonal placement since the only candidate needs host selected",
 				"rpMoID", candidateRPMoRefs[0].Value)
-			return getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec)
+			return getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, nil)
 		}
 
 		recs = append(recs, Recommendation{
@@ -247,10 +280,10 @@ func getZonalPlacementRecommendations(
 	} else {
 		var err error
 
-		recs, err = ClusterPlaceVMForCreate(vmCtx, vcClient, candidateRPMoRefs, configSpec, needsHost)
+		recs, err = ClusterPlaceVMForCreate(vmCtx, vcClient, candidateRPMoRefs, configSpec, needsHost, needsDatastore)
 		if err != nil {
 			vmCtx.Logger.Error(err, "PlaceVmsXCluster failed")
-			return nil
+			return nil, []error{err}
 		}
 	}
 
@@ -266,16 +299,23 @@ func getZonalPlacementRecommendations(
 
 	vmCtx.Logger.V(5).Info("Placement recommendations", "recommendations", recommendations)
 
-	return recommendations
+	return recommendations, nil
 }
 
-// MakePlacementDecision selects one of the recommendations for placement.
-func MakePlacementDecision(recommendations map[string][]Recommendation) (string, Recommendation) {
+// MakePlacementDecision selects one of the recommendations for placement. When zoneMemberCounts is
+// not nil, a zone with the fewest members is selected.
+func MakePlacementDecision(
+	recommendations map[string][]Recommendation,
+	zoneMemberCounts map[string]int) (string, Recommendation) {
+
 	// Use an explicit rand.Intn() instead of first entry returned by map iterator.
 	zoneNames := make([]string, 0, len(recommendations))
 	for zoneName := range recommendations {
 		zoneNames = append(zoneNames, zoneName)
 	}
+	if zoneMemberCounts != nil {
+		zoneNames = fewestMemberZones(zoneNames, zoneMemberCounts)
+	}
 	zoneName := zoneNames[rand.Intn(len(zoneNames))] //nolint:gosec
 
 	recs := recommendations[zoneName]
@@ -283,20 +323,41 @@ func MakePlacementDecision(recommendations map[string][]Recommendation) (string,
 }
 
 // Placement determines if the VM needs placement, and if so, determines where to place the VM
-// and updates the Labels and Annotations with the placement decision.
+// and updates the Labels and Annotations with the placement decision. An existing VM whose zone
+// label no longer matches its Status.Zone is placed in the zone of its label, along with the
+// datastore its disks are relocated to. A VM with affinity terms is only placed in the zones and
+// clusters that satisfy its required terms, and preferably in those that satisfy its preferred
+// terms. A VM whose resource policy has a host affinity is placed on one of the hosts selected by
+// the host affinity, and only in the clusters with such hosts when the host affinity is required.
+// A VM whose resource policy has a topology spread constraint is placed in a zone with the fewest
+// of the policy's other VMs, without exceeding the constraint's skew. When zoneNames is not nil,
+// the VM is only placed in the named zones, ex. the zones of the vCenter the VM is created in.
 func Placement(
 	vmCtx context.VirtualMachineContextA2,
 	client ctrlclient.Client,
 	vcClient *vim25.Client,
 	configSpec *types.VirtualMachineConfigSpec,
-	childRPName string) (*Result, error) {
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy,
+	zoneNames []string) (*Result, error) {
+
+	var childRPName string
+	var hostAffinity *vmopv1.VirtualMachineSetResourcePolicyHostAffinity
+	var spreadConstraint *vmopv1.VirtualMachineSetResourcePolicyTopologySpreadConstraint
+	if resourcePolicy != nil {
+		childRPName = resourcePolicy.Spec.ResourcePool.Name
+		hostAffinity = resourcePolicy.Spec.HostAffinity
+		spreadConstraint = resourcePolicy.Spec.TopologySpreadConstraint
+	}
 
 	existingRes, zonePlacement, instanceStoragePlacement := doesVMNeedPlacement(vmCtx)
-	if !zonePlacement && !instanceStoragePlacement {
+	zoneRelocation := existingRes.ZoneRelocation
+	affinityPlacement := vmCtx.VM.Spec.Affinity != nil
+	hostAffinityPlacement := hostAffinity != nil
+	if !zonePlacement && !instanceStoragePlacement && !zoneRelocation && !affinityPlacement && !hostAffinityPlacement {
 		return &existingRes, nil
 	}
 
-	candidates, err := getPlacementCandidates(vmCtx, client, vcClient, zonePlacement, childRPName)
+	candidates, err := getPlacementCandidates(vmCtx, client, vcClient, zonePlacement, childRPName, zoneNames)
 	if err != nil {
 		return nil, err
 	}
@@ -305,28 +366,148 @@ func Placement(
 		return nil, fmt.Errorf("no placement candidates available")
 	}
 
+	var clusterHosts map[string][]types.ManagedObjectReference
+	if hostAffinityPlacement {
+		candidates, clusterHosts, err = filterCandidatesByHostAffinity(vmCtx, client, vcClient, candidates, hostAffinity)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("no placement candidates have hosts selected by the required host affinity")
+		}
+	}
+
+	candidates, preferredCandidates, err := filterCandidatesByAffinity(vmCtx, client, vcClient, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no placement candidates satisfy the required VM affinity terms")
+	}
+
+	// The topology spread constraint only applies when the VM's zone is selected. The policy's VMs
+	// are placed one at a time so each VM counts the zones selected for the others.
+	var zoneMemberCounts map[string]int
+	if zonePlacement && spreadConstraint != nil {
+		defer lockSpreadPlacement(resourcePolicy)()
+
+		zoneMemberCounts, err = getZoneMemberCounts(vmCtx, client, resourcePolicy, candidates)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// TBD: May want to get the host for vGPU and other passthru devices too.
 	needsHost := instanceStoragePlacement
 
+	getRecommendations := func(candidates map[string][]string) map[string][]Recommendation {
+		var recommendations map[string][]Recommendation
+		// PlaceVmsXCluster() cannot be limited to a set of hosts so host affinity uses PlaceVM for
+		// each candidate instead, except when relocating since that also needs the datastore.
+		if (zonePlacement && !hostAffinityPlacement) || zoneRelocation {
+			recommendations, _ = getZonalPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, needsHost, zoneRelocation, false)
+		} else /* instanceStoragePlacement || affinityPlacement || hostAffinityPlacement */ {
+			recommendations, _ = getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, clusterHosts)
+			if len(recommendations) == 0 && hostAffinityPlacement && !hostAffinity.Required {
+				// The host affinity is only preferred so fall back to any host.
+				recommendations, _ = getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, nil)
+			}
+		}
+		return recommendations
+	}
+
+	candidateSets := []map[string][]string{candidates}
+	if len(preferredCandidates) != 0 && !candidatesEqual(preferredCandidates, candidates) {
+		// Fall back to all the candidates if none of the preferred candidates are suitable.
+		candidateSets = []map[string][]string{preferredCandidates, candidates}
+	}
+
+	if zoneMemberCounts != nil {
+		// DRS recommends the best cluster without regard to the spread so try the zones with the
+		// fewest members first.
+		var spreadCandidateSets []map[string][]string
+		for _, c := range candidateSets {
+			spreadCandidateSets = append(spreadCandidateSets, spreadCandidates(c, zoneMemberCounts, spreadConstraint.MaxSkew)...)
+		}
+		candidateSets = spreadCandidateSets
+	}
+
 	var recommendations map[string][]Recommendation
-	if zonePlacement {
-		recommendations = getZonalPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, needsHost)
-	} else /* instanceStoragePlacement */ {
-		recommendations = getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec)
+	for _, c := range candidateSets {
+		if recommendations = getRecommendations(c); len(recommendations) != 0 {
+			break
+		}
 	}
 	if len(recommendations) == 0 {
 		return nil, fmt.Errorf("no placement recommendations available")
 	}
 
-	zoneName, rec := MakePlacementDecision(recommendations)
+	zoneName, rec := MakePlacementDecision(recommendations, zoneMemberCounts)
 	vmCtx.Logger.V(5).Info("Placement decision result", "zone", zoneName, "recommendation", rec)
 
+	if zoneMemberCounts != nil {
+		addInFlightPlacement(resourcePolicy, vmCtx.VM.Name, zoneName)
+	}
+
 	result := &Result{
 		ZonePlacement:            zonePlacement,
 		InstanceStoragePlacement: instanceStoragePlacement,
+		ZoneRelocation:           zoneRelocation,
 		ZoneName:                 zoneName,
 		PoolMoRef:                rec.PoolMoRef,
 		HostMoRef:                rec.HostMoRef,
+		DatastoreMoRef:           rec.DatastoreMoRef,
+	}
+
+	return result, nil
+}
+
+// DryRunResult is the result of a placement dry run.
+type DryRunResult struct {
+	// Recommendations are the placement recommendations of each zone in the order returned by DRS.
+	Recommendations map[string][]Recommendation
+	// Faults are the reasons why the candidates did not have any recommendations.
+	Faults []string
+}
+
+// DryRunPlacement determines where the VM could be placed without updating the VM. Unlike Placement,
+// DRS is consulted even when there is only one candidate, so that the faults explain why the VM does
+// not fit. When zoneNames is not nil, only the named zones are considered.
+func DryRunPlacement(
+	vmCtx context.VirtualMachineContextA2,
+	client ctrlclient.Client,
+	vcClient *vim25.Client,
+	configSpec *types.VirtualMachineConfigSpec,
+	childRPName string,
+	needsHost bool,
+	zoneNames []string) (*DryRunResult, error) {
+
+	zonePlacement := lib.IsWcpFaultDomainsFSSEnabled() && vmCtx.VM.Labels[topology.KubernetesTopologyZoneLabelKey] == ""
+
+	candidates, err := getPlacementCandidates(vmCtx, client, vcClient, zonePlacement, childRPName, zoneNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no placement candidates available")
+	}
+
+	var recommendations map[string][]Recommendation
+	var errs []error
+	if lib.IsWcpFaultDomainsFSSEnabled() {
+		recommendations, errs = getZonalPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, needsHost, false, true)
+	} else {
+		recommendations, errs = getPlacementRecommendations(vmCtx, vcClient, candidates, configSpec, nil)
+	}
+
+	result := &DryRunResult{
+		Recommendations: recommendations,
+	}
+	for _, err := range errs {
+		result.Faults = append(result.Faults, err.Error())
 	}
 
 	return result, nil
@@ -18,6 +18,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/pkg"
+	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -404,6 +405,72 @@ func UpdateHardwareConfigSpec(
 	}
 }
 
+// UpdateConfigSpecClassResize updates the ConfigSpec with the CPU and memory
+// hardware and allocation of the VM Class.
+func UpdateConfigSpecClassResize(
+	config *vimTypes.VirtualMachineConfigInfo,
+	configSpec *vimTypes.VirtualMachineConfigSpec,
+	vmClassSpec *vmopv1.VirtualMachineClassSpec,
+	minCPUFreq uint64) {
+
+	UpdateHardwareConfigSpec(config, configSpec, vmClassSpec)
+	UpdateConfigSpecCPUAllocation(config, configSpec, vmClassSpec, minCPUFreq)
+	UpdateConfigSpecMemoryAllocation(config, configSpec, vmClassSpec)
+}
+
+// IsHotResizeSupported returns true if the CPU and memory changes in the
+// ConfigSpec may be applied while the VM is powered on. The changes must be
+// enabled for the VM and supported by its guest OS. A nil guestOS means the
+// guest OS is not known, so no changes are supported.
+func IsHotResizeSupported(
+	config *vimTypes.VirtualMachineConfigInfo,
+	guestOS *vimTypes.GuestOsDescriptor,
+	configSpec *vimTypes.VirtualMachineConfigSpec) bool {
+
+	if configSpec.NumCPUs == 0 && configSpec.MemoryMB == 0 {
+		return true
+	}
+	if guestOS == nil {
+		return false
+	}
+
+	if nCPUs := configSpec.NumCPUs; nCPUs != 0 {
+		if nCPUs > config.Hardware.NumCPU &&
+			(!pointer.BoolDeref(config.CpuHotAddEnabled, false) || !pointer.BoolDeref(guestOS.SupportsCpuHotAdd, false)) {
+			return false
+		}
+		if nCPUs < config.Hardware.NumCPU &&
+			(!pointer.BoolDeref(config.CpuHotRemoveEnabled, false) || !pointer.BoolDeref(guestOS.SupportsCpuHotRemove, false)) {
+			return false
+		}
+	}
+
+	if memMB := configSpec.MemoryMB; memMB != 0 {
+		// Memory may only be hot added, never hot removed.
+		if memMB < int64(config.Hardware.MemoryMB) ||
+			!pointer.BoolDeref(config.MemoryHotAddEnabled, false) || !pointer.BoolDeref(guestOS.SupportsMemoryHotAdd, false) {
+			return false
+		}
+		if limit := config.HotPlugMemoryLimit; limit != 0 && memMB > limit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// classResizePending returns true if the VM's class has been changed since the
+// VM was last configured from its class.
+func classResizePending(vm *vmopv1.VirtualMachine) bool {
+	return vm.Status.Class != nil && vm.Status.Class.Name != vm.Spec.ClassName
+}
+
+// markClassResized records that the VM has been configured from its new class.
+func markClassResized(vm *vmopv1.VirtualMachine) {
+	vm.Status.Class.Name = vm.Spec.ClassName
+	conditions.MarkTrue(vm, vmopv1.VirtualMachineClassConfigurationSyncedCondition)
+}
+
 func UpdateConfigSpecAnnotation(
 	config *vimTypes.VirtualMachineConfigInfo,
 	configSpec *vimTypes.VirtualMachineConfigSpec) {
@@ -428,6 +495,13 @@ func UpdateConfigSpecFirmware(
 	configSpec *vimTypes.VirtualMachineConfigSpec,
 	vm *vmopv1.VirtualMachine) {
 
+	if bootOptions := vm.Spec.BootOptions; bootOptions != nil && bootOptions.Firmware != "" {
+		if val := string(bootOptions.Firmware); config.Firmware != val {
+			configSpec.Firmware = val
+		}
+		return
+	}
+
 	if val, ok := vm.Annotations[constants.FirmwareOverrideAnnotation]; ok {
 		if (val == "efi" || val == "bios") && config.Firmware != val {
 			configSpec.Firmware = val
@@ -435,6 +509,106 @@ func UpdateConfigSpecFirmware(
 	}
 }
 
+// UpdateConfigSpecBootOptions updates the ConfigSpec with the VM's boot options
+// when they differ from the VM's current boot options. The boot delays and boot
+// order are left unchanged when they are omitted from the VM's boot options.
+func UpdateConfigSpecBootOptions(
+	config *vimTypes.VirtualMachineConfigInfo,
+	configSpec *vimTypes.VirtualMachineConfigSpec,
+	vm *vmopv1.VirtualMachine) {
+
+	if vm.Spec.BootOptions == nil {
+		return
+	}
+
+	desired := virtualmachine.CreateBootOptions(vm.Spec.BootOptions, config.Hardware.Device)
+	current := config.BootOptions
+	if current == nil {
+		current = &vimTypes.VirtualMachineBootOptions{}
+	}
+
+	if pointer.BoolDeref(current.EfiSecureBootEnabled, false) != *desired.EfiSecureBootEnabled ||
+		pointer.BoolDeref(current.BootRetryEnabled, false) != *desired.BootRetryEnabled ||
+		(desired.BootDelay != 0 && current.BootDelay != desired.BootDelay) ||
+		(desired.BootRetryDelay != 0 && current.BootRetryDelay != desired.BootRetryDelay) ||
+		(len(desired.BootOrder) != 0 && !apiEquality.Semantic.DeepEqual(current.BootOrder, desired.BootOrder)) {
+
+		configSpec.BootOptions = desired
+	}
+}
+
+// UpdateConfigSpecCrypto updates the ConfigSpec to encrypt, decrypt or rekey the
+// VM home and the VM's non-FCD disks, and to add or remove its vTPM device, so that
+// the VM matches its crypto spec. A VM without a crypto spec is left as is, since it
+// may have been encrypted or given a vTPM by its class's ConfigSpec or its storage
+// policy. Each disk is checked against the key of its own
+// backing since, for example, a VM deployed from an image has an encrypted home
+// but unencrypted disks. The DeviceChange may already contain edits of the VM's
+// disks, which are updated in place rather than duplicated.
+func UpdateConfigSpecCrypto(
+	config *vimTypes.VirtualMachineConfigInfo,
+	configSpec *vimTypes.VirtualMachineConfigSpec,
+	vm *vmopv1.VirtualMachine) {
+
+	crypto := vm.Spec.Crypto
+	if crypto == nil {
+		return
+	}
+	wantEncrypted, wantVTPM, keyProviderID := crypto.Encrypted, crypto.VTPM, crypto.KeyProviderID
+
+	devices := object.VirtualDeviceList(config.Hardware.Device)
+	vTPMs := devices.SelectByType((*vimTypes.VirtualTPM)(nil))
+
+	switch {
+	case wantVTPM && len(vTPMs) == 0:
+		configSpec.DeviceChange = append(configSpec.DeviceChange, &vimTypes.VirtualDeviceConfigSpec{
+			Operation: vimTypes.VirtualDeviceConfigSpecOperationAdd,
+			Device:    virtualmachine.CreateVirtualTPMDevice(),
+		})
+	case !wantVTPM:
+		for _, dev := range vTPMs {
+			configSpec.DeviceChange = append(configSpec.DeviceChange, &vimTypes.VirtualDeviceConfigSpec{
+				Operation: vimTypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    dev,
+			})
+		}
+	}
+
+	// A vTPM requires the VM home to be encrypted, so the VM home is only
+	// decrypted when neither encryption nor a vTPM is desired.
+	configSpec.Crypto = virtualmachine.CryptoSpecForKeyID(config.KeyId, wantEncrypted || wantVTPM, keyProviderID)
+
+	for _, dev := range devices.SelectByType((*vimTypes.VirtualDisk)(nil)) {
+		disk := dev.(*vimTypes.VirtualDisk)
+		if disk.VDiskId != nil {
+			// FCDs are managed by CNS and encrypted via their storage policy.
+			continue
+		}
+
+		cryptoSpec := virtualmachine.CryptoSpecForKeyID(virtualmachine.GetVirtualDiskKeyID(disk), wantEncrypted, keyProviderID)
+		if cryptoSpec == nil {
+			continue
+		}
+
+		var diskSpec *vimTypes.VirtualDeviceConfigSpec
+		for _, dc := range configSpec.DeviceChange {
+			if spec := dc.GetVirtualDeviceConfigSpec(); spec.Operation == vimTypes.VirtualDeviceConfigSpecOperationEdit &&
+				spec.Device.GetVirtualDevice().Key == disk.Key {
+				diskSpec = spec
+				break
+			}
+		}
+		if diskSpec == nil {
+			diskSpec = &vimTypes.VirtualDeviceConfigSpec{
+				Operation: vimTypes.VirtualDeviceConfigSpecOperationEdit,
+				Device:    disk,
+			}
+			configSpec.DeviceChange = append(configSpec.DeviceChange, diskSpec)
+		}
+		diskSpec.Backing = &vimTypes.VirtualDeviceConfigSpecBackingSpec{Crypto: cryptoSpec}
+	}
+}
+
 // updateConfigSpec overlays the VM Class spec with the provided ConfigSpec to form a desired
 // ConfigSpec that will be used to reconfigure the VM.
 func updateConfigSpec(
@@ -449,11 +623,10 @@ func updateConfigSpec(
 	// reconfigured to match the desired CPU and memory reservation.  Maintain that
 	// behavior.  With the FSS enabled, VMs will be _created_ with desired HW spec, and we
 	// will not modify the hardware of the VM post creation.  So, don't populate the
-	// Hardware config and CPU/Memory reservation.
-	if !lib.IsVMClassAsConfigFSSDaynDateEnabled() {
-		UpdateHardwareConfigSpec(config, configSpec, &vmClassSpec)
-		UpdateConfigSpecCPUAllocation(config, configSpec, &vmClassSpec, updateArgs.MinCPUFreq)
-		UpdateConfigSpecMemoryAllocation(config, configSpec, &vmClassSpec)
+	// Hardware config and CPU/Memory reservation unless the VM's class has since been
+	// changed, in which case the VM is resized to match its new class.
+	if !lib.IsVMClassAsConfigFSSDaynDateEnabled() || classResizePending(vmCtx.VM) {
+		UpdateConfigSpecClassResize(config, configSpec, &vmClassSpec, updateArgs.MinCPUFreq)
 	}
 
 	UpdateConfigSpecAnnotation(config, configSpec)
@@ -484,6 +657,8 @@ func (s *Session) prePowerOnVMConfigSpec(
 	}
 	configSpec.DeviceChange = append(configSpec.DeviceChange, diskDeviceChanges...)
 
+	UpdateConfigSpecCrypto(config, configSpec, vmCtx.VM)
+
 	var expectedEthCards object.VirtualDeviceList
 	for idx := range updateArgs.NetworkResults.Results {
 		expectedEthCards = append(expectedEthCards, updateArgs.NetworkResults.Results[idx].Device)
@@ -534,6 +709,10 @@ func (s *Session) prePowerOnVMReconfigure(
 		}
 	}
 
+	if classResizePending(vmCtx.VM) {
+		markClassResized(vmCtx.VM)
+	}
+
 	return nil
 }
 
@@ -697,6 +876,11 @@ func (s *Session) prepareVMForPowerOn(
 		return err
 	}
 
+	err = s.reconfigureBootOptions(vmCtx, resVM, nil)
+	if err != nil {
+		return err
+	}
+
 	err = s.customize(vmCtx, resVM, cfg, updateArgs)
 	if err != nil {
 		return err
@@ -710,14 +894,88 @@ func (s *Session) prepareVMForPowerOn(
 	return nil
 }
 
-func (s *Session) poweredOnVMReconfigure(
+// reconfigureBootOptions reconfigures the VM's firmware and boot options to
+// match the VM's spec. This is done after the other pre power on changes so that
+// the boot order may refer to the devices, like network interfaces, added by
+// them. When the config is nil, the VM's current config is fetched.
+func (s *Session) reconfigureBootOptions(
 	vmCtx context.VirtualMachineContextA2,
 	resVM *res.VirtualMachine,
 	config *vimTypes.VirtualMachineConfigInfo) error {
 
+	if vmCtx.VM.Spec.BootOptions == nil {
+		return nil
+	}
+
+	if config == nil {
+		moVM, err := resVM.GetProperties(vmCtx, []string{"config.firmware", "config.bootOptions", "config.hardware.device"})
+		if err != nil {
+			return err
+		}
+		if moVM.Config == nil {
+			return fmt.Errorf("VM config is not available")
+		}
+		config = moVM.Config
+	}
+
+	configSpec := &vimTypes.VirtualMachineConfigSpec{}
+	UpdateConfigSpecFirmware(config, configSpec, vmCtx.VM)
+	UpdateConfigSpecBootOptions(config, configSpec, vmCtx.VM)
+	if configSpec.Firmware == "" && configSpec.BootOptions == nil {
+		return nil
+	}
+
+	vmCtx.Logger.Info("Boot Options Reconfigure", "configSpec", configSpec)
+	if err := resVM.Reconfigure(vmCtx, configSpec); err != nil {
+		vmCtx.Logger.Error(err, "boot options reconfigure failed")
+		return err
+	}
+
+	return nil
+}
+
+// poweredOnVMReconfigure reconfigures a powered on VM. The updateArgs are only
+// required when the VM's class has been changed.
+func (s *Session) poweredOnVMReconfigure(
+	vmCtx context.VirtualMachineContextA2,
+	resVM *res.VirtualMachine,
+	config *vimTypes.VirtualMachineConfigInfo,
+	updateArgs *VMUpdateArgs) error {
+
 	configSpec := &vimTypes.VirtualMachineConfigSpec{}
 	UpdateConfigSpecChangeBlockTracking(config, configSpec, nil, vmCtx.VM.Spec)
 
+	var resized bool
+	if updateArgs != nil {
+		resizeConfigSpec := &vimTypes.VirtualMachineConfigSpec{}
+		UpdateConfigSpecClassResize(config, resizeConfigSpec, &updateArgs.VMClass.Spec, updateArgs.MinCPUFreq)
+
+		var guestOS *vimTypes.GuestOsDescriptor
+		if resizeConfigSpec.NumCPUs != 0 || resizeConfigSpec.MemoryMB != 0 {
+			var err error
+			guestOS, err = virtualmachine.GetGuestOSDescriptor(vmCtx, s.Cluster, config.GuestId)
+			if err != nil {
+				vmCtx.Logger.Error(err, "failed to get guest OS descriptor")
+				return err
+			}
+		}
+
+		if IsHotResizeSupported(config, guestOS, resizeConfigSpec) {
+			configSpec.NumCPUs = resizeConfigSpec.NumCPUs
+			configSpec.MemoryMB = resizeConfigSpec.MemoryMB
+			configSpec.CpuAllocation = resizeConfigSpec.CpuAllocation
+			configSpec.MemoryAllocation = resizeConfigSpec.MemoryAllocation
+			resized = true
+		} else {
+			// The new class is applied the next time the VM is powered off.
+			conditions.MarkFalse(vmCtx.VM,
+				vmopv1.VirtualMachineClassConfigurationSyncedCondition,
+				vmopv1.VirtualMachineClassConfigurationPowerCyclePendingReason,
+				"VirtualMachineClass %s cannot be applied until the VM is power cycled because CPU or memory hot-add is not enabled for the VM or not supported by its guest OS",
+				vmCtx.VM.Spec.ClassName)
+		}
+	}
+
 	defaultConfigSpec := &vimTypes.VirtualMachineConfigSpec{}
 	if !apiEquality.Semantic.DeepEqual(configSpec, defaultConfigSpec) {
 		vmCtx.Logger.Info("PoweredOn Reconfigure", "configSpec", configSpec)
@@ -737,6 +995,38 @@ func (s *Session) poweredOnVMReconfigure(
 		}
 	}
 
+	if resized {
+		markClassResized(vmCtx.VM)
+	}
+
+	return nil
+}
+
+// poweredOffVMResize reconfigures a powered off VM to match its new class.
+func (s *Session) poweredOffVMResize(
+	vmCtx context.VirtualMachineContextA2,
+	resVM *res.VirtualMachine,
+	config *vimTypes.VirtualMachineConfigInfo,
+	getUpdateArgsFn func() (*VMUpdateArgs, error)) error {
+
+	updateArgs, err := getUpdateArgsFn()
+	if err != nil {
+		return err
+	}
+
+	configSpec := &vimTypes.VirtualMachineConfigSpec{}
+	UpdateConfigSpecClassResize(config, configSpec, &updateArgs.VMClass.Spec, updateArgs.MinCPUFreq)
+
+	defaultConfigSpec := &vimTypes.VirtualMachineConfigSpec{}
+	if !apiEquality.Semantic.DeepEqual(configSpec, defaultConfigSpec) {
+		vmCtx.Logger.Info("PoweredOff Resize Reconfigure", "configSpec", configSpec)
+		if err := resVM.Reconfigure(vmCtx, configSpec); err != nil {
+			vmCtx.Logger.Error(err, "powered off resize reconfigure failed")
+			return err
+		}
+	}
+
+	markClassResized(vmCtx.VM)
 	return nil
 }
 
@@ -813,7 +1103,18 @@ func (s *Session) UpdateVirtualMachine(
 
 		// BMV: We'll likely want to reconfigure a powered off VM too, but right now
 		// we'll defer that until the pre power on (and until more people complain
-		// that the UI appears wrong).
+		// that the UI appears wrong). The exceptions are a class change that could
+		// not be applied while the VM was powered on, and the boot options.
+		if existingPowerState == vmopv1.VirtualMachinePowerStateOff && classResizePending(vmCtx.VM) {
+			if moVM.Config == nil {
+				return fmt.Errorf("VM config is not available, connectionState=%s", moVM.Runtime.ConnectionState)
+			}
+			return s.poweredOffVMResize(vmCtx, resVM, moVM.Config, getUpdateArgsFn)
+		}
+
+		if existingPowerState == vmopv1.VirtualMachinePowerStateOff && moVM.Config != nil {
+			return s.reconfigureBootOptions(vmCtx, resVM, moVM.Config)
+		}
 
 	case vmopv1.VirtualMachinePowerStateSuspended:
 		if existingPowerState == vmopv1.VirtualMachinePowerStateOn {
@@ -868,10 +1169,16 @@ func (s *Session) UpdateVirtualMachine(
 				}
 			}
 
-			// Do not pass classConfigSpec to poweredOnVMReconfigure when VM is
-			// already powered on since we do not have to get VM class at this
-			// point.
-			return s.poweredOnVMReconfigure(vmCtx, resVM, config)
+			// Only get the update args, which includes the VM class, when the
+			// VM's class has been changed since there is no need to get the VM
+			// class otherwise.
+			var updateArgs *VMUpdateArgs
+			if classResizePending(vmCtx.VM) {
+				if updateArgs, err = getUpdateArgsFn(); err != nil {
+					return err
+				}
+			}
+			return s.poweredOnVMReconfigure(vmCtx, resVM, config, updateArgs)
 
 		case vmopv1.VirtualMachinePowerStateSuspended:
 			// A suspended VM cannot be reconfigured.
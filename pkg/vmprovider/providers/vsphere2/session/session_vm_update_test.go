@@ -5,6 +5,7 @@ package session_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -77,6 +78,107 @@ var _ = Describe("Update ConfigSpec", func() {
 		})
 	})
 
+	Context("Hot Resize", func() {
+		var guestOS *vimTypes.GuestOsDescriptor
+
+		BeforeEach(func() {
+			config.Hardware.NumCPU = 2
+			config.Hardware.MemoryMB = 2048
+			guestOS = &vimTypes.GuestOsDescriptor{
+				Id:                   "otherGuest",
+				SupportsCpuHotAdd:    pointer.Bool(true),
+				SupportsCpuHotRemove: pointer.Bool(true),
+				SupportsMemoryHotAdd: pointer.Bool(true),
+			}
+		})
+
+		It("supported when there are no hardware changes", func() {
+			Expect(session.IsHotResizeSupported(config, nil, configSpec)).To(BeTrue())
+		})
+
+		It("not supported when the guest OS is not known", func() {
+			config.CpuHotAddEnabled = pointer.Bool(true)
+			configSpec.NumCPUs = 4
+			Expect(session.IsHotResizeSupported(config, nil, configSpec)).To(BeFalse())
+		})
+
+		Context("CPU hot add", func() {
+			BeforeEach(func() {
+				configSpec.NumCPUs = 4
+			})
+
+			It("not supported when CPU hot add is disabled", func() {
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+
+			It("supported when CPU hot add is enabled", func() {
+				config.CpuHotAddEnabled = pointer.Bool(true)
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeTrue())
+			})
+
+			It("not supported when the guest OS does not support CPU hot add", func() {
+				config.CpuHotAddEnabled = pointer.Bool(true)
+				guestOS.SupportsCpuHotAdd = pointer.Bool(false)
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+		})
+
+		Context("CPU hot remove", func() {
+			BeforeEach(func() {
+				configSpec.NumCPUs = 1
+				config.CpuHotAddEnabled = pointer.Bool(true)
+			})
+
+			It("not supported when CPU hot remove is disabled", func() {
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+
+			It("supported when CPU hot remove is enabled", func() {
+				config.CpuHotRemoveEnabled = pointer.Bool(true)
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeTrue())
+			})
+
+			It("not supported when the guest OS does not support CPU hot remove", func() {
+				config.CpuHotRemoveEnabled = pointer.Bool(true)
+				guestOS.SupportsCpuHotRemove = nil
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+		})
+
+		Context("Memory hot add", func() {
+			BeforeEach(func() {
+				configSpec.MemoryMB = 4096
+			})
+
+			It("not supported when memory hot add is disabled", func() {
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+
+			It("supported when memory hot add is enabled", func() {
+				config.MemoryHotAddEnabled = pointer.Bool(true)
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeTrue())
+			})
+
+			It("not supported when the guest OS does not support memory hot add", func() {
+				config.MemoryHotAddEnabled = pointer.Bool(true)
+				guestOS.SupportsMemoryHotAdd = pointer.Bool(false)
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+
+			It("not supported when above the hot plug memory limit", func() {
+				config.MemoryHotAddEnabled = pointer.Bool(true)
+				config.HotPlugMemoryLimit = 3072
+				Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+			})
+		})
+
+		It("memory hot remove is not supported", func() {
+			config.MemoryHotAddEnabled = pointer.Bool(true)
+			configSpec.MemoryMB = 1024
+			Expect(session.IsHotResizeSupported(config, guestOS, configSpec)).To(BeFalse())
+		})
+	})
+
 	Context("CPU Allocation", func() {
 		var vmClassSpec *vmopv1.VirtualMachineClassSpec
 		var minCPUFreq uint64 = 1
@@ -668,6 +770,251 @@ var _ = Describe("Update ConfigSpec", func() {
 			session.UpdateConfigSpecFirmware(config, configSpec, vm)
 			Expect(configSpec.Firmware).To(BeEmpty())
 		})
+
+		It("Boot options firmware takes precedence over firmware annotation", func() {
+			vm.Annotations[constants.FirmwareOverrideAnnotation] = "bios"
+			vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{Firmware: vmopv1.VirtualMachineFirmwareTypeEFI}
+			session.UpdateConfigSpecFirmware(config, configSpec, vm)
+			Expect(configSpec.Firmware).To(Equal("efi"))
+		})
+	})
+
+	Context("Boot Options", func() {
+		var vm *vmopv1.VirtualMachine
+
+		BeforeEach(func() {
+			vm = &vmopv1.VirtualMachine{}
+			config.Hardware.Device = []vimTypes.BaseVirtualDevice{
+				&vimTypes.VirtualDisk{VirtualDevice: vimTypes.VirtualDevice{Key: 2000}},
+				&vimTypes.VirtualVmxnet3{VirtualVmxnet: vimTypes.VirtualVmxnet{VirtualEthernetCard: vimTypes.VirtualEthernetCard{VirtualDevice: vimTypes.VirtualDevice{Key: 4000}}}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			session.UpdateConfigSpecBootOptions(config, configSpec, vm)
+		})
+
+		It("No boot options", func() {
+			Expect(configSpec.BootOptions).To(BeNil())
+		})
+
+		Context("Boot options differ from the VM", func() {
+			BeforeEach(func() {
+				vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+					EFISecureBoot: true,
+					BootOrder: []vmopv1.VirtualMachineBootableDevice{
+						vmopv1.VirtualMachineBootableDeviceNetwork,
+						vmopv1.VirtualMachineBootableDeviceCDRom,
+						vmopv1.VirtualMachineBootableDeviceDisk,
+					},
+					BootDelay: &metav1.Duration{Duration: 5 * time.Second},
+				}
+			})
+
+			It("config spec has the boot options", func() {
+				bootOptions := configSpec.BootOptions
+				Expect(bootOptions).ToNot(BeNil())
+				Expect(bootOptions.EfiSecureBootEnabled).To(HaveValue(BeTrue()))
+				Expect(bootOptions.BootRetryEnabled).To(HaveValue(BeFalse()))
+				Expect(bootOptions.BootDelay).To(BeEquivalentTo(5000))
+				Expect(bootOptions.BootOrder).To(Equal([]vimTypes.BaseVirtualMachineBootOptionsBootableDevice{
+					&vimTypes.VirtualMachineBootOptionsBootableEthernetDevice{DeviceKey: 4000},
+					&vimTypes.VirtualMachineBootOptionsBootableDiskDevice{DeviceKey: 2000},
+				}))
+			})
+
+			Context("VM already has the boot options", func() {
+				BeforeEach(func() {
+					config.BootOptions = &vimTypes.VirtualMachineBootOptions{
+						EfiSecureBootEnabled: pointer.Bool(true),
+						BootDelay:            5000,
+						BootOrder: []vimTypes.BaseVirtualMachineBootOptionsBootableDevice{
+							&vimTypes.VirtualMachineBootOptionsBootableEthernetDevice{DeviceKey: 4000},
+							&vimTypes.VirtualMachineBootOptionsBootableDiskDevice{DeviceKey: 2000},
+						},
+					}
+				})
+
+				It("config spec show no changes", func() {
+					Expect(configSpec.BootOptions).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Context("Crypto", func() {
+		var vm *vmopv1.VirtualMachine
+		var disk, fcd *vimTypes.VirtualDisk
+		var diskBacking *vimTypes.VirtualDiskFlatVer2BackingInfo
+
+		BeforeEach(func() {
+			vm = &vmopv1.VirtualMachine{}
+			diskBacking = &vimTypes.VirtualDiskFlatVer2BackingInfo{}
+			disk = &vimTypes.VirtualDisk{VirtualDevice: vimTypes.VirtualDevice{Key: 100, Backing: diskBacking}}
+			fcd = &vimTypes.VirtualDisk{VirtualDevice: vimTypes.VirtualDevice{Key: 101}, VDiskId: &vimTypes.ID{Id: "fcd"}}
+			config.Hardware.Device = []vimTypes.BaseVirtualDevice{disk, fcd}
+		})
+
+		JustBeforeEach(func() {
+			session.UpdateConfigSpecCrypto(config, configSpec, vm)
+		})
+
+		It("No crypto spec and VM is not encrypted", func() {
+			Expect(configSpec.Crypto).To(BeNil())
+			Expect(configSpec.DeviceChange).To(BeEmpty())
+		})
+
+		Context("VM is to be encrypted with a vTPM", func() {
+			BeforeEach(func() {
+				vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{Encrypted: true, KeyProviderID: "my-kp", VTPM: true}
+			})
+
+			It("Encrypts the VM and its non-FCD disks, and adds a vTPM", func() {
+				Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecEncrypt{}))
+				Expect(configSpec.Crypto.(*vimTypes.CryptoSpecEncrypt).CryptoKeyId.ProviderId.Id).To(Equal("my-kp"))
+
+				Expect(configSpec.DeviceChange).To(HaveLen(2))
+				tpmSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+				Expect(tpmSpec.Operation).To(Equal(vimTypes.VirtualDeviceConfigSpecOperationAdd))
+				Expect(tpmSpec.Device).To(BeAssignableToTypeOf(&vimTypes.VirtualTPM{}))
+
+				diskSpec := configSpec.DeviceChange[1].GetVirtualDeviceConfigSpec()
+				Expect(diskSpec.Operation).To(Equal(vimTypes.VirtualDeviceConfigSpecOperationEdit))
+				Expect(diskSpec.Device.GetVirtualDevice().Key).To(Equal(disk.Key))
+				Expect(diskSpec.Backing).ToNot(BeNil())
+				Expect(diskSpec.Backing.Crypto).To(Equal(configSpec.Crypto))
+			})
+
+			Context("DeviceChange already edits the disk", func() {
+				BeforeEach(func() {
+					configSpec.DeviceChange = []vimTypes.BaseVirtualDeviceConfigSpec{
+						&vimTypes.VirtualDeviceConfigSpec{
+							Operation: vimTypes.VirtualDeviceConfigSpecOperationEdit,
+							Device:    disk,
+						},
+					}
+				})
+
+				It("Sets the crypto backing on the existing disk edit", func() {
+					Expect(configSpec.DeviceChange).To(HaveLen(2))
+					diskSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+					Expect(diskSpec.Backing).ToNot(BeNil())
+					Expect(diskSpec.Backing.Crypto).To(Equal(configSpec.Crypto))
+				})
+			})
+
+			Context("VM home is encrypted with a vTPM but the disk is not", func() {
+				BeforeEach(func() {
+					config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key", ProviderId: &vimTypes.KeyProviderId{Id: "my-kp"}}
+					config.Hardware.Device = append(config.Hardware.Device, &vimTypes.VirtualTPM{})
+				})
+
+				It("Encrypts only the disk", func() {
+					Expect(configSpec.Crypto).To(BeNil())
+					Expect(configSpec.DeviceChange).To(HaveLen(1))
+					diskSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+					Expect(diskSpec.Device.GetVirtualDevice().Key).To(Equal(disk.Key))
+					Expect(diskSpec.Backing.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecEncrypt{}))
+				})
+			})
+
+			Context("VM and its disk are already encrypted with a vTPM", func() {
+				BeforeEach(func() {
+					config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key", ProviderId: &vimTypes.KeyProviderId{Id: "my-kp"}}
+					diskBacking.KeyId = &vimTypes.CryptoKeyId{KeyId: "disk-key", ProviderId: &vimTypes.KeyProviderId{Id: "my-kp"}}
+					config.Hardware.Device = append(config.Hardware.Device, &vimTypes.VirtualTPM{})
+				})
+
+				It("config spec show no changes", func() {
+					Expect(configSpec.Crypto).To(BeNil())
+					Expect(configSpec.DeviceChange).To(BeEmpty())
+				})
+			})
+
+			Context("VM and its disk are encrypted with another key provider", func() {
+				BeforeEach(func() {
+					config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key", ProviderId: &vimTypes.KeyProviderId{Id: "old-kp"}}
+					diskBacking.KeyId = &vimTypes.CryptoKeyId{KeyId: "disk-key", ProviderId: &vimTypes.KeyProviderId{Id: "old-kp"}}
+					config.Hardware.Device = append(config.Hardware.Device, &vimTypes.VirtualTPM{})
+				})
+
+				It("Rekeys the VM and its disk with the new key provider", func() {
+					Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecShallowRecrypt{}))
+					Expect(configSpec.Crypto.(*vimTypes.CryptoSpecShallowRecrypt).NewKeyId.ProviderId.Id).To(Equal("my-kp"))
+
+					Expect(configSpec.DeviceChange).To(HaveLen(1))
+					diskSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+					Expect(diskSpec.Device.GetVirtualDevice().Key).To(Equal(disk.Key))
+					Expect(diskSpec.Backing.Crypto).To(Equal(configSpec.Crypto))
+				})
+			})
+		})
+
+		Context("VM is to have only a vTPM", func() {
+			BeforeEach(func() {
+				vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{VTPM: true}
+			})
+
+			It("Encrypts only the VM home and adds a vTPM", func() {
+				Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecEncrypt{}))
+				Expect(configSpec.DeviceChange).To(HaveLen(1))
+				Expect(configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec().Device).To(BeAssignableToTypeOf(&vimTypes.VirtualTPM{}))
+			})
+
+			Context("VM and its disk are encrypted with a vTPM", func() {
+				BeforeEach(func() {
+					config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key"}
+					diskBacking.KeyId = &vimTypes.CryptoKeyId{KeyId: "disk-key"}
+					config.Hardware.Device = append(config.Hardware.Device, &vimTypes.VirtualTPM{})
+				})
+
+				It("Decrypts only the disk", func() {
+					Expect(configSpec.Crypto).To(BeNil())
+					Expect(configSpec.DeviceChange).To(HaveLen(1))
+					diskSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+					Expect(diskSpec.Device.GetVirtualDevice().Key).To(Equal(disk.Key))
+					Expect(diskSpec.Backing.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecDecrypt{}))
+				})
+			})
+		})
+
+		Context("VM is encrypted with a vTPM and has no crypto spec", func() {
+			BeforeEach(func() {
+				config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key"}
+				diskBacking.KeyId = &vimTypes.CryptoKeyId{KeyId: "disk-key"}
+				config.Hardware.Device = append(config.Hardware.Device, &vimTypes.VirtualTPM{})
+			})
+
+			It("config spec show no changes", func() {
+				Expect(configSpec.Crypto).To(BeNil())
+				Expect(configSpec.DeviceChange).To(BeEmpty())
+			})
+		})
+
+		Context("VM is encrypted with a vTPM but crypto spec is neither encrypted nor has a vTPM", func() {
+			var vTPM *vimTypes.VirtualTPM
+
+			BeforeEach(func() {
+				vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{}
+				vTPM = &vimTypes.VirtualTPM{VirtualDevice: vimTypes.VirtualDevice{Key: 200}}
+				config.KeyId = &vimTypes.CryptoKeyId{KeyId: "key"}
+				diskBacking.KeyId = &vimTypes.CryptoKeyId{KeyId: "disk-key"}
+				config.Hardware.Device = append(config.Hardware.Device, vTPM)
+			})
+
+			It("Decrypts the VM and its non-FCD disks, and removes the vTPM", func() {
+				Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimTypes.CryptoSpecDecrypt{}))
+
+				Expect(configSpec.DeviceChange).To(HaveLen(2))
+				tpmSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+				Expect(tpmSpec.Operation).To(Equal(vimTypes.VirtualDeviceConfigSpecOperationRemove))
+				Expect(tpmSpec.Device).To(Equal(vTPM))
+
+				diskSpec := configSpec.DeviceChange[1].GetVirtualDeviceConfigSpec()
+				Expect(diskSpec.Device.GetVirtualDevice().Key).To(Equal(disk.Key))
+				Expect(diskSpec.Backing.Crypto).To(Equal(configSpec.Crypto))
+			})
+		})
 	})
 
 	Context("Ethernet Card Changes", func() {
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	goctx "context"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/pbm"
+	pbmTypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/vim25"
+	vimTypes "github.com/vmware/govmomi/vim25/types"
+)
+
+// GetCompatibleDatastores returns the datastores, of the ones given, that are compatible with the
+// storage profile.
+func GetCompatibleDatastores(
+	ctx goctx.Context,
+	vimClient *vim25.Client,
+	datastores []vimTypes.ManagedObjectReference,
+	storageProfileID string) ([]vimTypes.ManagedObjectReference, error) {
+
+	if len(datastores) == 0 {
+		return nil, nil
+	}
+
+	c, err := pbm.NewClient(ctx, vimClient)
+	if err != nil {
+		return nil, err
+	}
+
+	hubs := make([]pbmTypes.PbmPlacementHub, 0, len(datastores))
+	for _, ds := range datastores {
+		hubs = append(hubs, pbmTypes.PbmPlacementHub{HubType: ds.Type, HubId: ds.Value})
+	}
+
+	req := []pbmTypes.BasePbmPlacementRequirement{
+		&pbmTypes.PbmPlacementCapabilityProfileRequirement{
+			ProfileId: pbmTypes.PbmProfileId{UniqueId: storageProfileID},
+		},
+	}
+
+	result, err := c.CheckRequirements(ctx, hubs, nil, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to check the requirements of storage profile ID: %s", storageProfileID)
+	}
+
+	var compatible []vimTypes.ManagedObjectReference
+	for _, hub := range result.CompatibleDatastores() {
+		compatible = append(compatible, vimTypes.ManagedObjectReference{Type: hub.HubType, Value: hub.HubId})
+	}
+
+	return compatible, nil
+}
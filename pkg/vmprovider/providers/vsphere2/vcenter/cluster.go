@@ -8,7 +8,9 @@ import (
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // ClusterMinCPUFreq returns the minimum frequency across all the hosts in the cluster. This is needed to
@@ -42,3 +44,114 @@ func ClusterMinCPUFreq(ctx goctx.Context, cluster *object.ClusterComputeResource
 
 	return minFreq, nil
 }
+
+// ClusterHostIncompatibilities returns the reasons why no host in the cluster meets the requirements,
+// or nil if at least one host does.
+func ClusterHostIncompatibilities(
+	ctx goctx.Context,
+	cluster *object.ClusterComputeResource,
+	req HostRequirements) ([]string, error) {
+
+	hosts, err := GetClusterHosts(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return HostIncompatibilities(hosts, req), nil
+}
+
+// GetClusterHosts returns the hosts in the cluster with the properties needed to check the
+// HostRequirements.
+func GetClusterHosts(ctx goctx.Context, cluster *object.ClusterComputeResource) ([]mo.HostSystem, error) {
+	var cr mo.ComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"host"}, &cr); err != nil {
+		return nil, err
+	}
+
+	if len(cr.Host) == 0 {
+		return nil, nil
+	}
+
+	var hosts []mo.HostSystem
+	pc := property.DefaultCollector(cluster.Client())
+	if err := pc.Retrieve(ctx, cr.Host, hostRequirementsProperties, &hosts); err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
+
+// HostIncompatibilities returns the reasons why none of the hosts meets the requirements, or nil
+// if at least one host does.
+func HostIncompatibilities(hosts []mo.HostSystem, req HostRequirements) []string {
+	if len(hosts) == 0 {
+		return []string{"cluster has no hosts"}
+	}
+
+	var reasons []string
+	seen := map[string]struct{}{}
+	for _, h := range hosts {
+		hostReasons := hostIncompatibilities(h, req)
+		if len(hostReasons) == 0 {
+			return nil
+		}
+
+		for _, r := range hostReasons {
+			if _, ok := seen[r]; !ok {
+				seen[r] = struct{}{}
+				reasons = append(reasons, r)
+			}
+		}
+	}
+
+	return reasons
+}
+
+// GetClusterVMMoIDs returns the MoIDs of the VMs in the cluster.
+func GetClusterVMMoIDs(ctx goctx.Context, cluster *object.ClusterComputeResource) ([]string, error) {
+	v, err := view.NewManager(cluster.Client()).CreateContainerView(
+		ctx, cluster.Reference(), []string{"VirtualMachine"}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = v.Destroy(ctx)
+	}()
+
+	refs, err := v.Find(ctx, []string{"VirtualMachine"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	moIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		moIDs = append(moIDs, ref.Value)
+	}
+
+	return moIDs, nil
+}
+
+// GetClusterRules returns the DRS rules of the cluster.
+func GetClusterRules(ctx goctx.Context, cluster *object.ClusterComputeResource) ([]types.BaseClusterRuleInfo, error) {
+	config, err := cluster.Configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.Rule, nil
+}
+
+// ReconfigureCluster applies the incremental DRS rule and group changes in the spec to the cluster.
+func ReconfigureCluster(
+	ctx goctx.Context,
+	cluster *object.ClusterComputeResource,
+	spec *types.ClusterConfigSpecEx) error {
+
+	t, err := cluster.Reconfigure(ctx, spec, true)
+	if err != nil {
+		return err
+	}
+
+	return t.Wait(ctx)
+}
@@ -7,12 +7,16 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/vmware/govmomi/vim25/types"
+
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
 func clusterTests() {
 	Describe("ClusterMinCPUFreq", minFreq)
+	Describe("Cluster VMs and rules", clusterVMsAndRules)
+	Describe("ClusterHostIncompatibilities", clusterHostIncompatibilities)
 }
 
 func minFreq() {
@@ -45,3 +49,128 @@ func minFreq() {
 		})
 	})
 }
+
+func clusterVMsAndRules() {
+	var (
+		ctx *builder.TestContextForVCSim
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("returns the VMs in the cluster", func() {
+		vcVM, err := ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		moIDs, err := vcenter.GetClusterVMMoIDs(ctx, ctx.GetSingleClusterCompute())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(moIDs).To(ContainElement(vcVM.Reference().Value))
+	})
+
+	It("adds and removes cluster rules", func() {
+		cluster := ctx.GetSingleClusterCompute()
+
+		vms, err := ctx.Finder.VirtualMachineList(ctx, "DC0_C0_RP0_VM*")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(vms)).To(BeNumerically(">=", 2))
+
+		rule := &types.ClusterAntiAffinityRuleSpec{
+			ClusterRuleInfo: types.ClusterRuleInfo{
+				Name:    "my-rule",
+				Enabled: types.NewBool(true),
+			},
+			Vm: []types.ManagedObjectReference{vms[0].Reference(), vms[1].Reference()},
+		}
+		Expect(vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{
+			RulesSpec: []types.ClusterRuleSpec{
+				{
+					ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+					Info:            rule,
+				},
+			},
+		})).To(Succeed())
+
+		rules, err := vcenter.GetClusterRules(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].GetClusterRuleInfo().Name).To(Equal("my-rule"))
+
+		Expect(vcenter.ReconfigureCluster(ctx, cluster, &types.ClusterConfigSpecEx{
+			RulesSpec: []types.ClusterRuleSpec{
+				{
+					ArrayUpdateSpec: types.ArrayUpdateSpec{
+						Operation: types.ArrayUpdateOperationRemove,
+						RemoveKey: rules[0].GetClusterRuleInfo().Key,
+					},
+				},
+			},
+		})).To(Succeed())
+
+		rules, err = vcenter.GetClusterRules(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+}
+
+func clusterHostIncompatibilities() {
+	var (
+		ctx *builder.TestContextForVCSim
+		req vcenter.HostRequirements
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+		// The vcsim hosts have 2 cores at 2294 MHz and just under 4 GiB of memory.
+		req = vcenter.HostRequirements{
+			NumCPUs:           2,
+			CPUReservationMHz: 1000,
+			MemoryBytes:       1024 * 1024 * 1024,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("returns no reasons when a host meets the requirements", func() {
+		reasons, err := vcenter.ClusterHostIncompatibilities(ctx, ctx.GetSingleClusterCompute(), req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reasons).To(BeEmpty())
+	})
+
+	It("returns the reasons when no host has enough CPU and memory", func() {
+		req.NumCPUs = 4
+		req.CPUReservationMHz = 10000
+		req.MemoryBytes = 8 * 1024 * 1024 * 1024
+
+		reasons, err := vcenter.ClusterHostIncompatibilities(ctx, ctx.GetSingleClusterCompute(), req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reasons).To(ConsistOf(
+			"not enough CPUs for 4 vCPUs",
+			"not enough CPU capacity for a 10000 MHz reservation",
+			"not enough memory for 8192 MiB",
+		))
+	})
+
+	It("returns the reasons when no host has the devices", func() {
+		req.VGPUProfiles = []string{"grid_p40-1q"}
+		req.DirectPathIODevices = []vcenter.PCIDeviceID{
+			{VendorID: 0x10de, DeviceID: 0x1eb8},
+			{VendorID: 0x10de, DeviceID: 0x1eb8},
+		}
+
+		reasons, err := vcenter.ClusterHostIncompatibilities(ctx, ctx.GetSingleClusterCompute(), req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reasons).To(ConsistOf(
+			`vGPU profile "grid_p40-1q" is not available`,
+			"2 DirectPath I/O devices with vendor ID 0x10de and device ID 0x1eb8 are not available",
+		))
+	})
+}
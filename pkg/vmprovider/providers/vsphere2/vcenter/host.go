@@ -39,3 +39,117 @@ func GetESXHostFQDN(
 	hostFQDN := strings.TrimSuffix(hostDNSConfig.HostName+"."+hostDNSConfig.DomainName, ".")
 	return strings.ToLower(hostFQDN), nil
 }
+
+// PCIDeviceID identifies a PCI device by its vendor and device IDs.
+type PCIDeviceID struct {
+	VendorID int64
+	DeviceID int64
+}
+
+// HostRequirements are the resources and devices a host must have to run a VM.
+type HostRequirements struct {
+	// NumCPUs is the number of vCPUs of the VM.
+	NumCPUs int64
+	// CPUReservationMHz is the CPU reservation of the VM in MHz.
+	CPUReservationMHz int64
+	// MemoryBytes is the memory size of the VM in bytes.
+	MemoryBytes int64
+	// VGPUProfiles are the names of the vGPU profiles of the VM.
+	VGPUProfiles []string
+	// DirectPathIODevices are the dynamic DirectPath I/O devices of the VM.
+	DirectPathIODevices []PCIDeviceID
+}
+
+// hostRequirementsProperties are the HostSystem properties needed to check the HostRequirements.
+var hostRequirementsProperties = []string{
+	"summary.hardware",
+	"hardware.pciDevice",
+	"config.pciPassthruInfo",
+	"config.sharedPassthruGpuTypes",
+}
+
+// hostIncompatibilities returns the reasons why the host does not meet the requirements.
+func hostIncompatibilities(host mo.HostSystem, req HostRequirements) []string {
+	hw := host.Summary.Hardware
+	if hw == nil {
+		return []string{"host hardware is unknown"}
+	}
+
+	var reasons []string
+
+	if req.NumCPUs > int64(hw.NumCpuThreads) {
+		reasons = append(reasons, fmt.Sprintf("not enough CPUs for %d vCPUs", req.NumCPUs))
+	}
+
+	if req.CPUReservationMHz > int64(hw.CpuMhz)*int64(hw.NumCpuCores) {
+		reasons = append(reasons, fmt.Sprintf("not enough CPU capacity for a %d MHz reservation", req.CPUReservationMHz))
+	}
+
+	if req.MemoryBytes > hw.MemorySize {
+		reasons = append(reasons, fmt.Sprintf("not enough memory for %d MiB", req.MemoryBytes/(1024*1024)))
+	}
+
+	var gpuTypes []string
+	if host.Config != nil {
+		gpuTypes = host.Config.SharedPassthruGpuTypes
+	}
+
+	for _, profile := range req.VGPUProfiles {
+		found := false
+		for _, gpuType := range gpuTypes {
+			if gpuType == profile {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			reasons = append(reasons, fmt.Sprintf("vGPU profile %q is not available", profile))
+		}
+	}
+
+	if len(req.DirectPathIODevices) > 0 {
+		required := map[PCIDeviceID]int{}
+		for _, dev := range req.DirectPathIODevices {
+			required[dev]++
+		}
+
+		available := hostPassthruDevices(host)
+		for _, dev := range req.DirectPathIODevices {
+			if count := required[dev]; count > available[dev] {
+				reasons = append(reasons, fmt.Sprintf(
+					"%d DirectPath I/O devices with vendor ID 0x%x and device ID 0x%x are not available",
+					count, dev.VendorID, dev.DeviceID))
+				// Only report each device once.
+				delete(required, dev)
+			}
+		}
+	}
+
+	return reasons
+}
+
+// hostPassthruDevices returns the number of PCI devices of the host, by their vendor and device IDs,
+// that have passthrough enabled.
+func hostPassthruDevices(host mo.HostSystem) map[PCIDeviceID]int {
+	if host.Hardware == nil || host.Config == nil {
+		return nil
+	}
+
+	enabled := map[string]bool{}
+	for _, info := range host.Config.PciPassthruInfo {
+		if i := info.GetHostPciPassthruInfo(); i.PassthruEnabled {
+			enabled[i.Id] = true
+		}
+	}
+
+	devices := map[PCIDeviceID]int{}
+	for _, dev := range host.Hardware.PciDevice {
+		if enabled[dev.Id] {
+			// The IDs are unsigned 16-bit values.
+			devices[PCIDeviceID{VendorID: int64(uint16(dev.VendorId)), DeviceID: int64(uint16(dev.DeviceId))}]++
+		}
+	}
+
+	return devices
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
@@ -95,22 +96,60 @@ func CreateOrUpdateChildResourcePool(
 		return "", err
 	}
 
-	spec := types.DefaultResourceConfigSpec() // TODO Set reservations & limits from rpSpec
-
 	if childRP == nil {
+		spec := types.DefaultResourceConfigSpec() // TODO Set reservations & limits from rpSpec
+		setSharesAndExpandableReservation(&spec, rpSpec)
+
 		rp, err := parentRP.Create(ctx, rpSpec.Name, spec)
 		if err != nil {
 			return "", err
 		}
 
 		childRP = rp
-	} else { //nolint
-		// TODO: 		//       Finish this clause
+	} else {
+		var o mo.ResourcePool
+		if err := childRP.Properties(ctx, childRP.Reference(), []string{"config"}, &o); err != nil {
+			return "", err
+		}
+
+		// Only the shares and expandable reservations are updated. Unset fields are left unchanged.
+		spec := types.ResourceConfigSpec{}
+		setSharesAndExpandableReservation(&spec, rpSpec)
+
+		if !sharesAndExpandableReservationEqual(o.Config.CpuAllocation, spec.CpuAllocation) ||
+			!sharesAndExpandableReservationEqual(o.Config.MemoryAllocation, spec.MemoryAllocation) {
+			if err := childRP.UpdateConfig(ctx, "", &spec); err != nil {
+				return "", err
+			}
+		}
 	}
 
 	return childRP.Reference().Value, nil
 }
 
+// GetChildResourcePoolRuntime returns the runtime info of the named child ResourcePool under the parent
+// ResourcePool, or nil if the child ResourcePool does not exist.
+func GetChildResourcePoolRuntime(
+	ctx goctx.Context,
+	vimClient *vim25.Client,
+	parentRPMoID, childName string) (*types.ResourcePoolRuntimeInfo, error) {
+
+	parentRP := object.NewResourcePool(vimClient,
+		types.ManagedObjectReference{Type: "ResourcePool", Value: parentRPMoID})
+
+	childRP, err := findChildRP(ctx, parentRP, childName)
+	if err != nil || childRP == nil {
+		return nil, err
+	}
+
+	var o mo.ResourcePool
+	if err := childRP.Properties(ctx, childRP.Reference(), []string{"runtime"}, &o); err != nil {
+		return nil, err
+	}
+
+	return &o.Runtime, nil
+}
+
 // DeleteChildResourcePool deletes the child ResourcePool under the parent ResourcePool.
 func DeleteChildResourcePool(
 	ctx goctx.Context,
@@ -141,6 +180,45 @@ func DeleteChildResourcePool(
 	return nil
 }
 
+var sharesLevels = map[vmopv1.ResourcePoolSharesLevel]types.SharesLevel{
+	vmopv1.ResourcePoolSharesLevelLow:    types.SharesLevelLow,
+	vmopv1.ResourcePoolSharesLevelNormal: types.SharesLevelNormal,
+	vmopv1.ResourcePoolSharesLevelHigh:   types.SharesLevelHigh,
+	vmopv1.ResourcePoolSharesLevelCustom: types.SharesLevelCustom,
+}
+
+// setSharesAndExpandableReservation sets the CPU and memory shares and expandable reservations of the
+// ResourcePool config spec, using the vSphere defaults for those not set in the rpSpec.
+func setSharesAndExpandableReservation(spec *types.ResourceConfigSpec, rpSpec *vmopv1.ResourcePoolSpec) {
+	setAllocation := func(info *types.ResourceAllocationInfo, shares *vmopv1.ResourcePoolShares, expandable *bool) {
+		info.Shares = &types.SharesInfo{Level: types.SharesLevelNormal}
+		if shares != nil {
+			if level, ok := sharesLevels[shares.Level]; ok {
+				info.Shares.Level = level
+			}
+			if info.Shares.Level == types.SharesLevelCustom {
+				info.Shares.Shares = shares.Shares
+			}
+		}
+
+		info.ExpandableReservation = types.NewBool(expandable == nil || *expandable)
+	}
+
+	setAllocation(&spec.CpuAllocation, rpSpec.Shares.CPU, rpSpec.ExpandableReservation.CPU)
+	setAllocation(&spec.MemoryAllocation, rpSpec.Shares.Memory, rpSpec.ExpandableReservation.Memory)
+}
+
+func sharesAndExpandableReservationEqual(current, desired types.ResourceAllocationInfo) bool {
+	if current.Shares == nil || current.Shares.Level != desired.Shares.Level {
+		return false
+	}
+	if desired.Shares.Level == types.SharesLevelCustom && current.Shares.Shares != desired.Shares.Shares {
+		return false
+	}
+
+	return current.ExpandableReservation != nil && *current.ExpandableReservation == *desired.ExpandableReservation
+}
+
 func findChildRP(
 	ctx goctx.Context,
 	parentRP *object.ResourcePool,
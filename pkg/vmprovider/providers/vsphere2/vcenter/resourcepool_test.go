@@ -8,6 +8,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/pointer"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 
@@ -144,6 +147,43 @@ func createDeleteExistResourcePoolChild() {
 			})
 		})
 
+		It("creates child ResourcePool with shares and expandable reservations", func() {
+			resourcePolicy.Spec.ResourcePool.Name = "my-shares-child-rp"
+			resourcePolicy.Spec.ResourcePool.Shares.CPU = &vmopv1.ResourcePoolShares{
+				Level:  vmopv1.ResourcePoolSharesLevelCustom,
+				Shares: 4242,
+			}
+			resourcePolicy.Spec.ResourcePool.Shares.Memory = &vmopv1.ResourcePoolShares{
+				Level: vmopv1.ResourcePoolSharesLevelHigh,
+			}
+			resourcePolicy.Spec.ResourcePool.ExpandableReservation.Memory = pointer.Bool(false)
+
+			childMoID, err := vcenter.CreateOrUpdateChildResourcePool(ctx, ctx.VCClient.Client, parentRPMoID, &resourcePolicy.Spec.ResourcePool)
+			Expect(err).ToNot(HaveOccurred())
+
+			rp := object.NewResourcePool(ctx.VCClient.Client, types.ManagedObjectReference{Type: "ResourcePool", Value: childMoID})
+			var o mo.ResourcePool
+			Expect(rp.Properties(ctx, rp.Reference(), []string{"config"}, &o)).To(Succeed())
+
+			cpu, mem := o.Config.CpuAllocation, o.Config.MemoryAllocation
+			Expect(cpu.Shares.Level).To(Equal(types.SharesLevelCustom))
+			Expect(cpu.Shares.Shares).To(BeEquivalentTo(4242))
+			Expect(*cpu.ExpandableReservation).To(BeTrue())
+			Expect(mem.Shares.Level).To(Equal(types.SharesLevelHigh))
+			Expect(*mem.ExpandableReservation).To(BeFalse())
+
+			By("updates the shares of the existing child ResourcePool", func() {
+				resourcePolicy.Spec.ResourcePool.Shares.CPU = nil
+
+				_, err := vcenter.CreateOrUpdateChildResourcePool(ctx, ctx.VCClient.Client, parentRPMoID, &resourcePolicy.Spec.ResourcePool)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(rp.Properties(ctx, rp.Reference(), []string{"config"}, &o)).To(Succeed())
+				Expect(o.Config.CpuAllocation.Shares.Level).To(Equal(types.SharesLevelNormal))
+				Expect(o.Config.MemoryAllocation.Shares.Level).To(Equal(types.SharesLevelHigh))
+			})
+		})
+
 		It("returns error when when parent ResourcePool MoID does not exist", func() {
 			childMoID, err := vcenter.CreateOrUpdateChildResourcePool(ctx, ctx.VCClient.Client, "bogus", &resourcePolicy.Spec.ResourcePool)
 			Expect(err).To(HaveOccurred())
@@ -176,6 +216,23 @@ func createDeleteExistResourcePoolChild() {
 		})
 	})
 
+	Context("GetChildResourcePoolRuntime", func() {
+		It("returns the runtime info when child ResourcePool exists", func() {
+			_, err := vcenter.CreateOrUpdateChildResourcePool(ctx, ctx.VCClient.Client, parentRPMoID, &resourcePolicy.Spec.ResourcePool)
+			Expect(err).ToNot(HaveOccurred())
+
+			runtime, err := vcenter.GetChildResourcePoolRuntime(ctx, ctx.VCClient.Client, parentRPMoID, resourcePolicy.Spec.ResourcePool.Name)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runtime).ToNot(BeNil())
+		})
+
+		It("returns nil when child ResourcePool does not exist", func() {
+			runtime, err := vcenter.GetChildResourcePoolRuntime(ctx, ctx.VCClient.Client, parentRPMoID, "bogus")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runtime).To(BeNil())
+		})
+	})
+
 	Context("DeleteChildResourcePool", func() {
 		It("deletes child ResourcePool", func() {
 			childName := resourcePolicy.Spec.ResourcePool.Name
@@ -19,6 +19,7 @@ func vcSimTests() {
 	Describe("GetVM", getVMTests)
 	Describe("Host", hostTests)
 	Describe("ResourcePool", resourcePoolTests)
+	Describe("WatchVM", watchVMTests)
 }
 
 func TestVCenter(t *testing.T) {
@@ -0,0 +1,62 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vcenter
+
+import (
+	goctx "context"
+
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// watchedVMProperties are the VM properties whose changes are reported by WatchVirtualMachines.
+// The recentTask property changes whenever a task on the VM is queued, started, or completed.
+var watchedVMProperties = []string{
+	"runtime.powerState",
+	"guest.ipAddress",
+	"guest.net",
+	"guestHeartbeatStatus",
+	"recentTask",
+}
+
+// WatchVirtualMachines uses a PropertyCollector to wait for changes to the power state, guest
+// IPs, heartbeat, and tasks of all the VMs in VC, and calls onUpdate with the MoIDs of the VMs
+// in each set of updates. The first set of updates contains every VM. This blocks until the
+// context is cancelled or an error occurs.
+func WatchVirtualMachines(
+	ctx goctx.Context,
+	vimClient *vim25.Client,
+	onUpdate func(moIDs []string)) error {
+
+	v, err := view.NewManager(vimClient).CreateContainerView(
+		ctx, vimClient.ServiceContent.RootFolder, []string{"VirtualMachine"}, true)
+	if err != nil {
+		return err
+	}
+
+	// Like the collector, destroy the view using the background context since the
+	// specified context is likely cancelled by now.
+	defer func() {
+		_ = v.Destroy(goctx.Background())
+	}()
+
+	ts := &types.TraversalSpec{
+		Type: "ContainerView",
+		Path: "view",
+		Skip: types.NewBool(false),
+	}
+	filter := new(property.WaitFilter).Add(v.Reference(), "VirtualMachine", watchedVMProperties, ts)
+	filter.Spec.ObjectSet[0].Skip = types.NewBool(true)
+
+	return property.WaitForUpdates(ctx, property.DefaultCollector(vimClient), filter, func(updates []types.ObjectUpdate) bool {
+		moIDs := make([]string, 0, len(updates))
+		for _, update := range updates {
+			moIDs = append(moIDs, update.Obj.Value)
+		}
+		onUpdate(moIDs)
+		return false
+	})
+}
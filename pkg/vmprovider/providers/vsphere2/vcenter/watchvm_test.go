@@ -0,0 +1,77 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vcenter_test
+
+import (
+	goctx "context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func watchVMTests() {
+	Describe("WatchVirtualMachines", watchVM)
+}
+
+func watchVM() {
+	// Use a VM that vcsim creates for us.
+	const vcVMName = "DC0_C0_RP0_VM0"
+
+	var (
+		ctx *builder.TestContextForVCSim
+
+		vcVM     *object.VirtualMachine
+		cancel   goctx.CancelFunc
+		updates  chan []string
+		watchErr chan error
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, vcVMName)
+		Expect(err).ToNot(HaveOccurred())
+
+		var watchCtx goctx.Context
+		watchCtx, cancel = goctx.WithCancel(ctx)
+		updates = make(chan []string, 16)
+		watchErr = make(chan error, 1)
+
+		go func() {
+			watchErr <- vcenter.WatchVirtualMachines(watchCtx, ctx.VCClient.Client, func(moIDs []string) {
+				updates <- moIDs
+			})
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(watchErr).Should(Receive(BeNil()))
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("returns all the VMs and then the VMs that changed", func() {
+		By("initial updates", func() {
+			var moIDs []string
+			Eventually(updates).Should(Receive(&moIDs))
+			Expect(moIDs).To(ContainElement(vcVM.Reference().Value))
+			Expect(len(moIDs)).To(BeNumerically(">", 1))
+		})
+
+		By("power state change", func() {
+			task, err := vcVM.PowerOff(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(task.Wait(ctx)).To(Succeed())
+
+			Eventually(updates).Should(Receive(ContainElement(vcVM.Reference().Value)))
+		})
+	})
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+)
+
+// AffinityTerm is one of the VM affinity or anti-affinity terms of a VM.
+type AffinityTerm struct {
+	vmopv1.VMAffinityTerm
+
+	// Name identifies the term within the VM's affinity, and is used to name the
+	// term's DRS rule.
+	Name string
+	// AntiAffinity is true when the term is a VM anti-affinity term.
+	AntiAffinity bool
+	// Required is true when the term must be satisfied.
+	Required bool
+}
+
+// GetAffinityTerms returns the VM affinity and anti-affinity terms of the VM.
+func GetAffinityTerms(vm *vmopv1.VirtualMachine) []AffinityTerm {
+	affinity := vm.Spec.Affinity
+	if affinity == nil {
+		return nil
+	}
+
+	var terms []AffinityTerm
+	add := func(spec *vmopv1.VirtualMachineAffinityVMAffinitySpec, kind string, antiAffinity bool) {
+		if spec == nil {
+			return
+		}
+		for i, t := range spec.Required {
+			terms = append(terms, AffinityTerm{
+				VMAffinityTerm: t,
+				Name:           fmt.Sprintf("%s-required-%d", kind, i),
+				AntiAffinity:   antiAffinity,
+				Required:       true,
+			})
+		}
+		for i, t := range spec.Preferred {
+			terms = append(terms, AffinityTerm{
+				VMAffinityTerm: t,
+				Name:           fmt.Sprintf("%s-preferred-%d", kind, i),
+				AntiAffinity:   antiAffinity,
+			})
+		}
+	}
+
+	add(affinity.VMAffinity, "affinity", false)
+	add(affinity.VMAntiAffinity, "antiaffinity", true)
+
+	return terms
+}
+
+// GetAffinityTermVMs returns the VMs in the VM's Namespace, other than the VM itself, that are
+// selected by the term.
+func GetAffinityTermVMs(
+	vmCtx context.VirtualMachineContextA2,
+	k8sClient ctrlclient.Client,
+	term AffinityTerm) ([]vmopv1.VirtualMachine, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector of affinity term %s: %w", term.Name, err)
+	}
+
+	vmList := &vmopv1.VirtualMachineList{}
+	if err := k8sClient.List(vmCtx, vmList,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	vms := make([]vmopv1.VirtualMachine, 0, len(vmList.Items))
+	for _, vm := range vmList.Items {
+		if vm.Name != vmCtx.VM.Name {
+			vms = append(vms, vm)
+		}
+	}
+
+	return vms, nil
+}
+
+// affinityRuleNamePrefix returns the prefix of the names of the VM's DRS VM-VM rules. Since a "/"
+// is not allowed in a Namespace or VM name, the prefix of one VM is never a prefix of another's.
+func affinityRuleNamePrefix(vm *vmopv1.VirtualMachine) string {
+	return fmt.Sprintf("vmoperator/%s/%s/", vm.Namespace, vm.Name)
+}
+
+// ReconcileAffinityRules creates, updates, and deletes the DRS VM-VM rules on the VM's cluster so
+// that there is a rule for each of the VM's host topology affinity terms. Each rule contains the VM
+// and the VMs selected by the term that are in the same cluster, and is mandatory when the term is
+// required. A rule is not created until the term selects another VM in the cluster because DRS
+// requires at least two VMs in a rule.
+//
+// The VM is annotated before its first rule is created, and the annotation is removed once the VM
+// has no rules, so the cluster's rules are only looked up for VMs that have or had a host
+// topology affinity term.
+func ReconcileAffinityRules(
+	vmCtx context.VirtualMachineContextA2,
+	k8sClient ctrlclient.Client,
+	vcVM *object.VirtualMachine,
+	cluster *object.ClusterComputeResource) error {
+
+	if !hasHostAffinityTerms(vmCtx.VM) && !hasAffinityRules(vmCtx.VM) {
+		return nil
+	}
+
+	existingRules, err := getAffinityRules(vmCtx, cluster)
+	if err != nil {
+		return err
+	}
+
+	desiredRules, err := desiredAffinityRules(vmCtx, k8sClient, vcVM, cluster)
+	if err != nil {
+		return err
+	}
+
+	var ruleSpecs []types.ClusterRuleSpec
+
+	for name, rule := range desiredRules {
+		existingRule, ok := existingRules[name]
+		if !ok {
+			ruleSpecs = append(ruleSpecs, types.ClusterRuleSpec{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+				Info:            rule,
+			})
+			continue
+		}
+
+		if !affinityRuleEqual(existingRule, rule) {
+			rule.GetClusterRuleInfo().Key = existingRule.GetClusterRuleInfo().Key
+			ruleSpecs = append(ruleSpecs, types.ClusterRuleSpec{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationEdit},
+				Info:            rule,
+			})
+		}
+	}
+
+	for name, existingRule := range existingRules {
+		if _, ok := desiredRules[name]; !ok {
+			ruleSpecs = append(ruleSpecs, removeClusterRuleSpec(existingRule))
+		}
+	}
+
+	if len(desiredRules) > 0 {
+		if vmCtx.VM.Annotations == nil {
+			vmCtx.VM.Annotations = map[string]string{}
+		}
+		vmCtx.VM.Annotations[constants.AffinityRulesAnnotation] = "true"
+	}
+
+	if len(ruleSpecs) > 0 {
+		vmCtx.Logger.V(4).Info("Updating DRS VM affinity rules", "clusterMoID", cluster.Reference().Value)
+		if err := vcenter.ReconfigureCluster(vmCtx, cluster, &types.ClusterConfigSpecEx{RulesSpec: ruleSpecs}); err != nil {
+			return err
+		}
+	}
+
+	if len(desiredRules) == 0 {
+		delete(vmCtx.VM.Annotations, constants.AffinityRulesAnnotation)
+	}
+
+	return nil
+}
+
+// DeleteAffinityRules deletes the VM's DRS VM-VM rules from the VM's cluster. Nothing is done when
+// no rules were created for the VM.
+func DeleteAffinityRules(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine) error {
+
+	if !hasAffinityRules(vmCtx.VM) {
+		return nil
+	}
+
+	cluster, err := GetVMClusterComputeResource(vmCtx, vcVM)
+	if err != nil {
+		return err
+	}
+
+	existingRules, err := getAffinityRules(vmCtx, cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(existingRules) == 0 {
+		return nil
+	}
+
+	ruleSpecs := make([]types.ClusterRuleSpec, 0, len(existingRules))
+	for _, existingRule := range existingRules {
+		ruleSpecs = append(ruleSpecs, removeClusterRuleSpec(existingRule))
+	}
+
+	vmCtx.Logger.V(4).Info("Deleting DRS VM affinity rules", "clusterMoID", cluster.Reference().Value)
+	return vcenter.ReconfigureCluster(vmCtx, cluster, &types.ClusterConfigSpecEx{RulesSpec: ruleSpecs})
+}
+
+// hasHostAffinityTerms returns true if the VM has an affinity term whose topology is the host.
+func hasHostAffinityTerms(vm *vmopv1.VirtualMachine) bool {
+	for _, term := range GetAffinityTerms(vm) {
+		if term.TopologyKey == vmopv1.VirtualMachineAffinityTopologyKeyHost {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAffinityRules returns true if DRS VM-VM rules may have been created for the VM.
+func hasAffinityRules(vm *vmopv1.VirtualMachine) bool {
+	_, ok := vm.Annotations[constants.AffinityRulesAnnotation]
+	return ok
+}
+
+// getAffinityRules returns the VM's DRS VM-VM rules on the cluster by name.
+func getAffinityRules(
+	vmCtx context.VirtualMachineContextA2,
+	cluster *object.ClusterComputeResource) (map[string]types.BaseClusterRuleInfo, error) {
+
+	rules, err := vcenter.GetClusterRules(vmCtx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := affinityRuleNamePrefix(vmCtx.VM)
+	vmRules := map[string]types.BaseClusterRuleInfo{}
+	for _, rule := range rules {
+		if name := rule.GetClusterRuleInfo().Name; strings.HasPrefix(name, prefix) {
+			vmRules[name] = rule
+		}
+	}
+
+	return vmRules, nil
+}
+
+func desiredAffinityRules(
+	vmCtx context.VirtualMachineContextA2,
+	k8sClient ctrlclient.Client,
+	vcVM *object.VirtualMachine,
+	cluster *object.ClusterComputeResource) (map[string]types.BaseClusterRuleInfo, error) {
+
+	rules := map[string]types.BaseClusterRuleInfo{}
+	var clusterVMMoIDs map[string]struct{}
+
+	for _, term := range GetAffinityTerms(vmCtx.VM) {
+		if term.TopologyKey != vmopv1.VirtualMachineAffinityTopologyKeyHost {
+			continue
+		}
+
+		if clusterVMMoIDs == nil {
+			moIDs, err := vcenter.GetClusterVMMoIDs(vmCtx, cluster)
+			if err != nil {
+				return nil, err
+			}
+
+			clusterVMMoIDs = make(map[string]struct{}, len(moIDs))
+			for _, moID := range moIDs {
+				clusterVMMoIDs[moID] = struct{}{}
+			}
+		}
+
+		vms, err := GetAffinityTermVMs(vmCtx, k8sClient, term)
+		if err != nil {
+			return nil, err
+		}
+
+		vmMoRefs := []types.ManagedObjectReference{vcVM.Reference()}
+		for _, vm := range vms {
+			if _, ok := clusterVMMoIDs[vm.Status.UniqueID]; ok {
+				vmMoRefs = append(vmMoRefs, types.ManagedObjectReference{Type: "VirtualMachine", Value: vm.Status.UniqueID})
+			}
+		}
+
+		if len(vmMoRefs) < 2 {
+			continue
+		}
+
+		sort.Slice(vmMoRefs, func(i, j int) bool { return vmMoRefs[i].Value < vmMoRefs[j].Value })
+
+		info := types.ClusterRuleInfo{
+			Name:        affinityRuleNamePrefix(vmCtx.VM) + term.Name,
+			Enabled:     types.NewBool(true),
+			Mandatory:   types.NewBool(term.Required),
+			UserCreated: types.NewBool(true),
+		}
+
+		if term.AntiAffinity {
+			rules[info.Name] = &types.ClusterAntiAffinityRuleSpec{ClusterRuleInfo: info, Vm: vmMoRefs}
+		} else {
+			rules[info.Name] = &types.ClusterAffinityRuleSpec{ClusterRuleInfo: info, Vm: vmMoRefs}
+		}
+	}
+
+	return rules, nil
+}
+
+// affinityRuleEqual returns true if the existing rule has the same type, mandatory setting, and
+// VMs as the desired rule.
+func affinityRuleEqual(existing, desired types.BaseClusterRuleInfo) bool {
+	var existingVMs, desiredVMs []types.ManagedObjectReference
+
+	switch r := existing.(type) {
+	case *types.ClusterAffinityRuleSpec:
+		if _, ok := desired.(*types.ClusterAffinityRuleSpec); !ok {
+			return false
+		}
+		existingVMs = r.Vm
+		desiredVMs = desired.(*types.ClusterAffinityRuleSpec).Vm
+	case *types.ClusterAntiAffinityRuleSpec:
+		if _, ok := desired.(*types.ClusterAntiAffinityRuleSpec); !ok {
+			return false
+		}
+		existingVMs = r.Vm
+		desiredVMs = desired.(*types.ClusterAntiAffinityRuleSpec).Vm
+	default:
+		return false
+	}
+
+	existingMandatory := existing.GetClusterRuleInfo().Mandatory
+	if (existingMandatory != nil && *existingMandatory) != *desired.GetClusterRuleInfo().Mandatory {
+		return false
+	}
+
+	if len(existingVMs) != len(desiredVMs) {
+		return false
+	}
+
+	vmMoIDs := make(map[string]struct{}, len(existingVMs))
+	for _, ref := range existingVMs {
+		vmMoIDs[ref.Value] = struct{}{}
+	}
+	for _, ref := range desiredVMs {
+		if _, ok := vmMoIDs[ref.Value]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func removeClusterRuleSpec(rule types.BaseClusterRuleInfo) types.ClusterRuleSpec {
+	return types.ClusterRuleSpec{
+		ArrayUpdateSpec: types.ArrayUpdateSpec{
+			Operation: types.ArrayUpdateOperationRemove,
+			RemoveKey: rule.GetClusterRuleInfo().Key,
+		},
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func affinityTests() {
+
+	var (
+		ctx     *builder.TestContextForVCSim
+		vmCtx   context.VirtualMachineContextA2
+		vcVM    *object.VirtualMachine
+		otherVM *vmopv1.VirtualMachine
+		cluster *object.ClusterComputeResource
+	)
+
+	BeforeEach(func() {
+		otherVM = builder.DummyVirtualMachineA2()
+		otherVM.Name = "other-vm"
+		otherVM.Namespace = "affinity-ns"
+		otherVM.Labels["app"] = "db"
+
+		vm := builder.DummyVirtualMachineA2()
+		vm.Name = "affinity-vm"
+		vm.Namespace = otherVM.Namespace
+		vm.Labels["app"] = "db"
+		vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+			VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+				Required: []vmopv1.VMAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+						TopologyKey:   vmopv1.VirtualMachineAffinityTopologyKeyHost,
+					},
+				},
+			},
+		}
+
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+		vcOtherVM, err := ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM1")
+		Expect(err).ToNot(HaveOccurred())
+		cluster = ctx.GetSingleClusterCompute()
+
+		vm.Status.UniqueID = vcVM.Reference().Value
+		otherVM.Status.UniqueID = vcOtherVM.Reference().Value
+		Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+		Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+
+		vmCtx = context.VirtualMachineContextA2{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vm.Name),
+			VM:      vm,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getRules := func() []types.BaseClusterRuleInfo {
+		rules, err := vcenter.GetClusterRules(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		return rules
+	}
+
+	It("GetAffinityTermVMs returns the other VMs selected by the term", func() {
+		terms := virtualmachine.GetAffinityTerms(vmCtx.VM)
+		Expect(terms).To(HaveLen(1))
+		Expect(terms[0].Name).To(Equal("antiaffinity-required-0"))
+		Expect(terms[0].AntiAffinity).To(BeTrue())
+		Expect(terms[0].Required).To(BeTrue())
+
+		vms, err := virtualmachine.GetAffinityTermVMs(vmCtx, ctx.Client, terms[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vms).To(HaveLen(1))
+		Expect(vms[0].Name).To(Equal(otherVM.Name))
+	})
+
+	It("Creates, updates, and deletes the DRS VM-VM rules", func() {
+		Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+
+		rules := getRules()
+		Expect(rules).To(HaveLen(1))
+		rule, ok := rules[0].(*types.ClusterAntiAffinityRuleSpec)
+		Expect(ok).To(BeTrue())
+		Expect(rule.Name).To(Equal("vmoperator/affinity-ns/affinity-vm/antiaffinity-required-0"))
+		Expect(*rule.Mandatory).To(BeTrue())
+		Expect(rule.Vm).To(ConsistOf(vcVM.Reference(), types.ManagedObjectReference{Type: "VirtualMachine", Value: otherVM.Status.UniqueID}))
+		Expect(vmCtx.VM.Annotations).To(HaveKey(constants.AffinityRulesAnnotation))
+
+		By("Reconcile again does not change the rule", func() {
+			key := rule.Key
+			Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+			rules := getRules()
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].GetClusterRuleInfo().Key).To(Equal(key))
+		})
+
+		By("Preferred term updates the rule to not be mandatory", func() {
+			vmCtx.VM.Spec.Affinity.VMAntiAffinity.Preferred = vmCtx.VM.Spec.Affinity.VMAntiAffinity.Required
+			vmCtx.VM.Spec.Affinity.VMAntiAffinity.Required = nil
+			Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+
+			rules := getRules()
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].GetClusterRuleInfo().Name).To(Equal("vmoperator/affinity-ns/affinity-vm/antiaffinity-preferred-0"))
+			Expect(*rules[0].GetClusterRuleInfo().Mandatory).To(BeFalse())
+		})
+
+		By("Removed affinity deletes the rule", func() {
+			vmCtx.VM.Spec.Affinity = nil
+			Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+			Expect(getRules()).To(BeEmpty())
+			Expect(vmCtx.VM.Annotations).ToNot(HaveKey(constants.AffinityRulesAnnotation))
+		})
+	})
+
+	It("Does not look up the rules of a VM without affinity rules", func() {
+		vmCtx.VM.Spec.Affinity = nil
+		// The cluster and VM are not used when there are no rules to reconcile.
+		Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, nil, nil)).To(Succeed())
+		Expect(virtualmachine.DeleteAffinityRules(vmCtx, nil)).To(Succeed())
+		Expect(vmCtx.VM.Annotations).ToNot(HaveKey(constants.AffinityRulesAnnotation))
+	})
+
+	It("Does not create a rule when the term selects no other VM in the cluster", func() {
+		vmCtx.VM.Spec.Affinity.VMAntiAffinity.Required[0].LabelSelector.MatchLabels["app"] = "web"
+		Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+		Expect(getRules()).To(BeEmpty())
+		Expect(vmCtx.VM.Annotations).ToNot(HaveKey(constants.AffinityRulesAnnotation))
+	})
+
+	It("DeleteAffinityRules deletes the VM's rules", func() {
+		Expect(virtualmachine.ReconcileAffinityRules(vmCtx, ctx.Client, vcVM, cluster)).To(Succeed())
+		Expect(getRules()).To(HaveLen(1))
+
+		Expect(virtualmachine.DeleteAffinityRules(vmCtx, vcVM)).To(Succeed())
+		Expect(getRules()).To(BeEmpty())
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/pointer"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+)
+
+// CreateBootOptions returns the vim25 boot options for the VM's boot options.
+// The boot order refers to the VM's devices, so a type of device in the boot
+// order is omitted when the VM does not have a device of that type. The disk
+// and network boot devices are the VM's first disk and first network interface.
+func CreateBootOptions(
+	bootOptions *vmopv1.VirtualMachineBootOptions,
+	devices object.VirtualDeviceList) *types.VirtualMachineBootOptions {
+
+	vimBootOptions := &types.VirtualMachineBootOptions{
+		EfiSecureBootEnabled: pointer.Bool(bootOptions.EFISecureBoot),
+		BootRetryEnabled:     pointer.Bool(bootOptions.BootRetry),
+	}
+
+	if d := bootOptions.BootDelay; d != nil {
+		vimBootOptions.BootDelay = d.Milliseconds()
+	}
+	if d := bootOptions.BootRetryDelay; d != nil {
+		vimBootOptions.BootRetryDelay = d.Milliseconds()
+	}
+
+	for _, bootableDevice := range bootOptions.BootOrder {
+		switch bootableDevice {
+		case vmopv1.VirtualMachineBootableDeviceDisk:
+			if disks := devices.SelectByType((*types.VirtualDisk)(nil)); len(disks) > 0 {
+				vimBootOptions.BootOrder = append(vimBootOptions.BootOrder,
+					&types.VirtualMachineBootOptionsBootableDiskDevice{DeviceKey: disks[0].GetVirtualDevice().Key})
+			}
+		case vmopv1.VirtualMachineBootableDeviceNetwork:
+			if ethCards := devices.SelectByType((*types.VirtualEthernetCard)(nil)); len(ethCards) > 0 {
+				vimBootOptions.BootOrder = append(vimBootOptions.BootOrder,
+					&types.VirtualMachineBootOptionsBootableEthernetDevice{DeviceKey: ethCards[0].GetVirtualDevice().Key})
+			}
+		case vmopv1.VirtualMachineBootableDeviceCDRom:
+			if cdroms := devices.SelectByType((*types.VirtualCdrom)(nil)); len(cdroms) > 0 {
+				vimBootOptions.BootOrder = append(vimBootOptions.BootOrder,
+					&types.VirtualMachineBootOptionsBootableCdromDevice{})
+			}
+		}
+	}
+
+	return vimBootOptions
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+)
+
+var _ = Describe("CreateBootOptions", func() {
+
+	var (
+		bootOptions *vmopv1.VirtualMachineBootOptions
+		devices     object.VirtualDeviceList
+	)
+
+	BeforeEach(func() {
+		bootOptions = &vmopv1.VirtualMachineBootOptions{
+			EFISecureBoot:  true,
+			BootRetry:      true,
+			BootDelay:      &metav1.Duration{Duration: 2 * time.Second},
+			BootRetryDelay: &metav1.Duration{Duration: 30 * time.Second},
+			BootOrder: []vmopv1.VirtualMachineBootableDevice{
+				vmopv1.VirtualMachineBootableDeviceCDRom,
+				vmopv1.VirtualMachineBootableDeviceNetwork,
+				vmopv1.VirtualMachineBootableDeviceDisk,
+			},
+		}
+		devices = object.VirtualDeviceList{
+			&types.VirtualCdrom{VirtualDevice: types.VirtualDevice{Key: 3000}},
+			&types.VirtualDisk{VirtualDevice: types.VirtualDevice{Key: 2000}},
+			&types.VirtualDisk{VirtualDevice: types.VirtualDevice{Key: 2001}},
+			&types.VirtualE1000{VirtualEthernetCard: types.VirtualEthernetCard{VirtualDevice: types.VirtualDevice{Key: 4000}}},
+		}
+	})
+
+	It("returns the boot options", func() {
+		vimBootOptions := virtualmachine.CreateBootOptions(bootOptions, devices)
+		Expect(vimBootOptions.EfiSecureBootEnabled).To(HaveValue(BeTrue()))
+		Expect(vimBootOptions.BootRetryEnabled).To(HaveValue(BeTrue()))
+		Expect(vimBootOptions.BootDelay).To(BeEquivalentTo(2000))
+		Expect(vimBootOptions.BootRetryDelay).To(BeEquivalentTo(30000))
+		Expect(vimBootOptions.BootOrder).To(Equal([]types.BaseVirtualMachineBootOptionsBootableDevice{
+			&types.VirtualMachineBootOptionsBootableCdromDevice{},
+			&types.VirtualMachineBootOptionsBootableEthernetDevice{DeviceKey: 4000},
+			&types.VirtualMachineBootOptionsBootableDiskDevice{DeviceKey: 2000},
+		}))
+	})
+
+	When("VM does not have the devices in the boot order", func() {
+		BeforeEach(func() {
+			devices = nil
+		})
+
+		It("omits the devices from the boot order", func() {
+			vimBootOptions := virtualmachine.CreateBootOptions(bootOptions, devices)
+			Expect(vimBootOptions.BootOrder).To(BeEmpty())
+		})
+	})
+})
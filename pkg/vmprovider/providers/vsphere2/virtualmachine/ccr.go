@@ -8,6 +8,9 @@ import (
 	"fmt"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 // GetVMClusterComputeResource returns the VM's ClusterComputeResource.
@@ -32,3 +35,48 @@ func GetVMClusterComputeResource(
 
 	return cluster, nil
 }
+
+// GetGuestOSDescriptor returns the descriptor of the guest OS from the
+// ClusterComputeResource's EnvironmentBrowser, or nil if the cluster does not
+// know the guest OS.
+func GetGuestOSDescriptor(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	guestID string) (*types.GuestOsDescriptor, error) {
+
+	if guestID == "" {
+		return nil, nil
+	}
+
+	var ccr mo.ClusterComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"environmentBrowser"}, &ccr); err != nil {
+		return nil, err
+	}
+	if ccr.EnvironmentBrowser == nil {
+		return nil, nil
+	}
+
+	req := types.QueryConfigOptionEx{
+		This: *ccr.EnvironmentBrowser,
+		Spec: &types.EnvironmentBrowserConfigOptionQuerySpec{
+			GuestId: []string{guestID},
+		},
+	}
+
+	res, err := methods.QueryConfigOptionEx(ctx, cluster.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Returnval == nil {
+		return nil, nil
+	}
+
+	// The descriptors are not filtered when the guest ID is not known.
+	for i := range res.Returnval.GuestOSDescriptor {
+		if desc := &res.Returnval.GuestOSDescriptor[i]; desc.Id == guestID {
+			return desc, nil
+		}
+	}
+
+	return nil, nil
+}
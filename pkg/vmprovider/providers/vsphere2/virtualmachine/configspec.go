@@ -47,7 +47,9 @@ func CreateConfigSpec(
 		Type:         vmopv1.ManagedByExtensionType,
 	}
 
-	if val, ok := vmCtx.VM.Annotations[constants.FirmwareOverrideAnnotation]; ok && (val == "efi" || val == "bios") {
+	if bootOptions := vmCtx.VM.Spec.BootOptions; bootOptions != nil && bootOptions.Firmware != "" {
+		configSpec.Firmware = string(bootOptions.Firmware)
+	} else if val, ok := vmCtx.VM.Annotations[constants.FirmwareOverrideAnnotation]; ok && (val == "efi" || val == "bios") {
 		configSpec.Firmware = val
 	} else if vmImageStatus != nil && vmImageStatus.Firmware != "" {
 		// Use the image's firmware type if present.
@@ -62,6 +64,30 @@ func CreateConfigSpec(
 		configSpec.ChangeTrackingEnabled = pointer.Bool(true)
 	}
 
+	if bootOptions := vmCtx.VM.Spec.BootOptions; bootOptions != nil {
+		// The VM's devices are not known until the VM is created, so the boot
+		// order is set when the VM is reconfigured before it is powered on.
+		configSpec.BootOptions = CreateBootOptions(bootOptions, nil)
+	}
+
+	if crypto := vmCtx.VM.Spec.Crypto; crypto != nil {
+		// A vTPM requires the VM home to be encrypted. The disks are not known
+		// until the VM is created, so they are encrypted when a cloned VM's disks
+		// are relocated, or when the VM is reconfigured before it is powered on.
+		if crypto.Encrypted || crypto.VTPM {
+			configSpec.Crypto = CreateCryptoSpecEncrypt(crypto.KeyProviderID)
+		}
+		if crypto.VTPM && !hasVirtualTPMDeviceChange(configSpec.DeviceChange) {
+			// Copy the DeviceChange so the class ConfigSpec is not modified.
+			deviceChange := make([]types.BaseVirtualDeviceConfigSpec, 0, len(configSpec.DeviceChange)+1)
+			deviceChange = append(deviceChange, configSpec.DeviceChange...)
+			configSpec.DeviceChange = append(deviceChange, &types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationAdd,
+				Device:    CreateVirtualTPMDevice(),
+			})
+		}
+	}
+
 	// Populate the CPU reservation and limits in the ConfigSpec if VAPI fields specify any.
 	// VM Class VAPI does not support Limits, so they will never be non nil.
 	// TODO: Remove limits: issues/56
@@ -106,6 +132,17 @@ func CreateConfigSpec(
 	return &configSpec
 }
 
+func hasVirtualTPMDeviceChange(deviceChange []types.BaseVirtualDeviceConfigSpec) bool {
+	for _, dc := range deviceChange {
+		if spec := dc.GetVirtualDeviceConfigSpec(); spec != nil {
+			if _, ok := spec.Device.(*types.VirtualTPM); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // CreateConfigSpecForPlacement creates a ConfigSpec that is suitable for Placement.
 // baseConfigSpec will likely be - or at least derived from - the ConfigSpec returned by CreateConfigSpec above.
 func CreateConfigSpecForPlacement(
@@ -64,6 +64,86 @@ var _ = Describe("CreateConfigSpec", func() {
 		Expect(configSpec.CpuAllocation).ToNot(BeNil())
 		Expect(configSpec.MemoryAllocation).ToNot(BeNil())
 		Expect(configSpec.Firmware).To(Equal(vmImageStatus.Firmware))
+		Expect(configSpec.Crypto).To(BeNil())
+	})
+
+	Context("VM has boot options", func() {
+		BeforeEach(func() {
+			vm.Annotations[constants.FirmwareOverrideAnnotation] = "efi"
+			vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+				Firmware:  vmopv1.VirtualMachineFirmwareTypeBIOS,
+				BootOrder: []vmopv1.VirtualMachineBootableDevice{vmopv1.VirtualMachineBootableDeviceDisk},
+				BootRetry: true,
+			}
+		})
+
+		It("config spec has the firmware and boot options without the boot order", func() {
+			configSpec = virtualmachine.CreateConfigSpec(
+				vmCtx,
+				nil,
+				vmClassSpec,
+				vmImageStatus,
+				minCPUFreq)
+			Expect(configSpec).ToNot(BeNil())
+			Expect(configSpec.Firmware).To(Equal("bios"))
+			Expect(configSpec.BootOptions).ToNot(BeNil())
+			Expect(configSpec.BootOptions.BootRetryEnabled).To(HaveValue(BeTrue()))
+			Expect(configSpec.BootOptions.BootOrder).To(BeEmpty())
+		})
+	})
+
+	Context("VM has crypto spec", func() {
+		BeforeEach(func() {
+			vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{
+				Encrypted:     true,
+				KeyProviderID: "my-kp",
+				VTPM:          true,
+			}
+		})
+
+		JustBeforeEach(func() {
+			configSpec = virtualmachine.CreateConfigSpec(
+				vmCtx,
+				nil,
+				vmClassSpec,
+				vmImageStatus,
+				minCPUFreq)
+			Expect(configSpec).ToNot(BeNil())
+		})
+
+		It("config spec encrypts the VM and adds a vTPM device", func() {
+			Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimtypes.CryptoSpecEncrypt{}))
+			encrypt := configSpec.Crypto.(*vimtypes.CryptoSpecEncrypt)
+			Expect(encrypt.CryptoKeyId.ProviderId).ToNot(BeNil())
+			Expect(encrypt.CryptoKeyId.ProviderId.Id).To(Equal("my-kp"))
+
+			Expect(configSpec.DeviceChange).To(HaveLen(1))
+			dSpec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+			Expect(dSpec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			Expect(dSpec.Device).To(BeAssignableToTypeOf(&vimtypes.VirtualTPM{}))
+		})
+
+		When("key provider is not specified", func() {
+			BeforeEach(func() {
+				vm.Spec.Crypto.KeyProviderID = ""
+			})
+
+			It("config spec uses the default key provider", func() {
+				Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimtypes.CryptoSpecEncrypt{}))
+				Expect(configSpec.Crypto.(*vimtypes.CryptoSpecEncrypt).CryptoKeyId.ProviderId).To(BeNil())
+			})
+		})
+
+		When("only a vTPM is specified", func() {
+			BeforeEach(func() {
+				vm.Spec.Crypto.Encrypted = false
+			})
+
+			It("config spec encrypts the VM home for the vTPM", func() {
+				Expect(configSpec.Crypto).To(BeAssignableToTypeOf(&vimtypes.CryptoSpecEncrypt{}))
+				Expect(configSpec.DeviceChange).To(HaveLen(1))
+			})
+		})
 	})
 
 	Context("Use VM Class ConfigSpec", func() {
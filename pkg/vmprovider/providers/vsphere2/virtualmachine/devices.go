@@ -15,6 +15,8 @@ const (
 	// A negative device range is traditionally used.
 	pciDevicesStartDeviceKey      = int32(-200)
 	instanceStorageStartDeviceKey = int32(-300)
+	virtualTPMDeviceKey           = int32(-400)
+	snapshotRevertStartDeviceKey  = int32(-500)
 )
 
 func CreatePCIPassThroughDevice(deviceKey int32, backingInfo vimTypes.BaseVirtualDeviceBackingInfo) vimTypes.BaseVirtualDevice {
@@ -98,3 +100,68 @@ func CreateInstanceStorageDiskDevices(isVolumes []vmopv1.VirtualMachineVolume) [
 
 	return devices
 }
+
+// CreateVirtualTPMDevice creates a vim25 VirtualTPM device.
+func CreateVirtualTPMDevice() vimTypes.BaseVirtualDevice {
+	return &vimTypes.VirtualTPM{
+		VirtualDevice: vimTypes.VirtualDevice{
+			Key: virtualTPMDeviceKey,
+		},
+	}
+}
+
+// CreateCryptoSpecEncrypt creates the CryptoSpec that encrypts the VM with a key
+// from the specified key provider. When the key provider is empty, the default
+// key provider is used.
+func CreateCryptoSpecEncrypt(keyProviderID string) *vimTypes.CryptoSpecEncrypt {
+	cryptoSpec := &vimTypes.CryptoSpecEncrypt{}
+	if keyProviderID != "" {
+		cryptoSpec.CryptoKeyId.ProviderId = &vimTypes.KeyProviderId{Id: keyProviderID}
+	}
+	return cryptoSpec
+}
+
+// CreateCryptoSpecShallowRecrypt creates the CryptoSpec that rekeys the VM with
+// a new key from the specified key provider.
+func CreateCryptoSpecShallowRecrypt(keyProviderID string) *vimTypes.CryptoSpecShallowRecrypt {
+	return &vimTypes.CryptoSpecShallowRecrypt{
+		NewKeyId: vimTypes.CryptoKeyId{
+			ProviderId: &vimTypes.KeyProviderId{Id: keyProviderID},
+		},
+	}
+}
+
+// CryptoSpecForKeyID returns the CryptoSpec that changes the VM home or disk that
+// is encrypted with keyID, or that is not encrypted when keyID is nil, to the
+// desired encryption. An object encrypted with a key from a different key provider
+// than the specified one is rekeyed. When the key provider is empty, any key
+// provider is accepted. Returns nil when no change is needed.
+func CryptoSpecForKeyID(
+	keyID *vimTypes.CryptoKeyId,
+	wantEncrypted bool,
+	keyProviderID string) vimTypes.BaseCryptoSpec {
+
+	switch {
+	case wantEncrypted && keyID == nil:
+		return CreateCryptoSpecEncrypt(keyProviderID)
+	case wantEncrypted && keyProviderID != "" && (keyID.ProviderId == nil || keyID.ProviderId.Id != keyProviderID):
+		return CreateCryptoSpecShallowRecrypt(keyProviderID)
+	case !wantEncrypted && keyID != nil:
+		return &vimTypes.CryptoSpecDecrypt{}
+	}
+	return nil
+}
+
+// GetVirtualDiskKeyID returns the key the disk is encrypted with, or nil if the
+// disk is not encrypted.
+func GetVirtualDiskKeyID(disk *vimTypes.VirtualDisk) *vimTypes.CryptoKeyId {
+	switch backing := disk.Backing.(type) {
+	case *vimTypes.VirtualDiskFlatVer2BackingInfo:
+		return backing.KeyId
+	case *vimTypes.VirtualDiskSeSparseBackingInfo:
+		return backing.KeyId
+	case *vimTypes.VirtualDiskSparseVer2BackingInfo:
+		return backing.KeyId
+	}
+	return nil
+}
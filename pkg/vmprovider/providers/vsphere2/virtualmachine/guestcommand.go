@@ -0,0 +1,306 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/guest"
+	"github.com/vmware/govmomi/guest/toolbox"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+const (
+	// GuestCommandOutputLimit is the maximum number of bytes of the command's
+	// stdout and stderr that are recorded in the status.
+	GuestCommandOutputLimit = 4 * 1024
+
+	// DefaultGuestCommandTimeout is how long a command may run when the
+	// command does not specify a timeout.
+	DefaultGuestCommandTimeout = 5 * time.Minute
+
+	windowsCmdPath  = `C:\Windows\System32\cmd.exe`
+	linuxShellPath  = "/bin/bash"
+	windowsTempPath = `C:\Windows\Temp\`
+	linuxTempPath   = "/tmp/"
+)
+
+// GuestCommandOutputPaths returns the paths of the files in the guest to which
+// the command's stdout and stderr are redirected. The paths are derived from
+// the command's UID so they are known on every reconcile of the command.
+func GuestCommandOutputPaths(
+	guestFamily types.VirtualMachineGuestOsFamily,
+	guestCmd *vmopv1.VirtualMachineGuestCommand) (string, string) {
+
+	dir := linuxTempPath
+	if guestFamily == types.VirtualMachineGuestOsFamilyWindowsGuest {
+		dir = windowsTempPath
+	}
+
+	prefix := dir + "vmop-guestcmd-" + string(guestCmd.UID)
+	return prefix + ".stdout", prefix + ".stderr"
+}
+
+// CreateGuestProgramSpec returns the GuestProgramSpec that runs the command
+// with its stdout and stderr redirected to the specified paths in the guest.
+func CreateGuestProgramSpec(
+	guestFamily types.VirtualMachineGuestOsFamily,
+	guestCmd *vmopv1.VirtualMachineGuestCommand,
+	stdoutPath, stderrPath string) *types.GuestProgramSpec {
+
+	spec := &types.GuestProgramSpec{
+		WorkingDirectory: guestCmd.Spec.WorkingDir,
+	}
+
+	for _, env := range guestCmd.Spec.Env {
+		spec.EnvVariables = append(spec.EnvVariables, env.Key+"="+env.Value)
+	}
+
+	redirects := fmt.Sprintf("1> %s 2> %s", stdoutPath, stderrPath)
+	spec.ProgramPath, spec.Arguments = guestProgramPathAndArguments(
+		guestFamily, guestCmd.Spec.Command, guestCmd.Spec.Args, redirects)
+
+	return spec
+}
+
+// guestProgramPathAndArguments returns the path of the program that runs the
+// command, and the program's arguments. The command and each of its arguments
+// are quoted for the shell that parses them. Any redirects are appended to the
+// arguments.
+func guestProgramPathAndArguments(
+	guestFamily types.VirtualMachineGuestOsFamily,
+	command string,
+	args []string,
+	redirects string) (string, string) {
+
+	// Redirecting the output requires the command to be run by a shell, except
+	// for a Linux command that is an absolute path.
+	switch {
+	case guestFamily == types.VirtualMachineGuestOsFamilyWindowsGuest:
+		words := []string{windowsCommandQuote(command)}
+		for _, arg := range args {
+			words = append(words, windowsArgQuote(arg))
+		}
+		cmdLine := strings.TrimSpace(strings.Join(words, " ") + " " + redirects)
+		// With /s, cmd.exe strips the outer quotes and runs the rest of the
+		// line as is.
+		return windowsCmdPath, fmt.Sprintf(`/s /c "%s"`, cmdLine)
+	case strings.HasPrefix(command, "/"):
+		words := make([]string, 0, len(args))
+		for _, arg := range args {
+			words = append(words, posixQuote(arg))
+		}
+		return command, strings.TrimSpace(strings.Join(words, " ") + " " + redirects)
+	default:
+		words := []string{posixQuote(command)}
+		for _, arg := range args {
+			words = append(words, posixQuote(arg))
+		}
+		script := posixQuote(strings.Join(words, " "))
+		return linuxShellPath, strings.TrimSpace(fmt.Sprintf("-c %s %s", script, redirects))
+	}
+}
+
+// posixQuote returns s quoted as a single word for a POSIX shell. Words that
+// only contain characters with no special meaning to the shell are not quoted.
+func posixQuote(s string) string {
+	isSafe := func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("_-./=:,+@%", r)
+	}
+	if s != "" && strings.IndexFunc(s, func(r rune) bool { return !isSafe(r) }) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// windowsCmdSpecialChars are the characters that cmd.exe interprets outside of
+// a quoted string.
+const windowsCmdSpecialChars = `()%!^"<>&|`
+
+// windowsArgQuote returns s quoted as a single argument for a program that
+// parses its command line with the Microsoft C runtime rules, with the cmd.exe
+// special characters escaped so cmd.exe passes the argument through as is.
+func windowsArgQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\v\"") {
+		return windowsCmdEscape(s)
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	backslashes := 0
+	for _, r := range s {
+		switch r {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			// Escape the backslashes preceding the quote and the quote itself.
+			b.WriteString(strings.Repeat(`\`, 2*backslashes+1))
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		b.WriteRune(r)
+	}
+	// Escape the trailing backslashes so they do not escape the closing quote.
+	b.WriteString(strings.Repeat(`\`, 2*backslashes))
+	b.WriteByte('"')
+
+	return windowsCmdEscape(b.String())
+}
+
+// windowsCommandQuote returns the path of the program quoted for cmd.exe. Unlike
+// an argument, the quotes around the path are not escaped so cmd.exe finds the
+// program when its path contains spaces.
+func windowsCommandQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t"+windowsCmdSpecialChars) {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, "") + `"`
+}
+
+// windowsCmdEscape escapes the cmd.exe special characters in s with a caret.
+func windowsCmdEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(windowsCmdSpecialChars, r) {
+			b.WriteByte('^')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ReadGuestCommandOutput reads up to GuestCommandOutputLimit bytes of the
+// command's output, and returns whether the output was truncated.
+func ReadGuestCommandOutput(r io.Reader) (string, bool, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, GuestCommandOutputLimit+1))
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(buf) > GuestCommandOutputLimit {
+		return string(buf[:GuestCommandOutputLimit]), true, nil
+	}
+	return string(buf), false, nil
+}
+
+// GuestCommandTimeout returns how long the command may run.
+func GuestCommandTimeout(guestCmd *vmopv1.VirtualMachineGuestCommand) time.Duration {
+	if t := guestCmd.Spec.Timeout; t != nil {
+		return t.Duration
+	}
+	return DefaultGuestCommandTimeout
+}
+
+// RunGuestCommand runs the command in the VM's guest through VM Tools. The
+// command is started on the first call, and on subsequent calls the command's
+// process is checked until the command has exited, at which time the command's
+// exit code and output are recorded in the command's status. The command is
+// terminated if it does not exit before its timeout.
+func RunGuestCommand(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	auth types.BaseGuestAuthentication,
+	guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+
+	client, err := toolbox.NewClient(vmCtx, vcVM.Client(), vcVM, auth)
+	if err != nil {
+		return errors.Wrap(err, "failed to create guest operations client")
+	}
+
+	stdoutPath, stderrPath := GuestCommandOutputPaths(client.GuestFamily, guestCmd)
+
+	if guestCmd.Status.PID == 0 {
+		spec := CreateGuestProgramSpec(client.GuestFamily, guestCmd, stdoutPath, stderrPath)
+		vmCtx.Logger.Info("Starting guest command", "programPath", spec.ProgramPath)
+
+		pid, err := client.ProcessManager.StartProgram(vmCtx, auth, spec)
+		if err != nil {
+			return errors.Wrap(err, "failed to start guest command")
+		}
+
+		now := metav1.Now()
+		guestCmd.Status.PID = pid
+		guestCmd.Status.StartTime = &now
+		return nil
+	}
+
+	procs, err := client.ProcessManager.ListProcesses(vmCtx, auth, []int64{guestCmd.Status.PID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get guest command process")
+	}
+	if len(procs) == 0 {
+		return fmt.Errorf("guest command process %d not found", guestCmd.Status.PID)
+	}
+
+	proc := procs[0]
+	if proc.EndTime == nil {
+		if start := guestCmd.Status.StartTime; start != nil && time.Since(start.Time) > GuestCommandTimeout(guestCmd) {
+			vmCtx.Logger.Info("Terminating guest command that exceeded its timeout", "pid", proc.Pid)
+			if err := client.ProcessManager.TerminateProcess(vmCtx, auth, proc.Pid); err != nil {
+				return errors.Wrap(err, "failed to terminate guest command")
+			}
+			now := metav1.Now()
+			guestCmd.Status.CompletionTime = &now
+			removeGuestFiles(vmCtx, client.FileManager, auth, stdoutPath, stderrPath)
+		}
+		return nil
+	}
+
+	exitCode := proc.ExitCode
+	guestCmd.Status.ExitCode = &exitCode
+
+	guestCmd.Status.Stdout, guestCmd.Status.StdoutTruncated, err = downloadGuestCommandOutput(vmCtx, client, stdoutPath)
+	if err != nil {
+		return err
+	}
+	guestCmd.Status.Stderr, guestCmd.Status.StderrTruncated, err = downloadGuestCommandOutput(vmCtx, client, stderrPath)
+	if err != nil {
+		return err
+	}
+
+	completionTime := metav1.NewTime(*proc.EndTime)
+	guestCmd.Status.CompletionTime = &completionTime
+	removeGuestFiles(vmCtx, client.FileManager, auth, stdoutPath, stderrPath)
+
+	return nil
+}
+
+func downloadGuestCommandOutput(
+	vmCtx context.VirtualMachineContextA2,
+	client *toolbox.Client,
+	path string) (string, bool, error) {
+
+	f, _, err := client.Download(vmCtx, path)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "failed to download guest command output %s", path)
+	}
+	defer f.Close()
+
+	return ReadGuestCommandOutput(f)
+}
+
+func removeGuestFiles(
+	vmCtx context.VirtualMachineContextA2,
+	fileManager *guest.FileManager,
+	auth types.BaseGuestAuthentication,
+	paths ...string) {
+
+	for _, path := range paths {
+		if err := fileManager.DeleteFile(vmCtx, auth, path); err != nil {
+			vmCtx.Logger.Error(err, "failed to remove guest file", "path", path)
+		}
+	}
+}
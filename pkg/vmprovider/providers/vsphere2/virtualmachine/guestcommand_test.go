@@ -0,0 +1,147 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+)
+
+var _ = Describe("Guest command", func() {
+
+	const (
+		linuxGuest   = types.VirtualMachineGuestOsFamilyLinuxGuest
+		windowsGuest = types.VirtualMachineGuestOsFamilyWindowsGuest
+	)
+
+	var (
+		guestCmd *vmopv1.VirtualMachineGuestCommand
+	)
+
+	BeforeEach(func() {
+		guestCmd = &vmopv1.VirtualMachineGuestCommand{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-cmd",
+				UID:  "abc-123",
+			},
+			Spec: vmopv1.VirtualMachineGuestCommandSpec{
+				Command:    "systemctl",
+				Args:       []string{"restart", "my-agent"},
+				WorkingDir: "/var/tmp",
+				Env: []common.KeyValuePair{
+					{Key: "FOO", Value: "bar"},
+				},
+			},
+		}
+	})
+
+	Context("GuestCommandOutputPaths", func() {
+		It("returns Linux paths", func() {
+			stdout, stderr := virtualmachine.GuestCommandOutputPaths(linuxGuest, guestCmd)
+			Expect(stdout).To(Equal("/tmp/vmop-guestcmd-abc-123.stdout"))
+			Expect(stderr).To(Equal("/tmp/vmop-guestcmd-abc-123.stderr"))
+		})
+
+		It("returns Windows paths", func() {
+			stdout, stderr := virtualmachine.GuestCommandOutputPaths(windowsGuest, guestCmd)
+			Expect(stdout).To(Equal(`C:\Windows\Temp\vmop-guestcmd-abc-123.stdout`))
+			Expect(stderr).To(Equal(`C:\Windows\Temp\vmop-guestcmd-abc-123.stderr`))
+		})
+	})
+
+	Context("CreateGuestProgramSpec", func() {
+		It("runs a Linux command with the shell", func() {
+			spec := virtualmachine.CreateGuestProgramSpec(linuxGuest, guestCmd, "/tmp/out", "/tmp/err")
+			Expect(spec.ProgramPath).To(Equal("/bin/bash"))
+			Expect(spec.Arguments).To(Equal("-c 'systemctl restart my-agent' 1> /tmp/out 2> /tmp/err"))
+			Expect(spec.WorkingDirectory).To(Equal("/var/tmp"))
+			Expect(spec.EnvVariables).To(ConsistOf("FOO=bar"))
+		})
+
+		It("runs a Linux command with an absolute path directly", func() {
+			guestCmd.Spec.Command = "/usr/bin/systemctl"
+			spec := virtualmachine.CreateGuestProgramSpec(linuxGuest, guestCmd, "/tmp/out", "/tmp/err")
+			Expect(spec.ProgramPath).To(Equal("/usr/bin/systemctl"))
+			Expect(spec.Arguments).To(Equal("restart my-agent 1> /tmp/out 2> /tmp/err"))
+		})
+
+		It("runs a Windows command with cmd.exe", func() {
+			guestCmd.Spec.Command = "ipconfig"
+			guestCmd.Spec.Args = []string{"/all"}
+			spec := virtualmachine.CreateGuestProgramSpec(windowsGuest, guestCmd, `C:\out`, `C:\err`)
+			Expect(spec.ProgramPath).To(Equal(`C:\Windows\System32\cmd.exe`))
+			Expect(spec.Arguments).To(Equal(`/s /c "ipconfig /all 1> C:\out 2> C:\err"`))
+		})
+
+		It("quotes the arguments of a Linux command run with the shell", func() {
+			guestCmd.Spec.Command = "echo"
+			guestCmd.Spec.Args = []string{"it's", "a & b", "$HOME"}
+			spec := virtualmachine.CreateGuestProgramSpec(linuxGuest, guestCmd, "/tmp/out", "/tmp/err")
+			Expect(spec.ProgramPath).To(Equal("/bin/bash"))
+			Expect(spec.Arguments).To(Equal(
+				`-c 'echo '\''it'\''\'\'''\''s'\'' '\''a & b'\'' '\''$HOME'\''' 1> /tmp/out 2> /tmp/err`))
+		})
+
+		It("quotes the arguments of a Linux command with an absolute path", func() {
+			guestCmd.Spec.Command = "/usr/bin/echo"
+			guestCmd.Spec.Args = []string{"it's", "a & b", ""}
+			spec := virtualmachine.CreateGuestProgramSpec(linuxGuest, guestCmd, "/tmp/out", "/tmp/err")
+			Expect(spec.Arguments).To(Equal(`'it'\''s' 'a & b' '' 1> /tmp/out 2> /tmp/err`))
+		})
+
+		It("quotes the command and arguments of a Windows command", func() {
+			guestCmd.Spec.Command = `C:\Program Files\app.exe`
+			guestCmd.Spec.Args = []string{"a b", `say "hi" & bye`, `C:\dir\`, "50%"}
+			spec := virtualmachine.CreateGuestProgramSpec(windowsGuest, guestCmd, `C:\out`, `C:\err`)
+			Expect(spec.Arguments).To(Equal(
+				`/s /c ""C:\Program Files\app.exe" ^"a b^" ^"say \^"hi\^" ^& bye^" C:\dir\ 50^% 1> C:\out 2> C:\err"`))
+		})
+	})
+
+	Context("ReadGuestCommandOutput", func() {
+		It("returns the output", func() {
+			out, truncated, err := virtualmachine.ReadGuestCommandOutput(strings.NewReader("hello"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("hello"))
+			Expect(truncated).To(BeFalse())
+		})
+
+		It("returns the output at the limit", func() {
+			output := strings.Repeat("x", virtualmachine.GuestCommandOutputLimit)
+			out, truncated, err := virtualmachine.ReadGuestCommandOutput(strings.NewReader(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(output))
+			Expect(truncated).To(BeFalse())
+		})
+
+		It("truncates the output over the limit", func() {
+			output := strings.Repeat("x", virtualmachine.GuestCommandOutputLimit+10)
+			out, truncated, err := virtualmachine.ReadGuestCommandOutput(strings.NewReader(output))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HaveLen(virtualmachine.GuestCommandOutputLimit))
+			Expect(truncated).To(BeTrue())
+		})
+	})
+
+	Context("GuestCommandTimeout", func() {
+		It("returns the default timeout", func() {
+			Expect(virtualmachine.GuestCommandTimeout(guestCmd)).To(Equal(virtualmachine.DefaultGuestCommandTimeout))
+		})
+
+		It("returns the command's timeout", func() {
+			guestCmd.Spec.Timeout = &metav1.Duration{Duration: 30 * time.Second}
+			Expect(virtualmachine.GuestCommandTimeout(guestCmd)).To(Equal(30 * time.Second))
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	goctx "context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/guest/toolbox"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// guestExecPollInterval is how often the process of a command that is run
+// by a probe is checked for whether it has exited.
+const guestExecPollInterval = 500 * time.Millisecond
+
+// RunGuestExec runs the command in the VM's guest through VM Tools, waits for
+// the command to exit, and returns the command's exit code. The command is
+// terminated, and the context's error is returned, when the context is done
+// before the command exits.
+func RunGuestExec(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	auth types.BaseGuestAuthentication,
+	action *vmopv1.GuestExecAction) (int32, error) {
+
+	client, err := toolbox.NewClient(vmCtx, vcVM.Client(), vcVM, auth)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create guest operations client")
+	}
+
+	spec := &types.GuestProgramSpec{}
+	spec.ProgramPath, spec.Arguments = guestProgramPathAndArguments(client.GuestFamily, action.Command, action.Args, "")
+
+	pid, err := client.ProcessManager.StartProgram(vmCtx, auth, spec)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to start guest command")
+	}
+
+	ticker := time.NewTicker(guestExecPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-vmCtx.Done():
+			// The context is done so use a new one to terminate the process.
+			if err := client.ProcessManager.TerminateProcess(goctx.Background(), auth, pid); err != nil {
+				vmCtx.Logger.Error(err, "failed to terminate guest command", "pid", pid)
+			}
+			return 0, fmt.Errorf("guest command did not exit: %w", vmCtx.Err())
+		case <-ticker.C:
+		}
+
+		procs, err := client.ProcessManager.ListProcesses(vmCtx, auth, []int64{pid})
+		if err != nil {
+			if vmCtx.Err() != nil {
+				// Terminate the process on the next iteration.
+				continue
+			}
+			return 0, errors.Wrap(err, "failed to get guest command process")
+		}
+		if len(procs) == 0 {
+			return 0, fmt.Errorf("guest command process %d not found", pid)
+		}
+		if procs[0].EndTime != nil {
+			return procs[0].ExitCode, nil
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/guest/toolbox"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// CopyFileToGuest copies the content into the file at the path in the VM's
+// guest through VM Tools. An existing file is only replaced when overwrite
+// is true.
+func CopyFileToGuest(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	auth types.BaseGuestAuthentication,
+	guestPath string,
+	content []byte,
+	overwrite bool) error {
+
+	client, err := toolbox.NewClient(vmCtx, vcVM.Client(), vcVM, auth)
+	if err != nil {
+		return errors.Wrap(err, "failed to create guest operations client")
+	}
+
+	p := soap.DefaultUpload
+	p.ContentLength = int64(len(content))
+
+	vmCtx.Logger.Info("Copying file to guest", "guestPath", guestPath, "size", p.ContentLength)
+	if err := client.Upload(vmCtx, bytes.NewReader(content), guestPath, p, &types.GuestFileAttributes{}, overwrite); err != nil {
+		return errors.Wrapf(err, "failed to copy file to guest path %s", guestPath)
+	}
+
+	return nil
+}
+
+// CopyFileFromGuest returns the content of the file at the path in the VM's
+// guest through VM Tools, and the size of the file. The content is not
+// returned and an error is returned when the file is larger than sizeLimit.
+func CopyFileFromGuest(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	auth types.BaseGuestAuthentication,
+	guestPath string,
+	sizeLimit int64) ([]byte, int64, error) {
+
+	client, err := toolbox.NewClient(vmCtx, vcVM.Client(), vcVM, auth)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to create guest operations client")
+	}
+
+	vmCtx.Logger.Info("Copying file from guest", "guestPath", guestPath)
+	f, size, err := client.Download(vmCtx, guestPath)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to copy file from guest path %s", guestPath)
+	}
+	defer f.Close()
+
+	if size > sizeLimit {
+		return nil, size, fmt.Errorf("guest file %s size %d exceeds the size limit %d", guestPath, size, sizeLimit)
+	}
+
+	// Do not trust the reported size when reading the content.
+	content, err := io.ReadAll(io.LimitReader(f, sizeLimit+1))
+	if err != nil {
+		return nil, size, errors.Wrapf(err, "failed to read guest file %s", guestPath)
+	}
+	if int64(len(content)) > sizeLimit {
+		return nil, int64(len(content)), fmt.Errorf("guest file %s exceeds the size limit %d", guestPath, sizeLimit)
+	}
+
+	return content, int64(len(content)), nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// RelocateArgs contains the placement the VM is relocated to.
+type RelocateArgs struct {
+	PoolMoRef        types.ManagedObjectReference
+	HostMoRef        *types.ManagedObjectReference
+	DatastoreMoRef   *types.ManagedObjectReference
+	StorageProfileID string
+}
+
+// CreateRelocateSpec returns the RelocateSpec that moves the VM's compute and
+// storage together. The VM's disks are moved to the new datastore, except for
+// the disks backed by an FCD, which are managed by CNS and remain on their
+// current datastore.
+func CreateRelocateSpec(
+	disks []*types.VirtualDisk,
+	args RelocateArgs) *types.VirtualMachineRelocateSpec {
+
+	pool := args.PoolMoRef
+	relocateSpec := &types.VirtualMachineRelocateSpec{
+		Pool:      &pool,
+		Host:      args.HostMoRef,
+		Datastore: args.DatastoreMoRef,
+	}
+
+	var profile []types.BaseVirtualMachineProfileSpec
+	if args.StorageProfileID != "" {
+		profile = []types.BaseVirtualMachineProfileSpec{
+			&types.VirtualMachineDefinedProfileSpec{ProfileId: args.StorageProfileID},
+		}
+		relocateSpec.Profile = profile
+	}
+
+	for _, disk := range disks {
+		locator := types.VirtualMachineRelocateSpecDiskLocator{
+			DiskId: disk.Key,
+		}
+
+		if disk.VDiskId != nil {
+			backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo)
+			if !ok || backing.GetVirtualDeviceFileBackingInfo().Datastore == nil {
+				continue
+			}
+			locator.Datastore = *backing.GetVirtualDeviceFileBackingInfo().Datastore
+		} else if args.DatastoreMoRef != nil {
+			locator.Datastore = *args.DatastoreMoRef
+			locator.Profile = profile
+		} else {
+			continue
+		}
+
+		relocateSpec.Disk = append(relocateSpec.Disk, locator)
+	}
+
+	return relocateSpec
+}
+
+// Relocate relocates the VM, and its storage, to the placement in the args.
+func Relocate(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	args RelocateArgs) error {
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"config.hardware.device"}, &o); err != nil {
+		return err
+	}
+
+	var disks []*types.VirtualDisk
+	if o.Config != nil {
+		for _, device := range object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil)) {
+			disks = append(disks, device.(*types.VirtualDisk))
+		}
+	}
+
+	relocateSpec := CreateRelocateSpec(disks, args)
+	vmCtx.Logger.Info("Relocating VM", "relocateSpec", relocateSpec)
+
+	t, err := vcVM.Relocate(vmCtx, *relocateSpec, types.VirtualMachineMovePriorityDefaultPriority)
+	if err != nil {
+		return err
+	}
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "relocate VM task failed", "taskInfo", taskInfo)
+		}
+		return errors.Wrapf(err, "relocate VM task failed")
+	}
+
+	return nil
+}
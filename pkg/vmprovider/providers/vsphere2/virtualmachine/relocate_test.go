@@ -0,0 +1,84 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+)
+
+var _ = Describe("CreateRelocateSpec", func() {
+
+	var (
+		args     virtualmachine.RelocateArgs
+		disks    []*types.VirtualDisk
+		curDSRef types.ManagedObjectReference
+	)
+
+	BeforeEach(func() {
+		curDSRef = types.ManagedObjectReference{Type: "Datastore", Value: "cur-ds"}
+		args = virtualmachine.RelocateArgs{
+			PoolMoRef:        types.ManagedObjectReference{Type: "ResourcePool", Value: "pool"},
+			HostMoRef:        &types.ManagedObjectReference{Type: "HostSystem", Value: "host"},
+			DatastoreMoRef:   &types.ManagedObjectReference{Type: "Datastore", Value: "new-ds"},
+			StorageProfileID: "profile-id",
+		}
+
+		newDisk := func(key int32, fcd bool) *types.VirtualDisk {
+			disk := &types.VirtualDisk{
+				VirtualDevice: types.VirtualDevice{
+					Key: key,
+					Backing: &types.VirtualDiskFlatVer2BackingInfo{
+						VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+							Datastore: &curDSRef,
+						},
+					},
+				},
+			}
+			if fcd {
+				disk.VDiskId = &types.ID{Id: "fcd-id"}
+			}
+			return disk
+		}
+
+		disks = []*types.VirtualDisk{newDisk(100, false), newDisk(101, true)}
+	})
+
+	It("moves the compute and the non-FCD disks to the new placement", func() {
+		spec := virtualmachine.CreateRelocateSpec(disks, args)
+		Expect(spec.Pool).To(HaveValue(Equal(args.PoolMoRef)))
+		Expect(spec.Host).To(Equal(args.HostMoRef))
+		Expect(spec.Datastore).To(Equal(args.DatastoreMoRef))
+		Expect(spec.Profile).To(HaveLen(1))
+		Expect(spec.Profile[0].(*types.VirtualMachineDefinedProfileSpec).ProfileId).To(Equal("profile-id"))
+
+		Expect(spec.Disk).To(HaveLen(2))
+		Expect(spec.Disk[0].DiskId).To(BeEquivalentTo(100))
+		Expect(spec.Disk[0].Datastore).To(Equal(*args.DatastoreMoRef))
+		Expect(spec.Disk[0].Profile).To(HaveLen(1))
+
+		By("FCD disk remains on its datastore", func() {
+			Expect(spec.Disk[1].DiskId).To(BeEquivalentTo(101))
+			Expect(spec.Disk[1].Datastore).To(Equal(curDSRef))
+			Expect(spec.Disk[1].Profile).To(BeEmpty())
+		})
+	})
+
+	When("placement did not recommend a datastore", func() {
+		BeforeEach(func() {
+			args.DatastoreMoRef = nil
+		})
+
+		It("does not move the non-FCD disks", func() {
+			spec := virtualmachine.CreateRelocateSpec(disks, args)
+			Expect(spec.Datastore).To(BeNil())
+			Expect(spec.Disk).To(HaveLen(1))
+			Expect(spec.Disk[0].DiskId).To(BeEquivalentTo(101))
+		})
+	})
+})
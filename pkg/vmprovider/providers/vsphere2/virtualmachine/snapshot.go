@@ -0,0 +1,492 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
+)
+
+// SnapshotArgs contains the options used to create a VM snapshot. ID is the
+// managed object ID of the snapshot when it has already been created.
+type SnapshotArgs struct {
+	ID          string
+	Name        string
+	Description string
+	Memory      bool
+	Quiesce     bool
+}
+
+// FindSnapshot returns the node in the snapshot tree with the given name,
+// or nil if no such snapshot exists.
+func FindSnapshot(
+	tree []types.VirtualMachineSnapshotTree,
+	name string) *types.VirtualMachineSnapshotTree {
+
+	for i := range tree {
+		if tree[i].Name == name {
+			return &tree[i]
+		}
+		if node := FindSnapshot(tree[i].ChildSnapshotList, name); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// FindSnapshotByID returns the node in the snapshot tree with the given
+// managed object ID, or nil if no such snapshot exists.
+func FindSnapshotByID(
+	tree []types.VirtualMachineSnapshotTree,
+	id string) *types.VirtualMachineSnapshotTree {
+
+	for i := range tree {
+		if tree[i].Snapshot.Value == id {
+			return &tree[i]
+		}
+		if node := FindSnapshotByID(tree[i].ChildSnapshotList, id); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// CreateSnapshot creates a snapshot of the VM unless the snapshot with the
+// ID in the args already exists, and returns the snapshot's node in the VM's
+// snapshot tree. Snapshots are identified by their ID since a VM may have
+// several snapshots with the same name.
+func CreateSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	args SnapshotArgs) (*types.VirtualMachineSnapshotTree, error) {
+
+	if args.ID != "" {
+		node, err := getSnapshotByID(vmCtx, vcVM, args.ID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, errors.Errorf("snapshot %q with ID %q not found", args.Name, args.ID)
+		}
+		return node, nil
+	}
+
+	vmCtx.Logger.Info("Creating snapshot", "snapshotName", args.Name,
+		"memory", args.Memory, "quiesce", args.Quiesce)
+
+	t, err := vcVM.CreateSnapshot(vmCtx, args.Name, args.Description, args.Memory, args.Quiesce)
+	if err != nil {
+		return nil, err
+	}
+
+	taskInfo, err := t.WaitForResult(vmCtx)
+	if err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "create snapshot task failed", "taskInfo", taskInfo)
+		}
+		return nil, errors.Wrapf(err, "create snapshot task failed")
+	}
+
+	ref, ok := taskInfo.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, errors.Errorf("create snapshot task returned unexpected result %T", taskInfo.Result)
+	}
+
+	node, err := getSnapshotByID(vmCtx, vcVM, ref.Value)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, errors.Errorf("snapshot %q with ID %q not found after creation", args.Name, ref.Value)
+	}
+
+	return node, nil
+}
+
+// DeleteSnapshot deletes the VM's snapshot with the given managed object ID.
+// The snapshot's children, if any, are not deleted but are re-parented to the
+// deleted snapshot's parent. No error is returned if the snapshot does not
+// exist.
+func DeleteSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	id string) error {
+
+	node, err := getSnapshotByID(vmCtx, vcVM, id)
+	if err != nil || node == nil {
+		return err
+	}
+
+	vmCtx.Logger.Info("Deleting snapshot", "snapshotName", node.Name, "snapshotID", id)
+
+	consolidate := true
+	t, err := vcVM.RemoveSnapshot(vmCtx, id, false, &consolidate)
+	if err != nil {
+		return err
+	}
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "remove snapshot task failed", "taskInfo", taskInfo)
+		}
+		return errors.Wrapf(err, "remove snapshot task failed")
+	}
+
+	return nil
+}
+
+// RevertToSnapshot reverts the VM to the snapshot with the given name, and
+// returns the UUIDs of the first class disks, such as the ones backing PVCs,
+// that were attached to the VM prior to the revert but not after it. Those
+// disks must be attached again through CNS by the caller.
+//
+// The VM is never powered on by the revert, even if the snapshot includes the
+// VM's memory, so the caller may bring the VM to its desired power state
+// afterwards. The other disks attached to the VM prior to the revert remain
+// attached after the revert, and disks detached since the snapshot was taken
+// are not attached again. The VM's last restart time is preserved as well so
+// the revert does not cause a pending restart.
+func RevertToSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	name string) ([]string, error) {
+
+	node, err := getSnapshot(vmCtx, vcVM, name)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, errors.Errorf("snapshot %q not found", name)
+	}
+
+	propertyPaths := []string{"config.hardware.device", "config.extraConfig"}
+
+	var before mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), propertyPaths, &before); err != nil {
+		return nil, err
+	}
+
+	vmCtx.Logger.Info("Reverting to snapshot", "snapshotName", name, "snapshotID", node.Snapshot.Value)
+
+	t, err := vcVM.RevertToSnapshot(vmCtx, node.Snapshot.Value, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "revert to snapshot task failed", "taskInfo", taskInfo)
+		}
+		return nil, errors.Wrapf(err, "revert to snapshot task failed")
+	}
+
+	var after mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), propertyPaths, &after); err != nil {
+		return nil, err
+	}
+
+	if before.Config == nil || after.Config == nil {
+		return nil, nil
+	}
+
+	detachedFCDs := GetSnapshotRevertDetachedFirstClassDisks(
+		before.Config.Hardware.Device,
+		after.Config.Hardware.Device)
+
+	configSpec := types.VirtualMachineConfigSpec{}
+	configSpec.DeviceChange, err = GetSnapshotRevertDiskDeviceChanges(
+		before.Config.Hardware.Device,
+		after.Config.Hardware.Device)
+	if err != nil {
+		return nil, err
+	}
+	configSpec.ExtraConfig = getSnapshotRevertExtraConfig(
+		before.Config.ExtraConfig,
+		after.Config.ExtraConfig)
+
+	if len(configSpec.DeviceChange) == 0 && len(configSpec.ExtraConfig) == 0 {
+		return detachedFCDs, nil
+	}
+
+	vmCtx.Logger.Info("Restoring VM configuration after revert to snapshot",
+		"deviceChanges", len(configSpec.DeviceChange), "extraConfig", configSpec.ExtraConfig)
+
+	t, err = vcVM.Reconfigure(vmCtx, configSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskInfo, err := t.WaitForResult(vmCtx); err != nil {
+		if taskInfo != nil {
+			vmCtx.Logger.V(5).Error(err, "reconfigure after revert to snapshot failed", "taskInfo", taskInfo)
+		}
+		return nil, errors.Wrapf(err, "reconfigure after revert to snapshot failed")
+	}
+
+	return detachedFCDs, nil
+}
+
+// GetSnapshotRevertDiskDeviceChanges returns the device changes that attach
+// the disks from before a revert that are missing after the revert, and that
+// detach the disks present after the revert that were not attached before it.
+// Disks are matched by their UUID, and the backing files of the detached disks
+// are not deleted.
+//
+// First class disks missing after the revert are not attached since they are
+// attached by CNS. A disk is attached to its controller and unit from before
+// the revert when they are still available, otherwise to the next free unit of
+// its controller, or of a SCSI controller if its controller no longer exists.
+func GetSnapshotRevertDiskDeviceChanges(
+	before, after []types.BaseVirtualDevice) ([]types.BaseVirtualDeviceConfigSpec, error) {
+
+	beforeDisks := diskUUIDToDisk(before)
+	afterDisks := diskUUIDToDisk(after)
+
+	var deviceChanges []types.BaseVirtualDeviceConfigSpec
+
+	// The devices that remain after the device changes, used to find the
+	// controller units that are in use.
+	devices := object.VirtualDeviceList{}
+	for _, device := range after {
+		if disk, ok := device.(*types.VirtualDisk); ok {
+			if uuid := diskUUID(disk); uuid != "" && beforeDisks[uuid] == nil {
+				continue
+			}
+		}
+		devices = append(devices, device)
+	}
+
+	var addDisks []*types.VirtualDisk
+	deviceKey := snapshotRevertStartDeviceKey
+
+	for _, uuid := range diskUUIDs(before) {
+		if _, ok := afterDisks[uuid]; ok {
+			continue
+		}
+		if isFirstClassDisk(beforeDisks[uuid]) {
+			continue
+		}
+
+		disk := *beforeDisks[uuid]
+		disk.Key = deviceKey
+		deviceKey--
+		addDisks = append(addDisks, &disk)
+	}
+
+	// The disks keep their units when available, before the remaining disks
+	// are assigned to free units.
+	var unassigned []*types.VirtualDisk
+	for _, disk := range addDisks {
+		if isDiskUnitAvailable(devices, disk) {
+			devices = append(devices, disk)
+		} else {
+			unassigned = append(unassigned, disk)
+		}
+	}
+	for _, disk := range unassigned {
+		if err := assignDiskUnit(devices, disk); err != nil {
+			return nil, err
+		}
+		devices = append(devices, disk)
+	}
+
+	for _, disk := range addDisks {
+		deviceChanges = append(deviceChanges, &types.VirtualDeviceConfigSpec{
+			Operation: types.VirtualDeviceConfigSpecOperationAdd,
+			Device:    disk,
+		})
+	}
+
+	for _, uuid := range diskUUIDs(after) {
+		if _, ok := beforeDisks[uuid]; ok {
+			continue
+		}
+
+		deviceChanges = append(deviceChanges, &types.VirtualDeviceConfigSpec{
+			Operation: types.VirtualDeviceConfigSpecOperationRemove,
+			Device:    afterDisks[uuid],
+		})
+	}
+
+	return deviceChanges, nil
+}
+
+// GetSnapshotRevertDetachedFirstClassDisks returns the UUIDs of the first class
+// disks from before a revert that are missing after the revert.
+func GetSnapshotRevertDetachedFirstClassDisks(before, after []types.BaseVirtualDevice) []string {
+	beforeDisks := diskUUIDToDisk(before)
+	afterDisks := diskUUIDToDisk(after)
+
+	var uuids []string
+	for _, uuid := range diskUUIDs(before) {
+		if _, ok := afterDisks[uuid]; !ok && isFirstClassDisk(beforeDisks[uuid]) {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids
+}
+
+// isDiskUnitAvailable returns true if the disk's controller exists and its
+// unit is not used by another device.
+func isDiskUnitAvailable(devices object.VirtualDeviceList, disk *types.VirtualDisk) bool {
+	if _, ok := devices.FindByKey(disk.ControllerKey).(types.BaseVirtualController); !ok || disk.UnitNumber == nil {
+		return false
+	}
+
+	for _, device := range devices {
+		d := device.GetVirtualDevice()
+		if d.ControllerKey == disk.ControllerKey && d.UnitNumber != nil && *d.UnitNumber == *disk.UnitNumber {
+			return false
+		}
+	}
+
+	return true
+}
+
+// assignDiskUnit assigns the disk to the next free unit of its controller, or
+// of a SCSI controller if its controller does not exist.
+func assignDiskUnit(devices object.VirtualDeviceList, disk *types.VirtualDisk) error {
+	controller, ok := devices.FindByKey(disk.ControllerKey).(types.BaseVirtualController)
+	if !ok {
+		controller = devices.PickController((*types.VirtualSCSIController)(nil))
+		if controller == nil {
+			return errors.Errorf("no controller available to attach disk %s", diskUUID(disk))
+		}
+	}
+
+	devices.AssignController(disk, controller)
+	if *disk.UnitNumber < 0 {
+		return errors.Errorf("no unit available on controller %d to attach disk %s",
+			controller.GetVirtualController().Key, diskUUID(disk))
+	}
+
+	return nil
+}
+
+// getSnapshotRevertExtraConfig returns the ExtraConfig that restores the
+// VM's last restart time to its value prior to a revert.
+func getSnapshotRevertExtraConfig(
+	before, after []types.BaseOptionValue) []types.BaseOptionValue {
+
+	beforeVal := extraConfigValue(before, vmutil.ExtraConfigKeyLastRestartTime)
+	if beforeVal == extraConfigValue(after, vmutil.ExtraConfigKeyLastRestartTime) {
+		return nil
+	}
+
+	return []types.BaseOptionValue{
+		&types.OptionValue{
+			Key:   vmutil.ExtraConfigKeyLastRestartTime,
+			Value: beforeVal,
+		},
+	}
+}
+
+func extraConfigValue(extraConfig []types.BaseOptionValue, key string) string {
+	for i := range extraConfig {
+		if extraConfig[i] == nil {
+			continue
+		}
+		if ov := extraConfig[i].GetOptionValue(); ov != nil && ov.Key == key {
+			if val, ok := ov.Value.(string); ok {
+				return val
+			}
+		}
+	}
+	return ""
+}
+
+// diskUUIDs returns the UUIDs of the disks in the order of the devices.
+func diskUUIDs(devices []types.BaseVirtualDevice) []string {
+	var uuids []string
+	for _, device := range devices {
+		if disk, ok := device.(*types.VirtualDisk); ok {
+			if uuid := diskUUID(disk); uuid != "" {
+				uuids = append(uuids, uuid)
+			}
+		}
+	}
+	return uuids
+}
+
+func diskUUIDToDisk(devices []types.BaseVirtualDevice) map[string]*types.VirtualDisk {
+	disks := map[string]*types.VirtualDisk{}
+	for _, device := range devices {
+		if disk, ok := device.(*types.VirtualDisk); ok {
+			if uuid := diskUUID(disk); uuid != "" {
+				disks[uuid] = disk
+			}
+		}
+	}
+	return disks
+}
+
+// isFirstClassDisk returns true if the disk is a first class disk, such as the
+// ones backing PVCs.
+func isFirstClassDisk(disk *types.VirtualDisk) bool {
+	return disk.VDiskId != nil && disk.VDiskId.Id != ""
+}
+
+func diskUUID(disk *types.VirtualDisk) string {
+	switch backing := disk.Backing.(type) {
+	case *types.VirtualDiskFlatVer2BackingInfo:
+		return backing.Uuid
+	case *types.VirtualDiskSeSparseBackingInfo:
+		return backing.Uuid
+	case *types.VirtualDiskSparseVer2BackingInfo:
+		return backing.Uuid
+	case *types.VirtualDiskRawDiskMappingVer1BackingInfo:
+		return backing.Uuid
+	default:
+		return ""
+	}
+}
+
+func getSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	name string) (*types.VirtualMachineSnapshotTree, error) {
+
+	tree, err := getSnapshotTree(vmCtx, vcVM)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindSnapshot(tree, name), nil
+}
+
+func getSnapshotByID(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	id string) (*types.VirtualMachineSnapshotTree, error) {
+
+	tree, err := getSnapshotTree(vmCtx, vcVM)
+	if err != nil {
+		return nil, err
+	}
+
+	return FindSnapshotByID(tree, id), nil
+}
+
+func getSnapshotTree(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine) ([]types.VirtualMachineSnapshotTree, error) {
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"snapshot"}, &o); err != nil {
+		return nil, err
+	}
+
+	if o.Snapshot == nil {
+		return nil, nil
+	}
+
+	return o.Snapshot.RootSnapshotList, nil
+}
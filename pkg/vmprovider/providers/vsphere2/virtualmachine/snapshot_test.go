@@ -0,0 +1,346 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/pointer"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func snapshotTests() {
+
+	var (
+		ctx   *builder.TestContextForVCSim
+		vcVM  *object.VirtualMachine
+		vmCtx context.VirtualMachineContextA2
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = context.VirtualMachineContextA2{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachineA2(),
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getSnapshotTree := func() *mo.VirtualMachine {
+		var o mo.VirtualMachine
+		ExpectWithOffset(1, vcVM.Properties(ctx, vcVM.Reference(), []string{"snapshot"}, &o)).To(Succeed())
+		return &o
+	}
+
+	Context("CreateSnapshot", func() {
+		It("creates the snapshot", func() {
+			node, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{
+				Name:        "snap-1",
+				Description: "my snapshot",
+				Quiesce:     true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node).ToNot(BeNil())
+			Expect(node.Name).To(Equal("snap-1"))
+			Expect(node.Description).To(Equal("my snapshot"))
+			Expect(node.Quiesced).To(BeTrue())
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot).ToNot(BeNil())
+			Expect(o.Snapshot.CurrentSnapshot).ToNot(BeNil())
+			Expect(*o.Snapshot.CurrentSnapshot).To(Equal(node.Snapshot))
+		})
+
+		It("is idempotent", func() {
+			args := virtualmachine.SnapshotArgs{Name: "snap-1"}
+			node1, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, args)
+			Expect(err).ToNot(HaveOccurred())
+			args.ID = node1.Snapshot.Value
+			node2, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node2.Snapshot).To(Equal(node1.Snapshot))
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot.RootSnapshotList).To(HaveLen(1))
+			Expect(o.Snapshot.RootSnapshotList[0].ChildSnapshotList).To(BeEmpty())
+		})
+
+		It("creates a snapshot with the same name as another snapshot", func() {
+			args := virtualmachine.SnapshotArgs{Name: "snap-1"}
+			node1, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, args)
+			Expect(err).ToNot(HaveOccurred())
+			node2, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node2.Snapshot).ToNot(Equal(node1.Snapshot))
+			Expect(node2.Name).To(Equal("snap-1"))
+		})
+
+		It("returns an error when the snapshot with the ID does not exist", func() {
+			_, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1", ID: "snapshot-bogus"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`snapshot "snap-1" with ID "snapshot-bogus" not found`))
+		})
+
+		It("creates child snapshots", func() {
+			parent, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+			child, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot.RootSnapshotList).To(HaveLen(1))
+			Expect(o.Snapshot.RootSnapshotList[0].Snapshot).To(Equal(parent.Snapshot))
+			Expect(o.Snapshot.RootSnapshotList[0].ChildSnapshotList).To(HaveLen(1))
+			Expect(o.Snapshot.RootSnapshotList[0].ChildSnapshotList[0].Snapshot).To(Equal(child.Snapshot))
+		})
+	})
+
+	Context("DeleteSnapshot", func() {
+		It("deletes the snapshot", func() {
+			node, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, node.Snapshot.Value)).To(Succeed())
+
+			o := getSnapshotTree()
+			if o.Snapshot != nil {
+				Expect(virtualmachine.FindSnapshot(o.Snapshot.RootSnapshotList, "snap-1")).To(BeNil())
+			}
+		})
+
+		It("keeps the children of the deleted snapshot", func() {
+			node, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, node.Snapshot.Value)).To(Succeed())
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot).ToNot(BeNil())
+			Expect(virtualmachine.FindSnapshot(o.Snapshot.RootSnapshotList, "snap-1")).To(BeNil())
+			Expect(virtualmachine.FindSnapshot(o.Snapshot.RootSnapshotList, "snap-2")).ToNot(BeNil())
+		})
+
+		It("deletes only the snapshot with the ID when snapshots have the same name", func() {
+			node1, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+			node2, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, node2.Snapshot.Value)).To(Succeed())
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot).ToNot(BeNil())
+			Expect(virtualmachine.FindSnapshotByID(o.Snapshot.RootSnapshotList, node1.Snapshot.Value)).ToNot(BeNil())
+			Expect(virtualmachine.FindSnapshotByID(o.Snapshot.RootSnapshotList, node2.Snapshot.Value)).To(BeNil())
+		})
+
+		It("returns success when the snapshot does not exist", func() {
+			Expect(virtualmachine.DeleteSnapshot(vmCtx, vcVM, "snapshot-does-not-exist")).To(Succeed())
+		})
+	})
+
+	Context("RevertToSnapshot", func() {
+		It("reverts to the snapshot", func() {
+			snap1, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			detached, err := virtualmachine.RevertToSnapshot(vmCtx, vcVM, "snap-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(detached).To(BeEmpty())
+
+			o := getSnapshotTree()
+			Expect(o.Snapshot).ToNot(BeNil())
+			Expect(o.Snapshot.CurrentSnapshot).ToNot(BeNil())
+			Expect(*o.Snapshot.CurrentSnapshot).To(Equal(snap1.Snapshot))
+		})
+
+		It("returns an error when the snapshot does not exist", func() {
+			_, err := virtualmachine.RevertToSnapshot(vmCtx, vcVM, "does-not-exist")
+			Expect(err).To(MatchError(`snapshot "does-not-exist" not found`))
+		})
+
+		It("keeps the last restart time from before the revert", func() {
+			_, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{Name: "snap-1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			t, err := vcVM.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+				ExtraConfig: []types.BaseOptionValue{
+					&types.OptionValue{Key: vmutil.ExtraConfigKeyLastRestartTime, Value: "12345"},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Wait(ctx)).To(Succeed())
+
+			_, err = virtualmachine.RevertToSnapshot(vmCtx, vcVM, "snap-1")
+			Expect(err).ToNot(HaveOccurred())
+
+			var o mo.VirtualMachine
+			Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+			lastRestartTime, err := vmutil.GetLastRestartTimeFromExtraConfig(ctx, o.Config.ExtraConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lastRestartTime).ToNot(BeNil())
+			Expect(lastRestartTime.UnixNano()).To(Equal(int64(12345)))
+		})
+	})
+
+	Context("GetSnapshotRevertDiskDeviceChanges", func() {
+		const controllerKey = int32(1000)
+
+		newDisk := func(key int32, uuid string, unitNumber int32) *types.VirtualDisk {
+			return &types.VirtualDisk{
+				VirtualDevice: types.VirtualDevice{
+					Key:           key,
+					ControllerKey: controllerKey,
+					UnitNumber:    &unitNumber,
+					Backing: &types.VirtualDiskFlatVer2BackingInfo{
+						VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+							FileName: "[datastore1] vm/" + uuid + ".vmdk",
+						},
+						Uuid: uuid,
+					},
+				},
+			}
+		}
+
+		newFCD := func(key int32, uuid string, unitNumber int32) *types.VirtualDisk {
+			disk := newDisk(key, uuid, unitNumber)
+			disk.VDiskId = &types.ID{Id: "fcd-" + uuid}
+			return disk
+		}
+
+		newController := func() *types.ParaVirtualSCSIController {
+			return &types.ParaVirtualSCSIController{
+				VirtualSCSIController: types.VirtualSCSIController{
+					VirtualController: types.VirtualController{
+						VirtualDevice: types.VirtualDevice{Key: controllerKey},
+					},
+					ScsiCtlrUnitNumber: 7,
+				},
+			}
+		}
+
+		addedDisks := func(changes []types.BaseVirtualDeviceConfigSpec) []*types.VirtualDisk {
+			var disks []*types.VirtualDisk
+			for _, change := range changes {
+				if spec := change.GetVirtualDeviceConfigSpec(); spec.Operation == types.VirtualDeviceConfigSpecOperationAdd {
+					disks = append(disks, spec.Device.(*types.VirtualDisk))
+				}
+			}
+			return disks
+		}
+
+		It("returns no changes when the disks are the same", func() {
+			before := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-1", 1)}
+			after := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-1", 1)}
+			Expect(virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)).To(BeEmpty())
+		})
+
+		It("attaches disks missing after the revert and detaches extra disks", func() {
+			before := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-1", 1)}
+			after := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2002, "data-2", 2), &types.VirtualCdrom{}}
+
+			changes, err := virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+
+			add := changes[0].GetVirtualDeviceConfigSpec()
+			Expect(add.Operation).To(Equal(types.VirtualDeviceConfigSpecOperationAdd))
+			Expect(add.FileOperation).To(BeEmpty())
+			addedDisk := add.Device.(*types.VirtualDisk)
+			Expect(addedDisk.Key).To(BeNumerically("<", 0))
+			Expect(addedDisk.Backing.(*types.VirtualDiskFlatVer2BackingInfo).Uuid).To(Equal("data-1"))
+			Expect(addedDisk.ControllerKey).To(Equal(controllerKey))
+			Expect(*addedDisk.UnitNumber).To(Equal(int32(1)))
+
+			remove := changes[1].GetVirtualDeviceConfigSpec()
+			Expect(remove.Operation).To(Equal(types.VirtualDeviceConfigSpecOperationRemove))
+			Expect(remove.FileOperation).To(BeEmpty())
+			Expect(remove.Device.GetVirtualDevice().Key).To(Equal(int32(2002)))
+		})
+
+		It("attaches a disk to the unit of a detached disk", func() {
+			before := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-1", 1)}
+			after := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-2", 1)}
+
+			changes, err := virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+
+			disks := addedDisks(changes)
+			Expect(disks).To(HaveLen(1))
+			Expect(*disks[0].UnitNumber).To(Equal(int32(1)))
+		})
+
+		It("attaches a disk to a free unit when its unit is used by another device", func() {
+			cdrom := &types.VirtualCdrom{
+				VirtualDevice: types.VirtualDevice{Key: 3000, ControllerKey: controllerKey, UnitNumber: pointer.Int32(1)},
+			}
+			before := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newDisk(2001, "data-1", 1), newDisk(2002, "data-2", 2)}
+			after := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), cdrom}
+
+			changes, err := virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)
+			Expect(err).ToNot(HaveOccurred())
+
+			disks := addedDisks(changes)
+			Expect(disks).To(HaveLen(2))
+			Expect(disks[0].Key).ToNot(Equal(disks[1].Key))
+			Expect(disks[0].ControllerKey).To(Equal(controllerKey))
+			Expect(*disks[0].UnitNumber).To(Equal(int32(3)))
+			Expect(disks[1].ControllerKey).To(Equal(controllerKey))
+			Expect(*disks[1].UnitNumber).To(Equal(int32(2)))
+		})
+
+		It("attaches a disk to another controller when its controller no longer exists", func() {
+			otherController := newController()
+			otherController.Key = 1001
+			disk := newDisk(2001, "data-1", 1)
+			before := []types.BaseVirtualDevice{newController(), disk}
+			after := []types.BaseVirtualDevice{otherController}
+
+			changes, err := virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)
+			Expect(err).ToNot(HaveOccurred())
+
+			disks := addedDisks(changes)
+			Expect(disks).To(HaveLen(1))
+			Expect(disks[0].ControllerKey).To(Equal(int32(1001)))
+			Expect(*disks[0].UnitNumber).To(Equal(int32(0)))
+		})
+
+		It("returns an error when there is no controller for a disk", func() {
+			before := []types.BaseVirtualDevice{newController(), newDisk(2001, "data-1", 1)}
+			_, err := virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, nil)
+			Expect(err).To(MatchError("no controller available to attach disk data-1"))
+		})
+
+		It("does not attach first class disks", func() {
+			before := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0), newFCD(2001, "pvc-1", 1)}
+			after := []types.BaseVirtualDevice{newController(), newDisk(2000, "boot", 0)}
+
+			Expect(virtualmachine.GetSnapshotRevertDiskDeviceChanges(before, after)).To(BeEmpty())
+			Expect(virtualmachine.GetSnapshotRevertDetachedFirstClassDisks(before, after)).To(ConsistOf("pvc-1"))
+		})
+	})
+}
@@ -4,6 +4,10 @@
 package virtualmachine
 
 import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
@@ -44,3 +48,51 @@ func GetDefaultDiskProvisioningType(
 
 	return string(types.OvfCreateImportSpecParamsDiskProvisioningTypeThin), nil
 }
+
+// ExpandDisk expands the VM's virtual disk with the specified UUID to the
+// specified capacity. Nothing is done if the disk is already at least that
+// large since a disk cannot be shrunk.
+func ExpandDisk(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	uuid string,
+	capacityInBytes int64) error {
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"config.hardware.device"}, &o); err != nil {
+		return err
+	}
+	if o.Config == nil {
+		return fmt.Errorf("VM config is not available")
+	}
+
+	disk, ok := diskUUIDToDisk(o.Config.Hardware.Device)[uuid]
+	if !ok {
+		return fmt.Errorf("disk %q not found", uuid)
+	}
+
+	if disk.CapacityInBytes >= capacityInBytes {
+		return nil
+	}
+
+	vmCtx.Logger.Info("Expanding disk", "diskUUID", uuid,
+		"capacityInBytes", disk.CapacityInBytes, "newCapacityInBytes", capacityInBytes)
+
+	disk.CapacityInBytes = capacityInBytes
+	disk.CapacityInKB = capacityInBytes / 1024
+	configSpec := types.VirtualMachineConfigSpec{
+		DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+			&types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationEdit,
+				Device:    disk,
+			},
+		},
+	}
+
+	task, err := vcVM.Reconfigure(vmCtx, configSpec)
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(vmCtx)
+}
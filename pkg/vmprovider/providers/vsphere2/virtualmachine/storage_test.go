@@ -0,0 +1,81 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func storageTests() {
+
+	var (
+		ctx   *builder.TestContextForVCSim
+		vcVM  *object.VirtualMachine
+		vmCtx context.VirtualMachineContextA2
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{WithV1A2: true})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCtx = context.VirtualMachineContextA2{
+			Context: ctx,
+			Logger:  suite.GetLogger().WithValues("vmName", vcVM.Name()),
+			VM:      builder.DummyVirtualMachineA2(),
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	getDisk := func() *types.VirtualDisk {
+		var o mo.VirtualMachine
+		ExpectWithOffset(1, vcVM.Properties(ctx, vcVM.Reference(), []string{"config.hardware.device"}, &o)).To(Succeed())
+		disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil))
+		ExpectWithOffset(1, disks).ToNot(BeEmpty())
+		return disks[0].(*types.VirtualDisk)
+	}
+
+	Context("ExpandDisk", func() {
+		var (
+			diskUUID        string
+			capacityInBytes int64
+		)
+
+		BeforeEach(func() {
+			disk := getDisk()
+			diskUUID = disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo).Uuid
+			capacityInBytes = disk.CapacityInBytes
+		})
+
+		It("expands the disk", func() {
+			Expect(virtualmachine.ExpandDisk(vmCtx, vcVM, diskUUID, 2*capacityInBytes)).To(Succeed())
+			Expect(getDisk().CapacityInBytes).To(Equal(2 * capacityInBytes))
+		})
+
+		It("does not shrink the disk", func() {
+			Expect(virtualmachine.ExpandDisk(vmCtx, vcVM, diskUUID, capacityInBytes/2)).To(Succeed())
+			Expect(getDisk().CapacityInBytes).To(Equal(capacityInBytes))
+		})
+
+		It("returns an error when the disk does not exist", func() {
+			err := virtualmachine.ExpandDisk(vmCtx, vcVM, "does-not-exist", 2*capacityInBytes)
+			Expect(err).To(MatchError(`disk "does-not-exist" not found`))
+		})
+	})
+}
@@ -12,11 +12,14 @@ import (
 )
 
 func vcSimTests() {
+	Describe("Affinity", affinityTests)
 	Describe("ClusterComputeResource", ccrTests)
 	Describe("Delete", deleteTests)
 	Describe("Publish", publishTests)
 	Describe("Backup", backupTests)
 	Describe("GuestInfo", guestInfoTests)
+	Describe("Snapshot", snapshotTests)
+	Describe("Storage", storageTests)
 }
 
 var suite = builder.NewTestSuite()
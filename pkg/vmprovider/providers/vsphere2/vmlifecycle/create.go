@@ -17,6 +17,11 @@ type CreateArgs struct {
 	UseContentLibrary bool
 	ProviderItemID    string
 
+	// CloneFromVMMoID is the MoID of the VM that is cloned when the VM is
+	// cloned from another VirtualMachine instead of deployed from its image.
+	CloneFromVMMoID string
+	LinkedClone     bool
+
 	ConfigSpec          *types.VirtualMachineConfigSpec
 	StorageProvisioning string
 	FolderMoID          string
@@ -33,6 +38,10 @@ func CreateVirtualMachine(
 	finder *find.Finder,
 	createArgs *CreateArgs) (*types.ManagedObjectReference, error) {
 
+	if createArgs.CloneFromVMMoID != "" {
+		return cloneVMFromVirtualMachine(vmCtx, finder, createArgs)
+	}
+
 	if createArgs.UseContentLibrary {
 		return deployFromContentLibrary(vmCtx, clClient, restClient, createArgs)
 	}
@@ -9,11 +9,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	"k8s.io/utils/pointer"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/placement"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
 )
 
 // CloneVMFromInventory creates a new VM by cloning the source VM. This is not reachable/used
@@ -31,6 +36,36 @@ func cloneVMFromInventory(
 		return nil, errors.Wrapf(err, "failed to find clone source VM: %s", srcVMName)
 	}
 
+	return cloneVM(vmCtx, srcVM, createArgs)
+}
+
+// cloneVMFromVirtualMachine creates a new VM by cloning the VM of the VirtualMachine
+// referenced by the VM's spec.clone.
+func cloneVMFromVirtualMachine(
+	vmCtx context.VirtualMachineContextA2,
+	finder *find.Finder,
+	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
+
+	srcVMRef := vimtypes.ManagedObjectReference{Type: "VirtualMachine", Value: createArgs.CloneFromVMMoID}
+
+	obj, err := finder.ObjectReference(vmCtx, srcVMRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find clone source VM: %s", srcVMRef.Value)
+	}
+
+	srcVM, ok := obj.(*object.VirtualMachine)
+	if !ok {
+		return nil, fmt.Errorf("clone source %s is not a VM but %T", srcVMRef.Value, obj)
+	}
+
+	return cloneVM(vmCtx, srcVM, createArgs)
+}
+
+func cloneVM(
+	vmCtx context.VirtualMachineContextA2,
+	srcVM *object.VirtualMachine,
+	createArgs *CreateArgs) (*vimtypes.ManagedObjectReference, error) {
+
 	cloneSpec, err := createCloneSpec(vmCtx, createArgs, srcVM)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create CloneSpec")
@@ -70,6 +105,18 @@ func createCloneSpec(
 
 	virtualDisks := virtualDevices.SelectByType((*vimtypes.VirtualDisk)(nil))
 
+	if createArgs.CloneFromVMMoID != "" {
+		virtualDisks = resetCloneIdentity(createArgs, virtualDevices, virtualDisks)
+
+		if createArgs.LinkedClone {
+			snapshot, err := currentSnapshot(vmCtx, srcVM)
+			if err != nil {
+				return nil, err
+			}
+			cloneSpec.Snapshot = snapshot
+		}
+	}
+
 	for _, deviceChange := range resizeBootDiskDeviceChange(vmCtx, virtualDisks) {
 		if deviceChange.GetVirtualDeviceConfigSpec().Operation == vimtypes.VirtualDeviceConfigSpecOperationEdit {
 			cloneSpec.Location.DeviceChange = append(cloneSpec.Location.DeviceChange, deviceChange)
@@ -116,15 +163,78 @@ func createCloneSpec(
 
 	cloneSpec.Location.Host = relocateSpec.Host
 	cloneSpec.Location.Datastore = relocateSpec.Datastore
-	cloneSpec.Location.Disk = cloneVMDiskLocators(virtualDisks, createArgs, cloneSpec.Location)
+	cloneSpec.Location.Disk = cloneVMDiskLocators(virtualDisks, createArgs, cloneSpec.Location, vmCtx.VM.Spec.Crypto)
 
 	return cloneSpec, nil
 }
 
+// resetCloneIdentity updates the ConfigSpec of a VM that is cloned from another
+// VirtualMachine so the clone does not share the source VM's PVCs, MAC addresses,
+// or Cloud-Init data. The source VM's disks that are cloned are returned.
+func resetCloneIdentity(
+	createArgs *CreateArgs,
+	virtualDevices object.VirtualDeviceList,
+	virtualDisks object.VirtualDeviceList) object.VirtualDeviceList {
+
+	configSpec := createArgs.ConfigSpec
+	var cloneDisks object.VirtualDeviceList
+
+	for _, device := range virtualDisks {
+		// Disks backed by an FCD are PVCs that are attached to the source VM.
+		if disk := device.(*vimtypes.VirtualDisk); disk.VDiskId != nil {
+			configSpec.DeviceChange = append(configSpec.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+				Device:    disk,
+			})
+			continue
+		}
+		cloneDisks = append(cloneDisks, device)
+	}
+
+	// The clone gets new NICs, with new MAC addresses, from the ConfigSpec.
+	for _, device := range virtualDevices.SelectByType((*vimtypes.VirtualEthernetCard)(nil)) {
+		configSpec.DeviceChange = append(configSpec.DeviceChange, &vimtypes.VirtualDeviceConfigSpec{
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+			Device:    device,
+		})
+	}
+
+	// Clear the source VM's Cloud-Init data so the guest is not bootstrapped with
+	// the source VM's instance ID. The clone's bootstrap data is set after it is
+	// created.
+	configSpec.ExtraConfig = util.AppendNewExtraConfigValues(configSpec.ExtraConfig, map[string]string{
+		constants.CloudInitGuestInfoMetadata:         "",
+		constants.CloudInitGuestInfoMetadataEncoding: "",
+		constants.CloudInitGuestInfoUserdata:         "",
+		constants.CloudInitGuestInfoUserdataEncoding: "",
+	})
+
+	return cloneDisks
+}
+
+// currentSnapshot returns the source VM's current snapshot, which is the
+// snapshot whose disks back the disks of a linked clone.
+func currentSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	srcVM *object.VirtualMachine) (*vimtypes.ManagedObjectReference, error) {
+
+	var o mo.VirtualMachine
+	if err := srcVM.Properties(vmCtx, srcVM.Reference(), []string{"snapshot"}, &o); err != nil {
+		return nil, fmt.Errorf("failed to get clone source VM snapshot: %w", err)
+	}
+
+	if o.Snapshot == nil || o.Snapshot.CurrentSnapshot == nil {
+		return nil, fmt.Errorf("linked clone requires the clone source VM to have a snapshot")
+	}
+
+	return o.Snapshot.CurrentSnapshot, nil
+}
+
 func cloneVMDiskLocators(
 	disks object.VirtualDeviceList,
 	createArgs *CreateArgs,
-	location vimtypes.VirtualMachineRelocateSpec) []vimtypes.VirtualMachineRelocateSpecDiskLocator {
+	location vimtypes.VirtualMachineRelocateSpec,
+	crypto *vmopv1.VirtualMachineCryptoSpec) []vimtypes.VirtualMachineRelocateSpecDiskLocator {
 
 	diskLocators := make([]vimtypes.VirtualMachineRelocateSpecDiskLocator, 0, len(disks))
 
@@ -137,6 +247,17 @@ func cloneVMDiskLocators(
 			DiskMoveType: string(vimtypes.VirtualMachineRelocateDiskMoveOptionsMoveChildMostDiskBacking),
 		}
 
+		if createArgs.LinkedClone {
+			locator.DiskMoveType = string(vimtypes.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking)
+		}
+
+		if vDisk := disk.(*vimtypes.VirtualDisk); crypto != nil && crypto.Encrypted && vDisk.VDiskId == nil {
+			keyID := virtualmachine.GetVirtualDiskKeyID(vDisk)
+			if cryptoSpec := virtualmachine.CryptoSpecForKeyID(keyID, true, crypto.KeyProviderID); cryptoSpec != nil {
+				locator.Backing = &vimtypes.VirtualMachineRelocateSpecDiskLocatorBackingSpec{Crypto: cryptoSpec}
+			}
+		}
+
 		if backing, ok := disk.(*vimtypes.VirtualDisk).Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo); ok {
 			switch createArgs.StorageProvisioning {
 			case string(vimtypes.OvfCreateImportSpecParamsDiskProvisioningTypeThin):
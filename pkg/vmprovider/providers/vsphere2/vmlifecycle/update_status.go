@@ -7,10 +7,12 @@ import (
 	goctx "context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,7 +30,14 @@ import (
 var (
 	// The minimum properties needed to be retrieved in order to populate the Status. Callers may
 	// provide a MO with more. This often saves us a second round trip in the common steady state.
-	vmStatusPropertiesSelector = []string{"config.changeTrackingEnabled", "guest", "summary"}
+	vmStatusPropertiesSelector = []string{"config.changeTrackingEnabled", "config.keyId", "config.hardware.device", "guest", "snapshot", "summary"}
+)
+
+const (
+	// statsRefreshInterval is the minimum time between updates of a VM's quick
+	// stats and guest disks. The stats change on every sample, so updating them
+	// on every reconcile would patch the VM, which triggers another reconcile.
+	statsRefreshInterval = 5 * time.Minute
 )
 
 func UpdateStatus(
@@ -52,8 +61,8 @@ func UpdateStatus(
 	}
 	if vm.Status.Class == nil {
 		// In v1a2 we know this will always be the namespace scoped class since v1a2 doesn't have
-		// the bindings. Our handling of this field will be more complicated once we really
-		// support class changes and resizing/reconfiguring the VM the fly in response.
+		// the bindings. Once set, this field is only updated after the VM has been resized
+		// in response to a class change.
 		vm.Status.Class = &common.LocalObjectRef{
 			Kind:       "VirtualMachineClass",
 			APIVersion: vmopv1.SchemeGroupVersion.String(),
@@ -99,9 +108,26 @@ func UpdateStatus(
 
 	if config := vmMO.Config; config != nil {
 		vm.Status.ChangeBlockTracking = config.ChangeTrackingEnabled
+		vm.Status.Crypto = getCryptoStatus(config)
 	} else {
 		vm.Status.ChangeBlockTracking = nil
+		vm.Status.Crypto = nil
+	}
+
+	layoutEx := vmMO.LayoutEx
+	if vmMO.Snapshot != nil && layoutEx == nil {
+		// The file layout is only needed for the size of the snapshots, and is large for a
+		// VM with many disks, so it is only retrieved when the VM has snapshots.
+		var o mo.VirtualMachine
+		if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"layoutEx"}, &o); err != nil {
+			errs = append(errs, fmt.Errorf("failed to get VM file layout for snapshot status: %w", err))
+		}
+		layoutEx = o.LayoutEx
 	}
+	vm.Status.Snapshots, vm.Status.CurrentSnapshot = getSnapshotStatus(vmMO.Snapshot, layoutEx)
+	vm.Status.GuestDisks, vm.Status.GuestDisksSampleTime = getGuestDiskStatus(
+		vm.Status.GuestDisks, vm.Status.GuestDisksSampleTime, vmMO.Guest)
+	vm.Status.QuickStats = getQuickStatsStatus(vm.Status.QuickStats, summary.Runtime.PowerState, summary.QuickStats)
 
 	if lib.IsWcpFaultDomainsFSSEnabled() {
 		zoneName := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
@@ -122,7 +148,9 @@ func UpdateStatus(
 			}
 		}
 
-		if zoneName != "" {
+		// Once set, the zone is only changed after the VM has been relocated to the
+		// zone of its label, so a failed relocation is retried.
+		if zoneName != "" && vm.Status.Zone == "" {
 			vm.Status.Zone = zoneName
 		}
 	}
@@ -228,6 +256,146 @@ func guestIPStackInfoToIPStackStatus(guestIPStack *types.GuestStackInfo) vmopv1.
 	return status
 }
 
+func getCryptoStatus(config *types.VirtualMachineConfigInfo) *vmopv1.VirtualMachineCryptoStatus {
+	status := &vmopv1.VirtualMachineCryptoStatus{}
+
+	if keyID := config.KeyId; keyID != nil {
+		status.Encrypted = true
+		if keyID.ProviderId != nil {
+			status.KeyProviderID = keyID.ProviderId.Id
+		}
+	}
+
+	devices := object.VirtualDeviceList(config.Hardware.Device)
+	status.VTPM = len(devices.SelectByType((*types.VirtualTPM)(nil))) > 0
+
+	if !status.Encrypted && !status.VTPM {
+		return nil
+	}
+	return status
+}
+
+func getSnapshotStatus(
+	snapshotInfo *types.VirtualMachineSnapshotInfo,
+	layoutEx *types.VirtualMachineFileLayoutEx) ([]vmopv1.VirtualMachineSnapshotTreeStatus, string) {
+
+	if snapshotInfo == nil {
+		return nil, ""
+	}
+
+	var currentSnapshot string
+	var out []vmopv1.VirtualMachineSnapshotTreeStatus
+
+	var walk func(tree []types.VirtualMachineSnapshotTree, parent *types.ManagedObjectReference)
+	walk = func(tree []types.VirtualMachineSnapshotTree, parent *types.ManagedObjectReference) {
+		for i := range tree {
+			node := &tree[i]
+			isCurrent := snapshotInfo.CurrentSnapshot != nil && *snapshotInfo.CurrentSnapshot == node.Snapshot
+			if isCurrent {
+				currentSnapshot = node.Name
+			}
+
+			status := vmopv1.VirtualMachineSnapshotTreeStatus{
+				Name:         node.Name,
+				ID:           node.Snapshot.Value,
+				CreationTime: metav1.NewTime(node.CreateTime),
+			}
+			if parent != nil {
+				status.Parent = parent.Value
+			}
+			if layoutEx != nil {
+				size := object.SnapshotSize(node.Snapshot, parent, layoutEx, isCurrent)
+				status.Size = resource.NewQuantity(int64(size), resource.BinarySI)
+			}
+
+			out = append(out, status)
+			walk(node.ChildSnapshotList, &node.Snapshot)
+		}
+	}
+	walk(snapshotInfo.RootSnapshotList, nil)
+
+	return out, currentSnapshot
+}
+
+// getGuestDiskStatus returns the guest disks and when they were sampled. The
+// current guest disks are kept until they are older than the refresh interval,
+// unless the guest's filesystems have changed.
+func getGuestDiskStatus(
+	current []vmopv1.VirtualMachineGuestDiskStatus,
+	sampleTime *metav1.Time,
+	guestInfo *types.GuestInfo) ([]vmopv1.VirtualMachineGuestDiskStatus, *metav1.Time) {
+
+	if guestInfo == nil || len(guestInfo.Disk) == 0 {
+		return nil, nil
+	}
+
+	now := metav1.Now()
+	if sampleTime != nil && now.Sub(sampleTime.Time) < statsRefreshInterval && sameGuestDisks(current, guestInfo.Disk) {
+		return current, sampleTime
+	}
+
+	out := make([]vmopv1.VirtualMachineGuestDiskStatus, 0, len(guestInfo.Disk))
+	for _, disk := range guestInfo.Disk {
+		out = append(out, vmopv1.VirtualMachineGuestDiskStatus{
+			DiskPath:       disk.DiskPath,
+			FilesystemType: disk.FilesystemType,
+			Capacity:       resource.NewQuantity(disk.Capacity, resource.BinarySI),
+			FreeSpace:      resource.NewQuantity(disk.FreeSpace, resource.BinarySI),
+		})
+	}
+
+	return out, &now
+}
+
+// sameGuestDisks returns true if the guest disks in the status are of the same
+// filesystems, with the same capacity, as the guest's disks.
+func sameGuestDisks(current []vmopv1.VirtualMachineGuestDiskStatus, disks []types.GuestDiskInfo) bool {
+	if len(current) != len(disks) {
+		return false
+	}
+
+	for i, disk := range disks {
+		c := current[i]
+		if c.DiskPath != disk.DiskPath || c.FilesystemType != disk.FilesystemType ||
+			c.Capacity == nil || c.Capacity.Value() != disk.Capacity {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getQuickStatsStatus(
+	current *vmopv1.VirtualMachineQuickStatsStatus,
+	powerState types.VirtualMachinePowerState,
+	quickStats types.VirtualMachineQuickStats) *vmopv1.VirtualMachineQuickStatsStatus {
+
+	if powerState != types.VirtualMachinePowerStatePoweredOn {
+		return nil
+	}
+
+	now := metav1.Now()
+	if current != nil && current.SampleTime != nil && now.Sub(current.SampleTime.Time) < statsRefreshInterval {
+		return current
+	}
+
+	// The memory statistics are in MB.
+	memQuantity := func(mb int32) *resource.Quantity {
+		return resource.NewQuantity(int64(mb)*1024*1024, resource.BinarySI)
+	}
+
+	return &vmopv1.VirtualMachineQuickStatsStatus{
+		SampleTime:       &now,
+		CPUUsageMHz:      quickStats.OverallCpuUsage,
+		CPUDemandMHz:     quickStats.OverallCpuDemand,
+		GuestMemoryUsage: memQuantity(quickStats.GuestMemoryUsage),
+		HostMemoryUsage:  memQuantity(quickStats.HostMemoryUsage),
+		BalloonedMemory:  memQuantity(quickStats.BalloonedMemory),
+		SwappedMemory:    memQuantity(quickStats.SwappedMemory),
+		UptimeSeconds:    quickStats.UptimeSeconds,
+	}
+}
+
 func convertPowerState(powerState types.VirtualMachinePowerState) vmopv1.VirtualMachinePowerState {
 	switch powerState {
 	case types.VirtualMachinePowerStatePoweredOff:
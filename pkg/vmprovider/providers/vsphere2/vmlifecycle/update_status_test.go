@@ -4,12 +4,15 @@
 package vmlifecycle_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
@@ -154,6 +157,280 @@ var _ = Describe("UpdateStatus", func() {
 			Expect(status.HardwareVersion).To(Equal(int32(19)))
 		})
 	})
+
+	Context("Crypto", func() {
+		BeforeEach(func() {
+			vmMO.Config = &types.VirtualMachineConfigInfo{}
+		})
+
+		It("does not set the crypto status when VM is not encrypted", func() {
+			Expect(vmCtx.VM.Status.Crypto).To(BeNil())
+		})
+
+		When("VM is encrypted with a vTPM", func() {
+			BeforeEach(func() {
+				vmMO.Config.KeyId = &types.CryptoKeyId{
+					KeyId:      "key",
+					ProviderId: &types.KeyProviderId{Id: "my-kp"},
+				}
+				vmMO.Config.Hardware.Device = []types.BaseVirtualDevice{&types.VirtualTPM{}}
+			})
+
+			It("sets the crypto status", func() {
+				status := vmCtx.VM.Status.Crypto
+				Expect(status).ToNot(BeNil())
+				Expect(status.Encrypted).To(BeTrue())
+				Expect(status.KeyProviderID).To(Equal("my-kp"))
+				Expect(status.VTPM).To(BeTrue())
+			})
+		})
+	})
+
+	Context("Guest disks", func() {
+		BeforeEach(func() {
+			vmMO.Guest = &types.GuestInfo{
+				Disk: []types.GuestDiskInfo{
+					{DiskPath: "/", FilesystemType: "ext4", Capacity: 10 * 1024 * 1024 * 1024, FreeSpace: 1024 * 1024 * 1024},
+					{DiskPath: "/boot", FilesystemType: "xfs", Capacity: 1024 * 1024 * 1024, FreeSpace: 512 * 1024 * 1024},
+				},
+			}
+		})
+
+		It("sets the guest disks in the status", func() {
+			disks := vmCtx.VM.Status.GuestDisks
+			Expect(disks).To(HaveLen(2))
+
+			Expect(disks[0].DiskPath).To(Equal("/"))
+			Expect(disks[0].FilesystemType).To(Equal("ext4"))
+			Expect(disks[0].Capacity.String()).To(Equal("10Gi"))
+			Expect(disks[0].FreeSpace.String()).To(Equal("1Gi"))
+
+			Expect(disks[1].DiskPath).To(Equal("/boot"))
+			Expect(disks[1].FilesystemType).To(Equal("xfs"))
+			Expect(disks[1].Capacity.String()).To(Equal("1Gi"))
+			Expect(disks[1].FreeSpace.String()).To(Equal("512Mi"))
+
+			Expect(vmCtx.VM.Status.GuestDisksSampleTime).ToNot(BeNil())
+		})
+
+		When("the guest disks were recently sampled", func() {
+			var sampleTime metav1.Time
+
+			BeforeEach(func() {
+				sampleTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				vmCtx.VM.Status.GuestDisksSampleTime = &sampleTime
+				vmCtx.VM.Status.GuestDisks = []vmopv1.VirtualMachineGuestDiskStatus{
+					{
+						DiskPath:       "/",
+						FilesystemType: "ext4",
+						Capacity:       resource.NewQuantity(10*1024*1024*1024, resource.BinarySI),
+						FreeSpace:      resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+					},
+					{
+						DiskPath:       "/boot",
+						FilesystemType: "xfs",
+						Capacity:       resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+						FreeSpace:      resource.NewQuantity(256*1024*1024, resource.BinarySI),
+					},
+				}
+			})
+
+			It("does not update the free space of the guest disks", func() {
+				disks := vmCtx.VM.Status.GuestDisks
+				Expect(disks).To(HaveLen(2))
+				Expect(disks[0].FreeSpace.String()).To(Equal("2Gi"))
+				Expect(disks[1].FreeSpace.String()).To(Equal("256Mi"))
+				Expect(vmCtx.VM.Status.GuestDisksSampleTime.Equal(&sampleTime)).To(BeTrue())
+			})
+
+			When("the guest's filesystems have changed", func() {
+				BeforeEach(func() {
+					vmCtx.VM.Status.GuestDisks = vmCtx.VM.Status.GuestDisks[:1]
+				})
+
+				It("updates the guest disks", func() {
+					disks := vmCtx.VM.Status.GuestDisks
+					Expect(disks).To(HaveLen(2))
+					Expect(disks[0].FreeSpace.String()).To(Equal("1Gi"))
+					Expect(vmCtx.VM.Status.GuestDisksSampleTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+			})
+		})
+
+		When("the guest disks are stale", func() {
+			BeforeEach(func() {
+				sampleTime := metav1.NewTime(time.Now().Add(-time.Hour))
+				vmCtx.VM.Status.GuestDisksSampleTime = &sampleTime
+				vmCtx.VM.Status.GuestDisks = []vmopv1.VirtualMachineGuestDiskStatus{
+					{
+						DiskPath:       "/",
+						FilesystemType: "ext4",
+						Capacity:       resource.NewQuantity(10*1024*1024*1024, resource.BinarySI),
+						FreeSpace:      resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+					},
+				}
+			})
+
+			It("updates the guest disks", func() {
+				disks := vmCtx.VM.Status.GuestDisks
+				Expect(disks).To(HaveLen(2))
+				Expect(disks[0].FreeSpace.String()).To(Equal("1Gi"))
+				Expect(vmCtx.VM.Status.GuestDisksSampleTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+
+		When("the guest does not report any disks", func() {
+			BeforeEach(func() {
+				vmMO.Guest.Disk = nil
+				sampleTime := metav1.NewTime(time.Now().Add(-time.Minute))
+				vmCtx.VM.Status.GuestDisksSampleTime = &sampleTime
+			})
+
+			It("clears the guest disks", func() {
+				Expect(vmCtx.VM.Status.GuestDisks).To(BeNil())
+				Expect(vmCtx.VM.Status.GuestDisksSampleTime).To(BeNil())
+			})
+		})
+	})
+
+	Context("QuickStats", func() {
+		BeforeEach(func() {
+			vmMO.Summary.QuickStats = types.VirtualMachineQuickStats{
+				OverallCpuUsage:  100,
+				OverallCpuDemand: 200,
+				GuestMemoryUsage: 256,
+				HostMemoryUsage:  1024,
+				BalloonedMemory:  64,
+				SwappedMemory:    32,
+				UptimeSeconds:    3600,
+			}
+		})
+
+		It("does not set the quick stats when the VM is not powered on", func() {
+			Expect(vmCtx.VM.Status.QuickStats).To(BeNil())
+		})
+
+		When("VM is powered on", func() {
+			BeforeEach(func() {
+				vmMO.Summary.Runtime.PowerState = types.VirtualMachinePowerStatePoweredOn
+			})
+
+			It("sets the quick stats in the status", func() {
+				stats := vmCtx.VM.Status.QuickStats
+				Expect(stats).ToNot(BeNil())
+				Expect(stats.CPUUsageMHz).To(Equal(int32(100)))
+				Expect(stats.CPUDemandMHz).To(Equal(int32(200)))
+				Expect(stats.GuestMemoryUsage.String()).To(Equal("256Mi"))
+				Expect(stats.HostMemoryUsage.String()).To(Equal("1Gi"))
+				Expect(stats.BalloonedMemory.String()).To(Equal("64Mi"))
+				Expect(stats.SwappedMemory.String()).To(Equal("32Mi"))
+				Expect(stats.UptimeSeconds).To(Equal(int32(3600)))
+				Expect(stats.SampleTime).ToNot(BeNil())
+			})
+
+			When("the quick stats were recently sampled", func() {
+				BeforeEach(func() {
+					sampleTime := metav1.NewTime(time.Now().Add(-time.Minute))
+					vmCtx.VM.Status.QuickStats = &vmopv1.VirtualMachineQuickStatsStatus{
+						SampleTime:  &sampleTime,
+						CPUUsageMHz: 50,
+					}
+				})
+
+				It("does not update the quick stats", func() {
+					stats := vmCtx.VM.Status.QuickStats
+					Expect(stats).ToNot(BeNil())
+					Expect(stats.CPUUsageMHz).To(Equal(int32(50)))
+					Expect(stats.UptimeSeconds).To(BeZero())
+				})
+			})
+
+			When("the quick stats are stale", func() {
+				BeforeEach(func() {
+					sampleTime := metav1.NewTime(time.Now().Add(-time.Hour))
+					vmCtx.VM.Status.QuickStats = &vmopv1.VirtualMachineQuickStatsStatus{
+						SampleTime:  &sampleTime,
+						CPUUsageMHz: 50,
+					}
+				})
+
+				It("updates the quick stats", func() {
+					stats := vmCtx.VM.Status.QuickStats
+					Expect(stats).ToNot(BeNil())
+					Expect(stats.CPUUsageMHz).To(Equal(int32(100)))
+					Expect(stats.SampleTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+			})
+		})
+	})
+
+	Context("Snapshots", func() {
+		var (
+			snap1, snap2 types.ManagedObjectReference
+		)
+
+		BeforeEach(func() {
+			snap1 = types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-1"}
+			snap2 = types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-2"}
+
+			vmMO.Snapshot = &types.VirtualMachineSnapshotInfo{
+				CurrentSnapshot: &snap2,
+				RootSnapshotList: []types.VirtualMachineSnapshotTree{
+					{
+						Snapshot: snap1,
+						Name:     "snap-1",
+						ChildSnapshotList: []types.VirtualMachineSnapshotTree{
+							{
+								Snapshot: snap2,
+								Name:     "snap-2",
+							},
+						},
+					},
+				},
+			}
+			vmMO.LayoutEx = &types.VirtualMachineFileLayoutEx{
+				File: []types.VirtualMachineFileLayoutExFileInfo{
+					{Key: 1, Size: 1024},
+					{Key: 2, Size: 2048},
+				},
+				Snapshot: []types.VirtualMachineFileLayoutExSnapshotLayout{
+					{Key: snap1, DataKey: 1},
+					{Key: snap2, DataKey: 2},
+				},
+			}
+		})
+
+		It("sets the snapshot tree in the status", func() {
+			status := vmCtx.VM.Status
+			Expect(status.CurrentSnapshot).To(Equal("snap-2"))
+			Expect(status.Snapshots).To(HaveLen(2))
+
+			Expect(status.Snapshots[0].Name).To(Equal("snap-1"))
+			Expect(status.Snapshots[0].ID).To(Equal(snap1.Value))
+			Expect(status.Snapshots[0].Parent).To(BeEmpty())
+			Expect(status.Snapshots[0].Size).ToNot(BeNil())
+			Expect(status.Snapshots[0].Size.Value()).To(BeEquivalentTo(1024))
+
+			Expect(status.Snapshots[1].Name).To(Equal("snap-2"))
+			Expect(status.Snapshots[1].ID).To(Equal(snap2.Value))
+			Expect(status.Snapshots[1].Parent).To(Equal(snap1.Value))
+			Expect(status.Snapshots[1].Size).ToNot(BeNil())
+			Expect(status.Snapshots[1].Size.Value()).To(BeEquivalentTo(2048))
+		})
+
+		When("the file layout was not retrieved", func() {
+			BeforeEach(func() {
+				vmMO.LayoutEx = nil
+			})
+
+			It("retrieves the file layout for the size of the snapshots", func() {
+				status := vmCtx.VM.Status
+				Expect(status.Snapshots).To(HaveLen(2))
+				Expect(status.Snapshots[0].Size).ToNot(BeNil())
+				Expect(status.Snapshots[1].Size).ToNot(BeNil())
+			})
+		})
+	})
 })
 
 var _ = Describe("VirtualMachineTools Status to VM Status Condition", func() {
@@ -19,6 +19,7 @@ import (
 	"github.com/vmware/govmomi/ovf"
 	"github.com/vmware/govmomi/task"
 	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrlruntime "sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,6 +50,12 @@ const (
 	ovfCacheMaxItem                 = 100
 	ovfCacheItemExpiration          = 30 * time.Minute
 	ovfCacheExpirationCheckInterval = 5 * time.Minute
+
+	// The hosts of a cluster are cached briefly so the VM classes that are reconciled
+	// together do not each retrieve them.
+	clusterHostsCacheMaxItem                 = 100
+	clusterHostsCacheItemExpiration          = 1 * time.Minute
+	clusterHostsCacheExpirationCheckInterval = 30 * time.Second
 )
 
 var log = logf.Log.WithName(VsphereVMProviderName)
@@ -66,8 +73,20 @@ type vSphereVMProvider struct {
 	ovfCache          *util.Cache[VersionedOVFEnvelope]
 	ovfCacheLockPool  *util.LockPool[string, *sync.RWMutex]
 
-	vcClientLock sync.Mutex
-	vcClient     *vcclient.Client
+	// clusterHostsCache has the hosts of each cluster that were retrieved to compute
+	// the compatibility of the VM classes, keyed by the vCenter host and cluster MoID.
+	clusterHostsCache *util.Cache[[]mo.HostSystem]
+
+	// vcClients has the client for each vCenter, keyed by the vCenter name. The default
+	// vCenter has an empty name. vCenters has the configs of the additional vCenters that
+	// are used to route a VM to its vCenter, and is loaded with the first client.
+	// contentVCenters has the name of the vCenter of each content library and library
+	// item that has been looked up, keyed by its ID.
+	vcClientLock    sync.Mutex
+	vcClients       map[string]*vcclient.Client
+	vCenters        []vcconfig.VCenterConfig
+	vCentersLoaded  bool
+	contentVCenters map[string]string
 }
 
 func NewVSphereVMProviderFromClient(
@@ -77,12 +96,22 @@ func NewVSphereVMProviderFromClient(
 	ovfCache, ovfLockPool := InitOvfCacheAndLockPool(
 		ovfCacheItemExpiration, ovfCacheExpirationCheckInterval, ovfCacheMaxItem)
 
+	clusterHostsCache := util.NewCache[[]mo.HostSystem](
+		clusterHostsCacheItemExpiration, clusterHostsCacheExpirationCheckInterval, clusterHostsCacheMaxItem)
+
+	// Nothing needs to be cleaned up when the cached hosts expire.
+	go func() {
+		for range clusterHostsCache.ExpiredChan() {
+		}
+	}()
+
 	return &vSphereVMProvider{
 		k8sClient:         client,
 		eventRecorder:     recorder,
 		globalExtraConfig: getExtraConfig(),
 		ovfCache:          ovfCache,
 		ovfCacheLockPool:  ovfLockPool,
+		clusterHostsCache: clusterHostsCache,
 	}
 }
 
@@ -131,15 +160,133 @@ func getExtraConfig() map[string]string {
 	return ec
 }
 
+// getVcClient returns the client for the default vCenter.
 func (vs *vSphereVMProvider) getVcClient(ctx goctx.Context) (*vcclient.Client, error) {
+	return vs.getVcClientByName(ctx, "")
+}
+
+// getVcClientForVM returns the client for the vCenter of the VM.
+func (vs *vSphereVMProvider) getVcClientForVM(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine) (*vcclient.Client, error) {
+
+	vcName, err := vs.getVCenterNameForVM(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.getVcClientByName(ctx, vcName)
+}
+
+// getVCenterNameForVM returns the name of the vCenter the VM was created in, or the name
+// of the vCenter of the VM's zone or namespace if the VM's vCenter was not yet recorded.
+func (vs *vSphereVMProvider) getVCenterNameForVM(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine) (string, error) {
+
+	if vcName, ok := vm.Annotations[vmopv1.VCenterNameAnnotation]; ok {
+		return vcName, nil
+	}
+
+	vCenters, err := vs.getVCenterConfigs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return vcconfig.VCenterNameForZoneAndNamespace(vCenters, vm.Labels[topology.KubernetesTopologyZoneLabelKey], vm.Namespace), nil
+}
+
+// getVcClientForZoneAndNamespace returns the client for the vCenter of the zone, or the
+// namespace if no vCenter has the zone. Either may be empty.
+func (vs *vSphereVMProvider) getVcClientForZoneAndNamespace(
+	ctx goctx.Context,
+	zone, namespace string) (*vcclient.Client, error) {
+
+	vCenters, err := vs.getVCenterConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.getVcClientByName(ctx, vcconfig.VCenterNameForZoneAndNamespace(vCenters, zone, namespace))
+}
+
+// getPlacementZones returns the names of the zones a VM may be placed in, which are the
+// zones that are in the same vCenter as the VM, or nil if the VM may be placed in any zone.
+// The VM's vCenter is chosen before the VM is placed, so the VM cannot be placed in a zone
+// whose ResourcePools are in another vCenter.
+func (vs *vSphereVMProvider) getPlacementZones(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine) ([]string, error) {
+
+	if !lib.IsWcpFaultDomainsFSSEnabled() {
+		return nil, nil
+	}
+
+	vCenters, err := vs.getVCenterConfigs(ctx)
+	if err != nil || len(vCenters) == 0 {
+		return nil, err
+	}
+
+	zones, err := topology.GetAvailabilityZones(ctx, vs.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	vcName, err := vs.getVCenterNameForVM(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+	zoneNames := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if vcconfig.VCenterNameForZoneAndNamespace(vCenters, zone.Name, vm.Namespace) == vcName {
+			zoneNames = append(zoneNames, zone.Name)
+		}
+	}
+
+	return zoneNames, nil
+}
+
+// getVCenterConfigs returns the configs of the additional vCenters.
+func (vs *vSphereVMProvider) getVCenterConfigs(ctx goctx.Context) ([]vcconfig.VCenterConfig, error) {
 	vs.vcClientLock.Lock()
 	defer vs.vcClientLock.Unlock()
 
-	if vs.vcClient != nil {
-		return vs.vcClient, nil
+	if !vs.vCentersLoaded {
+		vCenters, err := vcconfig.GetVCenterConfigs(ctx, vs.k8sClient)
+		if err != nil {
+			return nil, err
+		}
+
+		vs.vCenters = vCenters
+		vs.vCentersLoaded = true
 	}
 
-	config, err := vcconfig.GetProviderConfig(ctx, vs.k8sClient)
+	return vs.vCenters, nil
+}
+
+// getVCenterNames returns the names of all the vCenters, starting with the default vCenter.
+func (vs *vSphereVMProvider) getVCenterNames(ctx goctx.Context) ([]string, error) {
+	vCenters, err := vs.getVCenterConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vcNames := []string{""}
+	for _, vc := range vCenters {
+		vcNames = append(vcNames, vc.Name)
+	}
+	return vcNames, nil
+}
+
+func (vs *vSphereVMProvider) getVcClientByName(ctx goctx.Context, vcName string) (*vcclient.Client, error) {
+	vs.vcClientLock.Lock()
+	defer vs.vcClientLock.Unlock()
+
+	if vcClient := vs.vcClients[vcName]; vcClient != nil {
+		return vcClient, nil
+	}
+
+	config, err := vcconfig.GetProviderConfigForVCenter(ctx, vs.k8sClient, vcName)
 	if err != nil {
 		return nil, err
 	}
@@ -149,10 +296,88 @@ func (vs *vSphereVMProvider) getVcClient(ctx goctx.Context) (*vcclient.Client, e
 		return nil, err
 	}
 
-	vs.vcClient = vcClient
+	if vs.vcClients == nil {
+		vs.vcClients = map[string]*vcclient.Client{}
+	}
+	vs.vcClients[vcName] = vcClient
 	return vcClient, nil
 }
 
+// getVcClientForLibrary returns the client for the vCenter that has the content library.
+func (vs *vSphereVMProvider) getVcClientForLibrary(
+	ctx goctx.Context,
+	libraryID string) (*vcclient.Client, error) {
+
+	return vs.getVcClientForContent(ctx, libraryID, func(vcClient *vcclient.Client) error {
+		_, err := library.NewManager(vcClient.RestClient()).GetLibraryByID(ctx, libraryID)
+		return err
+	})
+}
+
+// getVcClientForLibraryItem returns the client for the vCenter that has the library item.
+func (vs *vSphereVMProvider) getVcClientForLibraryItem(
+	ctx goctx.Context,
+	itemID string) (*vcclient.Client, error) {
+
+	return vs.getVcClientForContent(ctx, itemID, func(vcClient *vcclient.Client) error {
+		_, err := vcClient.ContentLibClient().GetLibraryItemID(ctx, itemID)
+		return err
+	})
+}
+
+// getVcClientForContent returns the client for the vCenter that has the content library or
+// library item with the ID. Content libraries are not assigned to a vCenter in the provider
+// ConfigMap, so each vCenter is searched with the get function, starting with the default
+// vCenter, and the vCenter the content is found in is remembered. The default vCenter is
+// used when there are no additional vCenters.
+func (vs *vSphereVMProvider) getVcClientForContent(
+	ctx goctx.Context,
+	id string,
+	get func(vcClient *vcclient.Client) error) (*vcclient.Client, error) {
+
+	vcNames, err := vs.getVCenterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vcNames) == 1 {
+		return vs.getVcClientByName(ctx, vcNames[0])
+	}
+
+	vs.vcClientLock.Lock()
+	vcName, ok := vs.contentVCenters[id]
+	vs.vcClientLock.Unlock()
+
+	if ok {
+		return vs.getVcClientByName(ctx, vcName)
+	}
+
+	for _, vcName := range vcNames {
+		vcClient, err := vs.getVcClientByName(ctx, vcName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := get(vcClient); err != nil {
+			if lib.IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		vs.vcClientLock.Lock()
+		if vs.contentVCenters == nil {
+			vs.contentVCenters = map[string]string{}
+		}
+		vs.contentVCenters[id] = vcName
+		vs.vcClientLock.Unlock()
+
+		return vcClient, nil
+	}
+
+	return nil, fmt.Errorf("content library or library item %s not found in any vCenter", id)
+}
+
 func (vs *vSphereVMProvider) UpdateVcPNID(ctx goctx.Context, vcPNID, vcPort string) error {
 	updated, err := vcconfig.UpdateVcInConfigMap(ctx, vs.k8sClient, vcPNID, vcPort)
 	if err != nil || !updated {
@@ -161,21 +386,26 @@ func (vs *vSphereVMProvider) UpdateVcPNID(ctx goctx.Context, vcPNID, vcPort stri
 
 	// Our controller-runtime client does not cache ConfigMaps & Secrets, so the next time
 	// getVcClient() is called, it will fetch newly updated CM.
-	vs.clearAndLogoutVcClient(ctx)
+	vs.clearAndLogoutVcClients(ctx)
 	return nil
 }
 
 func (vs *vSphereVMProvider) ResetVcClient(ctx goctx.Context) {
-	vs.clearAndLogoutVcClient(ctx)
+	vs.clearAndLogoutVcClients(ctx)
 }
 
-func (vs *vSphereVMProvider) clearAndLogoutVcClient(ctx goctx.Context) {
+// clearAndLogoutVcClients logs out the clients of all the vCenters, and forgets the
+// vCenter configs so that they are reloaded with the next client.
+func (vs *vSphereVMProvider) clearAndLogoutVcClients(ctx goctx.Context) {
 	vs.vcClientLock.Lock()
-	vcClient := vs.vcClient
-	vs.vcClient = nil
+	vcClients := vs.vcClients
+	vs.vcClients = nil
+	vs.vCenters = nil
+	vs.vCentersLoaded = false
+	vs.contentVCenters = nil
 	vs.vcClientLock.Unlock()
 
-	if vcClient != nil {
+	for _, vcClient := range vcClients {
 		vcClient.Logout(ctx)
 	}
 }
@@ -229,7 +459,7 @@ func (vs *vSphereVMProvider) getOvfEnvelope(
 		logger.Info("Cache item hit, using cached OVF")
 	} else {
 		logger.Info("Cache item miss, downloading OVF from vCenter")
-		client, err := vs.getVcClient(ctx)
+		client, err := vs.getVcClientForLibraryItem(ctx, itemID)
 		if err != nil {
 			return nil, err
 		}
@@ -257,7 +487,7 @@ func (vs *vSphereVMProvider) GetItemFromLibraryByName(ctx goctx.Context,
 	log.V(4).Info("Get item from ContentLibrary",
 		"UUID", contentLibrary, "item name", itemName)
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForLibrary(ctx, contentLibrary)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +498,7 @@ func (vs *vSphereVMProvider) GetItemFromLibraryByName(ctx goctx.Context,
 func (vs *vSphereVMProvider) UpdateContentLibraryItem(ctx goctx.Context, itemID, newName string, newDescription *string) error {
 	log.V(4).Info("Update Content Library Item", "itemID", itemID)
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForLibraryItem(ctx, itemID)
 	if err != nil {
 		return err
 	}
@@ -346,12 +576,12 @@ func (vs *vSphereVMProvider) computeCPUMinFrequency(ctx goctx.Context) (uint64,
 		return 0, err
 	}
 
-	client, err := vs.getVcClient(ctx)
-	if err != nil {
-		return 0, err
-	}
-
 	if !lib.IsWcpFaultDomainsFSSEnabled() {
+		client, err := vs.getVcClient(ctx)
+		if err != nil {
+			return 0, err
+		}
+
 		ccr, err := vcenter.GetResourcePoolOwnerMoRef(ctx, client.VimClient(), client.Config().ResourcePool)
 		if err != nil {
 			return 0, err
@@ -372,6 +602,13 @@ func (vs *vSphereVMProvider) computeCPUMinFrequency(ctx goctx.Context) (uint64,
 			moIDs = []string{az.Spec.ClusterComputeResourceMoId} // HA TEMP
 		}
 
+		// The zone's clusters are in the zone's vCenter.
+		client, err := vs.getVcClientForZoneAndNamespace(ctx, az.Name, "")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
 		for _, moID := range moIDs {
 			ccr := object.NewClusterComputeResource(client.VimClient(),
 				types.ManagedObjectReference{Type: "ClusterComputeResource", Value: moID})
@@ -388,12 +625,31 @@ func (vs *vSphereVMProvider) computeCPUMinFrequency(ctx goctx.Context) (uint64,
 	return minFreq, k8serrors.NewAggregate(errs)
 }
 
-func (vs *vSphereVMProvider) GetTasksByActID(ctx goctx.Context, actID string) (_ []types.TaskInfo, retErr error) {
-	vcClient, err := vs.getVcClient(ctx)
+// GetTasksByActID returns the tasks with the activation ID from all the vCenters.
+func (vs *vSphereVMProvider) GetTasksByActID(ctx goctx.Context, actID string) ([]types.TaskInfo, error) {
+	vcNames, err := vs.getVCenterNames(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	taskList := make([]types.TaskInfo, 0)
+	for _, vcName := range vcNames {
+		vcClient, err := vs.getVcClientByName(ctx, vcName)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks, err := getTasksByActID(ctx, vcClient, actID)
+		if err != nil {
+			return nil, err
+		}
+		taskList = append(taskList, tasks...)
+	}
+
+	return taskList, nil
+}
+
+func getTasksByActID(ctx goctx.Context, vcClient *vcclient.Client, actID string) (_ []types.TaskInfo, retErr error) {
 	taskManager := task.NewManager(vcClient.VimClient())
 	filterSpec := types.TaskFilterSpec{
 		ActivationId: []string{actID},
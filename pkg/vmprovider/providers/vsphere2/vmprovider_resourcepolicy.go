@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/vmware/govmomi/object"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/clustermodules"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/hostaffinity"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
 )
 
@@ -22,7 +24,7 @@ func (vs *vSphereVMProvider) IsVirtualMachineSetResourcePolicyReady(
 	azName string,
 	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) (bool, error) {
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForZoneAndNamespace(ctx, azName, resourcePolicy.Namespace)
 	if err != nil {
 		return false, err
 	}
@@ -52,9 +54,15 @@ func (vs *vSphereVMProvider) IsVirtualMachineSetResourcePolicyReady(
 		return false, err
 	}
 
-	if !rpExists || !folderExists || !modulesExist {
+	hostAffinityExists, err := hostaffinity.Exists(ctx, object.NewClusterComputeResource(client.VimClient(), clusterRef), resourcePolicy)
+	if err != nil {
+		return false, err
+	}
+
+	if !rpExists || !folderExists || !modulesExist || !hostAffinityExists {
 		log.V(4).Info("Resource policy is not ready", "resourcePolicy", resourcePolicy.Name,
-			"namespace", resourcePolicy.Name, "az", azName, "resourcePool", rpExists, "folder", folderExists, "modules", modulesExist)
+			"namespace", resourcePolicy.Name, "az", azName, "resourcePool", rpExists, "folder", folderExists,
+			"modules", modulesExist, "hostAffinity", hostAffinityExists)
 		return false, nil
 	}
 
@@ -71,7 +79,7 @@ func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(
 		return err
 	}
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForZoneAndNamespace(ctx, "", resourcePolicy.Namespace)
 	if err != nil {
 		return err
 	}
@@ -97,6 +105,24 @@ func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(
 		if err != nil {
 			errs = append(errs, err)
 		}
+
+		if err == nil {
+			cluster := object.NewClusterComputeResource(vimClient, clusterRef)
+			if resourcePolicy.Spec.HostAffinity != nil {
+				err = hostaffinity.CreateOrUpdate(ctx, vs.k8sClient, cluster, resourcePolicy)
+			} else {
+				err = hostaffinity.Delete(ctx, cluster, resourcePolicy)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if resourcePools, err := vs.getResourcePoolStatuses(ctx, resourcePolicy); err != nil {
+		errs = append(errs, err)
+	} else {
+		resourcePolicy.Status.ResourcePools = resourcePools
 	}
 
 	return k8serrors.NewAggregate(errs)
@@ -112,7 +138,7 @@ func (vs *vSphereVMProvider) DeleteVirtualMachineSetResourcePolicy(
 		return err
 	}
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForZoneAndNamespace(ctx, "", resourcePolicy.Namespace)
 	if err != nil {
 		return err
 	}
@@ -121,7 +147,15 @@ func (vs *vSphereVMProvider) DeleteVirtualMachineSetResourcePolicy(
 	var errs []error
 
 	for _, rpMoID := range rpMoIDs {
-		err := vcenter.DeleteChildResourcePool(ctx, vimClient, rpMoID, resourcePolicy.Spec.ResourcePool.Name)
+		clusterRef, err := vcenter.GetResourcePoolOwnerMoRef(ctx, vimClient, rpMoID)
+		if err == nil {
+			err = hostaffinity.Delete(ctx, object.NewClusterComputeResource(vimClient, clusterRef), resourcePolicy)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		err = vcenter.DeleteChildResourcePool(ctx, vimClient, rpMoID, resourcePolicy.Spec.ResourcePool.Name)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -244,6 +278,74 @@ func (vs *vSphereVMProvider) deleteClusterModules(
 	return errs
 }
 
+// getResourcePoolStatuses returns the usage of the resource policy's child ResourcePool in each of the
+// Namespace's zones and clusters.
+func (vs *vSphereVMProvider) getResourcePoolStatuses(
+	ctx context.Context,
+	resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) ([]vmopv1.ResourcePoolStatus, error) {
+
+	availabilityZones, err := topology.GetAvailabilityZones(ctx, vs.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var resourcePools []vmopv1.ResourcePoolStatus
+	var errs []error
+
+	for _, az := range availabilityZones {
+		nsInfo, ok := az.Spec.Namespaces[resourcePolicy.Namespace]
+		if !ok {
+			continue
+		}
+
+		rpMoIDs := nsInfo.PoolMoIDs
+		if len(rpMoIDs) == 0 {
+			rpMoIDs = []string{nsInfo.PoolMoId}
+		}
+
+		client, err := vs.getVcClientForZoneAndNamespace(ctx, az.Name, resourcePolicy.Namespace)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, rpMoID := range rpMoIDs {
+			runtime, err := vcenter.GetChildResourcePoolRuntime(ctx, client.VimClient(), rpMoID, resourcePolicy.Spec.ResourcePool.Name)
+			if err != nil || runtime == nil {
+				if err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+
+			clusterRef, err := vcenter.GetResourcePoolOwnerMoRef(ctx, client.VimClient(), rpMoID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			resourcePools = append(resourcePools, vmopv1.ResourcePoolStatus{
+				Zone:        az.Name,
+				ClusterMoID: clusterRef.Value,
+				CPU:         resourcePoolResourceUsage(runtime.Cpu, 1),
+				Memory:      resourcePoolResourceUsage(runtime.Memory, 1024*1024),
+			})
+		}
+	}
+
+	return resourcePools, k8serrors.NewAggregate(errs)
+}
+
+// resourcePoolResourceUsage returns the ResourcePool's usage of a resource, dividing the vSphere values
+// by the divisor to convert them to the units of the status.
+func resourcePoolResourceUsage(usage vimtypes.ResourcePoolResourceUsage, divisor int64) vmopv1.ResourcePoolResourceUsage {
+	return vmopv1.ResourcePoolResourceUsage{
+		Usage:                usage.OverallUsage / divisor,
+		ReservationUsed:      usage.ReservationUsed / divisor,
+		ReservationRemaining: usage.UnreservedForVm / divisor,
+	}
+}
+
 func (vs *vSphereVMProvider) getNamespaceFolderAndRPMoIDs(
 	ctx context.Context,
 	namespace string) (string, []string, error) {
@@ -11,12 +11,16 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
 	vsphere "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -38,6 +42,32 @@ func getVirtualMachineSetResourcePolicy(name, namespace string) *vmopv1.VirtualM
 	}
 }
 
+// createHostNodes creates a Node with the labels for each host in the cluster. Since the Node name
+// is the host's FQDN, the hosts must have a DNS config.
+func createHostNodes(
+	ctx *builder.TestContextForVCSim,
+	cluster *object.ClusterComputeResource,
+	labels map[string]string) []vimtypes.ManagedObjectReference {
+
+	var cr mo.ComputeResource
+	Expect(cluster.Properties(ctx, cluster.Reference(), []string{"host"}, &cr)).To(Succeed())
+
+	for _, host := range cr.Host {
+		hostFQDN, err := vcenter.GetESXHostFQDN(ctx, ctx.VCClient.Client, host.Value)
+		Expect(err).ToNot(HaveOccurred())
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   hostFQDN,
+				Labels: labels,
+			},
+		}
+		Expect(ctx.Client.Create(ctx, node)).To(Succeed())
+	}
+
+	return cr.Host
+}
+
 func resourcePolicyTests() {
 	Describe("VirtualMachineSetResourcePolicy Tests", func() {
 
@@ -69,12 +99,18 @@ func resourcePolicyTests() {
 		Context("VirtualMachineSetResourcePolicy", func() {
 			var (
 				resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+				hostAffinity   *vmopv1.VirtualMachineSetResourcePolicyHostAffinity
 			)
 
+			AfterEach(func() {
+				hostAffinity = nil
+			})
+
 			JustBeforeEach(func() {
 				testPolicyName := "test-policy"
 
 				resourcePolicy = getVirtualMachineSetResourcePolicy(testPolicyName, nsInfo.Namespace)
+				resourcePolicy.Spec.HostAffinity = hostAffinity
 				Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
 			})
 
@@ -144,11 +180,96 @@ func resourcePolicyTests() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			It("reports the usage of the resource pool", func() {
+				Expect(resourcePolicy.Status.ResourcePools).To(HaveLen(1))
+				rpStatus := resourcePolicy.Status.ResourcePools[0]
+				Expect(rpStatus.Zone).ToNot(BeEmpty())
+				Expect(rpStatus.ClusterMoID).To(Equal(ctx.GetSingleClusterCompute().Reference().Value))
+			})
+
 			It("creates expected child folder", func() {
 				_, err := ctx.Finder.Folder(ctx, path.Join(nsInfo.Folder.InventoryPath, resourcePolicy.Spec.Folder))
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			Context("when the resource policy has a host affinity", func() {
+				const (
+					ruleName      = "vmoperator/%s/test-policy-resourcepolicy"
+					hostGroupName = ruleName + "/hosts"
+				)
+
+				var (
+					cluster *object.ClusterComputeResource
+				)
+
+				BeforeEach(func() {
+					// Hosts only have a FQDN with instance storage.
+					testConfig.WithInstanceStorage = true
+					hostAffinity = &vmopv1.VirtualMachineSetResourcePolicyHostAffinity{
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"license": "db"}},
+						Required:     true,
+					}
+				})
+
+				JustBeforeEach(func() {
+					cluster = ctx.GetSingleClusterCompute()
+				})
+
+				getConfig := func() *vimtypes.ClusterConfigInfoEx {
+					config, err := cluster.Configuration(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					return config
+				}
+
+				It("creates the DRS host group of the selected hosts and the VM-Host rule", func() {
+					hosts := createHostNodes(ctx, cluster, map[string]string{"license": "db"})
+					Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
+
+					config := getConfig()
+
+					var hostGroup *vimtypes.ClusterHostGroup
+					for _, group := range config.Group {
+						if g, ok := group.(*vimtypes.ClusterHostGroup); ok && g.Name == fmt.Sprintf(hostGroupName, nsInfo.Namespace) {
+							hostGroup = g
+						}
+					}
+					Expect(hostGroup).ToNot(BeNil())
+					Expect(hostGroup.Host).To(ConsistOf(hosts))
+
+					var rule *vimtypes.ClusterVmHostRuleInfo
+					for _, r := range config.Rule {
+						if r, ok := r.(*vimtypes.ClusterVmHostRuleInfo); ok && r.Name == fmt.Sprintf(ruleName, nsInfo.Namespace) {
+							rule = r
+						}
+					}
+					Expect(rule).ToNot(BeNil())
+					Expect(*rule.Mandatory).To(BeTrue())
+
+					exists, err := vmProvider.IsVirtualMachineSetResourcePolicyReady(ctx, "", resourcePolicy)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(exists).To(BeTrue())
+				})
+
+				It("deletes the DRS groups and rule when the host affinity is removed", func() {
+					resourcePolicy.Spec.HostAffinity = nil
+					Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
+
+					config := getConfig()
+					Expect(config.Group).To(BeEmpty())
+					Expect(config.Rule).To(BeEmpty())
+				})
+
+				It("deletes the DRS groups and rule when the resource policy is deleted", func() {
+					Expect(getConfig().Rule).To(HaveLen(1))
+
+					Expect(vmProvider.DeleteVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
+
+					config := getConfig()
+					Expect(config.Group).To(BeEmpty())
+					Expect(config.Rule).To(BeEmpty())
+				})
+			})
+
 			Context("when HA is enabled", func() {
 				BeforeEach(func() {
 					testConfig.WithFaultDomains = true
@@ -228,6 +349,21 @@ func resourcePolicyTests() {
 						}
 					}
 				})
+
+				It("reports the usage of the resource pool for each cluster", func() {
+					var clusterMoIDs []string
+					for _, zoneName := range ctx.ZoneNames {
+						for _, cluster := range ctx.GetAZClusterComputes(zoneName) {
+							clusterMoIDs = append(clusterMoIDs, cluster.Reference().Value)
+						}
+					}
+
+					Expect(resourcePolicy.Status.ResourcePools).To(HaveLen(len(clusterMoIDs)))
+					for _, rpStatus := range resourcePolicy.Status.ResourcePools {
+						Expect(ctx.ZoneNames).To(ContainElement(rpStatus.Zone))
+						Expect(clusterMoIDs).To(ContainElement(rpStatus.ClusterMoID))
+					}
+				})
 			})
 		})
 	})
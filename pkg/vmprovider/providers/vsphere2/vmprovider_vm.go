@@ -6,16 +6,22 @@ package vsphere
 import (
 	goctx "context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/session"
 
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,14 +29,17 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
+	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsnodevmattachment/v1alpha1"
 	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
 	vcclient "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/client"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/hostaffinity"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/placement"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/storage"
@@ -82,11 +91,23 @@ func (vs *vSphereVMProvider) CreateOrUpdateVirtualMachine(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	vcName, err := vs.getVCenterNameForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return err
 	}
 
+	client, err := vs.getVcClientByName(vmCtx, vcName)
+	if err != nil {
+		return err
+	}
+
+	// Record the VM's vCenter so the VM is still found in it after the VM's zone is
+	// changed or the VM's namespace is assigned to another vCenter.
+	if vm.Annotations == nil {
+		vm.Annotations = map[string]string{}
+	}
+	vm.Annotations[vmopv1.VCenterNameAnnotation] = vcName
+
 	vcVM, err := vs.getVM(vmCtx, client, false)
 	if err != nil {
 		return err
@@ -122,7 +143,7 @@ func (vs *vSphereVMProvider) DeleteVirtualMachine(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return err
 	}
@@ -135,6 +156,24 @@ func (vs *vSphereVMProvider) DeleteVirtualMachine(
 		return nil
 	}
 
+	if err := virtualmachine.DeleteAffinityRules(vmCtx, vcVM); err != nil {
+		return err
+	}
+
+	// The resource policy may already be deleted, along with its host affinity DRS groups.
+	if resourcePolicy, err := GetVMSetResourcePolicy(vmCtx, vs.k8sClient); err == nil &&
+		resourcePolicy != nil && resourcePolicy.Spec.HostAffinity != nil {
+
+		cluster, err := virtualmachine.GetVMClusterComputeResource(vmCtx, vcVM)
+		if err != nil {
+			return err
+		}
+
+		if err := hostaffinity.RemoveVM(vmCtx, cluster, resourcePolicy, vcVM.Reference()); err != nil {
+			return err
+		}
+	}
+
 	return virtualmachine.DeleteVirtualMachine(vmCtx, vcVM)
 }
 
@@ -154,7 +193,7 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 		VM: vm,
 	}
 
-	client, err := vs.getVcClient(ctx)
+	client, err := vs.getVcClientForVM(ctx, vm)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get vCenter client")
 	}
@@ -177,7 +216,7 @@ func (vs *vSphereVMProvider) GetVirtualMachineGuestHeartbeat(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return "", err
 	}
@@ -205,7 +244,7 @@ func (vs *vSphereVMProvider) GetVirtualMachineGuestInfo(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +273,7 @@ func (vs *vSphereVMProvider) GetVirtualMachineWebMKSTicket(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return "", err
 	}
@@ -262,7 +301,7 @@ func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 		VM:      vm,
 	}
 
-	client, err := vs.getVcClient(vmCtx)
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
 	if err != nil {
 		return 0, err
 	}
@@ -281,6 +320,386 @@ func (vs *vSphereVMProvider) GetVirtualMachineHardwareVersion(
 	return contentlibrary.ParseVirtualHardwareVersion(o.Config.Version), nil
 }
 
+// RestartVirtualMachine restarts the VM in accordance with its restart mode
+// and blocks until the restart has completed or failed.
+func (vs *vSphereVMProvider) RestartVirtualMachine(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "restart")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	chanResult := vmutil.Restart(
+		logr.NewContext(vmCtx, vmCtx.Logger),
+		vcVM.Client(),
+		vmutil.ManagedObjectFromObject(vcVM),
+		true,
+		time.Now().UTC(),
+		vmutil.ParsePowerOpMode(string(vm.Spec.RestartMode)))
+
+	select {
+	case <-vmCtx.Done():
+		return vmCtx.Err()
+	case result := <-chanResult:
+		if err, ok := result.(error); ok {
+			return err
+		}
+		if result, ok := result.(vmutil.PowerOpResult); ok && !result.AnyChange() {
+			return fmt.Errorf("VM was not restarted")
+		}
+		return nil
+	}
+}
+
+func (vs *vSphereVMProvider) ExpandVirtualMachineDisk(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	diskUUID string,
+	capacity resource.Quantity) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "expandDisk")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.ExpandDisk(vmCtx, vcVM, diskUUID, capacity.Value())
+}
+
+func (vs *vSphereVMProvider) CreateSnapshot(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "createSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return err
+	}
+
+	node, err := virtualmachine.CreateSnapshot(vmCtx, vcVM, virtualmachine.SnapshotArgs{
+		ID:          vmSnapshot.Status.UniqueID,
+		Name:        vmSnapshot.Name,
+		Description: vmSnapshot.Spec.Description,
+		Memory:      vmSnapshot.Spec.Memory,
+		Quiesce:     vmSnapshot.Spec.Quiesce,
+	})
+	if err != nil {
+		return err
+	}
+
+	creationTime := metav1.NewTime(node.CreateTime)
+	vmSnapshot.Status.UniqueID = node.Snapshot.Value
+	vmSnapshot.Status.CreationTime = &creationTime
+
+	return nil
+}
+
+func (vs *vSphereVMProvider) DeleteSnapshot(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	vmSnapshot *vmopv1.VirtualMachineSnapshot) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "deleteSnapshot")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "snapshotName", vmSnapshot.Name),
+		VM:      vm,
+	}
+
+	// The snapshot is identified by the ID recorded when it was created, so there
+	// is nothing to delete without one.
+	if vmSnapshot.Status.UniqueID == "" {
+		return nil
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
+	if err != nil {
+		return err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, false)
+	if err != nil {
+		return err
+	} else if vcVM == nil {
+		// VM does not exist so neither does the snapshot.
+		return nil
+	}
+
+	return virtualmachine.DeleteSnapshot(vmCtx, vcVM, vmSnapshot.Status.UniqueID)
+}
+
+func (vs *vSphereVMProvider) RunGuestCommand(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	guestCmd *vmopv1.VirtualMachineGuestCommand) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "runGuestCommand")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "guestCommandName", guestCmd.Name),
+		VM:      vm,
+	}
+
+	vcVM, auth, err := vs.getVMForGuestOperations(vmCtx, guestCmd.Spec.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.RunGuestCommand(vmCtx, vcVM, auth, guestCmd)
+}
+
+// RunGuestProbeCommand runs the command of a probe's Exec action in the VM's
+// guest and returns the command's exit code.
+func (vs *vSphereVMProvider) RunGuestProbeCommand(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	action *vmopv1.GuestExecAction) (int32, error) {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "runGuestProbeCommand")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	vcVM, auth, err := vs.getVMForGuestOperations(vmCtx, action.CredentialsSecretName)
+	if err != nil {
+		return 0, err
+	}
+
+	return virtualmachine.RunGuestExec(vmCtx, vcVM, auth, action)
+}
+
+func (vs *vSphereVMProvider) CopyFileToGuest(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	fileTransfer *vmopv1.VirtualMachineGuestFileTransfer,
+	content []byte) error {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "copyFileToGuest")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "fileTransferName", fileTransfer.Name),
+		VM:      vm,
+	}
+
+	vcVM, auth, err := vs.getVMForGuestOperations(vmCtx, fileTransfer.Spec.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+
+	return virtualmachine.CopyFileToGuest(vmCtx, vcVM, auth, fileTransfer.Spec.GuestPath, content, fileTransfer.Spec.Overwrite)
+}
+
+func (vs *vSphereVMProvider) CopyFileFromGuest(
+	ctx goctx.Context,
+	vm *vmopv1.VirtualMachine,
+	fileTransfer *vmopv1.VirtualMachineGuestFileTransfer,
+	sizeLimit int64) ([]byte, int64, error) {
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "copyFileFromGuest")),
+		Logger:  log.WithValues("vmName", vm.NamespacedName(), "fileTransferName", fileTransfer.Name),
+		VM:      vm,
+	}
+
+	vcVM, auth, err := vs.getVMForGuestOperations(vmCtx, fileTransfer.Spec.CredentialsSecretName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return virtualmachine.CopyFileFromGuest(vmCtx, vcVM, auth, fileTransfer.Spec.GuestPath, sizeLimit)
+}
+
+// WatchVirtualMachines calls onUpdate with the MoIDs of the VC VMs whose power state,
+// guest IPs, heartbeat, or tasks changed. Each vCenter is watched concurrently, so onUpdate
+// may be called concurrently. This blocks until the context is cancelled or the watch of
+// any vCenter fails.
+func (vs *vSphereVMProvider) WatchVirtualMachines(
+	ctx goctx.Context,
+	onUpdate func(moIDs []string)) error {
+
+	vcNames, err := vs.getVCenterNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Stop watching the other vCenters when one watch stops.
+	ctx, cancel := goctx.WithCancel(ctx)
+	defer cancel()
+
+	watchErrs := make(chan error, len(vcNames))
+	for _, vcName := range vcNames {
+		client, err := vs.getVcClientByName(ctx, vcName)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			watchErrs <- vcenter.WatchVirtualMachines(ctx, client.VimClient(), onUpdate)
+		}()
+	}
+
+	return <-watchErrs
+}
+
+// PlaceVirtualMachine performs a placement dry run of a VM with the class, image, and storage
+// class of the placement request, and sets the recommendations of each zone and the faults of
+// the candidates that do not fit in the request's status. Nothing is created on VC.
+func (vs *vSphereVMProvider) PlaceVirtualMachine(
+	ctx goctx.Context,
+	placementReq *vmopv1.VirtualMachinePlacementRequest) error {
+
+	// This VM only describes the VM that would be placed and is never created.
+	vm := &vmopv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      placementReq.Name,
+			Namespace: placementReq.Namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: vmopv1.VirtualMachineSpec{
+			ClassName:    placementReq.Spec.ClassName,
+			ImageName:    placementReq.Spec.ImageName,
+			StorageClass: placementReq.Spec.StorageClass,
+			Network: &vmopv1.VirtualMachineNetworkSpec{
+				Disabled: true,
+			},
+		},
+	}
+	if zone := placementReq.Spec.Zone; zone != "" {
+		vm.Labels[topology.KubernetesTopologyZoneLabelKey] = zone
+	}
+
+	vmCtx := context.VirtualMachineContextA2{
+		Context: goctx.WithValue(ctx, types.ID{}, vs.getOpID(vm, "placeVirtualMachine")),
+		Logger:  log.WithValues("placementRequestName", vm.NamespacedName()),
+		VM:      vm,
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vm)
+	if err != nil {
+		return err
+	}
+
+	createArgs, err := vs.vmCreateGetPrereqs(vmCtx, client)
+	if err != nil {
+		return err
+	}
+
+	if err := vs.vmCreateGenConfigSpec(vmCtx, createArgs); err != nil {
+		return err
+	}
+
+	placementConfigSpec := virtualmachine.CreateConfigSpecForPlacement(
+		vmCtx,
+		createArgs.ConfigSpec,
+		createArgs.StorageClassesToIDs)
+
+	placementZones, err := vs.getPlacementZones(vmCtx, vm)
+	if err != nil {
+		return err
+	}
+
+	result, err := placement.DryRunPlacement(
+		vmCtx,
+		vs.k8sClient,
+		client.VimClient(),
+		placementConfigSpec,
+		createArgs.ChildResourcePoolName,
+		createArgs.HasInstanceStorage,
+		placementZones)
+	if err != nil {
+		return err
+	}
+
+	zoneNames := make([]string, 0, len(result.Recommendations))
+	for zoneName := range result.Recommendations {
+		zoneNames = append(zoneNames, zoneName)
+	}
+	sort.Strings(zoneNames)
+
+	zones := make([]vmopv1.VirtualMachinePlacementZoneRecommendations, 0, len(zoneNames))
+	for _, zoneName := range zoneNames {
+		zone := vmopv1.VirtualMachinePlacementZoneRecommendations{
+			Name: zoneName,
+		}
+
+		for _, rec := range result.Recommendations[zoneName] {
+			r := vmopv1.VirtualMachinePlacementRecommendation{
+				ResourcePool: rec.PoolMoRef.Value,
+			}
+			if rec.HostMoRef != nil {
+				r.Host = rec.HostMoRef.Value
+			}
+			if rec.DatastoreMoRef != nil {
+				r.Datastore = rec.DatastoreMoRef.Value
+			}
+			zone.Recommendations = append(zone.Recommendations, r)
+		}
+
+		zones = append(zones, zone)
+	}
+
+	placementReq.Status.Zones = zones
+	placementReq.Status.Faults = result.Faults
+
+	return nil
+}
+
+// getVMForGuestOperations returns the VC VM and the guest credentials from
+// the Secret that are used to perform guest operations in the VM.
+func (vs *vSphereVMProvider) getVMForGuestOperations(
+	vmCtx context.VirtualMachineContextA2,
+	credentialsSecretName string) (*object.VirtualMachine, *types.NamePasswordAuthentication, error) {
+
+	auth, err := getGuestAuthentication(vmCtx, vs.k8sClient, credentialsSecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := vs.getVcClientForVM(vmCtx, vmCtx.VM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vcVM, auth, nil
+}
+
 func (vs *vSphereVMProvider) createVirtualMachine(
 	vmCtx context.VirtualMachineContextA2,
 	vcClient *vcclient.Client) (*object.VirtualMachine, *VMCreateArgs, error) {
@@ -369,6 +788,17 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 
 	vmCtx.Logger.V(4).Info("Updating VirtualMachine")
 
+	if err := vs.vmUpdateRevertToSnapshot(vmCtx, vcVM); err != nil {
+		return err
+	}
+
+	// A failed relocation is reported by the VM's ZoneRelocated condition and
+	// retried on the next update, but does not block the VM's other updates.
+	relocateErr := vs.vmUpdateRelocateToZone(vmCtx, vcVM, vcClient)
+	if relocateErr != nil {
+		vmCtx.Logger.Error(relocateErr, "Failed to relocate VM to its zone")
+	}
+
 	{
 		// Hack - create just enough of the Session that's needed for update
 
@@ -390,10 +820,18 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 			return vs.vmUpdateGetArgs(vmCtx)
 		}
 
+		if err := vs.vmUpdateHostAffinity(vmCtx, vcVM, cluster); err != nil {
+			return err
+		}
+
 		err = ses.UpdateVirtualMachine(vmCtx, vcVM, getUpdateArgsFn)
 		if err != nil {
 			return err
 		}
+
+		if err := virtualmachine.ReconcileAffinityRules(vmCtx, vs.k8sClient, vcVM, cluster); err != nil {
+			return err
+		}
 	}
 
 	// Back up the VM at the end after a successful update.
@@ -423,9 +861,239 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 		}
 	}
 
+	return relocateErr
+}
+
+// vmUpdateHostAffinity adds the VM to the DRS VM group of its resource policy's host affinity, and
+// reports whether the VM runs on one of the hosts selected by the host affinity.
+func (vs *vSphereVMProvider) vmUpdateHostAffinity(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	cluster *object.ClusterComputeResource) error {
+
+	resourcePolicy, err := GetVMSetResourcePolicy(vmCtx, vs.k8sClient)
+	if err != nil {
+		return err
+	}
+
+	if resourcePolicy == nil || resourcePolicy.Spec.HostAffinity == nil {
+		conditions.Delete(vmCtx.VM, vmopv1.VirtualMachineHostAffinityCompliantCondition)
+		return nil
+	}
+
+	if err := hostaffinity.AddVM(vmCtx, cluster, resourcePolicy, vcVM.Reference()); err != nil {
+		return err
+	}
+
+	compliant, err := hostaffinity.IsVMCompliant(vmCtx, cluster, resourcePolicy, vcVM)
+	if err != nil {
+		return err
+	}
+
+	if compliant {
+		conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineHostAffinityCompliantCondition)
+	} else {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineHostAffinityCompliantCondition,
+			vmopv1.VirtualMachineHostAffinityNotCompliantReason,
+			"VM is not on a host selected by the host affinity of VirtualMachineSetResourcePolicy %s", resourcePolicy.Name)
+	}
+
+	return nil
+}
+
+// vmUpdateRevertToSnapshot reverts the VM to the snapshot named by
+// spec.currentSnapshot if the VM has not already been reverted to it.
+func (vs *vSphereVMProvider) vmUpdateRevertToSnapshot(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine) error {
+
+	snapshotName := vmCtx.VM.Spec.CurrentSnapshot
+	if snapshotName == "" || snapshotName == vmCtx.VM.Status.LastRevertedSnapshot {
+		return nil
+	}
+
+	detachedDiskUUIDs, err := virtualmachine.RevertToSnapshot(vmCtx, vcVM, snapshotName)
+	if err != nil {
+		return fmt.Errorf("failed to revert to snapshot %q: %w", snapshotName, err)
+	}
+	vmCtx.VM.Status.LastRevertedSnapshot = snapshotName
+
+	if err := vs.deleteDetachedVolumeAttachments(vmCtx, detachedDiskUUIDs); err != nil {
+		return fmt.Errorf("failed to reattach volumes after revert to snapshot %q: %w", snapshotName, err)
+	}
+
+	// The revert never powers on the VM, and a VM reverted to a snapshot that
+	// includes its memory is suspended. Since a suspended VM cannot be shut
+	// down by its guest, a VM that should be powered off is powered off here
+	// regardless of its power off mode. All other power state changes are
+	// handled by the update that follows.
+	if vmCtx.VM.Spec.PowerState == vmopv1.VirtualMachinePowerStateOff {
+		if _, err := vmutil.SetAndWaitOnPowerState(
+			logr.NewContext(vmCtx, vmCtx.Logger),
+			vcVM.Client(),
+			vmutil.ManagedObjectFromObject(vcVM),
+			true,
+			types.VirtualMachinePowerStatePoweredOff,
+			vmutil.PowerOpBehaviorHard); err != nil {
+
+			return err
+		}
+	}
+
 	return nil
 }
 
+// deleteDetachedVolumeAttachments deletes the CnsNodeVmAttachments of the VM's
+// disks that were detached by a revert to a snapshot. CNS does not attach a
+// volume again once its attachment is attached, so the attachments are deleted
+// for the volume controller to create them again, and CNS to attach the disks.
+func (vs *vSphereVMProvider) deleteDetachedVolumeAttachments(
+	vmCtx context.VirtualMachineContextA2,
+	diskUUIDs []string) error {
+
+	if len(diskUUIDs) == 0 {
+		return nil
+	}
+
+	detached := make(map[string]struct{}, len(diskUUIDs))
+	for _, uuid := range diskUUIDs {
+		detached[uuid] = struct{}{}
+	}
+
+	list := &cnsv1alpha1.CnsNodeVmAttachmentList{}
+	if err := vs.k8sClient.List(vmCtx, list, ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range list.Items {
+		attachment := &list.Items[i]
+		if attachment.Spec.NodeUUID != vmCtx.VM.Status.BiosUUID {
+			continue
+		}
+		if _, ok := detached[attachment.Status.AttachmentMetadata[constants.CNSAttachmentDiskUUIDKey]]; !ok {
+			continue
+		}
+
+		vmCtx.Logger.Info("Deleting CnsNodeVmAttachment of volume detached by revert to snapshot",
+			"attachment", attachment.Name)
+		if err := vs.k8sClient.Delete(vmCtx, attachment); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return k8serrors.NewAggregate(errs)
+}
+
+// vmUpdateRelocateToZone relocates the VM to the zone in its zone label when the label
+// was changed after the VM was created. The VM's Status.Zone is only updated after the
+// relocation succeeds, so a failed relocation is retried on the next update.
+func (vs *vSphereVMProvider) vmUpdateRelocateToZone(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client) error {
+
+	if !lib.IsWcpFaultDomainsFSSEnabled() {
+		return nil
+	}
+
+	zoneName := vmCtx.VM.Labels[topology.KubernetesTopologyZoneLabelKey]
+	if zoneName == "" || vmCtx.VM.Status.Zone == "" || zoneName == vmCtx.VM.Status.Zone {
+		return nil
+	}
+
+	vmCtx.Logger.Info("Relocating VM to new zone", "fromZone", vmCtx.VM.Status.Zone, "toZone", zoneName)
+	conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineZoneRelocatedCondition,
+		vmopv1.VirtualMachineZoneRelocationInProgressReason, "Relocating to zone %s", zoneName)
+
+	storageClassesToIDs, err := storage.GetVMStoragePoliciesIDs(vmCtx, vs.k8sClient)
+	if err != nil {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineZoneRelocatedCondition,
+			vmopv1.VirtualMachineZoneRelocationFailedReason, err.Error())
+		return err
+	}
+
+	result, err := vs.vmUpdateRelocatePlacement(vmCtx, vcVM, vcClient, storageClassesToIDs)
+	if err != nil {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineZoneRelocatedCondition,
+			vmopv1.VirtualMachineZoneRelocationPlacementFailedReason, err.Error())
+		return fmt.Errorf("failed to place VM in zone %s: %w", zoneName, err)
+	}
+
+	relocateArgs := virtualmachine.RelocateArgs{
+		PoolMoRef:        result.PoolMoRef,
+		HostMoRef:        result.HostMoRef,
+		DatastoreMoRef:   result.DatastoreMoRef,
+		StorageProfileID: storageClassesToIDs[vmCtx.VM.Spec.StorageClass],
+	}
+
+	if err := virtualmachine.Relocate(vmCtx, vcVM, relocateArgs); err != nil {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineZoneRelocatedCondition,
+			vmopv1.VirtualMachineZoneRelocationFailedReason, err.Error())
+		return fmt.Errorf("failed to relocate VM to zone %s: %w", zoneName, err)
+	}
+
+	vmCtx.VM.Status.Zone = zoneName
+	conditions.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineZoneRelocatedCondition)
+
+	return nil
+}
+
+// vmUpdateRelocatePlacement determines the placement of the VM in its new zone.
+func (vs *vSphereVMProvider) vmUpdateRelocatePlacement(
+	vmCtx context.VirtualMachineContextA2,
+	vcVM *object.VirtualMachine,
+	vcClient *vcclient.Client,
+	storageClassesToIDs map[string]string) (*placement.Result, error) {
+
+	resourcePolicy, err := GetVMSetResourcePolicy(vmCtx, vs.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var o mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"config"}, &o); err != nil {
+		return nil, err
+	}
+
+	configSpec := &types.VirtualMachineConfigSpec{
+		Name: vmCtx.VM.Name,
+	}
+	if o.Config != nil {
+		configSpec.Name = o.Config.Name
+		configSpec.GuestId = o.Config.GuestId
+		configSpec.NumCPUs = o.Config.Hardware.NumCPU
+		configSpec.MemoryMB = int64(o.Config.Hardware.MemoryMB)
+	}
+
+	placementConfigSpec := virtualmachine.CreateConfigSpecForPlacement(
+		vmCtx,
+		configSpec,
+		storageClassesToIDs)
+
+	zoneNames, err := vs.getPlacementZones(vmCtx, vmCtx.VM)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := placement.Placement(
+		vmCtx,
+		vs.k8sClient,
+		vcClient.VimClient(),
+		placementConfigSpec,
+		resourcePolicy,
+		zoneNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.ZoneRelocation || result.PoolMoRef.Value == "" {
+		return nil, fmt.Errorf("no placement recommendation for zone %s", result.ZoneName)
+	}
+
+	return result, nil
+}
+
 // vmCreateDoPlacement determines placement of the VM prior to creating the VM on VC.
 func (vs *vSphereVMProvider) vmCreateDoPlacement(
 	vmCtx context.VirtualMachineContextA2,
@@ -437,12 +1105,19 @@ func (vs *vSphereVMProvider) vmCreateDoPlacement(
 		createArgs.ConfigSpec,
 		createArgs.StorageClassesToIDs)
 
+	zoneNames, err := vs.getPlacementZones(vmCtx, vmCtx.VM)
+	if err != nil {
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionPlacementReady, "NotReady", err.Error())
+		return err
+	}
+
 	result, err := placement.Placement(
 		vmCtx,
 		vs.k8sClient,
 		vcClient.VimClient(),
 		placementConfigSpec,
-		createArgs.ChildResourcePoolName)
+		createArgs.ResourcePolicy,
+		zoneNames)
 	if err != nil {
 		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionPlacementReady, "NotReady", err.Error())
 		return err
@@ -678,6 +1353,10 @@ func (vs *vSphereVMProvider) vmCreateGetPrereqs(
 		prereqErrs = append(prereqErrs, err)
 	}
 
+	if err := vs.vmCreateGetCloneSource(vmCtx, createArgs); err != nil {
+		prereqErrs = append(prereqErrs, err)
+	}
+
 	if err := vs.vmCreateGetBootstrap(vmCtx, createArgs); err != nil {
 		prereqErrs = append(prereqErrs, err)
 	}
@@ -777,6 +1456,31 @@ func (vs *vSphereVMProvider) vmCreateGetSetResourcePolicy(
 	return nil
 }
 
+func (vs *vSphereVMProvider) vmCreateGetCloneSource(
+	vmCtx context.VirtualMachineContextA2,
+	createArgs *VMCreateArgs) error {
+
+	cloneSpec := vmCtx.VM.Spec.Clone
+	if cloneSpec == nil {
+		return nil
+	}
+
+	srcVM := &vmopv1.VirtualMachine{}
+	key := ctrlclient.ObjectKey{Name: cloneSpec.VMName, Namespace: vmCtx.VM.Namespace}
+	if err := vs.k8sClient.Get(vmCtx, key, srcVM); err != nil {
+		return fmt.Errorf("failed to get clone source VirtualMachine %s: %w", cloneSpec.VMName, err)
+	}
+
+	if srcVM.Status.UniqueID == "" {
+		return fmt.Errorf("clone source VirtualMachine %s has not been created", cloneSpec.VMName)
+	}
+
+	createArgs.CloneFromVMMoID = srcVM.Status.UniqueID
+	createArgs.LinkedClone = cloneSpec.Mode == vmopv1.VirtualMachineCloneModeLinked
+
+	return nil
+}
+
 func (vs *vSphereVMProvider) vmCreateGetBootstrap(
 	vmCtx context.VirtualMachineContextA2,
 	createArgs *VMCreateArgs) error {
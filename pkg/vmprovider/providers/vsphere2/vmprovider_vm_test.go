@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -21,8 +22,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/vapi/cluster"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
@@ -30,10 +34,13 @@ import (
 	conditions "github.com/vmware-tanzu/vm-operator/pkg/conditions2"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
 	vsphere "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2"
+	vcconfig "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/instancestorage"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -1053,6 +1060,105 @@ func vmTests() {
 				// TODO: More assertions!
 			})
 
+			Context("VM Class change", func() {
+				var (
+					vcVM       *object.VirtualMachine
+					newVMClass *vmopv1.VirtualMachineClass
+				)
+
+				JustBeforeEach(func() {
+					var err error
+					vcVM, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					newVMClass = builder.DummyVirtualMachineClassA2()
+					newVMClass.Name += "-large"
+					newVMClass.Namespace = nsInfo.Namespace
+					newVMClass.Spec.Hardware.Cpus = vmClass.Spec.Hardware.Cpus * 2
+					newVMClass.Spec.Hardware.Memory = resource.MustParse("4Gi")
+					Expect(ctx.Client.Create(ctx, newVMClass)).To(Succeed())
+				})
+
+				setHotAdd := func(enabled bool) {
+					t, err := vcVM.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+						CpuHotAddEnabled:    &enabled,
+						MemoryHotAddEnabled: &enabled,
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(t.Wait(ctx)).To(Succeed())
+				}
+
+				setGuestOSHotAdd := func() {
+					cluster, err := virtualmachine.GetVMClusterComputeResource(ctx, vcVM)
+					Expect(err).ToNot(HaveOccurred())
+					var o mo.ClusterComputeResource
+					Expect(cluster.Properties(ctx, cluster.Reference(), []string{"environmentBrowser"}, &o)).To(Succeed())
+					Expect(o.EnvironmentBrowser).ToNot(BeNil())
+
+					envBrowser := simulator.Map.Get(*o.EnvironmentBrowser).(*simulator.EnvironmentBrowser)
+					simulator.Map.Put(&hotAddEnvironmentBrowser{EnvironmentBrowser: envBrowser})
+					DeferCleanup(func() {
+						simulator.Map.Put(envBrowser)
+					})
+				}
+
+				getHardware := func() *types.VirtualMachineConfigSummary {
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"summary.config"}, &o)).To(Succeed())
+					return &o.Summary.Config
+				}
+
+				When("hot-add is enabled", func() {
+					It("resizes the powered on VM", func() {
+						setHotAdd(true)
+						setGuestOSHotAdd()
+
+						vm.Spec.ClassName = newVMClass.Name
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+						hw := getHardware()
+						Expect(hw.NumCpu).To(BeEquivalentTo(newVMClass.Spec.Hardware.Cpus))
+						Expect(hw.MemorySizeMB).To(BeEquivalentTo(4 * 1024))
+
+						Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+						Expect(vm.Status.Class.Name).To(Equal(newVMClass.Name))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineClassConfigurationSyncedCondition)).To(BeTrue())
+					})
+				})
+
+				When("hot-add is disabled", func() {
+					It("resizes the VM once it is powered off", func() {
+						setHotAdd(false)
+
+						vm.Spec.ClassName = newVMClass.Name
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+						hw := getHardware()
+						Expect(hw.NumCpu).To(BeEquivalentTo(vmClass.Spec.Hardware.Cpus))
+						Expect(vm.Status.Class.Name).To(Equal(vmClass.Name))
+						c := conditions.Get(vm, vmopv1.VirtualMachineClassConfigurationSyncedCondition)
+						Expect(c).ToNot(BeNil())
+						Expect(c.Status).To(Equal(metav1.ConditionFalse))
+						Expect(c.Reason).To(Equal(vmopv1.VirtualMachineClassConfigurationPowerCyclePendingReason))
+
+						By("powering off the VM", func() {
+							vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+							vm.Spec.PowerOffMode = vmopv1.VirtualMachinePowerOpModeHard
+							Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+							Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+						})
+
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+						hw = getHardware()
+						Expect(hw.NumCpu).To(BeEquivalentTo(newVMClass.Spec.Hardware.Cpus))
+						Expect(hw.MemorySizeMB).To(BeEquivalentTo(4 * 1024))
+						Expect(vm.Status.Class.Name).To(Equal(newVMClass.Name))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineClassConfigurationSyncedCondition)).To(BeTrue())
+					})
+				})
+			})
+
 			Context("VM Class with PCI passthrough devices", func() {
 				BeforeEach(func() {
 					vmClass.Spec.Hardware.Devices = vmopv1.VirtualDevices{
@@ -1176,6 +1282,100 @@ func vmTests() {
 				})
 			})
 
+			Context("Clone from VirtualMachine", func() {
+				var (
+					srcVcVM *object.VirtualMachine
+					cloneVM *vmopv1.VirtualMachine
+				)
+
+				macAddresses := func(vcVM *object.VirtualMachine) []string {
+					devices, err := vcVM.Device(ctx)
+					ExpectWithOffset(1, err).ToNot(HaveOccurred())
+					var macs []string
+					for _, dev := range devices.SelectByType((*types.VirtualEthernetCard)(nil)) {
+						macs = append(macs, dev.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard().MacAddress)
+					}
+					return macs
+				}
+
+				BeforeEach(func() {
+					testConfig.WithNetworkEnv = builder.NetworkEnvNamed
+
+					vm.Spec.Network.Disabled = false
+					vm.Spec.Network.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name:    "eth0",
+							Network: common.PartialObjectRef{Name: "VM Network"},
+						},
+					}
+				})
+
+				JustBeforeEach(func() {
+					var err error
+					srcVcVM, err = createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					status := vm.Status.DeepCopy()
+					Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+					vm.Status = *status
+					Expect(ctx.Client.Status().Update(ctx, vm)).To(Succeed())
+
+					cloneVM = builder.DummyBasicVirtualMachineA2("test-vm-clone", vm.Namespace)
+					cloneVM.Spec.ClassName = vm.Spec.ClassName
+					cloneVM.Spec.ImageName = vm.Spec.ImageName
+					cloneVM.Spec.StorageClass = vm.Spec.StorageClass
+					cloneVM.Spec.Network = vm.Spec.Network.DeepCopy()
+					cloneVM.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{
+						VMName: vm.Name,
+					}
+				})
+
+				It("Clones the VM with new MAC addresses", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, cloneVM)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vcVM.Reference()).ToNot(Equal(srcVcVM.Reference()))
+					Expect(conditions.IsTrue(cloneVM, vmopv1.VirtualMachineConditionCreated)).To(BeTrue())
+
+					srcMACs := macAddresses(srcVcVM)
+					Expect(srcMACs).ToNot(BeEmpty())
+					cloneMACs := macAddresses(vcVM)
+					Expect(cloneMACs).To(HaveLen(len(srcMACs)))
+					for _, mac := range cloneMACs {
+						Expect(srcMACs).ToNot(ContainElement(mac))
+					}
+				})
+
+				When("the source VirtualMachine does not exist", func() {
+					It("returns an error", func() {
+						cloneVM.Spec.Clone.VMName = "does-not-exist"
+						err := vmProvider.CreateOrUpdateVirtualMachine(ctx, cloneVM)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("failed to get clone source VirtualMachine"))
+					})
+				})
+
+				When("linked clone", func() {
+					JustBeforeEach(func() {
+						cloneVM.Spec.Clone.Mode = vmopv1.VirtualMachineCloneModeLinked
+					})
+
+					It("returns an error when the source VM does not have a snapshot", func() {
+						err := vmProvider.CreateOrUpdateVirtualMachine(ctx, cloneVM)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("linked clone requires the clone source VM to have a snapshot"))
+					})
+
+					It("Clones the VM from the source VM's current snapshot", func() {
+						task, err := srcVcVM.CreateSnapshot(ctx, "snap-1", "", false, false)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(task.Wait(ctx)).To(Succeed())
+
+						_, err = createOrUpdateAndGetVcVM(ctx, cloneVM)
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
+			})
+
 			// BMV: I don't think this is actually supported.
 			XIt("Create VM from VMTX in ContentLibrary", func() {
 				imageName := "test-vm-vmtx"
@@ -1225,6 +1425,232 @@ func vmTests() {
 						Expect(rp.Reference().Value).To(Equal(nsRP.Reference().Value))
 					})
 				})
+
+				It("relocates VM when its zone is changed", func() {
+					azName := ctx.ZoneNames[0]
+					newAZName := ctx.ZoneNames[1]
+					vm.Labels[topology.KubernetesTopologyZoneLabelKey] = azName
+
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Zone).To(Equal(azName))
+
+					vm.Labels[topology.KubernetesTopologyZoneLabelKey] = newAZName
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+					Expect(vm.Status.Zone).To(Equal(newAZName))
+					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineZoneRelocatedCondition)).To(BeTrue())
+
+					By("VM is relocated to the new zone's ResourcePool", func() {
+						rp, err := vcVM.ResourcePool(ctx)
+						Expect(err).ToNot(HaveOccurred())
+						nsRP := ctx.GetResourcePoolForNamespace(nsInfo.Namespace, newAZName, "")
+						Expect(nsRP).ToNot(BeNil())
+						Expect(rp.Reference().Value).To(Equal(nsRP.Reference().Value))
+					})
+
+					By("VM is relocated to a host in the new zone's cluster", func() {
+						cluster, err := virtualmachine.GetVMClusterComputeResource(ctx, vcVM)
+						Expect(err).ToNot(HaveOccurred())
+						zoneName, err := topology.LookupZoneForClusterMoID(ctx, ctx.Client, cluster.Reference().Value)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(zoneName).To(Equal(newAZName))
+					})
+				})
+
+				It("updates the VM when it cannot be relocated to its new zone", func() {
+					azName := ctx.ZoneNames[0]
+					vm.Labels[topology.KubernetesTopologyZoneLabelKey] = azName
+
+					_, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vm.Status.Zone).To(Equal(azName))
+					Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+
+					vm.Labels[topology.KubernetesTopologyZoneLabelKey] = "no-such-zone"
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					vm.Spec.PowerOffMode = vmopv1.VirtualMachinePowerOpModeHard
+					err = vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to place VM in zone no-such-zone"))
+
+					Expect(vm.Status.Zone).To(Equal(azName))
+					c := conditions.Get(vm, vmopv1.VirtualMachineZoneRelocatedCondition)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.VirtualMachineZoneRelocationPlacementFailedReason))
+
+					By("VM is powered off regardless", func() {
+						Expect(vm.Status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+					})
+
+					By("VM is relocated once its zone is valid", func() {
+						vm.Labels[topology.KubernetesTopologyZoneLabelKey] = ctx.ZoneNames[1]
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+						Expect(vm.Status.Zone).To(Equal(ctx.ZoneNames[1]))
+						Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineZoneRelocatedCondition)).To(BeTrue())
+					})
+				})
+
+				Context("VM affinity", func() {
+					var dbVMs []*vmopv1.VirtualMachine
+
+					createDBVM := func(azName string) {
+						dbVM := builder.DummyBasicVirtualMachineA2(fmt.Sprintf("db-vm-%d", len(dbVMs)), nsInfo.Namespace)
+						dbVM.Labels = map[string]string{
+							"app":                                   "db",
+							topology.KubernetesTopologyZoneLabelKey: azName,
+						}
+						Expect(ctx.Client.Create(ctx, dbVM)).To(Succeed())
+						dbVMs = append(dbVMs, dbVM)
+					}
+
+					dbZoneTerm := func() []vmopv1.VMAffinityTerm {
+						return []vmopv1.VMAffinityTerm{
+							{
+								LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+								TopologyKey:   vmopv1.VirtualMachineAffinityTopologyKeyZone,
+							},
+						}
+					}
+
+					AfterEach(func() {
+						dbVMs = nil
+					})
+
+					It("creates VM in the zone of the VMs selected by its required affinity", func() {
+						azName := ctx.ZoneNames[len(ctx.ZoneNames)-1]
+						createDBVM(azName)
+						vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+							VMAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{Required: dbZoneTerm()},
+						}
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, azName))
+					})
+
+					It("creates VM in a zone without the VMs selected by its required anti-affinity", func() {
+						for _, azName := range ctx.ZoneNames[1:] {
+							createDBVM(azName)
+						}
+						vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+							VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{Required: dbZoneTerm()},
+						}
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, ctx.ZoneNames[0]))
+					})
+
+					It("creates VM in the zone of the VMs selected by its preferred affinity", func() {
+						azName := ctx.ZoneNames[len(ctx.ZoneNames)-1]
+						createDBVM(azName)
+						vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+							VMAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{Preferred: dbZoneTerm()},
+						}
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, azName))
+					})
+
+					It("returns error when no zone satisfies its required anti-affinity", func() {
+						for _, azName := range ctx.ZoneNames {
+							createDBVM(azName)
+						}
+						vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+							VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{Required: dbZoneTerm()},
+						}
+
+						err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+						Expect(err).To(MatchError("no placement candidates satisfy the required VM affinity terms"))
+						Expect(conditions.IsFalse(vm, vmopv1.VirtualMachineConditionPlacementReady)).To(BeTrue())
+					})
+				})
+
+				Context("VM SetResourcePolicy topology spread", func() {
+					var resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+
+					JustBeforeEach(func() {
+						resourcePolicy = getVirtualMachineSetResourcePolicy("spread-policy", nsInfo.Namespace)
+						resourcePolicy.Spec.ClusterModuleGroups = nil
+						resourcePolicy.Spec.TopologySpreadConstraint = &vmopv1.VirtualMachineSetResourcePolicyTopologySpreadConstraint{
+							MaxSkew:     1,
+							TopologyKey: vmopv1.VirtualMachineAffinityTopologyKeyZone,
+						}
+						Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
+						Expect(ctx.Client.Create(ctx, resourcePolicy)).To(Succeed())
+
+						vm.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{ResourcePolicyName: resourcePolicy.Name}
+					})
+
+					AfterEach(func() {
+						resourcePolicy = nil
+					})
+
+					createMemberVM := func(name, azName string) {
+						memberVM := builder.DummyBasicVirtualMachineA2(name, nsInfo.Namespace)
+						memberVM.Labels = map[string]string{topology.KubernetesTopologyZoneLabelKey: azName}
+						memberVM.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{ResourcePolicyName: resourcePolicy.Name}
+						Expect(ctx.Client.Create(ctx, memberVM)).To(Succeed())
+					}
+
+					It("creates VM in the zone with the fewest members", func() {
+						azName := ctx.ZoneNames[len(ctx.ZoneNames)-1]
+						for i, zoneName := range ctx.ZoneNames {
+							if zoneName != azName {
+								createMemberVM(fmt.Sprintf("member-vm-%d", i), zoneName)
+							}
+						}
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, azName))
+
+						By("VM is created in the zone's child ResourcePool", func() {
+							vcVM := ctx.GetVMFromMoID(vm.Status.UniqueID)
+							rp, err := vcVM.ResourcePool(ctx)
+							Expect(err).ToNot(HaveOccurred())
+							childRP := ctx.GetResourcePoolForNamespace(nsInfo.Namespace, azName, resourcePolicy.Spec.ResourcePool.Name)
+							Expect(childRP).ToNot(BeNil())
+							Expect(rp.Reference().Value).To(Equal(childRP.Reference().Value))
+						})
+					})
+
+					It("does not count the VMs that use a different resource policy", func() {
+						azName := ctx.ZoneNames[0]
+						for i, zoneName := range ctx.ZoneNames {
+							if zoneName != azName {
+								createMemberVM(fmt.Sprintf("member-vm-%d", i), zoneName)
+							}
+						}
+						otherVM := builder.DummyBasicVirtualMachineA2("other-vm", nsInfo.Namespace)
+						otherVM.Labels = map[string]string{topology.KubernetesTopologyZoneLabelKey: azName}
+						otherVM.Spec.Reserved = &vmopv1.VirtualMachineReservedSpec{ResourcePolicyName: "other-policy"}
+						Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, azName))
+					})
+
+					It("counts the members whose zone label has not been observed yet", func() {
+						// The VMs exist without a zone label, as if the labels set when they were
+						// placed were not in the cache yet.
+						zoneNames := map[string]struct{}{}
+						for i := range ctx.ZoneNames {
+							memberVM := vm.DeepCopy()
+							memberVM.Name = fmt.Sprintf("unobserved-member-vm-%d", i)
+							Expect(ctx.Client.Create(ctx, memberVM)).To(Succeed())
+
+							_, err := createOrUpdateAndGetVcVM(ctx, memberVM)
+							Expect(err).ToNot(HaveOccurred())
+							zoneNames[memberVM.Labels[topology.KubernetesTopologyZoneLabelKey]] = struct{}{}
+						}
+
+						Expect(zoneNames).To(HaveLen(len(ctx.ZoneNames)))
+					})
+				})
 			})
 
 			Context("When Instance Storage FSS is enabled", func() {
@@ -1334,6 +1760,37 @@ func vmTests() {
 				Expect(state).To(Equal(types.VirtualMachinePowerStatePoweredOff))
 			})
 
+			It("Applies boot options", func() {
+				vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+					BootOrder: []vmopv1.VirtualMachineBootableDevice{vmopv1.VirtualMachineBootableDeviceDisk},
+					BootDelay: &metav1.Duration{Duration: 3 * time.Second},
+				}
+
+				vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+				Expect(err).ToNot(HaveOccurred())
+
+				var o mo.VirtualMachine
+				Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config"}, &o)).To(Succeed())
+				Expect(o.Config.BootOptions).ToNot(BeNil())
+				Expect(o.Config.BootOptions.BootDelay).To(BeEquivalentTo(3000))
+				disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil))
+				Expect(disks).ToNot(BeEmpty())
+				Expect(o.Config.BootOptions.BootOrder).To(Equal([]types.BaseVirtualMachineBootOptionsBootableDevice{
+					&types.VirtualMachineBootOptionsBootableDiskDevice{DeviceKey: disks[0].GetVirtualDevice().Key},
+				}))
+
+				By("applies boot options changes while the VM is powered off", func() {
+					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+					vm.Spec.BootOptions.BootRetry = true
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.bootOptions"}, &o)).To(Succeed())
+					Expect(o.Config.BootOptions.BootRetryEnabled).To(HaveValue(BeTrue()))
+				})
+			})
+
 			It("returns error when StorageClass is required but none specified", func() {
 				vm.Spec.StorageClass = ""
 				err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
@@ -1655,11 +2112,19 @@ func vmTests() {
 		})
 
 		Context("VM SetResourcePolicy", func() {
-			var resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+			var (
+				resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy
+				hostAffinity   *vmopv1.VirtualMachineSetResourcePolicyHostAffinity
+				nodeLabels     map[string]string
+			)
 
 			JustBeforeEach(func() {
 				resourcePolicyName := "test-policy"
 				resourcePolicy = getVirtualMachineSetResourcePolicy(resourcePolicyName, nsInfo.Namespace)
+				if hostAffinity != nil {
+					resourcePolicy.Spec.HostAffinity = hostAffinity
+					createHostNodes(ctx, ctx.GetSingleClusterCompute(), nodeLabels)
+				}
 				Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
 				Expect(ctx.Client.Create(ctx, resourcePolicy)).To(Succeed())
 
@@ -1672,6 +2137,78 @@ func vmTests() {
 
 			AfterEach(func() {
 				resourcePolicy = nil
+				hostAffinity = nil
+				nodeLabels = nil
+			})
+
+			Context("with a host affinity", func() {
+				var (
+					cluster *object.ClusterComputeResource
+				)
+
+				BeforeEach(func() {
+					// Hosts only have a FQDN with instance storage.
+					testConfig.WithInstanceStorage = true
+					hostAffinity = &vmopv1.VirtualMachineSetResourcePolicyHostAffinity{
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"license": "db"}},
+						Required:     true,
+					}
+					nodeLabels = map[string]string{"license": "db"}
+				})
+
+				JustBeforeEach(func() {
+					cluster = ctx.GetSingleClusterCompute()
+				})
+
+				getVMGroup := func() *types.ClusterVmGroup {
+					config, err := cluster.Configuration(ctx)
+					Expect(err).ToNot(HaveOccurred())
+					for _, group := range config.Group {
+						if g, ok := group.(*types.ClusterVmGroup); ok && g.Name == fmt.Sprintf("vmoperator/%s/%s/vms", nsInfo.Namespace, resourcePolicy.Name) {
+							return g
+						}
+					}
+					return nil
+				}
+
+				It("VM is added to the DRS VM group and is compliant", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					vmGroup := getVMGroup()
+					Expect(vmGroup).ToNot(BeNil())
+					Expect(vmGroup.Vm).To(ConsistOf(vcVM.Reference()))
+					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineHostAffinityCompliantCondition)).To(BeTrue())
+
+					By("VM is removed from the DRS VM group when deleted", func() {
+						Expect(vmProvider.DeleteVirtualMachine(ctx, vm)).To(Succeed())
+						Expect(getVMGroup().Vm).To(BeEmpty())
+					})
+				})
+
+				Context("when no hosts are selected", func() {
+					BeforeEach(func() {
+						nodeLabels = map[string]string{"license": "none"}
+					})
+
+					It("returns error when the host affinity is required", func() {
+						err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+						Expect(err).To(MatchError("no placement candidates have hosts selected by the required host affinity"))
+					})
+
+					It("VM is created but not compliant when the host affinity is preferred", func() {
+						resourcePolicy.Spec.HostAffinity.Required = false
+						Expect(vmProvider.CreateOrUpdateVirtualMachineSetResourcePolicy(ctx, resourcePolicy)).To(Succeed())
+						Expect(ctx.Client.Update(ctx, resourcePolicy)).To(Succeed())
+
+						_, err := createOrUpdateAndGetVcVM(ctx, vm)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(conditions.IsFalse(vm, vmopv1.VirtualMachineHostAffinityCompliantCondition)).To(BeTrue())
+						Expect(conditions.GetReason(vm, vmopv1.VirtualMachineHostAffinityCompliantCondition)).To(
+							Equal(vmopv1.VirtualMachineHostAffinityNotCompliantReason))
+					})
+				})
 			})
 
 			It("VM is created in child Folder and ResourcePool", func() {
@@ -1713,6 +2250,48 @@ func vmTests() {
 				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
 			})
 
+			Context("when the VM has host anti-affinity", func() {
+				BeforeEach(func() {
+					vm.Spec.Affinity = &vmopv1.VirtualMachineAffinitySpec{
+						VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+							Required: []vmopv1.VMAffinityTerm{
+								{
+									LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+									TopologyKey:   vmopv1.VirtualMachineAffinityTopologyKeyHost,
+								},
+							},
+						},
+					}
+				})
+
+				It("deletes the VM's DRS VM-VM rules", func() {
+					vcDBVM, err := ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+					Expect(err).ToNot(HaveOccurred())
+					dbVM := builder.DummyBasicVirtualMachineA2("db-vm", nsInfo.Namespace)
+					dbVM.Labels = map[string]string{"app": "db"}
+					dbVM.Status.UniqueID = vcDBVM.Reference().Value
+					Expect(ctx.Client.Create(ctx, dbVM)).To(Succeed())
+
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+					cluster := ctx.GetSingleClusterCompute()
+					rules, err := vcenter.GetClusterRules(ctx, cluster)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rules).To(HaveLen(1))
+					rule, ok := rules[0].(*types.ClusterAntiAffinityRuleSpec)
+					Expect(ok).To(BeTrue())
+					Expect(*rule.Mandatory).To(BeTrue())
+					Expect(rule.Vm).To(ConsistOf(
+						types.ManagedObjectReference{Type: "VirtualMachine", Value: vm.Status.UniqueID},
+						vcDBVM.Reference()))
+
+					Expect(vmProvider.DeleteVirtualMachine(ctx, vm)).To(Succeed())
+					rules, err = vcenter.GetClusterRules(ctx, cluster)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rules).To(BeEmpty())
+				})
+			})
+
 			Context("when the VM is off", func() {
 				BeforeEach(func() {
 					vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
@@ -1816,6 +2395,439 @@ func vmTests() {
 				Expect(version).To(Equal(int32(9)))
 			})
 		})
+
+		Context("Restart", func() {
+			var (
+				vcVM *object.VirtualMachine
+			)
+
+			BeforeEach(func() {
+				vm.Spec.RestartMode = vmopv1.VirtualMachinePowerOpModeHard
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				vcVM, err = createOrUpdateAndGetVcVM(ctx, vm)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("restarts the VM", func() {
+				Expect(vmProvider.RestartVirtualMachine(ctx, vm)).To(Succeed())
+
+				var o mo.VirtualMachine
+				Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+				lastRestartTime, err := vmutil.GetLastRestartTimeFromExtraConfig(ctx, o.Config.ExtraConfig)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(lastRestartTime).ToNot(BeNil())
+			})
+
+			It("returns an error when the VM is powered off", func() {
+				vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+
+				Expect(vmProvider.RestartVirtualMachine(ctx, vm)).ToNot(Succeed())
+			})
+		})
+
+		Context("Snapshots", func() {
+			var (
+				vmSnapshot *vmopv1.VirtualMachineSnapshot
+			)
+
+			BeforeEach(func() {
+				vmSnapshot = &vmopv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-snapshot",
+					},
+					Spec: vmopv1.VirtualMachineSnapshotSpec{
+						VMName:      vm.Name,
+						Description: "test snapshot",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				vmSnapshot.Namespace = vm.Namespace
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+			})
+
+			It("creates and deletes the snapshot", func() {
+				Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).ToNot(BeEmpty())
+				Expect(vmSnapshot.Status.CreationTime).ToNot(BeNil())
+
+				By("snapshot tree is reported in the VM status", func() {
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+					Expect(vm.Status.CurrentSnapshot).To(Equal(vmSnapshot.Name))
+					Expect(vm.Status.Snapshots).To(HaveLen(1))
+					Expect(vm.Status.Snapshots[0].Name).To(Equal(vmSnapshot.Name))
+					Expect(vm.Status.Snapshots[0].ID).To(Equal(vmSnapshot.Status.UniqueID))
+					Expect(vm.Status.Snapshots[0].Parent).To(BeEmpty())
+					Expect(vm.Status.Snapshots[0].Size).ToNot(BeNil())
+				})
+
+				Expect(vmProvider.DeleteSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.CurrentSnapshot).To(BeEmpty())
+				Expect(vm.Status.Snapshots).To(BeEmpty())
+			})
+
+			It("creating the snapshot is idempotent", func() {
+				Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+				id := vmSnapshot.Status.UniqueID
+
+				Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).To(Equal(id))
+			})
+
+			It("deletes the snapshot with the recorded ID when snapshots have the same name", func() {
+				otherSnapshot := vmSnapshot.DeepCopy()
+				Expect(vmProvider.CreateSnapshot(ctx, vm, otherSnapshot)).To(Succeed())
+				Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+				Expect(vmSnapshot.Status.UniqueID).ToNot(Equal(otherSnapshot.Status.UniqueID))
+
+				Expect(vmProvider.DeleteSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+				Expect(vm.Status.Snapshots).To(HaveLen(1))
+				Expect(vm.Status.Snapshots[0].ID).To(Equal(otherSnapshot.Status.UniqueID))
+			})
+
+			It("returns success when deleting a snapshot that does not exist", func() {
+				Expect(vmProvider.DeleteSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+				vmSnapshot.Status.UniqueID = "snapshot-does-not-exist"
+				Expect(vmProvider.DeleteSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+			})
+
+			Context("Revert to snapshot", func() {
+				var (
+					vmSnapshot2 *vmopv1.VirtualMachineSnapshot
+				)
+
+				JustBeforeEach(func() {
+					vmSnapshot2 = vmSnapshot.DeepCopy()
+					vmSnapshot2.Name = "test-snapshot-2"
+
+					Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot)).To(Succeed())
+					Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot2)).To(Succeed())
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+					Expect(vm.Status.CurrentSnapshot).To(Equal(vmSnapshot2.Name))
+				})
+
+				It("reverts the VM once", func() {
+					vm.Spec.CurrentSnapshot = vmSnapshot.Name
+					Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+					Expect(vm.Status.CurrentSnapshot).To(Equal(vmSnapshot.Name))
+					Expect(vm.Status.LastRevertedSnapshot).To(Equal(vmSnapshot.Name))
+
+					By("snapshots taken after the revert do not revert the VM again", func() {
+						vmSnapshot3 := vmSnapshot.DeepCopy()
+						vmSnapshot3.Name = "test-snapshot-3"
+						vmSnapshot3.Status = vmopv1.VirtualMachineSnapshotStatus{}
+						Expect(vmProvider.CreateSnapshot(ctx, vm, vmSnapshot3)).To(Succeed())
+
+						Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+						Expect(vm.Status.CurrentSnapshot).To(Equal(vmSnapshot3.Name))
+						Expect(vm.Status.LastRevertedSnapshot).To(Equal(vmSnapshot.Name))
+					})
+				})
+
+				It("returns an error when the snapshot does not exist", func() {
+					vm.Spec.CurrentSnapshot = "does-not-exist"
+					err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`snapshot "does-not-exist" not found`))
+					Expect(vm.Status.LastRevertedSnapshot).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("Guest commands", func() {
+			var (
+				guestCmd *vmopv1.VirtualMachineGuestCommand
+			)
+
+			BeforeEach(func() {
+				guestCmd = &vmopv1.VirtualMachineGuestCommand{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-guest-cmd",
+					},
+					Spec: vmopv1.VirtualMachineGuestCommandSpec{
+						VMName:                vm.Name,
+						Command:               "uptime",
+						CredentialsSecretName: "guest-credentials",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				guestCmd.Namespace = vm.Namespace
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+			})
+
+			It("returns an error when the credentials Secret does not exist", func() {
+				err := vmProvider.RunGuestCommand(ctx, vm, guestCmd)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get guest credentials Secret guest-credentials"))
+				Expect(guestCmd.Status.StartTime).To(BeNil())
+			})
+
+			It("returns an error when the credentials Secret does not have a username", func() {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      guestCmd.Spec.CredentialsSecretName,
+						Namespace: vm.Namespace,
+					},
+					Data: map[string][]byte{
+						vmopv1.VirtualMachineGuestCommandCredentialsPasswordKey: []byte("password"),
+					},
+				}
+				Expect(ctx.Client.Create(ctx, secret)).To(Succeed())
+
+				err := vmProvider.RunGuestCommand(ctx, vm, guestCmd)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`does not have the "username" key`))
+				Expect(guestCmd.Status.StartTime).To(BeNil())
+			})
+
+			It("returns an error running a probe command when the credentials Secret does not exist", func() {
+				action := &vmopv1.GuestExecAction{
+					Command:               "/usr/bin/true",
+					CredentialsSecretName: "guest-credentials",
+				}
+				_, err := vmProvider.RunGuestProbeCommand(ctx, vm, action)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get guest credentials Secret guest-credentials"))
+			})
+		})
+
+		Context("Guest file transfers", func() {
+			var (
+				fileTransfer *vmopv1.VirtualMachineGuestFileTransfer
+			)
+
+			BeforeEach(func() {
+				fileTransfer = &vmopv1.VirtualMachineGuestFileTransfer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-file-transfer",
+					},
+					Spec: vmopv1.VirtualMachineGuestFileTransferSpec{
+						VMName:                vm.Name,
+						CredentialsSecretName: "guest-credentials",
+						GuestPath:             "/etc/test.conf",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				fileTransfer.Namespace = vm.Namespace
+				Expect(vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)).To(Succeed())
+			})
+
+			It("returns an error copying to the guest when the credentials Secret does not exist", func() {
+				err := vmProvider.CopyFileToGuest(ctx, vm, fileTransfer, []byte("hello"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get guest credentials Secret guest-credentials"))
+			})
+
+			It("returns an error copying from the guest when the credentials Secret does not exist", func() {
+				content, _, err := vmProvider.CopyFileFromGuest(ctx, vm, fileTransfer, 1024)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get guest credentials Secret guest-credentials"))
+				Expect(content).To(BeNil())
+			})
+		})
+
+		Context("Placement requests", func() {
+			var (
+				placementReq *vmopv1.VirtualMachinePlacementRequest
+			)
+
+			BeforeEach(func() {
+				placementReq = &vmopv1.VirtualMachinePlacementRequest{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-placement-request",
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				placementReq.Namespace = vm.Namespace
+				placementReq.Spec.ClassName = vm.Spec.ClassName
+				placementReq.Spec.ImageName = vm.Spec.ImageName
+				placementReq.Spec.StorageClass = vm.Spec.StorageClass
+			})
+
+			It("returns the recommendations of the default zone", func() {
+				Expect(vmProvider.PlaceVirtualMachine(ctx, placementReq)).To(Succeed())
+				Expect(placementReq.Status.Faults).To(BeEmpty())
+				Expect(placementReq.Status.Zones).To(HaveLen(1))
+				Expect(placementReq.Status.Zones[0].Name).To(Equal(topology.DefaultAvailabilityZoneName))
+				Expect(placementReq.Status.Zones[0].Recommendations).ToNot(BeEmpty())
+
+				nsRP := ctx.GetResourcePoolForNamespace(nsInfo.Namespace, "", "")
+				Expect(nsRP).ToNot(BeNil())
+				for _, rec := range placementReq.Status.Zones[0].Recommendations {
+					Expect(rec.ResourcePool).To(Equal(nsRP.Reference().Value))
+				}
+			})
+
+			When("fault domains is enabled", func() {
+				BeforeEach(func() {
+					testConfig.WithFaultDomains = true
+				})
+
+				It("returns the recommendations of each zone", func() {
+					Expect(vmProvider.PlaceVirtualMachine(ctx, placementReq)).To(Succeed())
+					Expect(placementReq.Status.Zones).ToNot(BeEmpty())
+
+					for _, zone := range placementReq.Status.Zones {
+						Expect(zone.Name).To(BeElementOf(ctx.ZoneNames))
+						Expect(zone.Recommendations).ToNot(BeEmpty())
+
+						nsRP := ctx.GetResourcePoolForNamespace(nsInfo.Namespace, zone.Name, "")
+						Expect(nsRP).ToNot(BeNil())
+						Expect(zone.Recommendations[0].ResourcePool).To(Equal(nsRP.Reference().Value))
+					}
+				})
+
+				It("only returns the recommendations of the requested zone", func() {
+					placementReq.Spec.Zone = ctx.ZoneNames[0]
+
+					Expect(vmProvider.PlaceVirtualMachine(ctx, placementReq)).To(Succeed())
+					Expect(placementReq.Status.Zones).To(HaveLen(1))
+					Expect(placementReq.Status.Zones[0].Name).To(Equal(ctx.ZoneNames[0]))
+				})
+			})
+
+			When("the VirtualMachineClass does not exist", func() {
+				JustBeforeEach(func() {
+					placementReq.Spec.ClassName = "does-not-exist"
+				})
+
+				It("returns an error", func() {
+					err := vmProvider.PlaceVirtualMachine(ctx, placementReq)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("does-not-exist"))
+					Expect(placementReq.Status.Zones).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("Multiple vCenters", func() {
+			var (
+				vCenter vcconfig.VCenterConfig
+			)
+
+			BeforeEach(func() {
+				vCenter = vcconfig.VCenterConfig{
+					Name:              "other-vc",
+					VcCredsSecretName: "vmop-vcsim-dummy-creds",
+				}
+			})
+
+			JustBeforeEach(func() {
+				// Route the VM's namespace, or the vCenter's zones, to the same vcsim instance
+				// with a different client.
+				configMap := &corev1.ConfigMap{}
+				configMapKey := client.ObjectKey{Name: vcconfig.ProviderConfigMapName, Namespace: ctx.PodNamespace}
+				Expect(ctx.Client.Get(ctx, configMapKey, configMap)).To(Succeed())
+
+				vCenter.VcPNID = configMap.Data["VcPNID"]
+				vCenter.VcPort = configMap.Data["VcPort"]
+				vCenter.Datacenter = configMap.Data["Datacenter"]
+				vCenter.ResourcePool = configMap.Data["ResourcePool"]
+				if len(vCenter.Zones) == 0 {
+					vCenter.Namespaces = []string{vm.Namespace}
+				}
+				Expect(vcconfig.SetVCenterConfigsInConfigMap(configMap, []vcconfig.VCenterConfig{vCenter})).To(Succeed())
+				Expect(ctx.Client.Update(ctx, configMap)).To(Succeed())
+			})
+
+			It("creates the VM with the client of the namespace's vCenter", func() {
+				vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vcVM).ToNot(BeNil())
+				Expect(vm.Annotations).To(HaveKeyWithValue(vmopv1.VCenterNameAnnotation, "other-vc"))
+
+				By("watches all the vCenters", func() {
+					watchCtx, cancel := goctx.WithCancel(ctx)
+					defer cancel()
+
+					updates := make(chan []string, 4)
+					watchErr := make(chan error, 1)
+					go func() {
+						watchErr <- vmProvider.WatchVirtualMachines(watchCtx, func(moIDs []string) {
+							updates <- moIDs
+						})
+					}()
+
+					// Each vCenter's watch starts with all of its VMs.
+					for i := 0; i < 2; i++ {
+						Eventually(updates).Should(Receive(ContainElement(vcVM.Reference().Value)))
+					}
+
+					cancel()
+					Eventually(watchErr).Should(Receive(BeNil()))
+				})
+			})
+
+			It("gets content library items from the vCenter that has the library", func() {
+				item, err := vmProvider.GetItemFromLibraryByName(ctx, ctx.ContentLibraryID, ctx.ContentLibraryImageName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item).ToNot(BeNil())
+				Expect(item.Name).To(Equal(ctx.ContentLibraryImageName))
+
+				Expect(vmProvider.UpdateContentLibraryItem(ctx, item.ID, item.Name, nil)).To(Succeed())
+
+				_, err = vmProvider.GetItemFromLibraryByName(ctx, "bogus-library", ctx.ContentLibraryImageName)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not found in any vCenter"))
+			})
+
+			Context("when the vCenter has a zone", func() {
+				const zoneName = "az-0"
+
+				BeforeEach(func() {
+					testConfig.WithFaultDomains = true
+					vCenter.Zones = []string{zoneName}
+				})
+
+				It("places the VM only in the zones of the default vCenter", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vm)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(vcVM).ToNot(BeNil())
+
+					Expect(vm.Labels).To(HaveKey(topology.KubernetesTopologyZoneLabelKey))
+					Expect(vm.Labels[topology.KubernetesTopologyZoneLabelKey]).ToNot(Equal(zoneName))
+				})
+			})
+
+			Context("when the VM records a vCenter that is no longer configured", func() {
+				BeforeEach(func() {
+					vm.Annotations[vmopv1.VCenterNameAnnotation] = "bogus-vc"
+				})
+
+				It("routes the VM by its recorded vCenter and returns an error", func() {
+					err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(`vCenter "bogus-vc" not found`))
+				})
+			})
+
+			Context("when the vCenter's credentials Secret does not exist", func() {
+				BeforeEach(func() {
+					vCenter.VcCredsSecretName = "bogus-creds"
+				})
+
+				It("returns an error", func() {
+					err := vmProvider.CreateOrUpdateVirtualMachine(ctx, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("cannot find secret for provider credentials"))
+				})
+			})
+		})
 	})
 }
 
@@ -1856,3 +2868,20 @@ func getDVPG(
 
 	return network, dvpg
 }
+
+// hotAddEnvironmentBrowser reports that every guest OS supports CPU and memory
+// hot-add, which the vcsim EnvironmentBrowser does not report.
+type hotAddEnvironmentBrowser struct {
+	*simulator.EnvironmentBrowser
+}
+
+func (b *hotAddEnvironmentBrowser) QueryConfigOptionEx(req *types.QueryConfigOptionEx) soap.HasFault {
+	body := b.EnvironmentBrowser.QueryConfigOptionEx(req).(*methods.QueryConfigOptionExBody)
+	for i := range body.Res.Returnval.GuestOSDescriptor {
+		desc := &body.Res.Returnval.GuestOSDescriptor[i]
+		desc.SupportsCpuHotAdd = pointer.Bool(true)
+		desc.SupportsCpuHotRemove = pointer.Bool(true)
+		desc.SupportsMemoryHotAdd = pointer.Bool(true)
+	}
+	return body
+}
@@ -168,6 +168,31 @@ func getSecretData(
 	return data, nil
 }
 
+// getGuestAuthentication returns the guest operations credentials from the
+// Secret, in the VM's namespace, that has the username and password keys.
+func getGuestAuthentication(
+	vmCtx context.VirtualMachineContextA2,
+	k8sClient ctrlclient.Client,
+	secretName string) (*types.NamePasswordAuthentication, error) {
+
+	secret := &corev1.Secret{}
+	key := ctrlclient.ObjectKey{Name: secretName, Namespace: vmCtx.VM.Namespace}
+	if err := k8sClient.Get(vmCtx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get guest credentials Secret %s: %w", secretName, err)
+	}
+
+	username := string(secret.Data[vmopv1.VirtualMachineGuestCommandCredentialsUsernameKey])
+	if username == "" {
+		return nil, fmt.Errorf("guest credentials Secret %s does not have the %q key",
+			secretName, vmopv1.VirtualMachineGuestCommandCredentialsUsernameKey)
+	}
+
+	return &types.NamePasswordAuthentication{
+		Username: username,
+		Password: string(secret.Data[vmopv1.VirtualMachineGuestCommandCredentialsPasswordKey]),
+	}, nil
+}
+
 func GetVirtualMachineBootstrap(
 	vmCtx context.VirtualMachineContextA2,
 	k8sClient ctrlclient.Client) (vmlifecycle.BootstrapData, error) {
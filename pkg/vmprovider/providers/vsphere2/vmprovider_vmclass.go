@@ -0,0 +1,200 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	goctx "context"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	k8serrors "k8s.io/apimachinery/pkg/util/errors"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/lib"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/storage"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/virtualmachine"
+)
+
+// ComputeVirtualMachineClassCompatibility sets which zones of the class's namespace, and clusters
+// in them, can run VMs of the class in the class's status. A cluster can run the VMs when one of
+// its hosts has enough CPU and memory, and the vGPU profiles and DirectPath I/O devices of the
+// class, and one of its datastores is compatible with the class's instance storage policy.
+func (vs *vSphereVMProvider) ComputeVirtualMachineClassCompatibility(
+	ctx goctx.Context,
+	vmClass *vmopv1.VirtualMachineClass) error {
+
+	availabilityZones, err := topology.GetAvailabilityZones(ctx, vs.k8sClient)
+	if err != nil {
+		return err
+	}
+
+	hostReq, err := vs.getVMClassHostRequirements(ctx, vmClass)
+	if err != nil {
+		return err
+	}
+
+	var storageProfileID string
+	if storageClass := vmClass.Spec.Hardware.InstanceStorage.StorageClass; storageClass != "" {
+		vmCtx := context.VirtualMachineContextA2{
+			Context: ctx,
+			Logger:  log.WithValues("vmClassName", vmClass.Name),
+		}
+
+		storageProfileID, err = storage.GetStoragePolicyID(vmCtx, vs.k8sClient, storageClass)
+		if err != nil {
+			return err
+		}
+	}
+
+	zones := make([]vmopv1.VirtualMachineClassZoneStatus, 0, len(availabilityZones))
+	var errs []error
+
+	for _, az := range availabilityZones {
+		// VMs of the class can only be created in the zones of the class's namespace.
+		if _, ok := az.Spec.Namespaces[vmClass.Namespace]; !ok {
+			continue
+		}
+
+		client, err := vs.getVcClientForZoneAndNamespace(ctx, az.Name, vmClass.Namespace)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		moIDs := az.Spec.ClusterComputeResourceMoIDs
+		if len(moIDs) == 0 {
+			moIDs = []string{az.Spec.ClusterComputeResourceMoId} // HA TEMP
+		}
+
+		if !lib.IsWcpFaultDomainsFSSEnabled() {
+			ccr, err := vcenter.GetResourcePoolOwnerMoRef(ctx, client.VimClient(), client.Config().ResourcePool)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			moIDs = []string{ccr.Value}
+		}
+
+		zone := vmopv1.VirtualMachineClassZoneStatus{Name: az.Name}
+		for _, moID := range moIDs {
+			reasons, err := vs.getClusterIncompatibilities(ctx, client, moID, hostReq, storageProfileID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			zone.Clusters = append(zone.Clusters, vmopv1.VirtualMachineClassClusterStatus{
+				MoID:       moID,
+				Compatible: len(reasons) == 0,
+				Reasons:    reasons,
+			})
+			zone.Compatible = zone.Compatible || len(reasons) == 0
+		}
+
+		zones = append(zones, zone)
+	}
+
+	vmClass.Status.Zones = zones
+	return k8serrors.NewAggregate(errs)
+}
+
+// getVMClassHostRequirements returns the requirements a host must meet to run a VM of the class.
+func (vs *vSphereVMProvider) getVMClassHostRequirements(
+	ctx goctx.Context,
+	vmClass *vmopv1.VirtualMachineClass) (vcenter.HostRequirements, error) {
+
+	hw := vmClass.Spec.Hardware
+	req := vcenter.HostRequirements{
+		NumCPUs:     hw.Cpus,
+		MemoryBytes: hw.Memory.Value(),
+	}
+
+	if cpuReq := vmClass.Spec.Policies.Resources.Requests.Cpu; !cpuReq.IsZero() {
+		minFreq, err := vs.getOrComputeCPUMinFrequency(ctx)
+		if err != nil {
+			return req, err
+		}
+		req.CPUReservationMHz = virtualmachine.CPUQuantityToMhz(cpuReq, minFreq)
+	}
+
+	for _, dev := range hw.Devices.VGPUDevices {
+		req.VGPUProfiles = append(req.VGPUProfiles, dev.ProfileName)
+	}
+
+	for _, dev := range hw.Devices.DynamicDirectPathIODevices {
+		req.DirectPathIODevices = append(req.DirectPathIODevices,
+			vcenter.PCIDeviceID{VendorID: dev.VendorID, DeviceID: dev.DeviceID})
+	}
+
+	return req, nil
+}
+
+// getClusterIncompatibilities returns the reasons why the cluster cannot run a VM with the host
+// requirements and instance storage profile, or nil if it can.
+func (vs *vSphereVMProvider) getClusterIncompatibilities(
+	ctx goctx.Context,
+	client *vcclient.Client,
+	clusterMoID string,
+	hostReq vcenter.HostRequirements,
+	storageProfileID string) ([]string, error) {
+
+	cluster := object.NewClusterComputeResource(client.VimClient(),
+		types.ManagedObjectReference{Type: "ClusterComputeResource", Value: clusterMoID})
+
+	hosts, err := vs.getClusterHosts(ctx, client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := vcenter.HostIncompatibilities(hosts, hostReq)
+
+	if storageProfileID != "" {
+		datastores, err := cluster.Datastores(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		dsRefs := make([]types.ManagedObjectReference, 0, len(datastores))
+		for _, ds := range datastores {
+			dsRefs = append(dsRefs, ds.Reference())
+		}
+
+		compatible, err := storage.GetCompatibleDatastores(ctx, client.VimClient(), dsRefs, storageProfileID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(compatible) == 0 {
+			reasons = append(reasons, "no datastore is compatible with the instance storage policy")
+		}
+	}
+
+	return reasons, nil
+}
+
+// getClusterHosts returns the hosts of the cluster, which are cached so the VM classes that are
+// reconciled together retrieve them once.
+func (vs *vSphereVMProvider) getClusterHosts(
+	ctx goctx.Context,
+	client *vcclient.Client,
+	cluster *object.ClusterComputeResource) ([]mo.HostSystem, error) {
+
+	key := client.VimClient().URL().Host + "/" + cluster.Reference().Value
+	if hosts, ok := vs.clusterHostsCache.Get(key, nil); ok {
+		return hosts, nil
+	}
+
+	hosts, err := vcenter.GetClusterHosts(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	vs.clusterHostsCache.Put(key, hosts)
+	return hosts, nil
+}
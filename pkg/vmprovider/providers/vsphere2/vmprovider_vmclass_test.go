@@ -0,0 +1,186 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/simulator"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+	vsphere "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func vmClassTests() {
+
+	var (
+		testConfig builder.VCSimTestConfig
+		ctx        *builder.TestContextForVCSim
+		vmProvider vmprovider.VirtualMachineProviderInterfaceA2
+		nsInfo     builder.WorkloadNamespaceInfo
+		vmClass    *vmopv1.VirtualMachineClass
+	)
+
+	BeforeEach(func() {
+		testConfig = builder.VCSimTestConfig{WithV1A2: true}
+
+		vmClass = &vmopv1.VirtualMachineClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "small",
+			},
+			Spec: vmopv1.VirtualMachineClassSpec{
+				Hardware: vmopv1.VirtualMachineClassHardware{
+					Cpus:   2,
+					Memory: resource.MustParse("1Gi"),
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(testConfig)
+		vmProvider = vsphere.NewVSphereVMProviderFromClient(ctx.Client, ctx.Recorder)
+		nsInfo = ctx.CreateWorkloadNamespace()
+		vmClass.Namespace = nsInfo.Namespace
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		vmProvider = nil
+		nsInfo = builder.WorkloadNamespaceInfo{}
+	})
+
+	Context("ComputeVirtualMachineClassCompatibility", func() {
+		It("reports the cluster compatible", func() {
+			Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+
+			Expect(vmClass.Status.Zones).To(HaveLen(1))
+			zone := vmClass.Status.Zones[0]
+			Expect(zone.Compatible).To(BeTrue())
+			Expect(zone.Clusters).To(HaveLen(1))
+			Expect(zone.Clusters[0].MoID).To(Equal(ctx.GetSingleClusterCompute().Reference().Value))
+			Expect(zone.Clusters[0].Compatible).To(BeTrue())
+			Expect(zone.Clusters[0].Reasons).To(BeEmpty())
+		})
+
+		It("reports the hosts cached by an earlier computation", func() {
+			Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+			Expect(vmClass.Status.Zones).To(HaveLen(1))
+			Expect(vmClass.Status.Zones[0].Compatible).To(BeTrue())
+
+			for _, obj := range simulator.Map.All("HostSystem") {
+				host := obj.(*simulator.HostSystem)
+				hw := *host.Summary.Hardware
+				hw.MemorySize = 0
+				host.Summary.Hardware = &hw
+			}
+
+			Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+			Expect(vmClass.Status.Zones).To(HaveLen(1))
+			Expect(vmClass.Status.Zones[0].Compatible).To(BeTrue())
+
+			By("a new provider retrieves the hosts", func() {
+				vmProvider = vsphere.NewVSphereVMProviderFromClient(ctx.Client, ctx.Recorder)
+				Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+				Expect(vmClass.Status.Zones).To(HaveLen(1))
+				Expect(vmClass.Status.Zones[0].Compatible).To(BeFalse())
+			})
+		})
+
+		When("the class's namespace is not in any zone", func() {
+			JustBeforeEach(func() {
+				vmClass.Namespace = "other-namespace"
+			})
+
+			It("reports no zones", func() {
+				Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+				Expect(vmClass.Status.Zones).To(BeEmpty())
+			})
+		})
+
+		When("the class has instance storage", func() {
+			JustBeforeEach(func() {
+				vmClass.Spec.Hardware.InstanceStorage = vmopv1.InstanceStorage{
+					StorageClass: ctx.StorageClassName,
+					Volumes: []vmopv1.InstanceStorageVolume{
+						{Size: resource.MustParse("1Gi")},
+					},
+				}
+			})
+
+			It("reports the cluster compatible", func() {
+				Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+
+				Expect(vmClass.Status.Zones).To(HaveLen(1))
+				Expect(vmClass.Status.Zones[0].Compatible).To(BeTrue())
+			})
+		})
+
+		When("no host has enough memory or the vGPU profile", func() {
+			BeforeEach(func() {
+				vmClass.Spec.Hardware.Memory = resource.MustParse("8Gi")
+				vmClass.Spec.Hardware.Devices.VGPUDevices = []vmopv1.VGPUDevice{
+					{ProfileName: "grid_p40-1q"},
+				}
+			})
+
+			It("reports the cluster incompatible with the reasons", func() {
+				Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+
+				Expect(vmClass.Status.Zones).To(HaveLen(1))
+				zone := vmClass.Status.Zones[0]
+				Expect(zone.Compatible).To(BeFalse())
+				Expect(zone.Clusters).To(HaveLen(1))
+				Expect(zone.Clusters[0].Compatible).To(BeFalse())
+				Expect(zone.Clusters[0].Reasons).To(ConsistOf(
+					"not enough memory for 8192 MiB",
+					`vGPU profile "grid_p40-1q" is not available`,
+				))
+			})
+		})
+
+		When("fault domains are enabled", func() {
+			BeforeEach(func() {
+				testConfig.WithFaultDomains = true
+			})
+
+			It("reports each zone and its clusters", func() {
+				Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+
+				Expect(vmClass.Status.Zones).To(HaveLen(len(ctx.ZoneNames)))
+				for _, zone := range vmClass.Status.Zones {
+					Expect(ctx.ZoneNames).To(ContainElement(zone.Name))
+					Expect(zone.Compatible).To(BeTrue())
+					Expect(zone.Clusters).To(HaveLen(len(ctx.GetAZClusterComputes(zone.Name))))
+				}
+			})
+
+			When("a zone does not have the class's namespace", func() {
+				JustBeforeEach(func() {
+					az := &topologyv1.AvailabilityZone{}
+					Expect(ctx.Client.Get(ctx, ctrlclient.ObjectKey{Name: ctx.ZoneNames[0]}, az)).To(Succeed())
+					delete(az.Spec.Namespaces, vmClass.Namespace)
+					Expect(ctx.Client.Update(ctx, az)).To(Succeed())
+				})
+
+				It("does not report the zone", func() {
+					Expect(vmProvider.ComputeVirtualMachineClassCompatibility(ctx, vmClass)).To(Succeed())
+
+					Expect(vmClass.Status.Zones).To(HaveLen(len(ctx.ZoneNames) - 1))
+					for _, zone := range vmClass.Status.Zones {
+						Expect(zone.Name).ToNot(Equal(ctx.ZoneNames[0]))
+					}
+				})
+			})
+		})
+	})
+}
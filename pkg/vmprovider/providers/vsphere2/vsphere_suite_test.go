@@ -18,6 +18,7 @@ func vcSimTests() {
 	Describe("InitOvfCacheAndLockPool", initOvfCacheAndLockPoolTests)
 	Describe("ResourcePolicyTests", resourcePolicyTests)
 	Describe("VirtualMachine", vmTests)
+	Describe("VirtualMachineClass", vmClassTests)
 	Describe("VirtualMachineE2E", vmE2ETests)
 	Describe("VirtualMachineUtilsTest", vmUtilTests)
 }
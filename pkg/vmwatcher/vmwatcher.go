@@ -0,0 +1,152 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmwatcher
+
+import (
+	goctx "context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider"
+)
+
+const (
+	watcherName = "virtualmachine-watcher"
+
+	// UniqueIDField is the name of the field index of the VirtualMachine status.uniqueID
+	// field, which is used to find the VirtualMachine for a VC VM MoID.
+	UniqueIDField = "status.uniqueID"
+
+	// restartDelay is how long to wait before restarting the watch after it stopped. The
+	// watch usually stops because the VC client was reset or VC is unavailable.
+	restartDelay = 10 * time.Second
+
+	// eventBufferSize is the size of the buffered channel of VirtualMachine events.
+	eventBufferSize = 1024
+)
+
+// Watcher watches VC for changes to the VMs, and sends an event for each VirtualMachine
+// whose VM power state, guest IPs, heartbeat, or tasks changed.
+type Watcher interface {
+	ctrlmgr.Runnable
+	// Events returns the channel of events for the VirtualMachines that changed.
+	Events() <-chan event.GenericEvent
+}
+
+// watcher implements the Watcher interface.
+type watcher struct {
+	client     client.Client
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2
+	events     chan event.GenericEvent
+	log        logr.Logger
+}
+
+// NewWatcher initializes a VirtualMachine watcher. The client must have the UniqueIDField
+// index of the VirtualMachines.
+func NewWatcher(
+	client client.Client,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) Watcher {
+
+	return &watcher{
+		client:     client,
+		vmProvider: vmProvider,
+		events:     make(chan event.GenericEvent, eventBufferSize),
+		log:        ctrl.Log.WithName(watcherName),
+	}
+}
+
+// AddToManager adds the VirtualMachine watcher to the controller manager.
+func AddToManager(
+	ctx goctx.Context,
+	mgr ctrlmgr.Manager,
+	vmProvider vmprovider.VirtualMachineProviderInterfaceA2) (Watcher, error) {
+
+	// Index the VirtualMachines by their status.uniqueID field to map a VC VM to its VirtualMachine.
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&vmopv1.VirtualMachine{},
+		UniqueIDField,
+		UniqueIDIndexFn); err != nil {
+		return nil, err
+	}
+
+	// Add the watcher explicitly as runnable in order to receive a Start() event.
+	w := NewWatcher(mgr.GetClient(), vmProvider)
+	if err := mgr.Add(w); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// UniqueIDIndexFn returns the index values of the UniqueIDField of a VirtualMachine.
+func UniqueIDIndexFn(rawObj client.Object) []string {
+	vm := rawObj.(*vmopv1.VirtualMachine)
+	if vm.Status.UniqueID == "" {
+		return nil
+	}
+	return []string{vm.Status.UniqueID}
+}
+
+func (w *watcher) Events() <-chan event.GenericEvent {
+	return w.events
+}
+
+// Start watches VC until the context is cancelled, and restarts the watch after a delay
+// whenever it stops.
+func (w *watcher) Start(ctx goctx.Context) error {
+	w.log.Info("Start VirtualMachine Watcher")
+	defer w.log.Info("Stop VirtualMachine Watcher")
+
+	for {
+		err := w.vmProvider.WatchVirtualMachines(ctx, func(moIDs []string) {
+			w.enqueue(ctx, moIDs)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			w.log.Error(err, "VirtualMachine watch failed", "restartDelay", restartDelay)
+		} else {
+			w.log.Info("VirtualMachine watch stopped", "restartDelay", restartDelay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(restartDelay):
+		}
+	}
+}
+
+// enqueue sends an event for each VirtualMachine of the VC VM MoIDs. VC VMs that do not
+// belong to a VirtualMachine are ignored.
+func (w *watcher) enqueue(ctx goctx.Context, moIDs []string) {
+	for _, moID := range moIDs {
+		vmList := &vmopv1.VirtualMachineList{}
+		if err := w.client.List(ctx, vmList, client.MatchingFields{UniqueIDField: moID}); err != nil {
+			w.log.Error(err, "Failed to list VirtualMachines", "moID", moID)
+			continue
+		}
+
+		for i := range vmList.Items {
+			vm := &vmList.Items[i]
+			w.log.V(4).Info("Enqueue VirtualMachine because of a VC change", "vm", vm.NamespacedName(), "moID", moID)
+
+			select {
+			case w.events <- event.GenericEvent{Object: vm}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
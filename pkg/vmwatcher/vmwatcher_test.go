@@ -0,0 +1,118 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmwatcher
+
+import (
+	goctx "context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	fakevmprovider "github.com/vmware-tanzu/vm-operator/pkg/vmprovider/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("VirtualMachine watcher", func() {
+	var (
+		ctx    goctx.Context
+		cancel goctx.CancelFunc
+
+		testWatcher    Watcher
+		vm1            *vmopv1.VirtualMachine
+		vm2            *vmopv1.VirtualMachine
+		fakeVMProvider *fakevmprovider.VMProviderA2
+		updates        chan []string
+		startErr       chan error
+	)
+
+	BeforeEach(func() {
+		vm1 = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm-1",
+				Namespace: "dummy-ns",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				UniqueID: "vm-1",
+			},
+		}
+		vm2 = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm-2",
+				Namespace: "dummy-ns",
+			},
+		}
+
+		fakeClient := fake.NewClientBuilder().WithScheme(builder.NewScheme()).
+			WithIndex(&vmopv1.VirtualMachine{}, UniqueIDField, UniqueIDIndexFn).
+			WithObjects(vm1, vm2).
+			Build()
+
+		updates = make(chan []string)
+		fakeVMProvider = &fakevmprovider.VMProviderA2{}
+		fakeVMProvider.WatchVirtualMachinesFn = func(ctx goctx.Context, onUpdate func(moIDs []string)) error {
+			for {
+				select {
+				case moIDs := <-updates:
+					onUpdate(moIDs)
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+
+		testWatcher = NewWatcher(fakeClient, fakeVMProvider)
+	})
+
+	JustBeforeEach(func() {
+		ctx, cancel = goctx.WithCancel(goctx.Background())
+		startErr = make(chan error, 1)
+		go func() {
+			startErr <- testWatcher.Start(ctx)
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(startErr).Should(Receive(BeNil()))
+	})
+
+	It("Should send an event for the VirtualMachine of a changed VC VM", func() {
+		updates <- []string{"vm-1"}
+
+		var e event.GenericEvent
+		Eventually(testWatcher.Events()).Should(Receive(&e))
+		Expect(client.ObjectKeyFromObject(e.Object)).To(Equal(client.ObjectKeyFromObject(vm1)))
+	})
+
+	It("Should ignore VC VMs that do not belong to a VirtualMachine", func() {
+		updates <- []string{"vm-42"}
+		Consistently(testWatcher.Events()).ShouldNot(Receive())
+	})
+
+	Context("Watch fails", func() {
+		BeforeEach(func() {
+			fakeVMProvider.WatchVirtualMachinesFn = func(_ goctx.Context, _ func(moIDs []string)) error {
+				return errors.New("fake error")
+			}
+		})
+
+		It("Should not stop until the context is cancelled", func() {
+			// AfterEach checks that the watcher stops while waiting to restart the watch.
+			Consistently(startErr).ShouldNot(Receive())
+		})
+	})
+})
+
+func TestVMWatcher(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "VM Watcher")
+}
@@ -48,6 +48,7 @@ func KnownObjectTypes() []client.Object {
 		&v1alpha2.ClusterVirtualMachineImage{},
 		&v1alpha1.VirtualMachineImage{},
 		&v1alpha2.VirtualMachineImage{},
+		&v1alpha2.VirtualMachineResourceQuota{},
 		&cnsv1alpha1.CnsNodeVmAttachment{},
 		&ncpv1alpha1.VirtualNetworkInterface{},
 		&netopv1alpha1.NetworkInterface{},
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 )
@@ -306,3 +307,107 @@ func DummyVirtualMachineWebConsoleRequest(namespace, wcrName, vmName, pubKey str
 		},
 	}
 }
+
+func DummyVirtualMachineSnapshotA2(namespace, name, vmName string) *vmopv1.VirtualMachineSnapshot {
+	return &vmopv1.VirtualMachineSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineSnapshotSpec{
+			VMName:      vmName,
+			Description: "dummy snapshot",
+		},
+	}
+}
+
+func DummyVirtualMachineGuestCommandA2(namespace, name, vmName string) *vmopv1.VirtualMachineGuestCommand {
+	return &vmopv1.VirtualMachineGuestCommand{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineGuestCommandSpec{
+			VMName:                vmName,
+			Command:               "/usr/bin/uptime",
+			CredentialsSecretName: "dummy-guest-credentials",
+		},
+	}
+}
+
+func DummyVirtualMachineGuestFileTransferA2(namespace, name, vmName string) *vmopv1.VirtualMachineGuestFileTransfer {
+	return &vmopv1.VirtualMachineGuestFileTransfer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineGuestFileTransferSpec{
+			VMName:                vmName,
+			CredentialsSecretName: "dummy-guest-credentials",
+			Direction:             vmopv1.VirtualMachineGuestFileTransferToGuest,
+			GuestPath:             "/etc/dummy.conf",
+			Object: vmopv1.VirtualMachineGuestFileTransferObjectReference{
+				Kind: vmopv1.VirtualMachineGuestFileTransferObjectKindConfigMap,
+				Name: "dummy-config",
+				Key:  "dummy.conf",
+			},
+		},
+	}
+}
+
+func DummyVirtualMachinePlacementRequestA2(namespace, name string) *vmopv1.VirtualMachinePlacementRequest {
+	return &vmopv1.VirtualMachinePlacementRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachinePlacementRequestSpec{
+			ClassName:    DummyClassName,
+			ImageName:    DummyImageName,
+			StorageClass: DummyStorageClassName,
+		},
+	}
+}
+
+func DummyVirtualMachineResourceQuotaA2(namespace, name string) *vmopv1.VirtualMachineResourceQuota {
+	return &vmopv1.VirtualMachineResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				vmopv1.VirtualMachineResourceCPU:    resource.MustParse("8"),
+				vmopv1.VirtualMachineResourceMemory: resource.MustParse("16Gi"),
+			},
+		},
+	}
+}
+
+func DummyVirtualMachineReplicaSetA2(namespace, name string) *vmopv1.VirtualMachineReplicaSet {
+	labels := map[string]string{"app": name}
+	return &vmopv1.VirtualMachineReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineReplicaSetSpec{
+			Replicas: pointer.Int32(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: vmopv1.VirtualMachineTemplateSpec{
+				ObjectMeta: vmopv1.VirtualMachineTemplateObjectMeta{
+					Labels: labels,
+				},
+				Spec: vmopv1.VirtualMachineSpec{
+					ImageName:    DummyImageName,
+					ClassName:    DummyClassName,
+					PowerState:   vmopv1.VirtualMachinePowerStateOn,
+					PowerOffMode: vmopv1.VirtualMachinePowerOpModeHard,
+					SuspendMode:  vmopv1.VirtualMachinePowerOpModeHard,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// IsAllowed returns true if the user that made the request may perform the verb on
+// the named object. It is used to prevent a user from using the operator's
+// permissions to access objects that the user cannot.
+func IsAllowed(
+	ctx *context.WebhookRequestContext,
+	client ctrlclient.Client,
+	namespace, verb, resource, name string) (bool, error) {
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(ctx.UserInfo.Extra))
+	for k, val := range ctx.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(val)
+	}
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   ctx.UserInfo.Username,
+			UID:    ctx.UserInfo.UID,
+			Groups: ctx.UserInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Resource:  resource,
+				Name:      name,
+			},
+		},
+	}
+
+	if err := client.Create(ctx, sar); err != nil {
+		return false, err
+	}
+
+	return sar.Status.Allowed, nil
+}
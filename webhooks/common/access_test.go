@@ -0,0 +1,101 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package common_test
+
+import (
+	goctx "context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+var _ = Describe("IsAllowed", func() {
+
+	var (
+		ctx       *context.WebhookRequestContext
+		sar       *authorizationv1.SubjectAccessReview
+		allowed   bool
+		createErr error
+		k8sClient client.Client
+	)
+
+	BeforeEach(func() {
+		ctx = &context.WebhookRequestContext{
+			WebhookContext: &context.WebhookContext{
+				Context: goctx.Background(),
+				Logger:  ctrllog.Log.WithName("is-allowed"),
+			},
+			UserInfo: authenticationv1.UserInfo{
+				Username: "some-user",
+				UID:      "some-uid",
+				Groups:   []string{"some-group"},
+				Extra:    map[string]authenticationv1.ExtraValue{"some-key": {"some-value"}},
+			},
+		}
+		sar = nil
+		allowed = true
+		createErr = nil
+
+		k8sClient = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ goctx.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				sar = obj.(*authorizationv1.SubjectAccessReview)
+				sar.Status.Allowed = allowed
+				return createErr
+			},
+		}).Build()
+	})
+
+	It("Reviews the access of the user that made the request", func() {
+		ok, err := common.IsAllowed(ctx, k8sClient, "some-namespace", "get", "secrets", "some-secret")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		Expect(sar).ToNot(BeNil())
+		Expect(sar.Spec.User).To(Equal("some-user"))
+		Expect(sar.Spec.UID).To(Equal("some-uid"))
+		Expect(sar.Spec.Groups).To(ConsistOf("some-group"))
+		Expect(sar.Spec.Extra).To(HaveKeyWithValue("some-key", authorizationv1.ExtraValue{"some-value"}))
+		Expect(sar.Spec.ResourceAttributes).To(Equal(&authorizationv1.ResourceAttributes{
+			Namespace: "some-namespace",
+			Verb:      "get",
+			Resource:  "secrets",
+			Name:      "some-secret",
+		}))
+	})
+
+	When("The access is denied", func() {
+		BeforeEach(func() {
+			allowed = false
+		})
+
+		It("Returns not allowed", func() {
+			ok, err := common.IsAllowed(ctx, k8sClient, "some-namespace", "get", "secrets", "some-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("The review cannot be created", func() {
+		BeforeEach(func() {
+			createErr = errors.New("some error")
+		})
+
+		It("Returns the error", func() {
+			ok, err := common.IsAllowed(ctx, k8sClient, "some-namespace", "get", "secrets", "some-secret")
+			Expect(err).To(MatchError("some error"))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -114,6 +114,11 @@ func (m mutator) Mutate(ctx *context.WebhookRequestContext) admission.Response {
 		if SetDefaultPowerState(ctx, m.client, modified) {
 			wasMutated = true
 		}
+		if mutated, err := SetCloneDefaults(ctx, m.client, modified); err != nil {
+			return admission.Denied(err.Error())
+		} else if mutated {
+			wasMutated = true
+		}
 		if mutated, err := ResolveImageName(ctx, m.client, modified); err != nil {
 			return admission.Denied(err.Error())
 		} else if mutated {
@@ -269,6 +274,41 @@ func SetDefaultPowerState(
 	return false
 }
 
+// SetCloneDefaults sets the imageName and storageClass of a VM that is cloned
+// from another VM to the values of the source VM when they are not specified.
+// Return true if any of the fields were set, otherwise false.
+func SetCloneDefaults(
+	ctx *context.WebhookRequestContext,
+	c client.Client,
+	vm *vmopv1.VirtualMachine) (bool, error) {
+
+	if vm.Spec.Clone == nil || vm.Spec.Clone.VMName == "" {
+		return false, nil
+	}
+	if vm.Spec.ImageName != "" && vm.Spec.StorageClass != "" {
+		return false, nil
+	}
+
+	srcVM := &vmopv1.VirtualMachine{}
+	key := client.ObjectKey{Namespace: vm.Namespace, Name: vm.Spec.Clone.VMName}
+	if err := c.Get(ctx, key, srcVM); err != nil {
+		// The validation webhook reports the missing fields.
+		return false, client.IgnoreNotFound(err)
+	}
+
+	var mutated bool
+	if vm.Spec.ImageName == "" && srcVM.Spec.ImageName != "" {
+		vm.Spec.ImageName = srcVM.Spec.ImageName
+		mutated = true
+	}
+	if vm.Spec.StorageClass == "" && srcVM.Spec.StorageClass != "" {
+		vm.Spec.StorageClass = srcVM.Spec.StorageClass
+		mutated = true
+	}
+
+	return mutated, nil
+}
+
 // ResolveImageName mutates the vm.spec.imageName if it's not set to a vmi name
 // and there is a single namespace or cluster scope image with that status name.
 func ResolveImageName(
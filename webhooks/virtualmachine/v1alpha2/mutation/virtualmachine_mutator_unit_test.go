@@ -202,6 +202,68 @@ func unitTestsMutating() {
 		})
 	})
 
+	Describe("SetCloneDefaults", func() {
+		var srcVM *vmopv1.VirtualMachine
+
+		BeforeEach(func() {
+			srcVM = builder.DummyBasicVirtualMachineA2("src-vm", ctx.vm.Namespace)
+			srcVM.Spec.ImageName = "vmi-src"
+			srcVM.Spec.StorageClass = "src-storage-class"
+			Expect(ctx.Client.Create(ctx, srcVM)).To(Succeed())
+
+			ctx.vm.Spec.ImageName = ""
+			ctx.vm.Spec.StorageClass = ""
+			ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{VMName: srcVM.Name}
+		})
+
+		It("Should set the imageName and storageClass from the source VM", func() {
+			mutated, err := mutation.SetCloneDefaults(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mutated).To(BeTrue())
+			Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-src"))
+			Expect(ctx.vm.Spec.StorageClass).To(Equal("src-storage-class"))
+		})
+
+		When("the storageClass is specified", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.StorageClass = "my-storage-class"
+			})
+
+			It("Should not mutate the storageClass", func() {
+				mutated, err := mutation.SetCloneDefaults(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeTrue())
+				Expect(ctx.vm.Spec.ImageName).To(Equal("vmi-src"))
+				Expect(ctx.vm.Spec.StorageClass).To(Equal("my-storage-class"))
+			})
+		})
+
+		When("the source VM does not exist", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Clone.VMName = "does-not-exist"
+			})
+
+			It("Should not mutate the VM", func() {
+				mutated, err := mutation.SetCloneDefaults(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeFalse())
+				Expect(ctx.vm.Spec.ImageName).To(BeEmpty())
+			})
+		})
+
+		When("the VM is not a clone", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Clone = nil
+			})
+
+			It("Should not mutate the VM", func() {
+				mutated, err := mutation.SetCloneDefaults(&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(mutated).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("ResolveImageName", func() {
 		const (
 			dupImageStatusName    = "dup-status-name"
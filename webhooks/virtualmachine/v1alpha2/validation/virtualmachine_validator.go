@@ -15,11 +15,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/retry"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
@@ -27,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/pkg/errors"
+	vimTypes "github.com/vmware/govmomi/vim25/types"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep"
@@ -34,9 +41,12 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
 	"github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
+	"github.com/vmware-tanzu/vm-operator/pkg/quota"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	cloudinitvalidate "github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit/validate"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/instancestorage"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
@@ -48,6 +58,9 @@ const (
 	allowedRestrictedNetworkTCPProbePort = 6443
 
 	readinessProbeOnlyOneAction              = "only one action can be specified"
+	invalidHTTPGetPath                       = "must be an absolute path"
+	invalidHTTPGetStatusCodes                = "min must not be greater than max"
+	invalidProbeSuccessThreshold             = "must be 1"
 	updatesNotAllowedWhenPowerOn             = "updates to this field is not allowed when VM power is on"
 	storageClassNotAssignedFmt               = "Storage policy is not associated with the namespace %s"
 	storageClassNotFoundFmt                  = "Storage policy is not associated with the namespace %s"
@@ -61,11 +74,32 @@ const (
 	invalidNextRestartTimeOnUpdate           = "must be formatted as RFC3339Nano"
 	invalidNextRestartTimeOnUpdateNow        = "mutation webhooks are required to restart VM"
 	modifyAnnotationNotAllowedForNonAdmin    = "modifying this annotation is not allowed for non-admin users"
+	invalidCurrentSnapshotOnCreate           = "cannot revert VM to a snapshot on create"
+	invalidCurrentSnapshotNotForVMFmt        = "snapshot is of VM %s"
+	invalidCurrentSnapshotNotReady           = "snapshot is not ready"
+	invalidCloneSourceIsSelf                 = "cannot clone a VM from itself"
+	invalidZoneRemoval                       = "cannot remove the zone of a VM"
+	invalidZoneChangeInstanceStorage         = "cannot change the zone of a VM with instance storage volumes"
+	invalidZoneChangeVCenter                 = "cannot change the zone of a VM to a zone in another vCenter"
+	invalidZoneChangePVC                     = "cannot change the zone of a VM with persistent volume claims"
+	invalidCryptoFirmwareFmt                 = "encryption and vTPM require EFI firmware but the VM's firmware is %s"
+	invalidBootOptionsFirmwareFmt            = "firmware must match the image's firmware %s"
+	invalidSecureBootFirmwareFmt             = "secure boot requires EFI firmware but the VM's firmware is %s"
+	invalidNegativeDuration                  = "must be a non-negative duration"
+	exceededQuotaFmt                         = "exceeded quota: %s, requested: %s, used: %s, limited: %s"
+	quotaClassNotFoundFmt                    = "VirtualMachineClass %s must exist to check the usage of the VM against the namespace's resource quotas"
+	classChangeNotFoundFmt                   = "VirtualMachineClass %s must exist to change the class of the VM"
+	incompatibleClassChangeFmt               = "VirtualMachineClass %s is not compatible with the VM's VirtualMachineClass %s: only the CPU and memory may be changed"
+	probeCredentialsAccessDeniedFmt          = "user %q cannot get secrets %q"
 )
 
-// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha2,name=default.validating.virtualmachine.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachine,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachines,versions=v1alpha2,name=default.validating.virtualmachine.v1alpha2.vmoperator.vmware.com,sideEffects=NoneOnDryRun,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines/status,verbs=get
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas/status,verbs=get;update
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -113,11 +147,25 @@ func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateStartupProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateProbeCredentialsAccess(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validatePowerStateOnCreate(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateCurrentSnapshotOnCreate(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateClone(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm, nil)...)
+	fieldErrs = append(fieldErrs, v.validateBootOptions(ctx, vm, nil)...)
+	fieldErrs = append(fieldErrs, v.validateAffinity(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, nil)...)
 
+	// The usage of the VM is reserved in the resource quotas so the quotas are
+	// only checked once the VM is otherwise valid.
+	if len(fieldErrs) == 0 {
+		fieldErrs = append(fieldErrs, v.validateResourceQuota(ctx, vm, nil)...)
+	}
+
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
 		validationErrs = append(validationErrs, fieldErr.Error())
@@ -133,14 +181,22 @@ func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Resp
 // ValidateUpdate validates if the given VirtualMachineSpec update is valid.
 // Changes to following fields are not allowed:
 //   - ImageName
-//   - ClassName
 //   - StorageClass
 //   - ResourcePolicyName
 //   - Minimum VM Hardware Version
+//   - Clone
 //
 // Following fields can only be changed when the VM is powered off.
 //   - Bootstrap
 //   - Network
+//   - Crypto
+//   - BootOptions
+//
+// ClassName may only be changed to a class that differs in CPU and memory from
+// the VM's class, and may be changed when the VM is powered on. The new class
+// is applied by hot adding CPU and memory when possible, otherwise the next
+// time the VM is powered off. A change to a larger class must fit in the
+// namespace's VirtualMachineResourceQuotas.
 func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
 	vm, err := v.vmFromUnstructured(ctx.Obj)
 	if err != nil {
@@ -164,16 +220,29 @@ func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.
 	// Validations for allowed updates. Return validation responses here for conditional updates regardless
 	// of whether the update is allowed or not.
 	fieldErrs = append(fieldErrs, v.validateAvailabilityZone(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateClass(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateClassChange(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateLivenessProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateStartupProbe(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateProbeCredentialsAccess(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateAdvanced(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNextRestartTimeOnUpdate(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateCurrentSnapshotOnUpdate(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateBootOptions(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateAffinity(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vm, oldVM)...)
 
+	if len(fieldErrs) == 0 {
+		fieldErrs = append(fieldErrs, v.validateResourceQuota(ctx, vm, oldVM)...)
+	}
+
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
 		validationErrs = append(validationErrs, fieldErr.Error())
@@ -338,6 +407,247 @@ func (v validator) validateClass(ctx *context.WebhookRequestContext, vm *vmopv1.
 	return allErrs
 }
 
+// validateClassChange validates that the VM's class is only changed to a class
+// that may be applied by resizing the CPU and memory of the VM. Everything else
+// in the classes, such as the vGPU and passthrough devices and the instance
+// storage, must be the same.
+func (v validator) validateClassChange(ctx *context.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vm.Spec.ClassName == "" || oldVM.Spec.ClassName == vm.Spec.ClassName {
+		return allErrs
+	}
+
+	classPath := field.NewPath("spec", "className")
+
+	var classes [2]vmopv1.VirtualMachineClass
+	for i, name := range []string{oldVM.Spec.ClassName, vm.Spec.ClassName} {
+		if err := v.client.Get(ctx, client.ObjectKey{Name: name, Namespace: vm.Namespace}, &classes[i]); err != nil {
+			if apierrors.IsNotFound(err) {
+				return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, fmt.Sprintf(classChangeNotFoundFmt, name)))
+			}
+			return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+		}
+	}
+
+	compatible, err := isClassResizeCompatible(classes[0].Spec, classes[1].Spec)
+	if err != nil {
+		return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+	}
+	if !compatible {
+		allErrs = append(allErrs, field.Invalid(classPath, vm.Spec.ClassName,
+			fmt.Sprintf(incompatibleClassChangeFmt, vm.Spec.ClassName, oldVM.Spec.ClassName)))
+	}
+
+	return allErrs
+}
+
+// isClassResizeCompatible returns true if the class specs only differ in their
+// CPU and memory hardware, resource policies and ConfigSpec CPU and memory.
+func isClassResizeCompatible(oldSpec, newSpec vmopv1.VirtualMachineClassSpec) (bool, error) {
+	var configSpecs [2]*vimTypes.VirtualMachineConfigSpec
+	for i, spec := range []*vmopv1.VirtualMachineClassSpec{&oldSpec, &newSpec} {
+		if len(spec.ConfigSpec) != 0 {
+			configSpec, err := util.UnmarshalConfigSpecFromJSON(spec.ConfigSpec)
+			if err != nil {
+				return false, err
+			}
+			configSpec.NumCPUs = 0
+			configSpec.MemoryMB = 0
+			configSpec.CpuAllocation = nil
+			configSpec.MemoryAllocation = nil
+			configSpecs[i] = configSpec
+		}
+
+		spec.Hardware.Cpus = 0
+		spec.Hardware.Memory = resource.Quantity{}
+		spec.Policies = vmopv1.VirtualMachineClassPolicies{}
+		spec.Description = ""
+		spec.ConfigSpec = nil
+	}
+
+	if !reflect.DeepEqual(configSpecs[0], configSpecs[1]) {
+		return false, nil
+	}
+
+	return equality.Semantic.DeepEqual(oldSpec, newSpec), nil
+}
+
+// validateResourceQuota validates that creating the VM, or changing its class, does
+// not make the VMs in the namespace use more of a resource than is allowed by a
+// VirtualMachineResourceQuota. Only the resources whose usage is increased are
+// checked, so a VM may always be changed to a smaller class.
+//
+// The increased usage is reserved for the VM in the status of each quota so
+// concurrent requests cannot together exceed a quota. The quota controller
+// releases the reservation once it observes the change to the VM, or once the
+// reservation expires.
+func (v validator) validateResourceQuota(ctx *context.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if vm.Spec.ClassName == "" || (oldVM != nil && oldVM.Spec.ClassName == vm.Spec.ClassName) {
+		return allErrs
+	}
+
+	classPath := field.NewPath("spec", "className")
+
+	resourceQuotas := &vmopv1.VirtualMachineResourceQuotaList{}
+	if err := v.client.List(ctx, resourceQuotas, client.InNamespace(vm.Namespace)); err != nil {
+		return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+	}
+
+	if len(resourceQuotas.Items) == 0 {
+		return allErrs
+	}
+
+	requested, err := v.getClassUsage(ctx, vm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName,
+				fmt.Sprintf(quotaClassNotFoundFmt, vm.Spec.ClassName)))
+		}
+		return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+	}
+
+	if oldVM != nil {
+		// The old class may have been deleted, in which case the VM does not
+		// use any resources.
+		oldUsage, err := v.getClassUsage(ctx, oldVM)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+		}
+		requested = quota.Subtract(requested, oldUsage)
+	}
+
+	for _, resourceQuota := range resourceQuotas.Items {
+		fieldErr, err := v.reserveResourceQuota(ctx, client.ObjectKeyFromObject(&resourceQuota), vm, requested)
+		if err != nil {
+			return append(allErrs, field.Invalid(classPath, vm.Spec.ClassName, err.Error()))
+		}
+		if fieldErr != nil {
+			allErrs = append(allErrs, fieldErr)
+		}
+	}
+
+	return allErrs
+}
+
+// reserveResourceQuota reserves the requested resources for the VM in the status
+// of the quota, or returns a Forbidden error if the requested resources exceed the
+// quota. Expired reservations, and an earlier reservation for the same VM, are
+// released first. The status is updated with the resource version of the quota
+// that was checked, and the quota is checked again when the update conflicts.
+func (v validator) reserveResourceQuota(
+	ctx *context.WebhookRequestContext,
+	key client.ObjectKey,
+	vm *vmopv1.VirtualMachine,
+	requested corev1.ResourceList) (*field.Error, error) {
+
+	var fieldErr *field.Error
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		fieldErr = nil
+
+		resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+		if err := v.client.Get(ctx, key, resourceQuota); err != nil {
+			return err
+		}
+
+		used, err := v.getResourceQuotaUsage(ctx, resourceQuota)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		reservations := make([]vmopv1.VirtualMachineResourceQuotaReservation, 0, len(resourceQuota.Status.Reservations)+1)
+		for _, reservation := range resourceQuota.Status.Reservations {
+			if reservation.VMName == vm.Name || !reservation.ExpirationTime.After(now) {
+				used = quota.Subtract(used, reservation.Resources)
+				continue
+			}
+			reservations = append(reservations, reservation)
+		}
+
+		if exceeded := quota.Exceeded(resourceQuota.Spec.Hard, used, requested); len(exceeded) > 0 {
+			fieldErr = field.Forbidden(field.NewPath("spec", "className"), fmt.Sprintf(exceededQuotaFmt,
+				resourceQuota.Name,
+				quota.FormatResources(requested, exceeded),
+				quota.FormatResources(used, exceeded),
+				quota.FormatResources(resourceQuota.Spec.Hard, exceeded)))
+			return nil
+		}
+
+		if ctx.DryRun {
+			return nil
+		}
+
+		reservation := vmopv1.VirtualMachineResourceQuotaReservation{
+			VMName:         vm.Name,
+			ClassName:      vm.Spec.ClassName,
+			Resources:      corev1.ResourceList{},
+			ExpirationTime: metav1.NewTime(now.Add(quota.ReservationTTL)),
+		}
+		reserved := make(corev1.ResourceList, len(resourceQuota.Spec.Hard))
+		for name := range resourceQuota.Spec.Hard {
+			q := used[name]
+			if req, ok := requested[name]; ok && req.Sign() > 0 {
+				q.Add(req)
+				reservation.Resources[name] = req.DeepCopy()
+			}
+			reserved[name] = q
+		}
+		resourceQuota.Status.Used = reserved
+		resourceQuota.Status.Reservations = append(reservations, reservation)
+
+		return v.client.Status().Update(ctx, resourceQuota)
+	})
+
+	return fieldErr, err
+}
+
+// getResourceQuotaUsage returns the usage in the status of the quota. The usage of
+// the VMs in the namespace is used for any limited resource that is not in the
+// status yet.
+func (v validator) getResourceQuotaUsage(
+	ctx *context.WebhookRequestContext,
+	resourceQuota *vmopv1.VirtualMachineResourceQuota) (corev1.ResourceList, error) {
+
+	used := corev1.ResourceList{}
+	var namespaceUsed corev1.ResourceList
+
+	for name := range resourceQuota.Spec.Hard {
+		if q, ok := resourceQuota.Status.Used[name]; ok {
+			used[name] = q.DeepCopy()
+			continue
+		}
+
+		if namespaceUsed == nil {
+			var err error
+			if namespaceUsed, err = quota.NamespaceUsage(ctx, v.client, resourceQuota.Namespace); err != nil {
+				return nil, err
+			}
+		}
+		used[name] = namespaceUsed[name]
+	}
+
+	return used, nil
+}
+
+// getClassUsage returns the resources used by the VM of its class. A NotFound
+// error is returned when the class does not exist.
+func (v validator) getClassUsage(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) (corev1.ResourceList, error) {
+	if vm.Spec.ClassName == "" {
+		return corev1.ResourceList{}, nil
+	}
+
+	vmClass := &vmopv1.VirtualMachineClass{}
+	if err := v.client.Get(ctx, client.ObjectKey{Name: vm.Spec.ClassName, Namespace: vm.Namespace}, vmClass); err != nil {
+		return nil, err
+	}
+
+	return quota.ClassUsage(vmClass), nil
+}
+
 func (v validator) validateStorageClass(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -654,32 +964,211 @@ func (v validator) validateInstanceStorageVolumes(
 }
 
 func (v validator) validateReadinessProbe(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
-	var allErrs field.ErrorList
-
 	probe := vm.Spec.ReadinessProbe
 	if probe == nil {
-		return allErrs
+		return nil
 	}
 
-	readinessProbePath := field.NewPath("spec", "readinessProbe")
+	return v.validateProbe(ctx, probe, field.NewPath("spec", "readinessProbe"))
+}
 
-	if probe.TCPSocket != nil && probe.GuestHeartbeat != nil {
-		allErrs = append(allErrs, field.Forbidden(readinessProbePath, readinessProbeOnlyOneAction))
+func (v validator) validateLivenessProbe(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	probe := vm.Spec.LivenessProbe
+	if probe == nil {
+		return nil
+	}
+
+	probePath := field.NewPath("spec", "livenessProbe")
+	allErrs := v.validateProbe(ctx, &probe.VirtualMachineReadinessProbeSpec, probePath)
+	allErrs = append(allErrs, validateProbeSuccessThreshold(&probe.VirtualMachineReadinessProbeSpec, probePath)...)
+
+	return allErrs
+}
+
+func (v validator) validateStartupProbe(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	probe := vm.Spec.StartupProbe
+	if probe == nil {
+		return nil
+	}
+
+	probePath := field.NewPath("spec", "startupProbe")
+	allErrs := v.validateProbe(ctx, probe, probePath)
+	allErrs = append(allErrs, validateProbeSuccessThreshold(probe, probePath)...)
+
+	return allErrs
+}
+
+// validateProbeSuccessThreshold validates that the success threshold of a liveness or startup probe is 1,
+// as such a probe acts upon its first success.
+func validateProbeSuccessThreshold(probe *vmopv1.VirtualMachineReadinessProbeSpec, probePath *field.Path) field.ErrorList {
+	if probe.SuccessThreshold > 1 {
+		return field.ErrorList{
+			field.Invalid(probePath.Child("successThreshold"), probe.SuccessThreshold, invalidProbeSuccessThreshold),
+		}
 	}
 
+	return nil
+}
+
+// validateProbe validates the actions of a readiness, liveness or startup probe.
+func (v validator) validateProbe(
+	ctx *context.WebhookRequestContext,
+	probe *vmopv1.VirtualMachineReadinessProbeSpec,
+	probePath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	actions := 0
 	if probe.TCPSocket != nil {
-		tcpSocketPath := readinessProbePath.Child("tcpSocket")
+		actions++
+	}
+	if probe.HTTPGet != nil {
+		actions++
+	}
+	if probe.Exec != nil {
+		actions++
+	}
+	if probe.GuestHeartbeat != nil {
+		actions++
+	}
+	if len(probe.GuestInfo) != 0 {
+		actions++
+	}
+	if actions > 1 {
+		allErrs = append(allErrs, field.Forbidden(probePath, readinessProbeOnlyOneAction))
+	}
 
-		// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
-		if probe.TCPSocket.Port.IntValue() != allowedRestrictedNetworkTCPProbePort {
-			isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
-			if err != nil {
-				allErrs = append(allErrs, field.Forbidden(tcpSocketPath, err.Error()))
-			} else if isRestrictedEnv {
-				allErrs = append(allErrs,
-					field.NotSupported(tcpSocketPath.Child("port"), probe.TCPSocket.Port.IntValue(),
-						[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
-			}
+	if probe.TCPSocket != nil {
+		allErrs = append(allErrs, v.validateProbePort(ctx, probe.TCPSocket.Port, probePath.Child("tcpSocket"))...)
+	}
+
+	if probe.HTTPGet != nil {
+		allErrs = append(allErrs, v.validateHTTPGetAction(ctx, probe.HTTPGet, probePath.Child("httpGet"))...)
+	}
+
+	if probe.Exec != nil {
+		execPath := probePath.Child("exec")
+		if probe.Exec.Command == "" {
+			allErrs = append(allErrs, field.Required(execPath.Child("command"), ""))
+		}
+		if probe.Exec.CredentialsSecretName == "" {
+			allErrs = append(allErrs, field.Required(execPath.Child("credentialsSecretName"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+// validateProbeCredentialsAccess validates that the user may itself read the
+// credentials Secret of each Exec probe whose Secret is set or changed, since the
+// operator runs the probe's command in the guest with those credentials.
+func (v validator) validateProbeCredentialsAccess(
+	ctx *context.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if ctx.IsPrivilegedAccount {
+		return allErrs
+	}
+
+	var oldSecretNames map[string]string
+	if oldVM != nil {
+		oldSecretNames = probeCredentialsSecretNames(oldVM)
+	}
+	secretNames := probeCredentialsSecretNames(vm)
+
+	for _, probeName := range []string{"readinessProbe", "livenessProbe", "startupProbe"} {
+		secretName, ok := secretNames[probeName]
+		if !ok || secretName == oldSecretNames[probeName] {
+			continue
+		}
+
+		secretPath := field.NewPath("spec", probeName, "exec", "credentialsSecretName")
+		allowed, err := common.IsAllowed(ctx, v.client, vm.Namespace, "get", "secrets", secretName)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(secretPath, err))
+		} else if !allowed {
+			allErrs = append(allErrs, field.Forbidden(secretPath,
+				fmt.Sprintf(probeCredentialsAccessDeniedFmt, ctx.UserInfo.Username, secretName)))
+		}
+	}
+
+	return allErrs
+}
+
+// probeCredentialsSecretNames returns the credentials Secret of each Exec probe of
+// the VM, keyed by the name of the probe's field.
+func probeCredentialsSecretNames(vm *vmopv1.VirtualMachine) map[string]string {
+	names := map[string]string{}
+	add := func(probeName string, probe *vmopv1.VirtualMachineReadinessProbeSpec) {
+		if probe != nil && probe.Exec != nil && probe.Exec.CredentialsSecretName != "" {
+			names[probeName] = probe.Exec.CredentialsSecretName
+		}
+	}
+
+	add("readinessProbe", vm.Spec.ReadinessProbe)
+	if probe := vm.Spec.LivenessProbe; probe != nil {
+		add("livenessProbe", &probe.VirtualMachineReadinessProbeSpec)
+	}
+	add("startupProbe", vm.Spec.StartupProbe)
+
+	return names
+}
+
+func (v validator) validateHTTPGetAction(
+	ctx *context.WebhookRequestContext,
+	action *vmopv1.HTTPGetAction,
+	httpGetPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	switch action.Port.Type {
+	case intstr.Int:
+		for _, msg := range k8svalidation.IsValidPortNum(action.Port.IntValue()) {
+			allErrs = append(allErrs, field.Invalid(httpGetPath.Child("port"), action.Port.IntValue(), msg))
+		}
+	case intstr.String:
+		for _, msg := range k8svalidation.IsValidPortName(action.Port.StrVal) {
+			allErrs = append(allErrs, field.Invalid(httpGetPath.Child("port"), action.Port.StrVal, msg))
+		}
+	}
+	allErrs = append(allErrs, v.validateProbePort(ctx, action.Port, httpGetPath)...)
+
+	if action.Path != "" && !strings.HasPrefix(action.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("path"), action.Path, invalidHTTPGetPath))
+	}
+
+	for i, h := range action.HTTPHeaders {
+		for _, msg := range k8svalidation.IsHTTPHeaderName(h.Name) {
+			allErrs = append(allErrs, field.Invalid(httpGetPath.Child("httpHeaders").Index(i).Child("name"), h.Name, msg))
+		}
+	}
+
+	if r := action.StatusCodes; r != nil && r.Min > r.Max {
+		allErrs = append(allErrs, field.Invalid(httpGetPath.Child("statusCodes"), *r, invalidHTTPGetStatusCodes))
+	}
+
+	return allErrs
+}
+
+// validateProbePort validates the port of a probe action that requires network connectivity to the VM.
+func (v validator) validateProbePort(
+	ctx *context.WebhookRequestContext,
+	port intstr.IntOrString,
+	actionPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	// Validate port if environment is a restricted network environment between SV CP VMs and Workload VMs e.g. VMC.
+	if port.IntValue() != allowedRestrictedNetworkTCPProbePort {
+		isRestrictedEnv, err := v.isNetworkRestrictedForReadinessProbe(ctx)
+		if err != nil {
+			allErrs = append(allErrs, field.Forbidden(actionPath, err.Error()))
+		} else if isRestrictedEnv {
+			allErrs = append(allErrs,
+				field.NotSupported(actionPath.Child("port"), port.IntValue(),
+					[]string{strconv.Itoa(allowedRestrictedNetworkTCPProbePort)}))
 		}
 	}
 
@@ -757,6 +1246,76 @@ func (v validator) validateNextRestartTimeOnUpdate(
 	return allErrs
 }
 
+func (v validator) validateCurrentSnapshotOnCreate(
+	ctx *context.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vm.Spec.CurrentSnapshot != "" {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				field.NewPath("spec").Child("currentSnapshot"),
+				vm.Spec.CurrentSnapshot,
+				invalidCurrentSnapshotOnCreate))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateCurrentSnapshotOnUpdate(
+	ctx *context.WebhookRequestContext,
+	newVM, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	snapshotName := newVM.Spec.CurrentSnapshot
+	if snapshotName == "" || snapshotName == oldVM.Spec.CurrentSnapshot {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	currentSnapshotPath := field.NewPath("spec").Child("currentSnapshot")
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: newVM.Namespace, Name: snapshotName}, vmSnapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return append(allErrs, field.NotFound(currentSnapshotPath, snapshotName))
+		}
+		return append(allErrs, field.Invalid(currentSnapshotPath, snapshotName, err.Error()))
+	}
+
+	if vmSnapshot.Spec.VMName != newVM.Name {
+		allErrs = append(allErrs, field.Invalid(currentSnapshotPath, snapshotName,
+			fmt.Sprintf(invalidCurrentSnapshotNotForVMFmt, vmSnapshot.Spec.VMName)))
+	} else if !vmSnapshot.Status.Ready {
+		allErrs = append(allErrs, field.Invalid(currentSnapshotPath, snapshotName, invalidCurrentSnapshotNotReady))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateClone(
+	ctx *context.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	clone := vm.Spec.Clone
+	if clone == nil {
+		return allErrs
+	}
+
+	vmNamePath := field.NewPath("spec", "clone", "vmName")
+
+	if clone.VMName == "" {
+		allErrs = append(allErrs, field.Required(vmNamePath, ""))
+	} else if clone.VMName == vm.Name {
+		allErrs = append(allErrs, field.Invalid(vmNamePath, clone.VMName, invalidCloneSourceIsSelf))
+	}
+
+	return allErrs
+}
+
 func (v validator) validatePowerStateOnCreate(
 	ctx *context.WebhookRequestContext,
 	newVM *vmopv1.VirtualMachine) field.ErrorList {
@@ -843,6 +1402,12 @@ func (v validator) validateUpdatesWhenPoweredOn(ctx *context.WebhookRequestConte
 	if !equality.Semantic.DeepEqual(vm.Spec.Network, oldVM.Spec.Network) {
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("network"), updatesNotAllowedWhenPowerOn))
 	}
+	if !equality.Semantic.DeepEqual(vm.Spec.Crypto, oldVM.Spec.Crypto) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("crypto"), updatesNotAllowedWhenPowerOn))
+	}
+	if !equality.Semantic.DeepEqual(vm.Spec.BootOptions, oldVM.Spec.BootOptions) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("bootOptions"), updatesNotAllowedWhenPowerOn))
+	}
 
 	// TODO: More checks.
 
@@ -854,9 +1419,9 @@ func (v validator) validateImmutableFields(_ *context.WebhookRequestContext, vm,
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.ImageName, oldVM.Spec.ImageName, specPath.Child("imageName"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.ClassName, oldVM.Spec.ClassName, specPath.Child("className"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.StorageClass, oldVM.Spec.StorageClass, specPath.Child("storageClass"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.MinHardwareVersion, oldVM.Spec.MinHardwareVersion, specPath.Child("minHardwareVersion"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(vm.Spec.Clone, oldVM.Spec.Clone, specPath.Child("clone"))...)
 	// TODO: More checks.
 
 	// TODO: Allow privilege?
@@ -886,10 +1451,41 @@ func (v validator) validateAvailabilityZone(ctx *context.WebhookRequestContext,
 	zoneLabelPath := field.NewPath("metadata", "labels").Key(topology.KubernetesTopologyZoneLabelKey)
 
 	if oldVM != nil {
-		// Once the zone has been set then make sure the field is immutable.
+		// Once the zone has been set it may be changed, which relocates the VM to the new
+		// zone, but it may not be removed.
 		if oldVal := oldVM.Labels[topology.KubernetesTopologyZoneLabelKey]; oldVal != "" {
 			newVal := vm.Labels[topology.KubernetesTopologyZoneLabelKey]
-			return append(allErrs, validation.ValidateImmutableField(newVal, oldVal, zoneLabelPath)...)
+			if newVal == "" {
+				return append(allErrs, field.Forbidden(zoneLabelPath, invalidZoneRemoval))
+			}
+			if newVal == oldVal {
+				return allErrs
+			}
+
+			// Instance storage volumes are local to the VM's host so the VM cannot be relocated.
+			if len(instancestorage.FilterVolumes(vm)) > 0 {
+				return append(allErrs, field.Forbidden(zoneLabelPath, invalidZoneChangeInstanceStorage))
+			}
+
+			// The VM's PVCs are bound to volumes that may not be accessible from the new zone,
+			// and the volumes are not moved with the VM.
+			if hasPVCVolumes(vm) || hasPVCVolumes(oldVM) {
+				return append(allErrs, field.Forbidden(zoneLabelPath, invalidZoneChangePVC))
+			}
+
+			// The VM is managed in the vCenter it was created in so the VM cannot be
+			// relocated to another vCenter.
+			vCenters, err := v.getVCenterConfigs(ctx)
+			if err != nil {
+				return append(allErrs, field.InternalError(zoneLabelPath, err))
+			}
+			vcName, ok := oldVM.Annotations[vmopv1.VCenterNameAnnotation]
+			if !ok {
+				vcName = config.VCenterNameForZoneAndNamespace(vCenters, oldVal, vm.Namespace)
+			}
+			if config.VCenterNameForZoneAndNamespace(vCenters, newVal, vm.Namespace) != vcName {
+				return append(allErrs, field.Forbidden(zoneLabelPath, invalidZoneChangeVCenter))
+			}
 		}
 	}
 
@@ -903,6 +1499,184 @@ func (v validator) validateAvailabilityZone(ctx *context.WebhookRequestContext,
 	return allErrs
 }
 
+// hasPVCVolumes returns true if the VM has a PersistentVolumeClaim volume.
+func hasPVCVolumes(vm *vmopv1.VirtualMachine) bool {
+	for _, vol := range vm.Spec.Volumes {
+		if vol.PersistentVolumeClaim != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCrypto validates that the firmware of a VM that is encrypted or has a
+// vTPM is EFI.
+func (v validator) validateCrypto(ctx *context.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	crypto := vm.Spec.Crypto
+	if crypto == nil || (!crypto.Encrypted && !crypto.VTPM) {
+		return allErrs
+	}
+
+	if oldVM != nil && equality.Semantic.DeepEqual(crypto, oldVM.Spec.Crypto) {
+		return allErrs
+	}
+
+	cryptoPath := field.NewPath("spec", "crypto")
+
+	firmware, err := v.getVMFirmware(ctx, vm)
+	if err != nil {
+		return append(allErrs, field.Invalid(cryptoPath, crypto, err.Error()))
+	}
+
+	// The firmware is not known until the image exists, in which case the VM's
+	// firmware is checked when the VM is created.
+	if firmware != "" && firmware != string(vmopv1.VirtualMachineFirmwareTypeEFI) {
+		allErrs = append(allErrs, field.Invalid(cryptoPath, crypto, fmt.Sprintf(invalidCryptoFirmwareFmt, firmware)))
+	}
+
+	return allErrs
+}
+
+// validateBootOptions validates the boot options of a VM against the firmware
+// of the VM's image.
+func (v validator) validateBootOptions(ctx *context.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	bootOptions := vm.Spec.BootOptions
+	if bootOptions == nil {
+		return allErrs
+	}
+
+	if oldVM != nil && equality.Semantic.DeepEqual(bootOptions, oldVM.Spec.BootOptions) {
+		return allErrs
+	}
+
+	bootOptionsPath := field.NewPath("spec", "bootOptions")
+
+	if bootOptions.Firmware != "" {
+		imageFirmware, err := v.getImageFirmware(ctx, vm)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("firmware"), bootOptions.Firmware, err.Error()))
+		} else if imageFirmware != "" && imageFirmware != string(bootOptions.Firmware) {
+			allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("firmware"), bootOptions.Firmware,
+				fmt.Sprintf(invalidBootOptionsFirmwareFmt, imageFirmware)))
+		}
+	}
+
+	if bootOptions.EFISecureBoot {
+		firmware, err := v.getVMFirmware(ctx, vm)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("efiSecureBoot"), bootOptions.EFISecureBoot, err.Error()))
+		} else if firmware != "" && firmware != string(vmopv1.VirtualMachineFirmwareTypeEFI) {
+			allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("efiSecureBoot"), bootOptions.EFISecureBoot,
+				fmt.Sprintf(invalidSecureBootFirmwareFmt, firmware)))
+		}
+	}
+
+	seen := map[vmopv1.VirtualMachineBootableDevice]struct{}{}
+	for i, dev := range bootOptions.BootOrder {
+		if _, ok := seen[dev]; ok {
+			allErrs = append(allErrs, field.Duplicate(bootOptionsPath.Child("bootOrder").Index(i), dev))
+		}
+		seen[dev] = struct{}{}
+	}
+
+	if d := bootOptions.BootDelay; d != nil && d.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("bootDelay"), d.Duration.String(), invalidNegativeDuration))
+	}
+	if d := bootOptions.BootRetryDelay; d != nil && d.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(bootOptionsPath.Child("bootRetryDelay"), d.Duration.String(), invalidNegativeDuration))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateAffinity(_ *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) field.ErrorList {
+	var allErrs field.ErrorList
+
+	affinity := vm.Spec.Affinity
+	if affinity == nil {
+		return allErrs
+	}
+
+	affinityPath := field.NewPath("spec", "affinity")
+
+	validateTerms := func(termsPath *field.Path, terms []vmopv1.VMAffinityTerm) {
+		for i, term := range terms {
+			termPath := termsPath.Index(i)
+
+			if term.LabelSelector == nil {
+				allErrs = append(allErrs, field.Required(termPath.Child("labelSelector"), ""))
+			} else {
+				allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+					term.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, termPath.Child("labelSelector"))...)
+			}
+
+			switch term.TopologyKey {
+			case vmopv1.VirtualMachineAffinityTopologyKeyZone, vmopv1.VirtualMachineAffinityTopologyKeyHost:
+			default:
+				allErrs = append(allErrs, field.NotSupported(termPath.Child("topologyKey"), term.TopologyKey,
+					[]string{vmopv1.VirtualMachineAffinityTopologyKeyZone, vmopv1.VirtualMachineAffinityTopologyKeyHost}))
+			}
+		}
+	}
+
+	if vmAffinity := affinity.VMAffinity; vmAffinity != nil {
+		validateTerms(affinityPath.Child("vmAffinity", "required"), vmAffinity.Required)
+		validateTerms(affinityPath.Child("vmAffinity", "preferred"), vmAffinity.Preferred)
+	}
+	if vmAntiAffinity := affinity.VMAntiAffinity; vmAntiAffinity != nil {
+		validateTerms(affinityPath.Child("vmAntiAffinity", "required"), vmAntiAffinity.Required)
+		validateTerms(affinityPath.Child("vmAntiAffinity", "preferred"), vmAntiAffinity.Preferred)
+	}
+
+	return allErrs
+}
+
+// getVMFirmware returns the firmware the VM is booted with: the firmware in the
+// VM's boot options, the firmware override annotation, or the firmware of the
+// VM's image, in that order of precedence.
+func (v validator) getVMFirmware(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) (string, error) {
+	if bootOptions := vm.Spec.BootOptions; bootOptions != nil && bootOptions.Firmware != "" {
+		return string(bootOptions.Firmware), nil
+	}
+	if firmware := vm.Annotations[constants.FirmwareOverrideAnnotation]; firmware != "" {
+		return firmware, nil
+	}
+	return v.getImageFirmware(ctx, vm)
+}
+
+// getImageFirmware returns the firmware of the VirtualMachineImage or the
+// ClusterVirtualMachineImage referenced by the VM's spec.imageName, or an empty
+// string when the image does not exist.
+func (v validator) getImageFirmware(ctx *context.WebhookRequestContext, vm *vmopv1.VirtualMachine) (string, error) {
+	if vm.Spec.ImageName == "" {
+		return "", nil
+	}
+
+	vmi := &vmopv1.VirtualMachineImage{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: vm.Namespace, Name: vm.Spec.ImageName}, vmi)
+	if err == nil {
+		return vmi.Status.Firmware, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	cvmi := &vmopv1.ClusterVirtualMachineImage{}
+	err = v.client.Get(ctx, client.ObjectKey{Name: vm.Spec.ImageName}, cvmi)
+	if err == nil {
+		return cvmi.Status.Firmware, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	return "", nil
+}
+
 // vmFromUnstructured returns the VirtualMachine from the unstructured object.
 func (v validator) vmFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachine, error) {
 	vm := &vmopv1.VirtualMachine{}
@@ -922,6 +1696,17 @@ func (v validator) isNetworkRestrictedForReadinessProbe(ctx *context.WebhookRequ
 	return configMap.Data[isRestrictedNetworkKey] == "true", nil
 }
 
+// getVCenterConfigs returns the configs of the additional vCenters in the provider ConfigMap.
+func (v validator) getVCenterConfigs(ctx *context.WebhookRequestContext) ([]config.VCenterConfig, error) {
+	configMap := &corev1.ConfigMap{}
+	configMapKey := client.ObjectKey{Name: config.ProviderConfigMapName, Namespace: ctx.Namespace}
+	if err := v.client.Get(ctx, configMapKey, configMap); err != nil {
+		return nil, fmt.Errorf("error get ConfigMap: %s while validating the vCenter of the zone: %v", configMapKey, err)
+	}
+
+	return config.ConfigMapToVCenterConfigs(configMap)
+}
+
 func (v validator) validateAnnotation(ctx *context.WebhookRequestContext, vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -944,5 +1729,12 @@ func (v validator) validateAnnotation(ctx *context.WebhookRequestContext, vm, ol
 		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), modifyAnnotationNotAllowedForNonAdmin))
 	}
 
+	// The name of the default vCenter is empty, so whether the annotation is set matters.
+	vcName, ok := vm.Annotations[vmopv1.VCenterNameAnnotation]
+	oldVCName, oldOK := oldVM.Annotations[vmopv1.VCenterNameAnnotation]
+	if vcName != oldVCName || ok != oldOK {
+		allErrs = append(allErrs, field.Forbidden(annotationPath.Child(vmopv1.VCenterNameAnnotation), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
 	return allErrs
 }
@@ -4,15 +4,21 @@
 package validation_test
 
 import (
+	goctx "context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -20,23 +26,31 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	vimTypes "github.com/vmware/govmomi/vim25/types"
+
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep"
 	pkgbuilder "github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/lib"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/vmprovider/providers/vsphere2/constants"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
+	vmvalidation "github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine/v1alpha2/validation"
 )
 
 const (
 	updateSuffix          = "-updated"
 	dummyInstanceIDVal    = "dummy-instance-id"
 	dummyFirstBootDoneVal = "dummy-first-boot-done"
+	dummyVCenterName      = "dummy-vcenter"
 )
 
 func unitTests() {
@@ -48,6 +62,9 @@ func unitTests() {
 type unitValidatingWebhookContext struct {
 	builder.UnitTestContextForValidatingWebhook
 	vm, oldVM *vmopv1.VirtualMachine
+	// deniedSecrets is the set of Secrets that SubjectAccessReviews do not
+	// allow the user to get.
+	deniedSecrets map[string]bool
 }
 
 func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
@@ -69,11 +86,27 @@ func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhoo
 	zone := builder.DummyAvailabilityZone()
 	initObjects := []client.Object{zone}
 
-	return &unitValidatingWebhookContext{
+	ctx := &unitValidatingWebhookContext{
 		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, initObjects...),
 		vm:                                  vm,
 		oldVM:                               oldVM,
+		deniedSecrets:                       map[string]bool{},
 	}
+
+	sarClient := interceptor.NewClient(ctx.Client.(client.WithWatch), interceptor.Funcs{
+		Create: func(c goctx.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return cl.Create(c, obj, opts...)
+			}
+			attrs := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = attrs.Verb != "get" || attrs.Resource != "secrets" || !ctx.deniedSecrets[attrs.Name]
+			return nil
+		},
+	})
+	ctx.Validator = vmvalidation.NewValidator(sarClient)
+
+	return ctx
 }
 
 //nolint:gocyclo
@@ -97,6 +130,14 @@ func unitTestsValidateCreate() {
 		validStorageClass                 bool
 		withInstanceStorageVolumes        bool
 		invalidReadinessProbe             bool
+		invalidLivenessProbe              bool
+		invalidStartupProbe               bool
+		multipleActionsWithHTTPGet        bool
+		invalidHTTPGetProbe               bool
+		validHTTPGetProbe                 bool
+		invalidExecProbe                  bool
+		validExecProbe                    bool
+		deniedExecProbeSecret             bool
 		isRestrictedNetworkEnv            bool
 		isRestrictedNetworkValidProbePort bool
 		isNonRestrictedNetworkEnv         bool
@@ -106,6 +147,13 @@ func unitTestsValidateCreate() {
 		isEmptyAvailabilityZone           bool
 		powerState                        vmopv1.VirtualMachinePowerState
 		nextRestartTime                   string
+		currentSnapshot                   string
+		cloneVMName                       *string
+		withVTPM                          bool
+		imageFirmware                     string
+		firmwareOverride                  string
+		bootOptions                       *vmopv1.VirtualMachineBootOptions
+		affinity                          *vmopv1.VirtualMachineAffinitySpec
 		adminOnlyAnnotations              bool
 		isPrivilegedUser                  bool
 	}
@@ -171,6 +219,23 @@ func unitTestsValidateCreate() {
 				GuestHeartbeat: &vmopv1.GuestHeartbeatAction{},
 			}
 		}
+		if args.invalidLivenessProbe {
+			ctx.vm.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+				VirtualMachineReadinessProbeSpec: vmopv1.VirtualMachineReadinessProbeSpec{
+					TCPSocket:        &vmopv1.TCPSocketAction{},
+					GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+					SuccessThreshold: 2,
+					FailureThreshold: 3,
+				},
+			}
+		}
+		if args.invalidStartupProbe {
+			ctx.vm.Spec.StartupProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				TCPSocket:        &vmopv1.TCPSocketAction{},
+				GuestHeartbeat:   &vmopv1.GuestHeartbeatAction{},
+				SuccessThreshold: 2,
+			}
+		}
 		if args.isRestrictedNetworkEnv || args.isNonRestrictedNetworkEnv {
 			cm := &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
@@ -192,6 +257,50 @@ func unitTestsValidateCreate() {
 				TCPSocket: &vmopv1.TCPSocketAction{Port: intstr.FromInt(portValue)},
 			}
 		}
+		if args.multipleActionsWithHTTPGet {
+			ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				HTTPGet:   &vmopv1.HTTPGetAction{Port: intstr.FromInt(80)},
+				GuestInfo: []vmopv1.GuestInfoAction{{Key: "ready"}},
+			}
+		}
+		if args.invalidHTTPGetProbe {
+			ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				HTTPGet: &vmopv1.HTTPGetAction{
+					Path:        "healthz",
+					Port:        intstr.FromInt(0),
+					HTTPHeaders: []vmopv1.HTTPHeader{{Name: "Bad Header", Value: "value"}},
+					StatusCodes: &vmopv1.HTTPStatusCodeRange{Min: 400, Max: 200},
+				},
+			}
+		}
+		if args.validHTTPGetProbe {
+			ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				HTTPGet: &vmopv1.HTTPGetAction{
+					Path:        "/healthz?verbose=true",
+					Port:        intstr.FromInt(8080),
+					Scheme:      vmopv1.URISchemeHTTPS,
+					HTTPHeaders: []vmopv1.HTTPHeader{{Name: "X-Probe", Value: "vm-operator"}},
+					StatusCodes: &vmopv1.HTTPStatusCodeRange{Min: 200, Max: 299},
+				},
+			}
+		}
+		if args.invalidExecProbe {
+			ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				Exec: &vmopv1.GuestExecAction{},
+			}
+		}
+		if args.validExecProbe {
+			ctx.vm.Spec.ReadinessProbe = &vmopv1.VirtualMachineReadinessProbeSpec{
+				Exec: &vmopv1.GuestExecAction{
+					Command:               "/usr/bin/systemctl",
+					Args:                  []string{"is-active", "nginx"},
+					CredentialsSecretName: "guest-credentials",
+				},
+			}
+		}
+		if args.deniedExecProbeSecret {
+			ctx.deniedSecrets["guest-credentials"] = true
+		}
 
 		if args.isWCPFaultDomainsFSSEnabled {
 			Expect(os.Setenv(lib.WcpFaultDomainsFSS, "true")).To(Succeed())
@@ -215,6 +324,7 @@ func unitTestsValidateCreate() {
 		if args.adminOnlyAnnotations {
 			ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = updateSuffix
 			ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = updateSuffix
+			ctx.vm.Annotations[vmopv1.VCenterNameAnnotation] = ""
 		}
 
 		if args.isPrivilegedUser {
@@ -234,6 +344,29 @@ func unitTestsValidateCreate() {
 
 		ctx.vm.Spec.PowerState = args.powerState
 		ctx.vm.Spec.NextRestartTime = args.nextRestartTime
+		ctx.vm.Spec.CurrentSnapshot = args.currentSnapshot
+		if args.cloneVMName != nil {
+			ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{VMName: *args.cloneVMName}
+		}
+		if args.withVTPM {
+			ctx.vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{VTPM: true}
+		}
+		if args.imageFirmware != "" {
+			vmi := builder.DummyVirtualMachineImageA2(ctx.vm.Spec.ImageName)
+			vmi.Namespace = ctx.vm.Namespace
+			Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+			vmi.Status.Firmware = args.imageFirmware
+			Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+		}
+		if args.firmwareOverride != "" {
+			ctx.vm.Annotations[constants.FirmwareOverrideAnnotation] = args.firmwareOverride
+		}
+		if args.bootOptions != nil {
+			ctx.vm.Spec.BootOptions = args.bootOptions
+		}
+		if args.affinity != nil {
+			ctx.vm.Spec.Affinity = args.affinity
+		}
 
 		var err error
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
@@ -277,6 +410,35 @@ func unitTestsValidateCreate() {
 
 		Entry("should fail when Readiness probe has multiple actions", createArgs{invalidReadinessProbe: true}, false,
 			field.Forbidden(specPath.Child("readinessProbe"), "only one action can be specified").Error(), nil),
+		Entry("should fail when Liveness probe has multiple actions", createArgs{invalidLivenessProbe: true}, false,
+			field.Forbidden(specPath.Child("livenessProbe"), "only one action can be specified").Error(), nil),
+		Entry("should fail when Liveness probe success threshold is not 1", createArgs{invalidLivenessProbe: true}, false,
+			field.Invalid(specPath.Child("livenessProbe", "successThreshold"), 2, "must be 1").Error(), nil),
+		Entry("should fail when Startup probe has multiple actions", createArgs{invalidStartupProbe: true}, false,
+			field.Forbidden(specPath.Child("startupProbe"), "only one action can be specified").Error(), nil),
+		Entry("should fail when Startup probe success threshold is not 1", createArgs{invalidStartupProbe: true}, false,
+			field.Invalid(specPath.Child("startupProbe", "successThreshold"), 2, "must be 1").Error(), nil),
+		Entry("should fail when Readiness probe has HTTPGet and GuestInfo actions", createArgs{multipleActionsWithHTTPGet: true}, false,
+			field.Forbidden(specPath.Child("readinessProbe"), "only one action can be specified").Error(), nil),
+		Entry("should allow valid HTTPGet readiness probe", createArgs{isNonRestrictedNetworkEnv: true, validHTTPGetProbe: true}, true, nil, nil),
+		Entry("should deny when restricted network and HTTPGet port in readiness probe is not 6443", createArgs{isRestrictedNetworkEnv: true, validHTTPGetProbe: true}, false,
+			field.NotSupported(specPath.Child("readinessProbe", "httpGet", "port"), 8080, []string{"6443"}).Error(), nil),
+		Entry("should deny invalid HTTPGet readiness probe port", createArgs{invalidHTTPGetProbe: true}, false,
+			field.Invalid(specPath.Child("readinessProbe", "httpGet", "port"), 0, "must be between 1 and 65535, inclusive").Error(), nil),
+		Entry("should deny invalid HTTPGet readiness probe path", createArgs{invalidHTTPGetProbe: true}, false,
+			field.Invalid(specPath.Child("readinessProbe", "httpGet", "path"), "healthz", "must be an absolute path").Error(), nil),
+		Entry("should deny invalid HTTPGet readiness probe header name", createArgs{invalidHTTPGetProbe: true}, false,
+			field.Invalid(specPath.Child("readinessProbe", "httpGet", "httpHeaders").Index(0).Child("name"), "Bad Header", "").Error(), nil),
+		Entry("should deny invalid HTTPGet readiness probe status codes", createArgs{invalidHTTPGetProbe: true}, false,
+			"min must not be greater than max", nil),
+		Entry("should allow valid Exec readiness probe", createArgs{validExecProbe: true}, true, nil, nil),
+		Entry("should allow Exec readiness probe when service user cannot get the credentials Secret", createArgs{validExecProbe: true, deniedExecProbeSecret: true, isServiceUser: true}, true, nil, nil),
+		Entry("should deny Exec readiness probe when user cannot get the credentials Secret", createArgs{validExecProbe: true, deniedExecProbeSecret: true}, false,
+			field.Forbidden(specPath.Child("readinessProbe", "exec", "credentialsSecretName"), `user "" cannot get secrets "guest-credentials"`).Error(), nil),
+		Entry("should deny Exec readiness probe without a command", createArgs{invalidExecProbe: true}, false,
+			field.Required(specPath.Child("readinessProbe", "exec", "command"), "").Error(), nil),
+		Entry("should deny Exec readiness probe without a credentials Secret", createArgs{invalidExecProbe: true}, false,
+			field.Required(specPath.Child("readinessProbe", "exec", "credentialsSecretName"), "").Error(), nil),
 
 		Entry("should deny invalid volume name", createArgs{invalidVolumeName: true}, false,
 			field.Invalid(volPath.Index(0).Child("name"), "underscore_not_valid", validation.IsDNS1123Subdomain("underscore_not_valid")[0]).Error(), nil),
@@ -328,16 +490,284 @@ func unitTestsValidateCreate() {
 			createArgs{nextRestartTime: "hello"}, false,
 			field.Invalid(nextRestartTimePath, "hello", "cannot restart VM on create").Error(), nil),
 
+		Entry("should disallow creating VM with non-empty currentSnapshot value", createArgs{currentSnapshot: "snapshot"}, false,
+			field.Invalid(specPath.Child("currentSnapshot"), "snapshot", "cannot revert VM to a snapshot on create").Error(), nil),
+
+		Entry("should allow creating VM cloned from another VM", createArgs{cloneVMName: pointer.String("src-vm")}, true, nil, nil),
+		Entry("should disallow creating VM cloned from a VM without a name", createArgs{cloneVMName: pointer.String("")}, false,
+			field.Required(specPath.Child("clone", "vmName"), "").Error(), nil),
+		Entry("should disallow creating VM cloned from itself", createArgs{cloneVMName: pointer.String("dummy-vm-for-webhook-validation")}, false,
+			field.Invalid(specPath.Child("clone", "vmName"), "dummy-vm-for-webhook-validation", "cannot clone a VM from itself").Error(), nil),
+
+		Entry("should allow creating VM with vTPM when image firmware is EFI", createArgs{withVTPM: true, imageFirmware: "efi"}, true, nil, nil),
+		Entry("should allow creating VM with vTPM when image does not exist", createArgs{withVTPM: true}, true, nil, nil),
+		Entry("should allow creating VM with vTPM when firmware is overridden to EFI", createArgs{withVTPM: true, imageFirmware: "bios", firmwareOverride: "efi"}, true, nil, nil),
+		Entry("should disallow creating VM with vTPM when image firmware is BIOS", createArgs{withVTPM: true, imageFirmware: "bios"}, false,
+			field.Invalid(specPath.Child("crypto"), &vmopv1.VirtualMachineCryptoSpec{VTPM: true}, "encryption and vTPM require EFI firmware but the VM's firmware is bios").Error(), nil),
+
+		Entry("should allow creating VM with boot options", createArgs{imageFirmware: "efi", bootOptions: &vmopv1.VirtualMachineBootOptions{
+			Firmware:      vmopv1.VirtualMachineFirmwareTypeEFI,
+			EFISecureBoot: true,
+			BootOrder:     []vmopv1.VirtualMachineBootableDevice{vmopv1.VirtualMachineBootableDeviceCDRom, vmopv1.VirtualMachineBootableDeviceDisk},
+			BootDelay:     &metav1.Duration{Duration: 5 * time.Second},
+		}}, true, nil, nil),
+		Entry("should disallow creating VM with firmware that differs from the image firmware",
+			createArgs{imageFirmware: "efi", bootOptions: &vmopv1.VirtualMachineBootOptions{Firmware: vmopv1.VirtualMachineFirmwareTypeBIOS}}, false,
+			field.Invalid(specPath.Child("bootOptions", "firmware"), vmopv1.VirtualMachineFirmwareTypeBIOS, "firmware must match the image's firmware efi").Error(), nil),
+		Entry("should allow creating VM with secure boot when firmware is overridden to EFI",
+			createArgs{imageFirmware: "bios", firmwareOverride: "efi", bootOptions: &vmopv1.VirtualMachineBootOptions{EFISecureBoot: true}}, true, nil, nil),
+		Entry("should disallow creating VM with secure boot when image firmware is BIOS",
+			createArgs{imageFirmware: "bios", bootOptions: &vmopv1.VirtualMachineBootOptions{EFISecureBoot: true}}, false,
+			field.Invalid(specPath.Child("bootOptions", "efiSecureBoot"), true, "secure boot requires EFI firmware but the VM's firmware is bios").Error(), nil),
+		Entry("should disallow creating VM with duplicate boot order devices",
+			createArgs{bootOptions: &vmopv1.VirtualMachineBootOptions{BootOrder: []vmopv1.VirtualMachineBootableDevice{
+				vmopv1.VirtualMachineBootableDeviceNetwork, vmopv1.VirtualMachineBootableDeviceNetwork}}}, false,
+			field.Duplicate(specPath.Child("bootOptions", "bootOrder").Index(1), vmopv1.VirtualMachineBootableDeviceNetwork).Error(), nil),
+		Entry("should disallow creating VM with negative boot delay",
+			createArgs{bootOptions: &vmopv1.VirtualMachineBootOptions{BootDelay: &metav1.Duration{Duration: -time.Second}}}, false,
+			field.Invalid(specPath.Child("bootOptions", "bootDelay"), "-1s", "must be a non-negative duration").Error(), nil),
+
+		Entry("should allow creating VM with VM affinity terms", createArgs{affinity: &vmopv1.VirtualMachineAffinitySpec{
+			VMAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+				Required: []vmopv1.VMAffinityTerm{{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+					TopologyKey:   vmopv1.VirtualMachineAffinityTopologyKeyZone,
+				}},
+			},
+			VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+				Preferred: []vmopv1.VMAffinityTerm{{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					TopologyKey:   vmopv1.VirtualMachineAffinityTopologyKeyHost,
+				}},
+			},
+		}}, true, nil, nil),
+		Entry("should disallow creating VM with VM affinity term without label selector",
+			createArgs{affinity: &vmopv1.VirtualMachineAffinitySpec{VMAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+				Required: []vmopv1.VMAffinityTerm{{TopologyKey: vmopv1.VirtualMachineAffinityTopologyKeyZone}}}}}, false,
+			field.Required(specPath.Child("affinity", "vmAffinity", "required").Index(0).Child("labelSelector"), "").Error(), nil),
+		Entry("should disallow creating VM with VM anti-affinity term with invalid topology key",
+			createArgs{affinity: &vmopv1.VirtualMachineAffinitySpec{VMAntiAffinity: &vmopv1.VirtualMachineAffinityVMAffinitySpec{
+				Preferred: []vmopv1.VMAffinityTerm{{LabelSelector: &metav1.LabelSelector{}, TopologyKey: "rack"}}}}}, false,
+			field.NotSupported(specPath.Child("affinity", "vmAntiAffinity", "preferred").Index(0).Child("topologyKey"), "rack",
+				[]string{vmopv1.VirtualMachineAffinityTopologyKeyZone, vmopv1.VirtualMachineAffinityTopologyKeyHost}).Error(), nil),
+
 		Entry("should disallow creating VM with admin-only annotations set by SSO user", createArgs{adminOnlyAnnotations: true}, false,
 			strings.Join([]string{
 				field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 				field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+				field.Forbidden(annotationPath.Child(vmopv1.VCenterNameAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 			}, ", "), nil),
 		Entry("should allow creating VM with admin-only annotations set by service user", createArgs{isServiceUser: true, adminOnlyAnnotations: true}, true, nil, nil),
 
 		Entry("should allow creating VM with admin-only annotations set by WCP user when the Backup/Restore FSS is enabled", createArgs{adminOnlyAnnotations: true, isPrivilegedUser: true}, true, nil, nil),
 	)
 
+	Context("ResourceQuota", func() {
+		classNamePath := field.NewPath("spec", "className")
+
+		newQuotaClass := func(name string, cpus int64) *vmopv1.VirtualMachineClass {
+			vmClass := builder.DummyVirtualMachineClassA2()
+			vmClass.Name = name
+			vmClass.Namespace = ctx.vm.Namespace
+			vmClass.Spec.Hardware.Cpus = cpus
+			return vmClass
+		}
+
+		newQuota := func(cpus string) *vmopv1.VirtualMachineResourceQuota {
+			resourceQuota := builder.DummyVirtualMachineResourceQuotaA2(ctx.vm.Namespace, "dummy-quota")
+			resourceQuota.Spec.Hard = corev1.ResourceList{
+				vmopv1.VirtualMachineResourceCPU: resource.MustParse(cpus),
+			}
+			return resourceQuota
+		}
+
+		validateCreate := func(quotaCPUs string, expectedAllowed bool, expectedReason string) {
+			otherVM := builder.DummyBasicVirtualMachineA2("other-vm", ctx.vm.Namespace)
+			otherVM.Spec.ClassName = "small"
+			Expect(ctx.Client.Create(ctx, newQuotaClass("small", 2))).To(Succeed())
+			Expect(ctx.Client.Create(ctx, otherVM)).To(Succeed())
+			if quotaCPUs != "" {
+				Expect(ctx.Client.Create(ctx, newQuota(quotaCPUs))).To(Succeed())
+			}
+
+			ctx.vm.Spec.ClassName = "small"
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+
+			response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(Equal(expectedAllowed))
+			if expectedReason != "" {
+				Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+			}
+		}
+
+		DescribeTable("resource quota create", validateCreate,
+			Entry("should allow creating VM without a quota", "", true, ""),
+			Entry("should allow creating VM within the quota", "4", true, ""),
+			Entry("should disallow creating VM that exceeds the quota", "3", false,
+				field.Forbidden(classNamePath, "exceeded quota: dummy-quota, requested: cpu=2, used: cpu=2, limited: cpu=3").Error()),
+		)
+
+		getQuotaUsedCPU := func() string {
+			resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+			key := client.ObjectKey{Namespace: ctx.vm.Namespace, Name: "dummy-quota"}
+			ExpectWithOffset(1, ctx.Client.Get(ctx, key, resourceQuota)).To(Succeed())
+			q := resourceQuota.Status.Used[vmopv1.VirtualMachineResourceCPU]
+			return q.String()
+		}
+
+		getQuotaReservations := func() []vmopv1.VirtualMachineResourceQuotaReservation {
+			resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+			key := client.ObjectKey{Namespace: ctx.vm.Namespace, Name: "dummy-quota"}
+			ExpectWithOffset(1, ctx.Client.Get(ctx, key, resourceQuota)).To(Succeed())
+			return resourceQuota.Status.Reservations
+		}
+
+		It("should reserve the usage of the VM in the quota", func() {
+			validateCreate("5", true, "")
+			Expect(getQuotaUsedCPU()).To(Equal("4"))
+			reservations := getQuotaReservations()
+			Expect(reservations).To(HaveLen(1))
+			Expect(reservations[0].VMName).To(Equal(ctx.vm.Name))
+			Expect(reservations[0].ClassName).To(Equal("small"))
+			cpu := reservations[0].Resources[vmopv1.VirtualMachineResourceCPU]
+			Expect(cpu.String()).To(Equal("2"))
+
+			By("replacing the reservation when the same VM is validated again", func() {
+				response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+				Expect(response.Allowed).To(BeTrue())
+				Expect(getQuotaUsedCPU()).To(Equal("4"))
+				Expect(getQuotaReservations()).To(HaveLen(1))
+			})
+
+			By("disallowing another VM that exceeds the quota with the reserved usage", func() {
+				anotherVM := ctx.vm.DeepCopy()
+				anotherVM.Name = "another-vm"
+				var err error
+				ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(anotherVM)
+				Expect(err).ToNot(HaveOccurred())
+
+				response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(string(response.Result.Reason)).To(Equal(
+					field.Forbidden(classNamePath, "exceeded quota: dummy-quota, requested: cpu=2, used: cpu=4, limited: cpu=5").Error()))
+			})
+		})
+
+		It("should disallow concurrent creates of VMs that together exceed the quota", func() {
+			validateCreate("6", true, "")
+
+			requestCtxs := make([]pkgctx.WebhookRequestContext, 2)
+			for i := range requestCtxs {
+				vm := ctx.vm.DeepCopy()
+				vm.Name = fmt.Sprintf("concurrent-vm-%d", i)
+				requestCtxs[i] = ctx.WebhookRequestContext
+				var err error
+				requestCtxs[i].Obj, err = builder.ToUnstructured(vm)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			responses := make([]admission.Response, len(requestCtxs))
+			var wg sync.WaitGroup
+			for i := range requestCtxs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					responses[i] = ctx.ValidateCreate(&requestCtxs[i])
+				}(i)
+			}
+			wg.Wait()
+
+			var allowed int
+			for _, response := range responses {
+				if response.Allowed {
+					allowed++
+				}
+			}
+			Expect(allowed).To(Equal(1))
+			Expect(getQuotaUsedCPU()).To(Equal("6"))
+			Expect(getQuotaReservations()).To(HaveLen(2))
+		})
+
+		It("should release expired reservations", func() {
+			resourceQuota := newQuota("4")
+			Expect(ctx.Client.Create(ctx, resourceQuota)).To(Succeed())
+			resourceQuota.Status.Used = corev1.ResourceList{
+				vmopv1.VirtualMachineResourceCPU: resource.MustParse("4"),
+			}
+			resourceQuota.Status.Reservations = []vmopv1.VirtualMachineResourceQuotaReservation{
+				{
+					VMName:    "expired-vm",
+					ClassName: "small",
+					Resources: corev1.ResourceList{
+						vmopv1.VirtualMachineResourceCPU: resource.MustParse("4"),
+					},
+					ExpirationTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+				},
+			}
+			Expect(ctx.Client.Status().Update(ctx, resourceQuota)).To(Succeed())
+
+			Expect(ctx.Client.Create(ctx, newQuotaClass("small", 2))).To(Succeed())
+			ctx.vm.Spec.ClassName = "small"
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+
+			response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(BeTrue())
+			Expect(getQuotaUsedCPU()).To(Equal("2"))
+			reservations := getQuotaReservations()
+			Expect(reservations).To(HaveLen(1))
+			Expect(reservations[0].VMName).To(Equal(ctx.vm.Name))
+		})
+
+		It("should not reserve the usage of the VM on a dry run", func() {
+			ctx.WebhookRequestContext.DryRun = true
+			validateCreate("5", true, "")
+			Expect(getQuotaUsedCPU()).To(Equal("0"))
+		})
+
+		It("should retry reserving the usage of the VM when the quota was updated", func() {
+			var conflicts int
+			ctx.Validator = vmvalidation.NewValidator(interceptor.NewClient(ctx.Client.(client.WithWatch), interceptor.Funcs{
+				SubResourceUpdate: func(c goctx.Context, cl client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if conflicts == 0 {
+						conflicts++
+						// Another request reserved the usage of a VM first.
+						resourceQuota := obj.DeepCopyObject().(*vmopv1.VirtualMachineResourceQuota)
+						resourceQuota.Status.Used = corev1.ResourceList{
+							vmopv1.VirtualMachineResourceCPU: resource.MustParse("4"),
+						}
+						resourceQuota.Status.Reservations[0].VMName = "another-vm"
+						Expect(cl.Status().Update(c, resourceQuota)).To(Succeed())
+						return apierrors.NewConflict(vmopv1.SchemeGroupVersion.WithResource("virtualmachineresourcequotas").GroupResource(),
+							obj.GetName(), errors.New("the object has been modified"))
+					}
+					return cl.SubResource(subResourceName).Update(c, obj, opts...)
+				},
+			}))
+
+			validateCreate("5", false,
+				field.Forbidden(classNamePath, "exceeded quota: dummy-quota, requested: cpu=2, used: cpu=4, limited: cpu=5").Error())
+			Expect(conflicts).To(Equal(1))
+		})
+
+		It("should disallow creating VM whose class does not exist when there is a quota", func() {
+			Expect(ctx.Client.Create(ctx, newQuota("4"))).To(Succeed())
+
+			ctx.vm.Spec.ClassName = "does-not-exist"
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+
+			response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(field.Invalid(classNamePath, "does-not-exist",
+				"VirtualMachineClass does-not-exist must exist to check the usage of the VM against the namespace's resource quotas").Error()))
+		})
+	})
+
 	Context("Bootstrap", func() {
 		type testParams struct {
 			setup         func(ctx *unitValidatingWebhookContext)
@@ -1060,11 +1490,17 @@ func unitTestsValidateUpdate() {
 	type updateArgs struct {
 		isServiceUser               bool
 		changeClassName             bool
+		removeClassName             bool
 		changeImageName             bool
 		changeStorageClass          bool
 		changeResourcePolicy        bool
 		assignZoneName              bool
 		changeZoneName              bool
+		changeZoneVCenter           bool
+		withPVCVolumes              bool
+		recordedVCenter             bool
+		removeZoneName              bool
+		isWCPFaultDomainsFSSEnabled bool
 		isSysprepFeatureEnabled     bool
 		isSysprepTransportUsed      bool
 		withInstanceStorageVolumes  bool
@@ -1074,6 +1510,15 @@ func unitTestsValidateUpdate() {
 		newPowerStateEmptyAllowed   bool
 		nextRestartTime             string
 		lastRestartTime             string
+		currentSnapshot             string
+		snapshotVMName              string
+		snapshotNotReady            bool
+		changeClone                 bool
+		changeCrypto                bool
+		changeBootOptions           bool
+		withExecProbe               bool
+		changeExecProbeSecret       bool
+		deniedExecProbeSecret       bool
 		addAdminOnlyAnnotations     bool
 		updateAdminOnlyAnnotations  bool
 		removeAdminOnlyAnnotations  bool
@@ -1096,6 +1541,16 @@ func unitTestsValidateUpdate() {
 		}
 		if args.changeClassName {
 			ctx.vm.Spec.ClassName += updateSuffix
+
+			for _, name := range []string{ctx.oldVM.Spec.ClassName, ctx.vm.Spec.ClassName} {
+				vmClass := builder.DummyVirtualMachineClassA2()
+				vmClass.Name = name
+				vmClass.Namespace = ctx.vm.Namespace
+				Expect(ctx.Client.Create(ctx, vmClass)).To(Succeed())
+			}
+		}
+		if args.removeClassName {
+			ctx.vm.Spec.ClassName = ""
 		}
 		if args.changeStorageClass {
 			ctx.vm.Spec.StorageClass += updateSuffix
@@ -1113,6 +1568,44 @@ func unitTestsValidateUpdate() {
 		if args.changeZoneName {
 			ctx.oldVM.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyAvailabilityZoneName
 			ctx.vm.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyAvailabilityZoneName + updateSuffix
+
+			if !args.withPVCVolumes {
+				ctx.oldVM.Spec.Volumes = nil
+				ctx.vm.Spec.Volumes = nil
+			}
+
+			zone := builder.DummyAvailabilityZone()
+			zone.Name += updateSuffix
+			Expect(ctx.Client.Create(ctx, zone)).To(Succeed())
+
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      config.ProviderConfigMapName,
+					Namespace: ctx.Namespace,
+				},
+			}
+			if args.changeZoneVCenter {
+				Expect(config.SetVCenterConfigsInConfigMap(cm, []config.VCenterConfig{
+					{
+						Name:              dummyVCenterName,
+						VcPNID:            "dummy-vcenter.local",
+						VcCredsSecretName: "dummy-vcenter-creds",
+						Zones:             []string{zone.Name},
+					},
+				})).To(Succeed())
+			}
+			Expect(ctx.Client.Create(ctx, cm)).To(Succeed())
+		}
+		if args.recordedVCenter {
+			ctx.oldVM.Annotations[vmopv1.VCenterNameAnnotation] = dummyVCenterName
+			ctx.vm.Annotations[vmopv1.VCenterNameAnnotation] = dummyVCenterName
+		}
+		if args.removeZoneName {
+			ctx.oldVM.Labels[topology.KubernetesTopologyZoneLabelKey] = builder.DummyAvailabilityZoneName
+			delete(ctx.vm.Labels, topology.KubernetesTopologyZoneLabelKey)
+		}
+		if args.isWCPFaultDomainsFSSEnabled {
+			Expect(os.Setenv(lib.WcpFaultDomainsFSS, "true")).To(Succeed())
 		}
 
 		if args.withInstanceStorageVolumes {
@@ -1147,16 +1640,20 @@ func unitTestsValidateUpdate() {
 		if args.addAdminOnlyAnnotations {
 			ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
 			ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+			ctx.vm.Annotations[vmopv1.VCenterNameAnnotation] = ""
 		}
 		if args.updateAdminOnlyAnnotations {
 			ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
 			ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+			ctx.oldVM.Annotations[vmopv1.VCenterNameAnnotation] = ""
 			ctx.vm.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal + updateSuffix
 			ctx.vm.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal + updateSuffix
+			ctx.vm.Annotations[vmopv1.VCenterNameAnnotation] = dummyVCenterName
 		}
 		if args.removeAdminOnlyAnnotations {
 			ctx.oldVM.Annotations[vmopv1.InstanceIDAnnotation] = dummyInstanceIDVal
 			ctx.oldVM.Annotations[vmopv1.FirstBootDoneAnnotation] = dummyFirstBootDoneVal
+			ctx.oldVM.Annotations[vmopv1.VCenterNameAnnotation] = ""
 		}
 
 		if args.isPrivilegedUser {
@@ -1178,6 +1675,43 @@ func unitTestsValidateUpdate() {
 		ctx.oldVM.Spec.NextRestartTime = args.lastRestartTime
 		ctx.vm.Spec.NextRestartTime = args.nextRestartTime
 
+		if args.snapshotVMName != "" {
+			vmSnapshot := builder.DummyVirtualMachineSnapshotA2(ctx.vm.Namespace, "snapshot", args.snapshotVMName)
+			vmSnapshot.Status.Ready = !args.snapshotNotReady
+			Expect(ctx.Client.Create(ctx, vmSnapshot)).To(Succeed())
+		}
+		ctx.vm.Spec.CurrentSnapshot = args.currentSnapshot
+		if args.changeClone {
+			ctx.vm.Spec.Clone = &vmopv1.VirtualMachineCloneSpec{VMName: "src-vm"}
+		}
+		if args.changeCrypto {
+			ctx.vm.Spec.Crypto = &vmopv1.VirtualMachineCryptoSpec{Encrypted: true}
+		}
+		if args.changeBootOptions {
+			ctx.vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+				BootOrder: []vmopv1.VirtualMachineBootableDevice{vmopv1.VirtualMachineBootableDeviceNetwork},
+			}
+		}
+
+		if args.withExecProbe {
+			ctx.oldVM.Spec.LivenessProbe = &vmopv1.VirtualMachineLivenessProbeSpec{
+				VirtualMachineReadinessProbeSpec: vmopv1.VirtualMachineReadinessProbeSpec{
+					Exec: &vmopv1.GuestExecAction{
+						Command:               "/usr/bin/systemctl",
+						CredentialsSecretName: "guest-credentials",
+					},
+				},
+			}
+			ctx.vm.Spec.LivenessProbe = ctx.oldVM.Spec.LivenessProbe.DeepCopy()
+		}
+		if args.changeExecProbeSecret {
+			ctx.vm.Spec.LivenessProbe.Exec.CredentialsSecretName = "other-credentials"
+		}
+		if args.deniedExecProbeSecret {
+			ctx.deniedSecrets["guest-credentials"] = true
+			ctx.deniedSecrets["other-credentials"] = true
+		}
+
 		var err error
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
 		Expect(err).ToNot(HaveOccurred())
@@ -1210,18 +1744,54 @@ func unitTestsValidateUpdate() {
 	volumesPath := field.NewPath("spec", "volumes")
 	powerStatePath := field.NewPath("spec", "powerState")
 	nextRestartTimePath := field.NewPath("spec", "nextRestartTime")
+	zoneLabelPath := field.NewPath("metadata", "labels").Key(topology.KubernetesTopologyZoneLabelKey)
+	currentSnapshotPath := field.NewPath("spec", "currentSnapshot")
 	annotationPath := field.NewPath("metadata", "annotations")
 
 	DescribeTable("update table", validateUpdate,
 		Entry("should allow", updateArgs{}, true, nil, nil),
 
 		Entry("should deny image name change", updateArgs{changeImageName: true}, false, msg, nil),
-		Entry("should deny class name change", updateArgs{changeClassName: true}, false, msg, nil),
+		Entry("should allow class name change", updateArgs{changeClassName: true}, true, nil, nil),
+		Entry("should allow class name change when VM is powered on", updateArgs{changeClassName: true,
+			oldPowerState: vmopv1.VirtualMachinePowerStateOn, newPowerState: vmopv1.VirtualMachinePowerStateOn}, true, nil, nil),
+		Entry("should deny class name removal", updateArgs{removeClassName: true}, false,
+			field.Required(field.NewPath("spec", "className"), "").Error(), nil),
 		Entry("should deny storageClass change", updateArgs{changeStorageClass: true}, false, msg, nil),
 		Entry("should deny resourcePolicy change", updateArgs{changeResourcePolicy: true}, false, msg, nil),
+		Entry("should deny clone change", updateArgs{changeClone: true}, false, msg, nil),
+		Entry("should allow unchanged Exec probe when user cannot get the credentials Secret", updateArgs{withExecProbe: true, deniedExecProbeSecret: true}, true, nil, nil),
+		Entry("should allow Exec probe credentials Secret change", updateArgs{withExecProbe: true, changeExecProbeSecret: true}, true, nil, nil),
+		Entry("should deny Exec probe credentials Secret change when user cannot get the Secret", updateArgs{withExecProbe: true, changeExecProbeSecret: true, deniedExecProbeSecret: true}, false,
+			field.Forbidden(field.NewPath("spec", "livenessProbe", "exec", "credentialsSecretName"), `user "" cannot get secrets "other-credentials"`).Error(), nil),
+		Entry("should allow crypto change when VM is powered off", updateArgs{changeCrypto: true,
+			oldPowerState: vmopv1.VirtualMachinePowerStateOff, newPowerState: vmopv1.VirtualMachinePowerStateOff}, true, nil, nil),
+		Entry("should deny crypto change when VM is powered on", updateArgs{changeCrypto: true,
+			oldPowerState: vmopv1.VirtualMachinePowerStateOn, newPowerState: vmopv1.VirtualMachinePowerStateOn}, false,
+			field.Forbidden(field.NewPath("spec", "crypto"), "updates to this field is not allowed when VM power is on").Error(), nil),
+		Entry("should allow boot options change when VM is powered off", updateArgs{changeBootOptions: true,
+			oldPowerState: vmopv1.VirtualMachinePowerStateOff, newPowerState: vmopv1.VirtualMachinePowerStateOff}, true, nil, nil),
+		Entry("should deny boot options change when VM is powered on", updateArgs{changeBootOptions: true,
+			oldPowerState: vmopv1.VirtualMachinePowerStateOn, newPowerState: vmopv1.VirtualMachinePowerStateOn}, false,
+			field.Forbidden(field.NewPath("spec", "bootOptions"), "updates to this field is not allowed when VM power is on").Error(), nil),
 
 		Entry("should allow initial zone assignment", updateArgs{assignZoneName: true}, true, nil, nil),
 		Entry("should allow zone name change when WCP FaultDomains FSS is disabled", updateArgs{changeZoneName: true}, true, nil, nil),
+		Entry("should allow zone name change when WCP FaultDomains FSS is enabled", updateArgs{changeZoneName: true, isWCPFaultDomainsFSSEnabled: true}, true, nil, nil),
+		Entry("should deny zone name change to a zone in another vCenter when WCP FaultDomains FSS is enabled",
+			updateArgs{changeZoneName: true, changeZoneVCenter: true, isWCPFaultDomainsFSSEnabled: true}, false,
+			field.Forbidden(zoneLabelPath, "cannot change the zone of a VM to a zone in another vCenter").Error(), nil),
+		Entry("should deny zone name change of VM created in another vCenter when WCP FaultDomains FSS is enabled",
+			updateArgs{changeZoneName: true, recordedVCenter: true, isWCPFaultDomainsFSSEnabled: true}, false,
+			field.Forbidden(zoneLabelPath, "cannot change the zone of a VM to a zone in another vCenter").Error(), nil),
+		Entry("should deny zone name change of VM with PVC volumes when WCP FaultDomains FSS is enabled",
+			updateArgs{changeZoneName: true, withPVCVolumes: true, isWCPFaultDomainsFSSEnabled: true}, false,
+			field.Forbidden(zoneLabelPath, "cannot change the zone of a VM with persistent volume claims").Error(), nil),
+		Entry("should deny zone name removal when WCP FaultDomains FSS is enabled", updateArgs{removeZoneName: true, isWCPFaultDomainsFSSEnabled: true}, false,
+			field.Forbidden(zoneLabelPath, "cannot remove the zone of a VM").Error(), nil),
+		Entry("should deny zone name change of VM with instance storage volumes when WCP FaultDomains FSS is enabled",
+			updateArgs{changeZoneName: true, withInstanceStorageVolumes: true, isServiceUser: true, isWCPFaultDomainsFSSEnabled: true}, false,
+			field.Forbidden(zoneLabelPath, "cannot change the zone of a VM with instance storage volumes").Error(), nil),
 
 		Entry("should deny instance storage volume name change, when user is SSO user", updateArgs{changeInstanceStorageVolume: true}, false,
 			field.Forbidden(volumesPath, "adding or modifying instance storage volume claim(s) is not allowed").Error(), nil),
@@ -1256,20 +1826,35 @@ func unitTestsValidateUpdate() {
 			updateArgs{nextRestartTime: "hello"}, false,
 			field.Invalid(nextRestartTimePath, "hello", "must be formatted as RFC3339Nano").Error(), nil),
 
+		Entry("should allow updating VM with currentSnapshot of a ready snapshot of the VM",
+			updateArgs{currentSnapshot: "snapshot", snapshotVMName: "dummy-vm-for-webhook-validation"}, true, nil, nil),
+		Entry("should disallow updating VM with currentSnapshot of a snapshot that does not exist",
+			updateArgs{currentSnapshot: "snapshot"}, false,
+			field.NotFound(currentSnapshotPath, "snapshot").Error(), nil),
+		Entry("should disallow updating VM with currentSnapshot of a snapshot of another VM",
+			updateArgs{currentSnapshot: "snapshot", snapshotVMName: "other-vm"}, false,
+			field.Invalid(currentSnapshotPath, "snapshot", "snapshot is of VM other-vm").Error(), nil),
+		Entry("should disallow updating VM with currentSnapshot of a snapshot that is not ready",
+			updateArgs{currentSnapshot: "snapshot", snapshotVMName: "dummy-vm-for-webhook-validation", snapshotNotReady: true}, false,
+			field.Invalid(currentSnapshotPath, "snapshot", "snapshot is not ready").Error(), nil),
+
 		Entry("should disallow adding admin-only annotations by SSO user", updateArgs{addAdminOnlyAnnotations: true}, false,
 			strings.Join([]string{
 				field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 				field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+				field.Forbidden(annotationPath.Child(vmopv1.VCenterNameAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 			}, ", "), nil),
 		Entry("should disallow updating admin-only annotations by SSO user", updateArgs{updateAdminOnlyAnnotations: true}, false,
 			strings.Join([]string{
 				field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 				field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+				field.Forbidden(annotationPath.Child(vmopv1.VCenterNameAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 			}, ", "), nil),
 		Entry("should disallow removing admin-only annotations by SSO user", updateArgs{removeAdminOnlyAnnotations: true}, false,
 			strings.Join([]string{
 				field.Forbidden(annotationPath.Child(vmopv1.InstanceIDAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 				field.Forbidden(annotationPath.Child(vmopv1.FirstBootDoneAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
+				field.Forbidden(annotationPath.Child(vmopv1.VCenterNameAnnotation), "modifying this annotation is not allowed for non-admin users").Error(),
 			}, ", "), nil),
 		Entry("should allow adding admin-only annotations by service user", updateArgs{isServiceUser: true, addAdminOnlyAnnotations: true}, true, nil, nil),
 		Entry("should allow adding admin-only annotations by service user", updateArgs{isServiceUser: true, updateAdminOnlyAnnotations: true}, true, nil, nil),
@@ -1280,6 +1865,174 @@ func unitTestsValidateUpdate() {
 		Entry("should allow removing admin-only annotations by privileged users", updateArgs{isPrivilegedUser: true, removeAdminOnlyAnnotations: true}, true, nil, nil),
 	)
 
+	Context("ClassChange", func() {
+		classNamePath := field.NewPath("spec", "className")
+
+		var oldClass, newClass *vmopv1.VirtualMachineClass
+
+		BeforeEach(func() {
+			oldClass = builder.DummyVirtualMachineClassA2()
+			oldClass.Name = "small"
+			oldClass.Namespace = ctx.vm.Namespace
+			oldClass.Spec.Hardware.Devices.VGPUDevices = []vmopv1.VGPUDevice{{ProfileName: "profile-1"}}
+
+			newClass = oldClass.DeepCopy()
+			newClass.Name = "large"
+			newClass.Spec.Hardware.Cpus = oldClass.Spec.Hardware.Cpus * 2
+			newClass.Spec.Hardware.Memory = resource.MustParse("8Gi")
+			newClass.Spec.Policies.Resources.Requests.Cpu = resource.MustParse("2")
+		})
+
+		validateClassChange := func(expectedAllowed bool, expectedReason string) {
+			ctx.oldVM.Spec.ClassName = oldClass.Name
+			ctx.vm.Spec.ClassName = newClass.Name
+
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVM)
+			Expect(err).ToNot(HaveOccurred())
+
+			response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(Equal(expectedAllowed))
+			if expectedReason != "" {
+				Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+			}
+		}
+
+		incompatibleReason := func() string {
+			return field.Invalid(classNamePath, newClass.Name,
+				fmt.Sprintf("VirtualMachineClass %s is not compatible with the VM's VirtualMachineClass %s: only the CPU and memory may be changed",
+					newClass.Name, oldClass.Name)).Error()
+		}
+
+		createClasses := func() {
+			Expect(ctx.Client.Create(ctx, oldClass)).To(Succeed())
+			Expect(ctx.Client.Create(ctx, newClass)).To(Succeed())
+		}
+
+		It("should allow changing to a class with different CPU and memory", func() {
+			createClasses()
+			validateClassChange(true, "")
+		})
+
+		It("should disallow changing to a class with different vGPU devices", func() {
+			newClass.Spec.Hardware.Devices.VGPUDevices[0].ProfileName = "profile-2"
+			createClasses()
+			validateClassChange(false, incompatibleReason())
+		})
+
+		It("should disallow changing to a class with different passthrough devices", func() {
+			newClass.Spec.Hardware.Devices.DynamicDirectPathIODevices = []vmopv1.DynamicDirectPathIODevice{
+				{VendorID: 42, DeviceID: 43},
+			}
+			createClasses()
+			validateClassChange(false, incompatibleReason())
+		})
+
+		It("should disallow changing to a class with different instance storage", func() {
+			newClass.Spec.Hardware.InstanceStorage.Volumes = []vmopv1.InstanceStorageVolume{
+				{Size: resource.MustParse("256Gi")},
+			}
+			createClasses()
+			validateClassChange(false, incompatibleReason())
+		})
+
+		Context("ConfigSpec", func() {
+			setConfigSpec := func(vmClass *vmopv1.VirtualMachineClass, configSpec *vimTypes.VirtualMachineConfigSpec) {
+				data, err := util.MarshalConfigSpecToJSON(configSpec)
+				Expect(err).ToNot(HaveOccurred())
+				vmClass.Spec.ConfigSpec = data
+			}
+
+			BeforeEach(func() {
+				setConfigSpec(oldClass, &vimTypes.VirtualMachineConfigSpec{NumCPUs: 2, MemoryMB: 4096})
+			})
+
+			It("should allow changing to a class with a different ConfigSpec CPU and memory", func() {
+				setConfigSpec(newClass, &vimTypes.VirtualMachineConfigSpec{NumCPUs: 4, MemoryMB: 8192})
+				createClasses()
+				validateClassChange(true, "")
+			})
+
+			It("should disallow changing to a class with different ConfigSpec devices", func() {
+				setConfigSpec(newClass, &vimTypes.VirtualMachineConfigSpec{
+					NumCPUs:  4,
+					MemoryMB: 8192,
+					DeviceChange: []vimTypes.BaseVirtualDeviceConfigSpec{
+						&vimTypes.VirtualDeviceConfigSpec{
+							Operation: vimTypes.VirtualDeviceConfigSpecOperationAdd,
+							Device: &vimTypes.VirtualPCIPassthrough{
+								VirtualDevice: vimTypes.VirtualDevice{
+									Backing: &vimTypes.VirtualPCIPassthroughVmiopBackingInfo{Vgpu: "profile-2"},
+								},
+							},
+						},
+					},
+				})
+				createClasses()
+				validateClassChange(false, incompatibleReason())
+			})
+		})
+
+		It("should disallow changing to a class that does not exist", func() {
+			Expect(ctx.Client.Create(ctx, oldClass)).To(Succeed())
+			validateClassChange(false, field.Invalid(classNamePath, newClass.Name,
+				fmt.Sprintf("VirtualMachineClass %s must exist to change the class of the VM", newClass.Name)).Error())
+		})
+	})
+
+	Context("ResourceQuota", func() {
+		classNamePath := field.NewPath("spec", "className")
+
+		newQuotaClass := func(name string, cpus int64) *vmopv1.VirtualMachineClass {
+			vmClass := builder.DummyVirtualMachineClassA2()
+			vmClass.Name = name
+			vmClass.Namespace = ctx.vm.Namespace
+			vmClass.Spec.Hardware.Cpus = cpus
+			return vmClass
+		}
+
+		newQuota := func(cpus string) *vmopv1.VirtualMachineResourceQuota {
+			resourceQuota := builder.DummyVirtualMachineResourceQuotaA2(ctx.vm.Namespace, "dummy-quota")
+			resourceQuota.Spec.Hard = corev1.ResourceList{
+				vmopv1.VirtualMachineResourceCPU: resource.MustParse(cpus),
+			}
+			return resourceQuota
+		}
+
+		validateUpdate := func(oldClassName, newClassName, quotaCPUs string, expectedAllowed bool, expectedReason string) {
+			Expect(ctx.Client.Create(ctx, newQuotaClass("small", 2))).To(Succeed())
+			Expect(ctx.Client.Create(ctx, newQuotaClass("large", 4))).To(Succeed())
+			Expect(ctx.Client.Create(ctx, newQuota(quotaCPUs))).To(Succeed())
+
+			ctx.oldVM.Spec.ClassName = oldClassName
+			ctx.oldVM.ResourceVersion = ""
+			Expect(ctx.Client.Create(ctx, ctx.oldVM)).To(Succeed())
+
+			ctx.vm.Spec.ClassName = newClassName
+			var err error
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vm)
+			Expect(err).ToNot(HaveOccurred())
+			ctx.WebhookRequestContext.OldObj, err = builder.ToUnstructured(ctx.oldVM)
+			Expect(err).ToNot(HaveOccurred())
+
+			response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(Equal(expectedAllowed))
+			if expectedReason != "" {
+				Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+			}
+		}
+
+		DescribeTable("resource quota update", validateUpdate,
+			Entry("should allow not changing the class when over the quota", "large", "large", "2", true, ""),
+			Entry("should allow changing to a larger class within the quota", "small", "large", "4", true, ""),
+			Entry("should allow changing to a smaller class when over the quota", "large", "small", "2", true, ""),
+			Entry("should disallow changing to a larger class that exceeds the quota", "small", "large", "3", false,
+				field.Forbidden(classNamePath, "exceeded quota: dummy-quota, requested: cpu=2, used: cpu=2, limited: cpu=3").Error()),
+		)
+	})
+
 	When("the update is performed while object deletion", func() {
 		It("should allow the request", func() {
 			t := metav1.Now()
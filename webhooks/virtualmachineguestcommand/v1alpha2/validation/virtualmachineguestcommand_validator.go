@@ -0,0 +1,178 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	invalidTimeout  = "must be greater than zero"
+	specIsImmutable = "the spec of a VirtualMachineGuestCommand is immutable"
+	accessDeniedFmt = "user %q cannot %s %s %q"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachineguestcommand,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineguestcommands,versions=v1alpha2,name=default.validating.virtualmachineguestcommand.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestcommands,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestcommands/status,verbs=get
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create virtualmachineguestcommand validation webhook")
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(client client.Client) builder.Validator {
+	return validator{
+		client:    client,
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	client    client.Client
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.SchemeGroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineGuestCommand{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.Response {
+	guestCmd, err := v.guestCommandFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(guestCmd)...)
+	if len(fieldErrs) == 0 {
+		fieldErrs = append(fieldErrs, v.validateAccess(ctx, guestCmd)...)
+	}
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
+	guestCmd, err := v.guestCommandFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldGuestCmd, err := v.guestCommandFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(guestCmd, oldGuestCmd)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(guestCmd *vmopv1.VirtualMachineGuestCommand) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if guestCmd.Spec.VMName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("vmName"), ""))
+	}
+	if guestCmd.Spec.Command == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("command"), ""))
+	}
+	if guestCmd.Spec.CredentialsSecretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("credentialsSecretName"), ""))
+	}
+	if t := guestCmd.Spec.Timeout; t != nil && t.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("timeout"), t.Duration.String(), invalidTimeout))
+	}
+
+	return allErrs
+}
+
+// validateAccess validates that the user that requests the command may itself
+// read the credentials Secret with which the command is run in the guest.
+func (v validator) validateAccess(
+	ctx *context.WebhookRequestContext,
+	guestCmd *vmopv1.VirtualMachineGuestCommand) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if ctx.IsPrivilegedAccount {
+		return allErrs
+	}
+
+	secretPath := field.NewPath("spec", "credentialsSecretName")
+	secretName := guestCmd.Spec.CredentialsSecretName
+
+	allowed, err := common.IsAllowed(ctx, v.client, guestCmd.Namespace, "get", "secrets", secretName)
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(secretPath, err))
+	} else if !allowed {
+		allErrs = append(allErrs, field.Forbidden(secretPath,
+			fmt.Sprintf(accessDeniedFmt, ctx.UserInfo.Username, "get", "secrets", secretName)))
+	}
+
+	return allErrs
+}
+
+// validateImmutableFields validates that the spec is not changed after the
+// command has been requested since the command is only run once.
+func (v validator) validateImmutableFields(guestCmd, oldGuestCmd *vmopv1.VirtualMachineGuestCommand) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !equality.Semantic.DeepEqual(guestCmd.Spec, oldGuestCmd.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), specIsImmutable))
+	}
+
+	return allErrs
+}
+
+// guestCommandFromUnstructured returns the VirtualMachineGuestCommand from the unstructured object.
+func (v validator) guestCommandFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineGuestCommand, error) {
+	guestCmd := &vmopv1.VirtualMachineGuestCommand{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), guestCmd); err != nil {
+		return nil, err
+	}
+	return guestCmd, nil
+}
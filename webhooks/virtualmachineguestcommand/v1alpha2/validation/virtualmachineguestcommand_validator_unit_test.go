@@ -0,0 +1,229 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestcommand/v1alpha2/validation"
+)
+
+func unitTests() {
+	Describe("Invoking ValidateCreate", unitTestsValidateCreate)
+	Describe("Invoking ValidateUpdate", unitTestsValidateUpdate)
+	Describe("Invoking ValidateDelete", unitTestsValidateDelete)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	guestCmd    *vmopv1.VirtualMachineGuestCommand
+	oldGuestCmd *vmopv1.VirtualMachineGuestCommand
+	// deniedAccess is the set of "verb/resource" SubjectAccessReviews that are
+	// not allowed.
+	deniedAccess map[string]bool
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	guestCmd := builder.DummyVirtualMachineGuestCommandA2("some-namespace", "some-name", "some-vm-name")
+	obj, err := builder.ToUnstructured(guestCmd)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldGuestCmd *vmopv1.VirtualMachineGuestCommand
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldGuestCmd = guestCmd.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldGuestCmd)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	ctx := &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		guestCmd:                            guestCmd,
+		oldGuestCmd:                         oldGuestCmd,
+		deniedAccess:                        map[string]bool{},
+	}
+
+	sarClient := interceptor.NewClient(ctx.Client.(client.WithWatch), interceptor.Funcs{
+		Create: func(c context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return cl.Create(c, obj, opts...)
+			}
+			attrs := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = !ctx.deniedAccess[attrs.Verb+"/"+attrs.Resource]
+			return nil
+		},
+	})
+	ctx.Validator = validation.NewValidator(sarClient)
+
+	return ctx
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		emptyVMName                bool
+		emptyCommand               bool
+		emptyCredentialsSecretName bool
+		timeout                    *metav1.Duration
+		privileged                 bool
+		deniedAccess               []string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.emptyVMName {
+			ctx.guestCmd.Spec.VMName = ""
+		}
+		if args.emptyCommand {
+			ctx.guestCmd.Spec.Command = ""
+		}
+		if args.emptyCredentialsSecretName {
+			ctx.guestCmd.Spec.CredentialsSecretName = ""
+		}
+		ctx.guestCmd.Spec.Timeout = args.timeout
+		if args.privileged {
+			ctx.IsPrivilegedAccount = true
+		}
+		for _, access := range args.deniedAccess {
+			ctx.deniedAccess[access] = true
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.guestCmd)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, nil, nil),
+		Entry("should allow timeout", createArgs{timeout: &metav1.Duration{Duration: time.Minute}}, true, nil, nil),
+		Entry("should allow privileged account without access", createArgs{privileged: true, deniedAccess: []string{"get/secrets"}}, true, nil, nil),
+		Entry("should deny empty vmName", createArgs{emptyVMName: true}, false, "spec.vmName: Required value", nil),
+		Entry("should deny empty command", createArgs{emptyCommand: true}, false, "spec.command: Required value", nil),
+		Entry("should deny empty credentialsSecretName", createArgs{emptyCredentialsSecretName: true}, false, "spec.credentialsSecretName: Required value", nil),
+		Entry("should deny zero timeout", createArgs{timeout: &metav1.Duration{}}, false, `spec.timeout: Invalid value: "0s": must be greater than zero`, nil),
+		Entry("should deny negative timeout", createArgs{timeout: &metav1.Duration{Duration: -time.Second}}, false, `spec.timeout: Invalid value: "-1s": must be greater than zero`, nil),
+		Entry("should deny when user cannot get credentials Secret", createArgs{deniedAccess: []string{"get/secrets"}}, false, `spec.credentialsSecretName: Forbidden: user "" cannot get secrets`, nil),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateVMName  bool
+		updateCommand bool
+		updateArgs    bool
+		updateLabels  bool
+		updateStatus  bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.updateVMName {
+			ctx.guestCmd.Spec.VMName = "new-vm-name"
+		}
+		if args.updateCommand {
+			ctx.guestCmd.Spec.Command = "/usr/bin/reboot"
+		}
+		if args.updateArgs {
+			ctx.guestCmd.Spec.Args = []string{"-a"}
+		}
+		if args.updateLabels {
+			ctx.guestCmd.Labels = map[string]string{"foo": "bar"}
+		}
+		if args.updateStatus {
+			ctx.guestCmd.Status.PID = 42
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.guestCmd)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, nil, nil),
+		Entry("should allow labels change", updateArgs{updateLabels: true}, true, nil, nil),
+		Entry("should allow status change", updateArgs{updateStatus: true}, true, nil, nil),
+		Entry("should deny vmName change", updateArgs{updateVMName: true}, false, "spec: Forbidden: the spec of a VirtualMachineGuestCommand is immutable", nil),
+		Entry("should deny command change", updateArgs{updateCommand: true}, false, "spec: Forbidden: the spec of a VirtualMachineGuestCommand is immutable", nil),
+		Entry("should deny args change", updateArgs{updateArgs: true}, false, "spec: Forbidden: the spec of a VirtualMachineGuestCommand is immutable", nil),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	guestPathNotAbsolute = "must be an absolute path"
+	invalidSizeLimit     = "must be greater than zero and not greater than 1Mi"
+	specIsImmutable      = "the spec of a VirtualMachineGuestFileTransfer is immutable"
+	fromGuestToSecret    = "files cannot be copied from the guest into a Secret"
+	accessDeniedFmt      = "user %q cannot %s %s %q"
+)
+
+var (
+	// maxSizeLimit is the maximum size of a Secret or ConfigMap.
+	maxSizeLimit = resource.MustParse("1Mi")
+
+	windowsAbsolutePathRegex = regexp.MustCompile(`^[a-zA-Z]:\\`)
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachineguestfiletransfer,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineguestfiletransfers,versions=v1alpha2,name=default.validating.virtualmachineguestfiletransfer.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestfiletransfers,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineguestfiletransfers/status,verbs=get
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create virtualmachineguestfiletransfer validation webhook")
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(client client.Client) builder.Validator {
+	return validator{
+		client:    client,
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	client    client.Client
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.SchemeGroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineGuestFileTransfer{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.Response {
+	fileTransfer, err := v.fileTransferFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(fileTransfer)...)
+	if len(fieldErrs) == 0 {
+		fieldErrs = append(fieldErrs, v.validateAccess(ctx, fileTransfer)...)
+	}
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
+	fileTransfer, err := v.fileTransferFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldFileTransfer, err := v.fileTransferFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(fileTransfer, oldFileTransfer)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(fileTransfer *vmopv1.VirtualMachineGuestFileTransfer) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	spec := fileTransfer.Spec
+
+	if spec.VMName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("vmName"), ""))
+	}
+	if spec.CredentialsSecretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("credentialsSecretName"), ""))
+	}
+
+	switch spec.Direction {
+	case vmopv1.VirtualMachineGuestFileTransferToGuest, vmopv1.VirtualMachineGuestFileTransferFromGuest:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("direction"), spec.Direction,
+			[]string{string(vmopv1.VirtualMachineGuestFileTransferToGuest), string(vmopv1.VirtualMachineGuestFileTransferFromGuest)}))
+	}
+
+	guestPathPath := specPath.Child("guestPath")
+	if spec.GuestPath == "" {
+		allErrs = append(allErrs, field.Required(guestPathPath, ""))
+	} else if !strings.HasPrefix(spec.GuestPath, "/") && !windowsAbsolutePathRegex.MatchString(spec.GuestPath) {
+		allErrs = append(allErrs, field.Invalid(guestPathPath, spec.GuestPath, guestPathNotAbsolute))
+	}
+
+	allErrs = append(allErrs, v.validateObject(specPath.Child("object"), spec.Object)...)
+
+	// The operator may only read Secrets.
+	if spec.Direction == vmopv1.VirtualMachineGuestFileTransferFromGuest &&
+		spec.Object.Kind == vmopv1.VirtualMachineGuestFileTransferObjectKindSecret {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("object", "kind"), fromGuestToSecret))
+	}
+
+	if l := spec.SizeLimit; l != nil && (l.Sign() <= 0 || l.Cmp(maxSizeLimit) > 0) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("sizeLimit"), l.String(), invalidSizeLimit))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateObject(
+	objectPath *field.Path,
+	object vmopv1.VirtualMachineGuestFileTransferObjectReference) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	switch object.Kind {
+	case vmopv1.VirtualMachineGuestFileTransferObjectKindSecret, vmopv1.VirtualMachineGuestFileTransferObjectKindConfigMap:
+	default:
+		allErrs = append(allErrs, field.NotSupported(objectPath.Child("kind"), object.Kind,
+			[]string{string(vmopv1.VirtualMachineGuestFileTransferObjectKindSecret), string(vmopv1.VirtualMachineGuestFileTransferObjectKindConfigMap)}))
+	}
+
+	if object.Name == "" {
+		allErrs = append(allErrs, field.Required(objectPath.Child("name"), ""))
+	}
+
+	keyPath := objectPath.Child("key")
+	if object.Key == "" {
+		allErrs = append(allErrs, field.Required(keyPath, ""))
+	} else {
+		for _, msg := range validation.IsConfigMapKey(object.Key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, object.Key, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAccess validates that the user that requests the transfer may itself
+// read the credentials Secret, and read the object whose content is copied into
+// the guest or create and update the object into which the file is copied. This
+// prevents a user from using the operator's permissions to access objects that
+// the user cannot.
+func (v validator) validateAccess(
+	ctx *context.WebhookRequestContext,
+	fileTransfer *vmopv1.VirtualMachineGuestFileTransfer) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if ctx.IsPrivilegedAccount {
+		return allErrs
+	}
+
+	specPath := field.NewPath("spec")
+	spec := fileTransfer.Spec
+
+	type access struct {
+		path     *field.Path
+		verb     string
+		resource string
+		name     string
+	}
+
+	accesses := []access{
+		{specPath.Child("credentialsSecretName"), "get", "secrets", spec.CredentialsSecretName},
+	}
+
+	objectResource := "configmaps"
+	if spec.Object.Kind == vmopv1.VirtualMachineGuestFileTransferObjectKindSecret {
+		objectResource = "secrets"
+	}
+
+	objectPath := specPath.Child("object", "name")
+	if spec.Direction == vmopv1.VirtualMachineGuestFileTransferFromGuest {
+		accesses = append(accesses,
+			access{objectPath, "create", objectResource, spec.Object.Name},
+			access{objectPath, "update", objectResource, spec.Object.Name})
+	} else {
+		accesses = append(accesses, access{objectPath, "get", objectResource, spec.Object.Name})
+	}
+
+	for _, a := range accesses {
+		allowed, err := common.IsAllowed(ctx, v.client, fileTransfer.Namespace, a.verb, a.resource, a.name)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(a.path, err))
+		} else if !allowed {
+			allErrs = append(allErrs, field.Forbidden(a.path,
+				fmt.Sprintf(accessDeniedFmt, ctx.UserInfo.Username, a.verb, a.resource, a.name)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateImmutableFields validates that the spec is not changed after the
+// transfer has been requested since the file is only copied once.
+func (v validator) validateImmutableFields(fileTransfer, oldFileTransfer *vmopv1.VirtualMachineGuestFileTransfer) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !equality.Semantic.DeepEqual(fileTransfer.Spec, oldFileTransfer.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), specIsImmutable))
+	}
+
+	return allErrs
+}
+
+// fileTransferFromUnstructured returns the VirtualMachineGuestFileTransfer from the unstructured object.
+func (v validator) fileTransferFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineGuestFileTransfer, error) {
+	fileTransfer := &vmopv1.VirtualMachineGuestFileTransfer{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), fileTransfer); err != nil {
+		return nil, err
+	}
+	return fileTransfer, nil
+}
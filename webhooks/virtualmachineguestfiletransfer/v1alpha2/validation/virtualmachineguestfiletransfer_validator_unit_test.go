@@ -0,0 +1,253 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestfiletransfer/v1alpha2/validation"
+)
+
+func unitTests() {
+	Describe("Invoking ValidateCreate", unitTestsValidateCreate)
+	Describe("Invoking ValidateUpdate", unitTestsValidateUpdate)
+	Describe("Invoking ValidateDelete", unitTestsValidateDelete)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	fileTransfer    *vmopv1.VirtualMachineGuestFileTransfer
+	oldFileTransfer *vmopv1.VirtualMachineGuestFileTransfer
+	// deniedAccess is the set of "verb/resource" SubjectAccessReviews that are
+	// not allowed.
+	deniedAccess map[string]bool
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	fileTransfer := builder.DummyVirtualMachineGuestFileTransferA2("some-namespace", "some-name", "some-vm-name")
+	obj, err := builder.ToUnstructured(fileTransfer)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldFileTransfer *vmopv1.VirtualMachineGuestFileTransfer
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldFileTransfer = fileTransfer.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldFileTransfer)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	ctx := &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		fileTransfer:                        fileTransfer,
+		oldFileTransfer:                     oldFileTransfer,
+		deniedAccess:                        map[string]bool{},
+	}
+
+	sarClient := interceptor.NewClient(ctx.Client.(client.WithWatch), interceptor.Funcs{
+		Create: func(c context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return cl.Create(c, obj, opts...)
+			}
+			attrs := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = !ctx.deniedAccess[attrs.Verb+"/"+attrs.Resource]
+			return nil
+		},
+	})
+	ctx.Validator = validation.NewValidator(sarClient)
+
+	return ctx
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		emptyVMName                bool
+		emptyCredentialsSecretName bool
+		direction                  vmopv1.VirtualMachineGuestFileTransferDirection
+		guestPath                  string
+		objectKind                 vmopv1.VirtualMachineGuestFileTransferObjectKind
+		emptyObjectName            bool
+		objectKey                  string
+		sizeLimit                  string
+		privileged                 bool
+		deniedAccess               []string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.emptyVMName {
+			ctx.fileTransfer.Spec.VMName = ""
+		}
+		if args.emptyCredentialsSecretName {
+			ctx.fileTransfer.Spec.CredentialsSecretName = ""
+		}
+		if args.direction != "" {
+			ctx.fileTransfer.Spec.Direction = args.direction
+		}
+		if args.guestPath != "" {
+			ctx.fileTransfer.Spec.GuestPath = args.guestPath
+		}
+		if args.objectKind != "" {
+			ctx.fileTransfer.Spec.Object.Kind = args.objectKind
+		}
+		if args.emptyObjectName {
+			ctx.fileTransfer.Spec.Object.Name = ""
+		}
+		if args.objectKey != "" {
+			ctx.fileTransfer.Spec.Object.Key = args.objectKey
+		}
+		if args.sizeLimit != "" {
+			sizeLimit := resource.MustParse(args.sizeLimit)
+			ctx.fileTransfer.Spec.SizeLimit = &sizeLimit
+		}
+		if args.privileged {
+			ctx.IsPrivilegedAccount = true
+		}
+		for _, access := range args.deniedAccess {
+			ctx.deniedAccess[access] = true
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.fileTransfer)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, nil, nil),
+		Entry("should allow from guest", createArgs{direction: vmopv1.VirtualMachineGuestFileTransferFromGuest}, true, nil, nil),
+		Entry("should allow Secret", createArgs{objectKind: vmopv1.VirtualMachineGuestFileTransferObjectKindSecret}, true, nil, nil),
+		Entry("should allow privileged account without access", createArgs{privileged: true, deniedAccess: []string{"get/secrets", "get/configmaps"}}, true, nil, nil),
+		Entry("should allow Windows guest path", createArgs{guestPath: `C:\Users\foo\bar.txt`}, true, nil, nil),
+		Entry("should allow size limit", createArgs{sizeLimit: "1Mi"}, true, nil, nil),
+		Entry("should deny empty vmName", createArgs{emptyVMName: true}, false, "spec.vmName: Required value", nil),
+		Entry("should deny empty credentialsSecretName", createArgs{emptyCredentialsSecretName: true}, false, "spec.credentialsSecretName: Required value", nil),
+		Entry("should deny invalid direction", createArgs{direction: "Sideways"}, false, `spec.direction: Unsupported value: "Sideways"`, nil),
+		Entry("should deny relative guest path", createArgs{guestPath: "foo/bar"}, false, `spec.guestPath: Invalid value: "foo/bar": must be an absolute path`, nil),
+		Entry("should deny invalid object kind", createArgs{objectKind: "PersistentVolumeClaim"}, false, `spec.object.kind: Unsupported value: "PersistentVolumeClaim"`, nil),
+		Entry("should deny empty object name", createArgs{emptyObjectName: true}, false, "spec.object.name: Required value", nil),
+		Entry("should deny invalid object key", createArgs{objectKey: "foo/bar"}, false, `spec.object.key: Invalid value: "foo/bar"`, nil),
+		Entry("should deny zero size limit", createArgs{sizeLimit: "0"}, false, `spec.sizeLimit: Invalid value: "0": must be greater than zero and not greater than 1Mi`, nil),
+		Entry("should deny from guest into Secret", createArgs{direction: vmopv1.VirtualMachineGuestFileTransferFromGuest, objectKind: vmopv1.VirtualMachineGuestFileTransferObjectKindSecret}, false, "spec.object.kind: Forbidden: files cannot be copied from the guest into a Secret", nil),
+		Entry("should deny when user cannot get credentials Secret", createArgs{deniedAccess: []string{"get/secrets"}}, false, `spec.credentialsSecretName: Forbidden: user "" cannot get secrets`, nil),
+		Entry("should deny when user cannot get ConfigMap", createArgs{deniedAccess: []string{"get/configmaps"}}, false, `spec.object.name: Forbidden: user "" cannot get configmaps`, nil),
+		Entry("should deny when user cannot get Secret", createArgs{objectKind: vmopv1.VirtualMachineGuestFileTransferObjectKindSecret, deniedAccess: []string{"get/secrets"}}, false, `spec.object.name: Forbidden: user "" cannot get secrets`, nil),
+		Entry("should deny from guest when user cannot update ConfigMap", createArgs{direction: vmopv1.VirtualMachineGuestFileTransferFromGuest, deniedAccess: []string{"update/configmaps"}}, false, `spec.object.name: Forbidden: user "" cannot update configmaps`, nil),
+		Entry("should deny size limit greater than 1Mi", createArgs{sizeLimit: "2Mi"}, false, `spec.sizeLimit: Invalid value: "2Mi": must be greater than zero and not greater than 1Mi`, nil),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateGuestPath bool
+		updateObject    bool
+		updateLabels    bool
+		updateStatus    bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.updateGuestPath {
+			ctx.fileTransfer.Spec.GuestPath = "/etc/other.conf"
+		}
+		if args.updateObject {
+			ctx.fileTransfer.Spec.Object.Name = "other-config"
+		}
+		if args.updateLabels {
+			ctx.fileTransfer.Labels = map[string]string{"foo": "bar"}
+		}
+		if args.updateStatus {
+			ctx.fileTransfer.Status.Size = 42
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.fileTransfer)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, nil, nil),
+		Entry("should allow labels change", updateArgs{updateLabels: true}, true, nil, nil),
+		Entry("should allow status change", updateArgs{updateStatus: true}, true, nil, nil),
+		Entry("should deny guestPath change", updateArgs{updateGuestPath: true}, false, "spec: Forbidden: the spec of a VirtualMachineGuestFileTransfer is immutable", nil),
+		Entry("should deny object change", updateArgs{updateObject: true}, false, "spec: Forbidden: the spec of a VirtualMachineGuestFileTransfer is immutable", nil),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
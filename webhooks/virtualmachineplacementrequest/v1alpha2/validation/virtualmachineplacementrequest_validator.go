@@ -0,0 +1,141 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	specIsImmutable = "the spec of a VirtualMachinePlacementRequest is immutable"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachineplacementrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,versions=v1alpha2,name=default.validating.virtualmachineplacementrequest.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineplacementrequests/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create virtualmachineplacementrequest validation webhook")
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.SchemeGroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachinePlacementRequest{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.Response {
+	placementReq, err := v.placementRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(placementReq)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
+	placementReq, err := v.placementRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldPlacementReq, err := v.placementRequestFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateImmutableFields(placementReq, oldPlacementReq)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(placementReq *vmopv1.VirtualMachinePlacementRequest) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	spec := placementReq.Spec
+
+	if spec.ClassName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("className"), ""))
+	}
+	if spec.ImageName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("imageName"), ""))
+	}
+	if spec.StorageClass == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("storageClass"), ""))
+	}
+
+	return allErrs
+}
+
+// validateImmutableFields validates that the spec is not changed after the
+// placement has been requested since the dry run is only performed once.
+func (v validator) validateImmutableFields(placementReq, oldPlacementReq *vmopv1.VirtualMachinePlacementRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !equality.Semantic.DeepEqual(placementReq.Spec, oldPlacementReq.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), specIsImmutable))
+	}
+
+	return allErrs
+}
+
+// placementRequestFromUnstructured returns the VirtualMachinePlacementRequest from the unstructured object.
+func (v validator) placementRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachinePlacementRequest, error) {
+	placementReq := &vmopv1.VirtualMachinePlacementRequest{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), placementReq); err != nil {
+		return nil, err
+	}
+	return placementReq, nil
+}
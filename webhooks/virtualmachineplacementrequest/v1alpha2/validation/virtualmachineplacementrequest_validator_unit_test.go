@@ -0,0 +1,187 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking ValidateCreate", unitTestsValidateCreate)
+	Describe("Invoking ValidateUpdate", unitTestsValidateUpdate)
+	Describe("Invoking ValidateDelete", unitTestsValidateDelete)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	placementReq    *vmopv1.VirtualMachinePlacementRequest
+	oldPlacementReq *vmopv1.VirtualMachinePlacementRequest
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	placementReq := builder.DummyVirtualMachinePlacementRequestA2("some-namespace", "some-name")
+	obj, err := builder.ToUnstructured(placementReq)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldPlacementReq *vmopv1.VirtualMachinePlacementRequest
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldPlacementReq = placementReq.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldPlacementReq)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		placementReq:                        placementReq,
+		oldPlacementReq:                     oldPlacementReq,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		emptyClassName    bool
+		emptyImageName    bool
+		emptyStorageClass bool
+		zone              string
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.emptyClassName {
+			ctx.placementReq.Spec.ClassName = ""
+		}
+		if args.emptyImageName {
+			ctx.placementReq.Spec.ImageName = ""
+		}
+		if args.emptyStorageClass {
+			ctx.placementReq.Spec.StorageClass = ""
+		}
+		if args.zone != "" {
+			ctx.placementReq.Spec.Zone = args.zone
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.placementReq)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, nil, nil),
+		Entry("should allow zone", createArgs{zone: "zone-1"}, true, nil, nil),
+		Entry("should deny empty className", createArgs{emptyClassName: true}, false, "spec.className: Required value", nil),
+		Entry("should deny empty imageName", createArgs{emptyImageName: true}, false, "spec.imageName: Required value", nil),
+		Entry("should deny empty storageClass", createArgs{emptyStorageClass: true}, false, "spec.storageClass: Required value", nil),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateClassName bool
+		updateZone      bool
+		updateLabels    bool
+		updateStatus    bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.updateClassName {
+			ctx.placementReq.Spec.ClassName = "other-class"
+		}
+		if args.updateZone {
+			ctx.placementReq.Spec.Zone = "other-zone"
+		}
+		if args.updateLabels {
+			ctx.placementReq.Labels = map[string]string{"foo": "bar"}
+		}
+		if args.updateStatus {
+			ctx.placementReq.Status.Faults = []string{"insufficient resources"}
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.placementReq)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(Equal(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, nil, nil),
+		Entry("should allow labels change", updateArgs{updateLabels: true}, true, nil, nil),
+		Entry("should allow status change", updateArgs{updateStatus: true}, true, nil, nil),
+		Entry("should deny className change", updateArgs{updateClassName: true}, false, "spec: Forbidden: the spec of a VirtualMachinePlacementRequest is immutable", nil),
+		Entry("should deny zone change", updateArgs{updateZone: true}, false, "spec: Forbidden: the spec of a VirtualMachinePlacementRequest is immutable", nil),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
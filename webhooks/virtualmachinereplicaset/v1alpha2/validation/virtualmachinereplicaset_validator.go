@@ -0,0 +1,153 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	selectorDoesNotMatchTemplateLabels = "selector does not match template labels"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachinereplicaset,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,versions=v1alpha2,name=default.validating.virtualmachinereplicaset.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinereplicasets/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create virtualmachinereplicaset validation webhook")
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.SchemeGroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineReplicaSet{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.Response {
+	vmReplicaSet, err := v.vmReplicaSetFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(vmReplicaSet)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
+	vmReplicaSet, err := v.vmReplicaSetFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateSpec(vmReplicaSet)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSpec(vmReplicaSet *vmopv1.VirtualMachineReplicaSet) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if replicas := vmReplicaSet.Spec.Replicas; replicas != nil && *replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), *replicas, "must be greater than or equal to 0"))
+	}
+
+	allErrs = append(allErrs, v.validateSelector(vmReplicaSet, specPath)...)
+
+	return allErrs
+}
+
+// validateSelector validates that the selector is set and that it selects
+// the VMs created from the template. Otherwise, the replica set would create
+// VMs indefinitely since none of them would be counted as replicas.
+func (v validator) validateSelector(vmReplicaSet *vmopv1.VirtualMachineReplicaSet, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	selectorPath := specPath.Child("selector")
+
+	if vmReplicaSet.Spec.Selector == nil {
+		return append(allErrs, field.Required(selectorPath, ""))
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+		vmReplicaSet.Spec.Selector, metav1validation.LabelSelectorValidationOptions{}, selectorPath)...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(vmReplicaSet.Spec.Selector)
+	if err != nil {
+		return append(allErrs, field.Invalid(selectorPath, vmReplicaSet.Spec.Selector, err.Error()))
+	}
+
+	if selector.Empty() {
+		allErrs = append(allErrs, field.Invalid(selectorPath, vmReplicaSet.Spec.Selector, "empty selector is invalid"))
+	} else if !selector.Matches(labels.Set(vmReplicaSet.Spec.Template.ObjectMeta.Labels)) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("template", "metadata", "labels"),
+			vmReplicaSet.Spec.Template.ObjectMeta.Labels, selectorDoesNotMatchTemplateLabels))
+	}
+
+	return allErrs
+}
+
+// vmReplicaSetFromUnstructured returns the VirtualMachineReplicaSet from the unstructured object.
+func (v validator) vmReplicaSetFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineReplicaSet, error) {
+	vmReplicaSet := &vmopv1.VirtualMachineReplicaSet{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmReplicaSet); err != nil {
+		return nil, err
+	}
+	return vmReplicaSet, nil
+}
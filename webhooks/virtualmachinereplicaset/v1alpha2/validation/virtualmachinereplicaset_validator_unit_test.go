@@ -0,0 +1,196 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking ValidateCreate", unitTestsValidateCreate)
+	Describe("Invoking ValidateUpdate", unitTestsValidateUpdate)
+	Describe("Invoking ValidateDelete", unitTestsValidateDelete)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmReplicaSet    *vmopv1.VirtualMachineReplicaSet
+	oldVMReplicaSet *vmopv1.VirtualMachineReplicaSet
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmReplicaSet := builder.DummyVirtualMachineReplicaSetA2("some-namespace", "some-name")
+	obj, err := builder.ToUnstructured(vmReplicaSet)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldVMReplicaSet *vmopv1.VirtualMachineReplicaSet
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldVMReplicaSet = vmReplicaSet.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMReplicaSet)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmReplicaSet:                        vmReplicaSet,
+		oldVMReplicaSet:                     oldVMReplicaSet,
+	}
+}
+
+type specArgs struct {
+	negativeReplicas       bool
+	nilSelector            bool
+	emptySelector          bool
+	invalidSelector        bool
+	mismatchTemplateLabels bool
+}
+
+func updateSpec(vmReplicaSet *vmopv1.VirtualMachineReplicaSet, args specArgs) {
+	if args.negativeReplicas {
+		vmReplicaSet.Spec.Replicas = pointer.Int32(-1)
+	}
+	if args.nilSelector {
+		vmReplicaSet.Spec.Selector = nil
+	}
+	if args.emptySelector {
+		vmReplicaSet.Spec.Selector = &metav1.LabelSelector{}
+	}
+	if args.invalidSelector {
+		vmReplicaSet.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "foo", Operator: "bad-operator"},
+		}
+	}
+	if args.mismatchTemplateLabels {
+		vmReplicaSet.Spec.Template.ObjectMeta.Labels = map[string]string{"app": "other"}
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	validateCreate := func(args specArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		updateSpec(ctx.vmReplicaSet, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmReplicaSet)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", specArgs{}, true, nil, nil),
+		Entry("should deny negative replicas", specArgs{negativeReplicas: true}, false, "spec.replicas: Invalid value: -1", nil),
+		Entry("should deny missing selector", specArgs{nilSelector: true}, false, "spec.selector: Required value", nil),
+		Entry("should deny empty selector", specArgs{emptySelector: true}, false, "empty selector is invalid", nil),
+		Entry("should deny invalid selector", specArgs{invalidSelector: true}, false, "spec.selector.matchExpressions[0].operator", nil),
+		Entry("should deny selector that does not match the template labels", specArgs{mismatchTemplateLabels: true}, false,
+			"spec.template.metadata.labels: Invalid value: map[string]string{\"app\":\"other\"}: selector does not match template labels", nil),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		specArgs
+		updateReplicas bool
+		updateTemplate bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		updateSpec(ctx.vmReplicaSet, args.specArgs)
+		if args.updateReplicas {
+			ctx.vmReplicaSet.Spec.Replicas = pointer.Int32(5)
+		}
+		if args.updateTemplate {
+			ctx.vmReplicaSet.Spec.Template.Spec.ClassName = "new-class"
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmReplicaSet)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, nil, nil),
+		Entry("should allow replicas change", updateArgs{updateReplicas: true}, true, nil, nil),
+		Entry("should allow template change", updateArgs{updateTemplate: true}, true, nil, nil),
+		Entry("should deny negative replicas", updateArgs{specArgs: specArgs{negativeReplicas: true}}, false, "spec.replicas: Invalid value: -1", nil),
+		Entry("should deny selector that does not match the template labels", updateArgs{specArgs: specArgs{mismatchTemplateLabels: true}}, false,
+			"selector does not match template labels", nil),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
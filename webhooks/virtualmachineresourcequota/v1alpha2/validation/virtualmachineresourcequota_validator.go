@@ -0,0 +1,139 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	"github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/quota"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	negativeQuantity = "must be greater than or equal to 0"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha2-virtualmachineresourcequota,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas,versions=v1alpha2,name=default.validating.virtualmachineresourcequota.v1alpha2.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineresourcequotas/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create virtualmachineresourcequota validation webhook")
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.SchemeGroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineResourceQuota{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *context.WebhookRequestContext) admission.Response {
+	resourceQuota, err := v.resourceQuotaFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateHard(resourceQuota)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*context.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.Response {
+	resourceQuota, err := v.resourceQuotaFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+	fieldErrs = append(fieldErrs, v.validateHard(resourceQuota)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+// validateHard validates that the hard limits are of supported VM resources and
+// are not negative.
+func (v validator) validateHard(resourceQuota *vmopv1.VirtualMachineResourceQuota) field.ErrorList {
+	var allErrs field.ErrorList
+	hardPath := field.NewPath("spec", "hard")
+
+	names := make([]corev1.ResourceName, 0, len(resourceQuota.Spec.Hard))
+	for name := range resourceQuota.Spec.Hard {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	supported := make([]string, 0, len(quota.SupportedResources))
+	for _, name := range quota.SupportedResources {
+		supported = append(supported, string(name))
+	}
+
+	for _, name := range names {
+		if !quota.IsSupportedResource(name) {
+			allErrs = append(allErrs, field.NotSupported(hardPath.Key(string(name)), string(name), supported))
+			continue
+		}
+
+		if q := resourceQuota.Spec.Hard[name]; q.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(hardPath.Key(string(name)), q.String(), negativeQuantity))
+		}
+	}
+
+	return allErrs
+}
+
+// resourceQuotaFromUnstructured returns the VirtualMachineResourceQuota from the unstructured object.
+func (v validator) resourceQuotaFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineResourceQuota, error) {
+	resourceQuota := &vmopv1.VirtualMachineResourceQuota{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), resourceQuota); err != nil {
+		return nil, err
+	}
+	return resourceQuota, nil
+}
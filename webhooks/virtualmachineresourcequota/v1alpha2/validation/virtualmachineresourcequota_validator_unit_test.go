@@ -0,0 +1,183 @@
+// Copyright (c) 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe("Invoking ValidateCreate", unitTestsValidateCreate)
+	Describe("Invoking ValidateUpdate", unitTestsValidateUpdate)
+	Describe("Invoking ValidateDelete", unitTestsValidateDelete)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	resourceQuota    *vmopv1.VirtualMachineResourceQuota
+	oldResourceQuota *vmopv1.VirtualMachineResourceQuota
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	resourceQuota := builder.DummyVirtualMachineResourceQuotaA2("some-namespace", "some-name")
+	obj, err := builder.ToUnstructured(resourceQuota)
+	Expect(err).ToNot(HaveOccurred())
+
+	var oldResourceQuota *vmopv1.VirtualMachineResourceQuota
+	var oldObj *unstructured.Unstructured
+
+	if isUpdate {
+		oldResourceQuota = resourceQuota.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldResourceQuota)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		resourceQuota:                       resourceQuota,
+		oldResourceQuota:                    oldResourceQuota,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		unsupportedResource bool
+		negativeQuantity    bool
+		allResources        bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.unsupportedResource {
+			ctx.resourceQuota.Spec.Hard["pods"] = resource.MustParse("10")
+		}
+		if args.negativeQuantity {
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceCPU] = resource.MustParse("-1")
+		}
+		if args.allResources {
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceRequestsCPU] = resource.MustParse("4000m")
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceRequestsMemory] = resource.MustParse("8Gi")
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceLimitsCPU] = resource.MustParse("8000m")
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceLimitsMemory] = resource.MustParse("16Gi")
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceVGPUs] = resource.MustParse("0")
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceInstanceStorage] = resource.MustParse("1Ti")
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.resourceQuota)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, nil, nil),
+		Entry("should allow all supported resources", createArgs{allResources: true}, true, nil, nil),
+		Entry("should deny unsupported resource", createArgs{unsupportedResource: true}, false, `spec.hard[pods]: Unsupported value: "pods"`, nil),
+		Entry("should deny negative quantity", createArgs{negativeQuantity: true}, false, `spec.hard[cpu]: Invalid value: "-1": must be greater than or equal to 0`, nil),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type updateArgs struct {
+		updateHard          bool
+		unsupportedResource bool
+		updateStatus        bool
+	}
+
+	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
+		var err error
+
+		if args.updateHard {
+			ctx.resourceQuota.Spec.Hard[vmopv1.VirtualMachineResourceCPU] = resource.MustParse("16")
+		}
+		if args.unsupportedResource {
+			ctx.resourceQuota.Spec.Hard["storage"] = resource.MustParse("1Ti")
+		}
+		if args.updateStatus {
+			ctx.resourceQuota.Status.Used = ctx.resourceQuota.Spec.Hard.DeepCopy()
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.resourceQuota)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+		if expectedErr != nil {
+			Expect(response.Result.Message).To(Equal(expectedErr.Error()))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow", updateArgs{}, true, nil, nil),
+		Entry("should allow hard limit change", updateArgs{updateHard: true}, true, nil, nil),
+		Entry("should allow status change", updateArgs{updateStatus: true}, true, nil, nil),
+		Entry("should deny unsupported resource", updateArgs{unsupportedResource: true}, false, `spec.hard[storage]: Unsupported value: "storage"`, nil),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx      *unitValidatingWebhookContext
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("the delete is performed", func() {
+		JustBeforeEach(func() {
+			response = ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		})
+
+		It("should allow the request", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Result).ToNot(BeNil())
+		})
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -95,6 +96,12 @@ func (v validator) ValidateUpdate(ctx *context.WebhookRequestContext) admission.
 
 	var fieldErrs field.ErrorList
 	fieldErrs = append(fieldErrs, v.validateAllowedChanges(ctx, vmRP, oldVMRP)...)
+	// The host affinity may be changed, and its DRS host group is updated to the newly selected hosts.
+	fieldErrs = append(fieldErrs, v.validateHostAffinity(ctx, field.NewPath("spec", "hostAffinity"), vmRP.Spec.HostAffinity)...)
+	// The shares and expandable reservations of the ResourcePool may be changed, and are updated on its child ResourcePools.
+	fieldErrs = append(fieldErrs, v.validateResourcePool(ctx, field.NewPath("spec", "resourcepool"), vmRP.Spec.ResourcePool)...)
+	// The topology spread constraint may be changed, and applies to the VMs placed afterwards.
+	fieldErrs = append(fieldErrs, v.validateTopologySpreadConstraint(ctx, field.NewPath("spec", "topologySpreadConstraint"), vmRP.Spec.TopologySpreadConstraint)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -111,6 +118,8 @@ func (v validator) validateSpec(ctx *context.WebhookRequestContext, vmRP *vmopv1
 	fieldErrs = append(fieldErrs, v.validateResourcePool(ctx, specPath.Child("resourcepool"), vmRP.Spec.ResourcePool)...)
 	fieldErrs = append(fieldErrs, v.validateFolder(ctx, specPath.Child("folder"), vmRP.Spec.Folder)...)
 	fieldErrs = append(fieldErrs, v.validateClusterModules(ctx, specPath.Child("clustermodules"), vmRP.Spec.ClusterModuleGroups)...)
+	fieldErrs = append(fieldErrs, v.validateHostAffinity(ctx, specPath.Child("hostAffinity"), vmRP.Spec.HostAffinity)...)
+	fieldErrs = append(fieldErrs, v.validateTopologySpreadConstraint(ctx, specPath.Child("topologySpreadConstraint"), vmRP.Spec.TopologySpreadConstraint)...)
 
 	return fieldErrs
 }
@@ -124,6 +133,10 @@ func (v validator) validateResourcePool(ctx *context.WebhookRequestContext, fldP
 	fieldErrs = append(fieldErrs, validateReservationAndLimit(reservationsPath.Child("cpu"), reservation.Cpu, limits.Cpu)...)
 	fieldErrs = append(fieldErrs, validateReservationAndLimit(reservationsPath.Child("memory"), reservation.Memory, limits.Memory)...)
 
+	sharesPath := fldPath.Child("shares")
+	fieldErrs = append(fieldErrs, validateShares(sharesPath.Child("cpu"), rp.Shares.CPU)...)
+	fieldErrs = append(fieldErrs, validateShares(sharesPath.Child("memory"), rp.Shares.Memory)...)
+
 	return fieldErrs
 }
 
@@ -147,13 +160,58 @@ func (v validator) validateClusterModules(ctx *context.WebhookRequestContext, fl
 	return fieldErrs
 }
 
+func (v validator) validateHostAffinity(ctx *context.WebhookRequestContext, fldPath *field.Path, hostAffinity *vmopv1.VirtualMachineSetResourcePolicyHostAffinity) field.ErrorList {
+	var fieldErrs field.ErrorList
+
+	if hostAffinity == nil {
+		return fieldErrs
+	}
+
+	nodeSelectorPath := fldPath.Child("nodeSelector")
+	if hostAffinity.NodeSelector == nil {
+		fieldErrs = append(fieldErrs, field.Required(nodeSelectorPath, ""))
+	} else {
+		fieldErrs = append(fieldErrs, metav1validation.ValidateLabelSelector(
+			hostAffinity.NodeSelector, metav1validation.LabelSelectorValidationOptions{}, nodeSelectorPath)...)
+	}
+
+	return fieldErrs
+}
+
+func (v validator) validateTopologySpreadConstraint(
+	ctx *context.WebhookRequestContext,
+	fldPath *field.Path,
+	constraint *vmopv1.VirtualMachineSetResourcePolicyTopologySpreadConstraint) field.ErrorList {
+
+	var fieldErrs field.ErrorList
+
+	if constraint == nil {
+		return fieldErrs
+	}
+
+	if constraint.MaxSkew < 1 {
+		fieldErrs = append(fieldErrs, field.Invalid(fldPath.Child("maxSkew"), constraint.MaxSkew, "must be greater than zero"))
+	}
+
+	if constraint.TopologyKey != vmopv1.VirtualMachineAffinityTopologyKeyZone {
+		fieldErrs = append(fieldErrs, field.NotSupported(fldPath.Child("topologyKey"), constraint.TopologyKey,
+			[]string{vmopv1.VirtualMachineAffinityTopologyKeyZone}))
+	}
+
+	return fieldErrs
+}
+
 // validateAllowedChanges returns true only if immutable fields have not been modified.
 func (v validator) validateAllowedChanges(ctx *context.WebhookRequestContext, vmRP, oldVMRP *vmopv1.VirtualMachineSetResourcePolicy) field.ErrorList {
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
 
 	// Validate all fields under spec which are not allowed to change.
-	allErrs = append(allErrs, validation.ValidateImmutableField(vmRP.Spec.ResourcePool, oldVMRP.Spec.ResourcePool, specPath.Child("resourcepool"))...)
+	// The shares and expandable reservations of the ResourcePool are the only fields of it that may change.
+	resourcePool := vmRP.Spec.ResourcePool
+	resourcePool.Shares = oldVMRP.Spec.ResourcePool.Shares
+	resourcePool.ExpandableReservation = oldVMRP.Spec.ResourcePool.ExpandableReservation
+	allErrs = append(allErrs, validation.ValidateImmutableField(resourcePool, oldVMRP.Spec.ResourcePool, specPath.Child("resourcepool"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(vmRP.Spec.Folder, oldVMRP.Spec.Folder, specPath.Child("folder"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(vmRP.Spec.ClusterModuleGroups, oldVMRP.Spec.ClusterModuleGroups, specPath.Child("clustermodules"))...)
 
@@ -178,3 +236,13 @@ func validateReservationAndLimit(reservationPath *field.Path, reservation, limit
 		field.Invalid(reservationPath, reservation.String(), "reservation value cannot exceed the limit value"),
 	}
 }
+
+func validateShares(sharesPath *field.Path, shares *vmopv1.ResourcePoolShares) field.ErrorList {
+	if shares == nil || shares.Level != vmopv1.ResourcePoolSharesLevelCustom || shares.Shares > 0 {
+		return nil
+	}
+
+	return field.ErrorList{
+		field.Invalid(sharesPath.Child("shares"), shares.Shares, "must be greater than zero when the level is Custom"),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
@@ -60,6 +61,14 @@ func unitTestsValidateCreate() {
 		noMemoryLimit        bool
 		invalidCPURequest    bool
 		invalidMemoryRequest bool
+		hostAffinity         bool
+		noNodeSelector       bool
+		invalidNodeSelector  bool
+		spreadConstraint     bool
+		invalidMaxSkew       bool
+		invalidTopologyKey   bool
+		customShares         bool
+		customSharesNoShares bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -79,6 +88,39 @@ func unitTestsValidateCreate() {
 			ctx.vmRP.Spec.ResourcePool.Reservations.Memory = resource.MustParse("4Gi")
 			ctx.vmRP.Spec.ResourcePool.Limits.Memory = resource.MustParse("1Gi")
 		}
+		if args.hostAffinity || args.noNodeSelector || args.invalidNodeSelector {
+			ctx.vmRP.Spec.HostAffinity = &vmopv1.VirtualMachineSetResourcePolicyHostAffinity{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"license": "db"}},
+				Required:     true,
+			}
+		}
+		if args.noNodeSelector {
+			ctx.vmRP.Spec.HostAffinity.NodeSelector = nil
+		}
+		if args.invalidNodeSelector {
+			ctx.vmRP.Spec.HostAffinity.NodeSelector.MatchLabels = map[string]string{"license": "not valid"}
+		}
+		if args.spreadConstraint || args.invalidMaxSkew || args.invalidTopologyKey {
+			ctx.vmRP.Spec.TopologySpreadConstraint = &vmopv1.VirtualMachineSetResourcePolicyTopologySpreadConstraint{
+				MaxSkew:     1,
+				TopologyKey: vmopv1.VirtualMachineAffinityTopologyKeyZone,
+			}
+		}
+		if args.invalidMaxSkew {
+			ctx.vmRP.Spec.TopologySpreadConstraint.MaxSkew = 0
+		}
+		if args.invalidTopologyKey {
+			ctx.vmRP.Spec.TopologySpreadConstraint.TopologyKey = vmopv1.VirtualMachineAffinityTopologyKeyHost
+		}
+		if args.customShares || args.customSharesNoShares {
+			ctx.vmRP.Spec.ResourcePool.Shares.CPU = &vmopv1.ResourcePoolShares{
+				Level:  vmopv1.ResourcePoolSharesLevelCustom,
+				Shares: 1000,
+			}
+		}
+		if args.customSharesNoShares {
+			ctx.vmRP.Spec.ResourcePool.Shares.CPU.Shares = 0
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmRP)
 		Expect(err).ToNot(HaveOccurred())
@@ -102,6 +144,7 @@ func unitTestsValidateCreate() {
 
 	reservationsPath := field.NewPath("spec", "resourcepool", "reservations")
 	detailMsg := "reservation value cannot exceed the limit value"
+	spreadPath := field.NewPath("spec", "topologySpreadConstraint")
 	DescribeTable("create table", validateCreate,
 		Entry("should allow valid", createArgs{}, true, nil, nil),
 		Entry("should allow no cpu limit", createArgs{noCPULimit: true}, true, nil, nil),
@@ -110,6 +153,20 @@ func unitTestsValidateCreate() {
 			field.Invalid(reservationsPath.Child("cpu"), "2Gi", detailMsg).Error(), nil),
 		Entry("should deny invalid memory reservation", createArgs{invalidMemoryRequest: true}, false,
 			field.Invalid(reservationsPath.Child("memory"), "4Gi", detailMsg).Error(), nil),
+		Entry("should allow host affinity", createArgs{hostAffinity: true}, true, nil, nil),
+		Entry("should deny host affinity without node selector", createArgs{noNodeSelector: true}, false,
+			field.Required(field.NewPath("spec", "hostAffinity", "nodeSelector"), "").Error(), nil),
+		Entry("should deny host affinity with invalid node selector", createArgs{invalidNodeSelector: true}, false, nil, nil),
+		Entry("should allow topology spread constraint", createArgs{spreadConstraint: true}, true, nil, nil),
+		Entry("should deny topology spread constraint with invalid max skew", createArgs{invalidMaxSkew: true}, false,
+			field.Invalid(spreadPath.Child("maxSkew"), int32(0), "must be greater than zero").Error(), nil),
+		Entry("should deny topology spread constraint with unsupported topology key", createArgs{invalidTopologyKey: true}, false,
+			field.NotSupported(spreadPath.Child("topologyKey"), vmopv1.VirtualMachineAffinityTopologyKeyHost,
+				[]string{vmopv1.VirtualMachineAffinityTopologyKeyZone}).Error(), nil),
+		Entry("should allow custom shares", createArgs{customShares: true}, true, nil, nil),
+		Entry("should deny custom shares without shares", createArgs{customSharesNoShares: true}, false,
+			field.Invalid(field.NewPath("spec", "resourcepool", "shares", "cpu", "shares"), int32(0),
+				"must be greater than zero when the level is Custom").Error(), nil),
 	)
 }
 
@@ -119,8 +176,10 @@ func unitTestsValidateUpdate() {
 	)
 
 	type updateArgs struct {
-		changeCPU    bool
-		changeMemory bool
+		changeCPU                   bool
+		changeMemory                bool
+		changeShares                bool
+		changeExpandableReservation bool
 	}
 
 	validateUpdate := func(args updateArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -134,6 +193,12 @@ func unitTestsValidateUpdate() {
 			ctx.vmRP.Spec.ResourcePool.Reservations.Memory = resource.MustParse("5Gi")
 			ctx.vmRP.Spec.ResourcePool.Limits.Memory = resource.MustParse("10Gi")
 		}
+		if args.changeShares {
+			ctx.vmRP.Spec.ResourcePool.Shares.Memory = &vmopv1.ResourcePoolShares{Level: vmopv1.ResourcePoolSharesLevelHigh}
+		}
+		if args.changeExpandableReservation {
+			ctx.vmRP.Spec.ResourcePool.ExpandableReservation.CPU = pointer.Bool(false)
+		}
 
 		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmRP)
 		Expect(err).ToNot(HaveOccurred())
@@ -160,6 +225,8 @@ func unitTestsValidateUpdate() {
 		Entry("should allow", updateArgs{}, true, nil, nil),
 		Entry("should deny policy cpu change", updateArgs{changeCPU: true}, false, immutableFieldMsg, nil),
 		Entry("should deny policy memory change", updateArgs{changeMemory: true}, false, immutableFieldMsg, nil),
+		Entry("should allow policy shares change", updateArgs{changeShares: true}, true, nil, nil),
+		Entry("should allow policy expandable reservation change", updateArgs{changeExpandableReservation: true}, true, nil, nil),
 	)
 
 	When("the update is performed while object deletion", func() {
@@ -11,9 +11,15 @@ import (
 	"github.com/vmware-tanzu/vm-operator/webhooks/persistentvolumeclaim"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclass"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestcommand"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineguestfiletransfer"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineplacementrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinereplicaset"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineresourcequota"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineservice"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesetresourcepolicy"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinesnapshot"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinewebconsolerequest"
 )
 
@@ -28,15 +34,33 @@ func AddToManager(ctx *context.ControllerManagerContext, mgr ctrlmgr.Manager) er
 	if err := virtualmachineclass.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineClass webhooks")
 	}
+	if err := virtualmachineguestcommand.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineGuestCommand webhooks")
+	}
+	if err := virtualmachineguestfiletransfer.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineGuestFileTransfer webhooks")
+	}
+	if err := virtualmachineplacementrequest.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachinePlacementRequest webhooks")
+	}
+	if err := virtualmachineresourcequota.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineResourceQuota webhooks")
+	}
 	if err := virtualmachinepublishrequest.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachinePublishRequest webhooks")
 	}
+	if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineReplicaSet webhooks")
+	}
 	if err := virtualmachineservice.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineService webhooks")
 	}
 	if err := virtualmachinesetresourcepolicy.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineSetResourcePolicy webhooks")
 	}
+	if err := virtualmachinesnapshot.AddToManager(ctx, mgr); err != nil {
+		return errors.Wrap(err, "failed to initialize VirtualMachineSnapshot webhooks")
+	}
 	if err := virtualmachinewebconsolerequest.AddToManager(ctx, mgr); err != nil {
 		return errors.Wrap(err, "failed to initialize VirtualMachineWebConsoleRequest webhooks")
 	}